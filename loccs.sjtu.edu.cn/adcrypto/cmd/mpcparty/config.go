@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+)
+
+/**
+ * The JSON form of the config, shared by all parties except the ID.
+ * <p>
+ * For example, the simple sum of 3 parties modulo 2<sup>61</sup>-1:
+ * <pre>
+ * {
+ *   "id": 0,
+ *   "peers": ["127.0.0.1:9000", "127.0.0.1:9001", "127.0.0.1:9002"],
+ *   "threshold": 1,
+ *   "modulus": "0x1fffffffffffffff"
+ * }
+ * </pre>
+ * Numbers in strings are decimal, or hexadecimal with the prefix "0x". If "coefficients" (one for each party) is given,
+ * the linear function is &sum; <i>a<sub>i</sub></i><i>x<sub>i</sub></i>, otherwise it is the simple sum. If "key" is given,
+ * the parties authenticate each other with it when connecting, see <code>mpc.TCPTransport.SetHandshakeKey</code>.
+ */
+type partyConfigJSON struct {
+	ID *int `json:"id"`
+	Peers []string `json:"peers"`
+	Threshold int `json:"threshold"`
+	Modulus string `json:"modulus"`
+	Coefficients []string `json:"coefficients"`
+	Key *string `json:"key"`
+}
+
+/**
+ * The config of a party.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type partyConfig struct {
+	/**
+	 * ID of this party, from 0 to <i>n</i>-1.
+	 */
+	id int
+
+	/**
+	 * Addresses of all parties, indexed by ID.
+	 */
+	peers []string
+
+	/**
+	 * Threshold <i>t</i> of the linear mpc.
+	 */
+	threshold int
+
+	/**
+	 * The prime modulus <i>p</i>.
+	 */
+	modulus *big.Int
+
+	/**
+	 * Coefficients of the linear function, nil for the simple sum.
+	 */
+	coefficients []*big.Int
+
+	/**
+	 * Key shared by all parties to authenticate connections, nil if not given.
+	 */
+	key []byte
+}
+
+/**
+ * Read the config of a party in the JSON form.
+ *
+ * @param r The stream.
+ * @param id ID of this party, overrides the ID in the config if non-negative.
+ * @return The config.
+ * @return error If the config is invalid.
+ */
+func readPartyConfig(r io.Reader, id int) (*partyConfig, error){
+	var j partyConfigJSON
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&j)
+	if (err != nil) {return nil, errors.New("Invalid config: " + err.Error())}
+
+	feedback := new(partyConfig)
+	if (id >= 0){
+		feedback.id = id
+	} else if (j.ID != nil){
+		feedback.id = *j.ID
+	} else {
+		return nil, errors.New("ID of the party should be given in the config or by -id.")
+	}
+	if (len(j.Peers) < 3) {return nil, errors.New("At least 3 peers should be given.")}
+	if (feedback.id < 0 || feedback.id >= len(j.Peers)) {return nil, errors.New("ID of the party should be between 0 and the number of peers - 1.")}
+	feedback.peers = j.Peers
+	feedback.threshold = j.Threshold
+	feedback.modulus, err = parseNumber(j.Modulus)
+	if (err != nil) {return nil, errors.New("Invalid modulus, " + err.Error())}
+	if (j.Coefficients != nil){
+		if (len(j.Coefficients) != len(j.Peers)) {return nil, errors.New("Number of coefficients should be equal to number of peers.")}
+		feedback.coefficients = make([]*big.Int, len(j.Coefficients))
+		for i := 0; i < len(j.Coefficients); i++{
+			feedback.coefficients[i], err = parseNumber(j.Coefficients[i])
+			if (err != nil) {return nil, errors.New("Invalid coefficient, " + err.Error())}
+		}
+	}
+	if (j.Key != nil){
+		if (len(*j.Key) == 0) {return nil, errors.New("Key should not be empty.")}
+		feedback.key = []byte(*j.Key)
+	}
+	return feedback, nil
+}
+
+/**
+ * Parse a non-negative number, in decimal or in hexadecimal with the prefix "0x".
+ *
+ * @param value The string, surrounding spaces are ignored.
+ * @return The number.
+ * @return error If the string is invalid.
+ */
+func parseNumber(value string) (*big.Int, error){
+	value = strings.TrimSpace(value)
+	base := 10
+	if (strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X")){
+		value = value[2:]
+		base = 16
+	}
+	feedback, ok := new(big.Int).SetString(value, base)
+	if (!ok || feedback.Sign() < 0) {return nil, errors.New("should be a non-negative decimal or 0x-prefixed hexadecimal number.")}
+	return feedback, nil
+}
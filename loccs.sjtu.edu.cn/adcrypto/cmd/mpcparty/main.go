@@ -0,0 +1,154 @@
+/**
+ * Command mpcparty runs one party of the BGW linear multiparty computation over TCP.
+ * <p>
+ * Usage:
+ * <pre>
+ * mpcparty -config FILE [-id ID] [-in FILE] [-timeout DURATION]
+ * </pre>
+ * All parties use the same config (see <code>partyConfigJSON</code>) with their own ID, given in the config or by -id.
+ * A party listens on its own address in "peers", reads its private input (a decimal or 0x-prefixed hexadecimal number
+ * less than the modulus) from -in, or from stdin if -in is "-" or not given, exchanges the input and output messages with
+ * the other parties, and prints the result of the linear function. The party gives up if the computation is not done
+ * within the timeout, e.g. when another party is down.
+ * <p>
+ * The auxiliary data of Shamir's scheme are 1, 2, ..., <i>n</i> for all parties, so the modulus should be larger than <i>n</i>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+package main
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/mpc"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"time"
+)
+
+const usage = `Usage:
+  mpcparty -config FILE [-id ID] [-in FILE] [-timeout DURATION]
+`
+
+func main(){
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	if (err == flag.ErrHelp){
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if (err != nil){
+		fmt.Fprintln(os.Stderr, "mpcparty: " + err.Error())
+		os.Exit(1)
+	}
+}
+
+/**
+ * Run a party with the command-line arguments.
+ *
+ * @param args The arguments.
+ * @param stdin The stream of the private input if -in is "-".
+ * @param stdout The stream to print the result.
+ * @return error If the arguments, the config or the input is invalid, or the computation fails.
+ */
+func run(args []string, stdin io.Reader, stdout io.Writer) error{
+	flags := flag.NewFlagSet("mpcparty", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	configPath := flags.String("config", "", "config file")
+	id := flags.Int("id", -1, "ID of the party, overrides the config")
+	inPath := flags.String("in", "-", "private input file, - for stdin")
+	timeout := flags.Duration("timeout", time.Minute, "max time of the computation")
+	err := flags.Parse(args)
+	if (err != nil) {return err}
+	if (flags.NArg() != 0) {return errors.New("Unexpected argument " + flags.Arg(0) + ".")}
+	if (*configPath == "") {return errors.New("Config file should be given by -config.")}
+
+	file, err := os.Open(*configPath)
+	if (err != nil) {return err}
+	config, err := readPartyConfig(file, *id)
+	file.Close()
+	if (err != nil) {return err}
+	var input []byte
+	if (*inPath == "-"){
+		input, err = io.ReadAll(stdin)
+	} else {
+		input, err = os.ReadFile(*inPath)
+	}
+	if (err != nil) {return err}
+	secret, err := parseNumber(string(input))
+	if (err != nil) {return errors.New("Invalid private input, " + err.Error())}
+
+	listener, err := net.Listen("tcp", config.peers[config.id])
+	if (err != nil) {return err}
+	result, err := runParty(config, secret, listener, *timeout)
+	if (err != nil) {return err}
+	_, err = fmt.Fprintln(stdout, result.String())
+	return err
+}
+
+/**
+ * Run the linear mpc of a party over TCP.
+ *
+ * @param config The config of the party.
+ * @param secret The private input.
+ * @param listener The listener on the address of this party, closed when the party finishes.
+ * @param timeout Max time of the computation.
+ * @return The result of the linear function.
+ * @return error If the parameters are invalid, or the computation fails or times out.
+ */
+func runParty(config *partyConfig, secret *big.Int, listener net.Listener, timeout time.Duration) (*big.Int, error){
+	participantCount := len(config.peers)
+	transport, err := mpc.NewTCPTransportWithListener(config.id, config.peers, listener)
+	if (err != nil) {
+		listener.Close()
+		return nil, err
+	}
+	defer transport.Close()
+	transport.SetDialTimeout(timeout)
+	if (config.key != nil) {transport.SetHandshakeKey(config.key)}
+
+	lmpc, err := mpc.NewLinearMultipartyComputationBigInt(config.id, participantCount, config.threshold)
+	if (err != nil) {return nil, err}
+	if (config.coefficients == nil){
+		err = lmpc.InitializeSimpleSumWithModulus(config.modulus)
+	} else {
+		coefficients := make([]interface{}, participantCount)
+		for i := 0; i < participantCount; i++{
+			coefficients[i] = config.coefficients[i]
+		}
+		err = lmpc.InitializeWithModulus(coefficients, config.modulus)
+	}
+	if (err != nil) {return nil, err}
+	if (config.modulus.Cmp(big.NewInt(int64(participantCount))) <= 0){
+		return nil, errors.New("Modulus should be larger than the number of peers.")
+	}
+	if (secret.Cmp(config.modulus) >= 0){
+		return nil, errors.New("Private input should be less than the modulus.")
+	}
+	auxiliary := make([]interface{}, participantCount)
+	for i := 0; i < participantCount; i++{
+		auxiliary[i] = big.NewInt(int64(i + 1))
+	}
+	driver, err := mpc.NewLinearMultipartyComputationDriver(lmpc, transport)
+	if (err != nil) {return nil, err}
+
+	type outcome struct {
+		result interface{}
+		err error
+	}
+	done := make(chan outcome, 1)
+	go func(){
+		result, err := driver.Run(secret, auxiliary)
+		done <- outcome{result, err}
+	}()
+	select {
+	case o := <-done:
+		if (o.err != nil) {return nil, o.err}
+		return o.result.(*big.Int), nil
+	case <-time.After(timeout):
+		return nil, errors.New("Computation is not done within " + timeout.String() + ".")
+	}
+}
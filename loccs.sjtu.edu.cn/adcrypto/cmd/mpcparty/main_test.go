@@ -0,0 +1,191 @@
+package main
+
+import (
+	"testing"
+	"bytes"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// the test binary runs as a daemon when started by TestPartyDaemons
+func TestMain(m *testing.M) {
+	if (os.Getenv("MPCPARTY_TEST_DAEMON") == "1"){
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestRunParty(t *testing.T) {
+	modulus := "0x1fffffffffffffff"
+	t.Run("TestRunParty1", testRunParty(5, 2, modulus, nil, []int64{10, 20, 30, 40, 50}, 150))
+	t.Run("TestRunParty2", testRunParty(4, 1, modulus, []string{"3", "0x10", "0", "1"}, []int64{1, 2, 3, 4}, 3 + 32 + 4))
+	t.Run("TestRunParty3", testRunParty(3, 1, "101", nil, []int64{50, 50, 50}, 49))
+}
+
+func testRunParty(participantCount int, threshold int, modulus string, coefficients []string, secrets []int64, expected int64) func(t *testing.T) {
+	return func(t *testing.T) {
+		listeners := make([]net.Listener, participantCount)
+		peers := make([]string, participantCount)
+		for i := 0; i < participantCount; i++{
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when listening on loopback: %s", err))}
+			listeners[i] = listener
+			peers[i] = listener.Addr().String()
+		}
+		configData := writeConfig(peers, threshold, modulus, coefficients)
+
+		results := make(chan *big.Int, participantCount)
+		errs := make(chan error, participantCount)
+		for i := 0; i < participantCount; i++{
+			go func(i int) {
+				config, err := readPartyConfig(bytes.NewReader(configData), i)
+				if err != nil {errs <- err; return}
+				result, err := runParty(config, big.NewInt(secrets[i]), listeners[i], 10 * time.Second)
+				if err != nil {errs <- err; return}
+				results <- result
+			}(i)
+		}
+		for i := 0; i < participantCount; i++{
+			select {
+			case result := <-results:
+				if (result.Cmp(big.NewInt(expected)) != 0){
+					t.Error(fmt.Sprintf("Compute Result is False, Result:%v ,Expected: %d", result, expected))
+				}
+			case err := <-errs:
+				t.Error(fmt.Sprintf("Error happens when running party: %s", err))
+			}
+		}
+	}
+}
+
+func TestPartyDaemons(t *testing.T) {
+	participantCount := 5
+	// reserve free loopback ports for the daemons
+	peers := make([]string, participantCount)
+	for i := 0; i < participantCount; i++{
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when listening on loopback: %s", err))}
+		peers[i] = listener.Addr().String()
+		listener.Close()
+	}
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "party.json")
+	err := os.WriteFile(configPath, writeConfig(peers, 2, "0x1fffffffffffffff", []string{"1", "2", "3", "4", "5"}), 0600)
+	if err != nil {t.Fatal(err)}
+
+	daemons := make([]*exec.Cmd, participantCount)
+	outputs := make([]*bytes.Buffer, participantCount)
+	for i := 0; i < participantCount; i++{
+		args := []string{"-config", configPath, "-id", fmt.Sprint(i), "-timeout", "20s"}
+		if (i % 2 == 0){
+			// even parties read the input from a file, odd ones from stdin
+			inPath := filepath.Join(dir, fmt.Sprintf("input-%d", i))
+			err = os.WriteFile(inPath, []byte(fmt.Sprintf("%d\n", 1000 * (i + 1))), 0600)
+			if err != nil {t.Fatal(err)}
+			args = append(args, "-in", inPath)
+		}
+		daemons[i] = exec.Command(os.Args[0], args...)
+		daemons[i].Env = append(os.Environ(), "MPCPARTY_TEST_DAEMON=1")
+		if (i % 2 == 1) {daemons[i].Stdin = strings.NewReader(fmt.Sprintf("0x%x", 1000 * (i + 1)))}
+		outputs[i] = new(bytes.Buffer)
+		daemons[i].Stdout = outputs[i]
+		daemons[i].Stderr = outputs[i]
+		err = daemons[i].Start()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when starting daemon %d: %s", i, err))}
+	}
+	// 1*1000 + 2*2000 + 3*3000 + 4*4000 + 5*5000
+	expected := "55000"
+	for i := 0; i < participantCount; i++{
+		err = daemons[i].Wait()
+		if err != nil {
+			t.Error(fmt.Sprintf("Error happens when running daemon %d: %s, output: %s", i, err, outputs[i]))
+			continue
+		}
+		if (strings.TrimSpace(outputs[i].String()) != expected){
+			t.Error(fmt.Sprintf("Daemon %d Result is False, Result:%s ,Expected: %s", i, outputs[i], expected))
+		}
+	}
+}
+
+func TestReadPartyConfig(t *testing.T) {
+	valid := `{"id": 1, "peers": ["a:1", "b:2", "c:3"], "threshold": 1, "modulus": "101", "coefficients": ["1", "0x2", "3"]}`
+	config, err := readPartyConfig(strings.NewReader(valid), -1)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when reading config: %s", err))}
+	if (config.id != 1 || config.modulus.Int64() != 101 || config.coefficients[1].Int64() != 2){
+		t.Error("Config is read wrongly.")
+	}
+	config, err = readPartyConfig(strings.NewReader(valid), 2)
+	if (err != nil || config.id != 2) {t.Error("ID should be overridden.")}
+	config, err = readPartyConfig(strings.NewReader(`{"peers": ["a:1", "b:2", "c:3"], "threshold": 1, "modulus": "101", "key": "secret"}`), 0)
+	if (err != nil || string(config.key) != "secret") {t.Error("Key is read wrongly.")}
+
+	invalids := []string{
+		`{"peers": ["a:1", "b:2", "c:3"], "threshold": 1, "modulus": "101"}`,
+		`{"id": 3, "peers": ["a:1", "b:2", "c:3"], "threshold": 1, "modulus": "101"}`,
+		`{"id": 0, "peers": ["a:1", "b:2"], "threshold": 1, "modulus": "101"}`,
+		`{"id": 0, "peers": ["a:1", "b:2", "c:3"], "threshold": 1, "modulus": "-101"}`,
+		`{"id": 0, "peers": ["a:1", "b:2", "c:3"], "threshold": 1, "modulus": "101", "coefficients": ["1"]}`,
+		`{"id": 0, "peers": ["a:1", "b:2", "c:3"], "threshold": 1, "modulus": "101", "max": "5"}`,
+		`{"id": 0, "peers": ["a:1", "b:2", "c:3"], "threshold": 1, "modulus": "101", "key": ""}`,
+		`not json`,
+	}
+	for i := 0; i < len(invalids); i++{
+		_, err = readPartyConfig(strings.NewReader(invalids[i]), -1)
+		if err == nil {t.Error(fmt.Sprintf("Invalid config %d should not be accepted.", i))}
+	}
+}
+
+func TestRunInvalid(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "party.json")
+	err := os.WriteFile(configPath, writeConfig([]string{"127.0.0.1:0", "127.0.0.1:0", "127.0.0.1:0"}, 1, "101", nil), 0600)
+	if err != nil {t.Fatal(err)}
+	invalids := []struct {
+		args []string
+		input string
+	}{
+		{[]string{"-id", "0"}, "1"},
+		{[]string{"-config", configPath, "-id", "0"}, "not a number"},
+		{[]string{"-config", configPath, "-id", "0"}, "101"},
+		{[]string{"-config", configPath, "-id", "0", "-in", filepath.Join(dir, "missing")}, ""},
+		{[]string{"-config", configPath, "-id", "0", "extra"}, "1"},
+	}
+	for i := 0; i < len(invalids); i++{
+		err = run(invalids[i].args, strings.NewReader(invalids[i].input), new(bytes.Buffer))
+		if err == nil {t.Error(fmt.Sprintf("Invalid run %d should fail.", i))}
+	}
+}
+
+func TestRunPartyTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {t.Fatal(err)}
+	// the other parties never come up
+	peers := []string{listener.Addr().String(), "127.0.0.1:1", "127.0.0.1:1"}
+	config, err := readPartyConfig(bytes.NewReader(writeConfig(peers, 1, "101", nil)), 0)
+	if err != nil {t.Fatal(err)}
+	_, err = runParty(config, big.NewInt(1), listener, 200 * time.Millisecond)
+	if err == nil {t.Error("Party should fail when the other parties are down.")}
+}
+
+func writeConfig(peers []string, threshold int, modulus string, coefficients []string) []byte {
+	quoted := make([]string, len(peers))
+	for i := 0; i < len(peers); i++{
+		quoted[i] = fmt.Sprintf("%q", peers[i])
+	}
+	config := fmt.Sprintf(`{"peers": [%s], "threshold": %d, "modulus": %q`, strings.Join(quoted, ", "), threshold, modulus)
+	if (coefficients != nil){
+		quoted = make([]string, len(coefficients))
+		for i := 0; i < len(coefficients); i++{
+			quoted[i] = fmt.Sprintf("%q", coefficients[i])
+		}
+		config += fmt.Sprintf(`, "coefficients": [%s]`, strings.Join(quoted, ", "))
+	}
+	return []byte(config + "}")
+}
@@ -0,0 +1,266 @@
+/**
+ * Command shamir splits a secret into share files with Shamir's secret sharing scheme over <i>Zp</i>, and combines
+ * share files back into the secret.
+ * <p>
+ * Usage:
+ * <pre>
+ * shamir split -n 5 -k 3 [-field p256 | -modulus HEX] (-secret TEXT | -in FILE) -out DIR
+ * shamir combine [-out FILE] SHARE_FILE...
+ * </pre>
+ * <code>split</code> reads the secret from -secret, or from the file -in ("-" for stdin), and writes the share of
+ * participant <i>i</i> to DIR/share-<i>i</i>.txt (<i>i</i> from 1 to <i>n</i>). The field is one of the named primes
+ * p127 (2<sup>127</sup>-1), p256 (the prime of NIST P-256) and p521 (2<sup>521</sup>-1), or any prime given by -modulus.
+ * <code>combine</code> reads <i>k</i> or more share files and writes the secret to -out, or to stdout.
+ * See <code>shareFile</code> for the text format of the share files.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+package main
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+)
+
+/**
+ * The named prime fields.
+ */
+var namedFields = map[string]string{
+	"p127": "7fffffffffffffffffffffffffffffff",
+	"p256": "ffffffff00000001000000000000000000000000ffffffffffffffffffffffff",
+	"p521": "1ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+}
+
+const usage = `Usage:
+  shamir split -n N -k K [-field p127|p256|p521 | -modulus HEX] (-secret TEXT | -in FILE) -out DIR
+  shamir combine [-out FILE] SHARE_FILE...
+`
+
+func main(){
+	if (len(os.Args) < 2){
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "split":
+		err = runSplit(os.Args[2:], os.Stdin, os.Stdout)
+	case "combine":
+		err = runCombine(os.Args[2:], os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if (err != nil){
+		fmt.Fprintln(os.Stderr, "shamir: " + err.Error())
+		os.Exit(1)
+	}
+}
+
+/**
+ * Run the split subcommand.
+ *
+ * @param args The arguments after "split".
+ * @param stdin The stream of "-in -".
+ * @param stdout The stream to report the written files.
+ * @return error If the arguments are invalid, or error happens when sharing or writing.
+ */
+func runSplit(args []string, stdin io.Reader, stdout io.Writer) error{
+	flags := flag.NewFlagSet("split", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	participantCount := flags.Int("n", 0, "number of participants")
+	threshold := flags.Int("k", 0, "threshold")
+	fieldName := flags.String("field", "p256", "named prime field")
+	modulusHex := flags.String("modulus", "", "prime modulus in hexadecimal")
+	secretText := flags.String("secret", "", "secret text")
+	inPath := flags.String("in", "", "secret file, - for stdin")
+	outDir := flags.String("out", "", "output directory")
+	err := flags.Parse(args)
+	if (err != nil) {return err}
+	if (flags.NArg() != 0) {return errors.New("Unexpected argument " + flags.Arg(0) + ".")}
+	if (*outDir == "") {return errors.New("Output directory should be given by -out.")}
+	if ((*secretText == "") == (*inPath == "")) {return errors.New("Exactly one of -secret and -in should be given.")}
+
+	modulus, err := chooseModulus(*fieldName, *modulusHex)
+	if (err != nil) {return err}
+	var secret []byte
+	if (*secretText != ""){
+		secret = []byte(*secretText)
+	} else if (*inPath == "-"){
+		secret, err = io.ReadAll(stdin)
+	} else {
+		secret, err = os.ReadFile(*inPath)
+	}
+	if (err != nil) {return err}
+	if (len(secret) == 0) {return errors.New("Secret should not be empty.")}
+
+	shares, err := splitSecret(secret, *participantCount, *threshold, modulus)
+	if (err != nil) {return err}
+	err = os.MkdirAll(*outDir, 0700)
+	if (err != nil) {return err}
+	for i := 0; i < len(shares); i++{
+		path := filepath.Join(*outDir, fmt.Sprintf("share-%d.txt", i + 1))
+		file, err := os.OpenFile(path, os.O_WRONLY | os.O_CREATE | os.O_EXCL, 0600)
+		if (err != nil) {return err}
+		err = shares[i].write(file)
+		closeErr := file.Close()
+		if (err != nil) {return err}
+		if (closeErr != nil) {return closeErr}
+		fmt.Fprintln(stdout, path)
+	}
+	return nil
+}
+
+/**
+ * Run the combine subcommand.
+ *
+ * @param args The arguments after "combine".
+ * @param stdout The stream of the secret if -out is not given.
+ * @return error If the arguments or the share files are invalid, or error happens when writing.
+ */
+func runCombine(args []string, stdout io.Writer) error{
+	flags := flag.NewFlagSet("combine", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	outPath := flags.String("out", "", "output file")
+	err := flags.Parse(args)
+	if (err != nil) {return err}
+	if (flags.NArg() == 0) {return errors.New("Share files should be given.")}
+	shares := make([]*shareFile, flags.NArg())
+	for i := 0; i < flags.NArg(); i++{
+		file, err := os.Open(flags.Arg(i))
+		if (err != nil) {return err}
+		shares[i], err = readShareFile(file)
+		file.Close()
+		if (err != nil) {return errors.New(flags.Arg(i) + ": " + err.Error())}
+	}
+	secret, err := combineSecret(shares)
+	if (err != nil) {return err}
+	if (*outPath == ""){
+		_, err = stdout.Write(secret)
+		return err
+	}
+	return os.WriteFile(*outPath, secret, 0600)
+}
+
+/**
+ * Choose the modulus from a named field or a hexadecimal prime.
+ *
+ * @param fieldName Name of the field, used if modulusHex is empty.
+ * @param modulusHex The modulus in hexadecimal, can be empty.
+ * @return The modulus.
+ * @return error If the field is unknown, or the modulus is not a prime of at least 9 bits.
+ */
+func chooseModulus(fieldName string, modulusHex string) (*big.Int, error){
+	if (modulusHex == ""){
+		named, ok := namedFields[fieldName]
+		if (!ok) {return nil, errors.New("Unknown field " + fieldName + ", should be p127, p256 or p521.")}
+		modulusHex = named
+	}
+	modulus, err := parseHex(modulusHex)
+	if (err != nil) {return nil, errors.New("Invalid modulus, " + err.Error())}
+	if (modulus.BitLen() < 9 || !modulus.ProbablyPrime(20)){
+		return nil, errors.New("Modulus should be a prime of at least 9 bits.")
+	}
+	return modulus, nil
+}
+
+/**
+ * Split a secret into share files with ShamirSecretSharingBigInt, block by block.
+ *
+ * @param secret The secret.
+ * @param participantCount The number of participants <i>n</i>.
+ * @param threshold The threshold <i>k</i>.
+ * @param modulus The prime modulus.
+ * @return N share files.
+ * @return error If the parameters are invalid.
+ */
+func splitSecret(secret []byte, participantCount int, threshold int, modulus *big.Int) ([]*shareFile, error){
+	scheme, err := secretshare.NewShamirSecretSharingBigInt(participantCount, modulus)
+	if (err != nil) {return nil, err}
+	access, err := secretshare.NewThresholdAccessStructure(participantCount, threshold)
+	if (err != nil) {return nil, err}
+	err = scheme.SetAccessStructure(access)
+	if (err != nil) {return nil, err}
+	id := make([]byte, 8)
+	_, err = rand.Read(id)
+	if (err != nil) {return nil, err}
+
+	auxiliary := scheme.CreateDefaultAuxiliary()
+	feedback := make([]*shareFile, participantCount)
+	for i := 0; i < participantCount; i++{
+		feedback[i] = &shareFile{id: hex.EncodeToString(id), participantCount: participantCount, threshold: threshold,
+			participant: i, modulus: modulus, length: len(secret), x: auxiliary[i].(*big.Int)}
+	}
+	size := blockSize(modulus)
+	for start := 0; start < len(secret); start += size{
+		end := start + size
+		if (end > len(secret)) {end = len(secret)}
+		shares, err := scheme.GenerateShares(new(big.Int).SetBytes(secret[start:end]), auxiliary)
+		if (err != nil) {return nil, err}
+		for i := 0; i < participantCount; i++{
+			value := shares[i].GetValue().(*secretshare.ShamirSecretShareValue)
+			feedback[i].y = append(feedback[i].y, value.GetQr().(*big.Int))
+		}
+	}
+	return feedback, nil
+}
+
+/**
+ * Combine share files into the secret with ShamirSecretSharingBigInt, block by block.
+ *
+ * @param shares The share files, <i>k</i> or more from the same split.
+ * @return The secret.
+ * @return error If the share files do not match each other or are not enough.
+ */
+func combineSecret(shares []*shareFile) ([]byte, error){
+	first := shares[0]
+	for i := 1; i < len(shares); i++{
+		if (shares[i].id != first.id || shares[i].participantCount != first.participantCount || shares[i].threshold != first.threshold ||
+			shares[i].modulus.Cmp(first.modulus) != 0 || shares[i].length != first.length){
+			return nil, errors.New(fmt.Sprintf("Share %d does not come from the same split as share 1.", i + 1))
+		}
+		for j := 0; j < i; j++{
+			if (shares[j].participant == shares[i].participant){
+				return nil, errors.New(fmt.Sprintf("Shares %d and %d belong to the same participant.", j + 1, i + 1))
+			}
+		}
+	}
+	if (len(shares) < first.threshold){
+		return nil, errors.New(fmt.Sprintf("At least %d shares are needed, only %d given.", first.threshold, len(shares)))
+	}
+	scheme, err := secretshare.NewShamirSecretSharingBigInt(first.participantCount, first.modulus)
+	if (err != nil) {return nil, err}
+	access, err := secretshare.NewThresholdAccessStructure(first.participantCount, first.threshold)
+	if (err != nil) {return nil, err}
+	err = scheme.SetAccessStructure(access)
+	if (err != nil) {return nil, err}
+
+	size := blockSize(first.modulus)
+	feedback := make([]byte, 0, first.length)
+	for block := 0; block < len(first.y); block++{
+		secretShares := make([]*secretshare.SecretShare, len(shares))
+		for i := 0; i < len(shares); i++{
+			value := secretshare.NewShamirSecretShareValue(shares[i].x, shares[i].y[block])
+			secretShares[i] = secretshare.NewSecretShare(shares[i].participant, value)
+		}
+		result, err := scheme.CalculateSecret(secretShares)
+		if (err != nil) {return nil, err}
+		length := size
+		if (block == len(first.y) - 1) {length = first.length - block * size}
+		value := result.(*big.Int)
+		if (value.BitLen() > 8 * length){
+			return nil, errors.New("Shares are inconsistent, the recovered block is too large.")
+		}
+		feedback = append(feedback, value.FillBytes(make([]byte, length))...)
+	}
+	return feedback, nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"testing"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func TestSplitCombine(t *testing.T) {
+	t.Run("TestSplitCombine1", testSplitCombine(5, 3, []string{"-field", "p256"}, []byte("recovery key: 0123456789abcdef0123456789abcdef"), []int{5, 1, 3}))
+	t.Run("TestSplitCombine2", testSplitCombine(3, 2, []string{"-field", "p127"}, []byte{0, 0, 1, 0xff, 0}, []int{2, 3}))
+	t.Run("TestSplitCombine3", testSplitCombine(4, 4, []string{"-field", "p521"}, bytes.Repeat([]byte{0xff}, 200), []int{1, 2, 3, 4}))
+	t.Run("TestSplitCombine4", testSplitCombine(7, 4, []string{"-modulus", "101"}, []byte("small field"), []int{7, 6, 5, 4, 3}))
+}
+
+func testSplitCombine(participantCount int, threshold int, field []string, secret []byte, chosen []int) func(t *testing.T) {
+	return func(t *testing.T) {
+		dir := t.TempDir()
+		inPath := filepath.Join(dir, "secret")
+		err := os.WriteFile(inPath, secret, 0600)
+		if err != nil {t.Fatal(err)}
+		outDir := filepath.Join(dir, "shares")
+		args := append([]string{"-n", fmt.Sprint(participantCount), "-k", fmt.Sprint(threshold), "-in", inPath, "-out", outDir}, field...)
+		var listed bytes.Buffer
+		err = runSplit(args, nil, &listed)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when splitting: %s", err))}
+		if (strings.Count(listed.String(), "\n") != participantCount) {t.Error("Split should list all share files.")}
+
+		paths := make([]string, len(chosen))
+		for i := 0; i < len(chosen); i++{
+			paths[i] = filepath.Join(outDir, fmt.Sprintf("share-%d.txt", chosen[i]))
+		}
+		var recovered bytes.Buffer
+		err = runCombine(paths, &recovered)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when combining: %s", err))}
+		if (!bytes.Equal(recovered.Bytes(), secret)){
+			t.Error(fmt.Sprintf("Combine Result is False, Result:%x ,Expected: %x", recovered.Bytes(), secret))
+		}
+
+		err = runCombine(paths[:threshold - 1], &recovered)
+		if err == nil {t.Error("Secret should not be combined with too few shares.")}
+	}
+}
+
+func TestSplitStdinCombineFile(t *testing.T) {
+	dir := t.TempDir()
+	secret := []byte("secret from stdin\n")
+	err := runSplit([]string{"-n", "3", "-k", "2", "-in", "-", "-out", dir}, bytes.NewReader(secret), new(bytes.Buffer))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when splitting: %s", err))}
+	info, err := os.Stat(filepath.Join(dir, "share-1.txt"))
+	if err != nil {t.Fatal(err)}
+	if (info.Mode().Perm() != 0600) {t.Error(fmt.Sprintf("Share file should be private, mode: %v", info.Mode().Perm()))}
+
+	outPath := filepath.Join(dir, "recovered")
+	err = runCombine([]string{"-out", outPath, filepath.Join(dir, "share-3.txt"), filepath.Join(dir, "share-1.txt")}, nil)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when combining: %s", err))}
+	recovered, err := os.ReadFile(outPath)
+	if err != nil {t.Fatal(err)}
+	if (!bytes.Equal(recovered, secret)) {t.Error("Combine Result is False.")}
+
+	// existing shares are never overwritten
+	err = runSplit([]string{"-n", "3", "-k", "2", "-secret", "other", "-out", dir}, nil, new(bytes.Buffer))
+	if err == nil {t.Error("Existing share files should not be overwritten.")}
+}
+
+func TestShareFileFormat(t *testing.T) {
+	dir := t.TempDir()
+	err := runSplit([]string{"-n", "3", "-k", "2", "-field", "p127", "-secret", "0123456789abcdefXYZ", "-out", dir}, nil, new(bytes.Buffer))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when splitting: %s", err))}
+	data, err := os.ReadFile(filepath.Join(dir, "share-2.txt"))
+	if err != nil {t.Fatal(err)}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	expected := []string{shareFileMagic, "id: ", "participants: 3", "threshold: 2", "participant: 1",
+		"modulus: 7fffffffffffffffffffffffffffffff", "length: 19", "x: 2", "y: ", "y: "}
+	if (len(lines) != len(expected)) {t.Fatal(fmt.Sprintf("Share file should have %d lines, got:\n%s", len(expected), data))}
+	for i := 0; i < len(expected); i++{
+		if (!strings.HasPrefix(lines[i], expected[i])) {t.Error(fmt.Sprintf("Line %d is %q, expected %q.", i + 1, lines[i], expected[i]))}
+	}
+
+	sf, err := readShareFile(bytes.NewReader(data))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when reading share file: %s", err))}
+	var written bytes.Buffer
+	err = sf.write(&written)
+	if err != nil {t.Fatal(err)}
+	if (!bytes.Equal(written.Bytes(), data)) {t.Error("Share file does not survive reading and writing.")}
+}
+
+func TestShareFileInvalid(t *testing.T) {
+	valid := shareFileMagic + "\nid: 01\nparticipants: 3\nthreshold: 2\nparticipant: 0\nmodulus: 101\nlength: 2\nx: 1\ny: 5\ny: 6\n"
+	_, err := readShareFile(strings.NewReader(valid))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when reading share file: %s", err))}
+	invalids := []string{
+		"",
+		"ADCRYPTO-SHAMIR-SHARE v2\n",
+		strings.Replace(valid, "x: 1\n", "", 1),
+		strings.Replace(valid, "y: 6\n", "", 1),
+		strings.Replace(valid, "y: 6\n", "y: 6\ny: 7\n", 1),
+		strings.Replace(valid, "x: 1", "x: xyz", 1),
+		strings.Replace(valid, "participant: 0", "participant: 3", 1),
+		strings.Replace(valid, "threshold: 2", "threshold: 2\nthreshold: 3", 1),
+		valid + "color: red\n",
+	}
+	for i := 0; i < len(invalids); i++{
+		_, err = readShareFile(strings.NewReader(invalids[i]))
+		if err == nil {t.Error(fmt.Sprintf("Invalid share file %d should not be accepted.", i))}
+	}
+}
+
+func TestCombineMismatch(t *testing.T) {
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	err := runSplit([]string{"-n", "3", "-k", "2", "-secret", "first", "-out", dir1}, nil, new(bytes.Buffer))
+	if err != nil {t.Fatal(err)}
+	err = runSplit([]string{"-n", "3", "-k", "2", "-secret", "other", "-out", dir2}, nil, new(bytes.Buffer))
+	if err != nil {t.Fatal(err)}
+	err = runCombine([]string{filepath.Join(dir1, "share-1.txt"), filepath.Join(dir2, "share-2.txt")}, new(bytes.Buffer))
+	if err == nil {t.Error("Shares of different splits should not be combined.")}
+	err = runCombine([]string{filepath.Join(dir1, "share-1.txt"), filepath.Join(dir1, "share-1.txt")}, new(bytes.Buffer))
+	if err == nil {t.Error("Duplicated shares should not be combined.")}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	invalids := [][]string{
+		{"-n", "3", "-k", "2", "-secret", "s"},
+		{"-n", "3", "-k", "2", "-out", "x"},
+		{"-n", "3", "-k", "4", "-secret", "s", "-out", "x"},
+		{"-n", "3", "-k", "2", "-secret", "s", "-field", "p384", "-out", "x"},
+		{"-n", "3", "-k", "2", "-secret", "s", "-modulus", "100", "-out", "x"},
+		{"-n", "3", "-k", "2", "-secret", "s", "-modulus", "7", "-out", "x"},
+	}
+	for i := 0; i < len(invalids); i++{
+		args := invalids[i]
+		for j := 0; j < len(args); j++{
+			if (args[j] == "x") {args[j] = filepath.Join(t.TempDir(), "x")}
+		}
+		err := runSplit(args, nil, new(bytes.Buffer))
+		if err == nil {t.Error(fmt.Sprintf("Invalid arguments %d should not be accepted.", i))}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+/**
+ * The first line of a share file.
+ */
+const shareFileMagic = "ADCRYPTO-SHAMIR-SHARE v1"
+
+/**
+ * A share file, i.e. the share of one participant in the text format.
+ * <p>
+ * The format is a magic line followed by "key: value" lines, with all numbers except the counts in hexadecimal:
+ * <pre>
+ * ADCRYPTO-SHAMIR-SHARE v1
+ * id: 5f0c1d2e3a4b6978
+ * participants: 5
+ * threshold: 3
+ * participant: 2
+ * modulus: ffffffff00000001000000000000000000000000ffffffffffffffffffffffff
+ * length: 32
+ * x: 3
+ * y: 8e2f...
+ * y: 07a1...
+ * </pre>
+ * The secret of <i>length</i> bytes is cut into blocks of (bit length of modulus - 1) / 8 bytes, each of which is read as a
+ * big-endian integer and shared with Shamir's scheme over <i>Z</i><sub>modulus</sub>. The share holds one "y" line for
+ * each block, all evaluated on the same point <i>x</i>. Shares of the same split have the same random "id", so shares of
+ * different splits are not combined by mistake.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type shareFile struct {
+	/**
+	 * Random ID of the split, in hexadecimal.
+	 */
+	id string
+
+	/**
+	 * The number of participants <i>n</i>.
+	 */
+	participantCount int
+
+	/**
+	 * The threshold <i>k</i>.
+	 */
+	threshold int
+
+	/**
+	 * ID of the participant, from 0 to <i>n</i>-1.
+	 */
+	participant int
+
+	/**
+	 * The prime modulus <i>p</i>.
+	 */
+	modulus *big.Int
+
+	/**
+	 * Length of the secret in bytes.
+	 */
+	length int
+
+	/**
+	 * The evaluation point.
+	 */
+	x *big.Int
+
+	/**
+	 * The evaluations, one for each block of the secret.
+	 */
+	y []*big.Int
+}
+
+/**
+ * Write the share file in the text format.
+ *
+ * @param w The stream.
+ * @return error If error happens when writing.
+ */
+func (sf *shareFile) write(w io.Writer) error{
+	var builder strings.Builder
+	builder.WriteString(shareFileMagic + "\n")
+	fmt.Fprintf(&builder, "id: %s\n", sf.id)
+	fmt.Fprintf(&builder, "participants: %d\n", sf.participantCount)
+	fmt.Fprintf(&builder, "threshold: %d\n", sf.threshold)
+	fmt.Fprintf(&builder, "participant: %d\n", sf.participant)
+	fmt.Fprintf(&builder, "modulus: %x\n", sf.modulus)
+	fmt.Fprintf(&builder, "length: %d\n", sf.length)
+	fmt.Fprintf(&builder, "x: %x\n", sf.x)
+	for i := 0; i < len(sf.y); i++{
+		fmt.Fprintf(&builder, "y: %x\n", sf.y[i])
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+/**
+ * Read a share file in the text format.
+ *
+ * @param r The stream.
+ * @return The share file.
+ * @return error If the format is invalid.
+ */
+func readShareFile(r io.Reader) (*shareFile, error){
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), 1 << 20)
+	if (!scanner.Scan() || strings.TrimSpace(scanner.Text()) != shareFileMagic){
+		return nil, errors.New("Not a share file, the first line should be \"" + shareFileMagic + "\".")
+	}
+	feedback := new(shareFile)
+	seen := map[string]bool{}
+	for (scanner.Scan()){
+		line := strings.TrimSpace(scanner.Text())
+		if (line == "" || strings.HasPrefix(line, "#")) {continue}
+		colon := strings.Index(line, ":")
+		if (colon < 0) {return nil, errors.New("Invalid line \"" + line + "\", should be \"key: value\".")}
+		key := strings.TrimSpace(line[:colon])
+		value := strings.TrimSpace(line[colon + 1:])
+		if (key != "y" && seen[key]) {return nil, errors.New("Duplicated key \"" + key + "\".")}
+		seen[key] = true
+		var err error
+		switch key {
+		case "id":
+			feedback.id = value
+		case "participants":
+			feedback.participantCount, err = strconv.Atoi(value)
+		case "threshold":
+			feedback.threshold, err = strconv.Atoi(value)
+		case "participant":
+			feedback.participant, err = strconv.Atoi(value)
+		case "length":
+			feedback.length, err = strconv.Atoi(value)
+		case "modulus":
+			feedback.modulus, err = parseHex(value)
+		case "x":
+			feedback.x, err = parseHex(value)
+		case "y":
+			var y *big.Int
+			y, err = parseHex(value)
+			feedback.y = append(feedback.y, y)
+		default:
+			return nil, errors.New("Unknown key \"" + key + "\".")
+		}
+		if (err != nil) {return nil, errors.New("Invalid value of \"" + key + "\": " + err.Error())}
+	}
+	if (scanner.Err() != nil) {return nil, scanner.Err()}
+	for _, key := range []string{"id", "participants", "threshold", "participant", "modulus", "length", "x"}{
+		if (!seen[key]) {return nil, errors.New("Missing key \"" + key + "\".")}
+	}
+	if (feedback.threshold < 1 || feedback.threshold > feedback.participantCount ||
+		feedback.participant < 0 || feedback.participant >= feedback.participantCount || feedback.length < 0){
+		return nil, errors.New("Invalid parameters of the share.")
+	}
+	if (len(feedback.y) != blockCount(feedback.length, feedback.modulus)){
+		return nil, errors.New("Number of \"y\" lines does not match the length of the secret.")
+	}
+	return feedback, nil
+}
+
+/**
+ * Parse a non-negative hexadecimal integer.
+ *
+ * @param value The hexadecimal string, without prefix.
+ * @return The integer.
+ * @return error If the string is invalid.
+ */
+func parseHex(value string) (*big.Int, error){
+	feedback, ok := new(big.Int).SetString(value, 16)
+	if (!ok || feedback.Sign() < 0) {return nil, errors.New("should be a hexadecimal number.")}
+	return feedback, nil
+}
+
+/**
+ * Get the size of a block of the secret, so that every block is less than the modulus.
+ *
+ * @param modulus The modulus <i>p</i>.
+ * @return Number of bytes in a block.
+ */
+func blockSize(modulus *big.Int) int{
+	return (modulus.BitLen() - 1) / 8
+}
+
+/**
+ * Get the number of blocks of a secret.
+ *
+ * @param length Length of the secret in bytes.
+ * @param modulus The modulus <i>p</i>.
+ * @return Number of blocks.
+ */
+func blockCount(length int, modulus *big.Int) int{
+	size := blockSize(modulus)
+	if (size <= 0) {return -1}
+	return (length + size - 1) / size
+}
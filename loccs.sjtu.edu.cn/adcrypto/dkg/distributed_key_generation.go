@@ -0,0 +1,865 @@
+/**
+ * Package dkg implements dealerless distributed key generation on the secretshare primitives, i.e. Pedersen's and
+ * Feldman's verifiable secret sharing and Lagrange interpolation.
+ * <p>
+ * The rounds are not driven by the <code>Transport</code> and the driver of package mpc: the commitments and all kinds
+ * of complaints and responses must go through a broadcast channel on which every participant sees the same messages,
+ * otherwise participants may end up with different qualified sets, while an mpc <code>Transport</code> only delivers
+ * point-to-point messages carrying elements. The caller moves the messages between the stages over its own broadcast
+ * channel and private channels, as the tests do in memory.
+ */
+package dkg
+
+import (
+	"crypto/rand"
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+	"math/big"
+)
+
+/**
+ * This class implements the dealerless distributed key generation of one participant.
+ * <p>
+ * The protocol follows "Gennaro R, Jarecki S, Krawczyk H, Rabin T. Secure distributed key generation for
+ * discrete-log based cryptosystems. Journal of Cryptology. 2007 Jan 1;20(1):51-83.". In the first phase every
+ * participant deals a random value with Pedersen's verifiable secret sharing, and the shared secret <i>x</i> is
+ * the sum of the values dealt by the qualified participants, which no single participant knows. In the second phase
+ * the qualified dealers publish Feldman's commitments to their polynomials, from which the public key
+ * <i>y</i> = <i>g</i><sup><i>x</i></sup> is calculated.
+ * <p>
+ * The first phase has the following stages:
+ * <ol>
+ * 		<li> Deal: every participant generates the sub-shares of a random value and the commitments, sends the
+ * 		     sub-share to each participant privately and broadcasts the commitments;
+ * 		<li> Complaint: every participant verifies the received sub-shares, and broadcasts the dealers whose
+ * 		     sub-shares are invalid;
+ * 		<li> Response: every dealer broadcasts the sub-shares of the participants complaining against it,
+ * 		     which all participants verify against the commitments of the dealer;
+ * 		<li> Finalize: a dealer is disqualified if it is complained by threshold or more participants, or
+ * 		     fails to answer a complaint with a valid sub-share. Every participant sums the sub-shares from
+ * 		     the qualified dealers as its share of the secret.
+ * </ol>
+ * <p>
+ * The second phase has the following stages:
+ * <ol>
+ * 		<li> Extraction: every qualified dealer broadcasts <i>g</i><sup><i>a<sub>k</sub></i></sup> for the coefficients
+ * 		     <i>a<sub>k</sub></i> of its secret polynomial;
+ * 		<li> Extraction complaint: every participant verifies its sub-shares against these commitments, and broadcasts
+ * 		     the dealers whose commitments are wrong together with the sub-shares proving it;
+ * 		<li> Reconstruction: the polynomial of a dealer with a valid complaint, or without Feldman's commitments,
+ * 		     is reconstructed from the sub-shares broadcast by the participants, and its commitments are calculated.
+ * 		     The public key is the product of the first commitments of the qualified dealers.
+ * </ol>
+ * <p>
+ * Note 1: Any threshold <i>k</i> final shares recover the secret, i.e. the polynomials are of degree <i>k</i>-1.
+ * <p>
+ * Note 2: Each participant has a unique ID, start from 0 to <i>n</i>-1, and its evaluation point is ID + 1.
+ * <p>
+ * Note 3: A participant who has not dealt when the deal stage times out is excluded by <code>CloseDealStage</code>,
+ * and its complaints are no longer waited for. A qualified dealer who has not published Feldman's commitments
+ * when the extraction stage times out is reconstructed after <code>CloseExtractionStage</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type DistributedKeyGeneration struct {
+	/**
+	 * ID of this participant.
+	 */
+	id int
+
+	/**
+	 * Number of participants.
+	 */
+	participantCount int
+
+	/**
+	 * Threshold <i>k</i>.
+	 */
+	threshold int
+
+	/**
+	 * Pedersen's secret sharing scheme object.
+	 */
+	secretSharing *secretshare.PedersenSecretSharingBigInt
+
+	/**
+	 * The sub-shares dealt by this participant, kept for answering complaints.
+	 */
+	dealtShares []*secretshare.SecretShare
+
+	/**
+	 * The sub-shares received from all dealers, indexed by the dealer.
+	 */
+	receivedShares []*secretshare.SecretShare
+
+	/**
+	 * The commitments received from all dealers, indexed by the dealer.
+	 */
+	receivedCommitments [][]*big.Int
+
+	/**
+	 * complaints[i][j] is true if participant j complains against dealer i.
+	 */
+	complaints [][]bool
+
+	/**
+	 * Whether the complaints of each participant are received.
+	 */
+	complaintsReceived []bool
+
+	/**
+	 * answered[i][j] is true if dealer i has answered the complaint of participant j.
+	 */
+	answered [][]bool
+
+	/**
+	 * Dealers disqualified for invalid answers.
+	 */
+	disqualified []bool
+
+	/**
+	 * Whether the deal stage is closed, so that participants who have not dealt are no longer waited for.
+	 */
+	dealClosed bool
+
+	/**
+	 * Feldman's secret sharing scheme in the same group, verifying sub-shares against Feldman's commitments.
+	 */
+	feldmanSharing *secretshare.FeldmanSecretSharingBigInt
+
+	/**
+	 * Feldman's commitments received from the qualified dealers, indexed by the dealer.
+	 */
+	feldmanCommitments [][]*big.Int
+
+	/**
+	 * Whether the extraction stage is closed, so that the dealers who have not published Feldman's commitments are reconstructed.
+	 */
+	extractionClosed bool
+
+	/**
+	 * Whether the extraction complaints of each participant are received.
+	 */
+	extractionComplaintsReceived []bool
+
+	/**
+	 * Qualified dealers whose polynomials are reconstructed publicly.
+	 */
+	exposed []bool
+
+	/**
+	 * reconstructionShares[i][j] is the sub-share from dealer i revealed by participant j for the reconstruction.
+	 */
+	reconstructionShares [][]*secretshare.SecretShare
+}
+
+/**
+ * Construct the distributed key generation of a participant.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>k</i>, should be no more than <i>n</i>/2 so that the honest participants can recover the secret.
+ * @param group The group of commitments, same for all participants.
+ * @return feedback the constructed DistributedKeyGeneration
+ * @return error If any of ID, participantCount, threshold or group is invalid.
+ */
+func NewDistributedKeyGeneration(id int, participantCount int, threshold int, group *secretshare.PedersenGroup) (*DistributedKeyGeneration, error){
+	if (participantCount < 3){
+		return nil, errors.New("Invalid participant count. Should be larger than 2.")
+	}
+	if (id < 0 || (id >= participantCount)){
+		return nil, errors.New("Invalid id, should be between 0 and participantCount-1")
+	}
+	if (threshold < 1 || threshold > (participantCount / 2)){
+		return nil, errors.New("Threshold should be positive and never greater than 1/2 of the participant count.")
+	}
+	secretSharing, err := secretshare.NewPedersenSecretSharingBigInt(participantCount, group)
+	if (err != nil) {return nil, err}
+	access, err := secretshare.NewThresholdAccessStructure(participantCount, threshold)
+	if (err != nil) {return nil, err}
+	err = secretSharing.SetAccessStructure(access)
+	if (err != nil) {return nil, err}
+	feldmanSharing, err := secretshare.NewFeldmanSecretSharingBigInt(participantCount, &group.FeldmanGroup)
+	if (err != nil) {return nil, err}
+	err = feldmanSharing.SetAccessStructure(access)
+	if (err != nil) {return nil, err}
+
+	feedback := new(DistributedKeyGeneration)
+	feedback.id = id
+	feedback.participantCount = participantCount
+	feedback.threshold = threshold
+	feedback.secretSharing = secretSharing
+	feedback.feldmanSharing = feldmanSharing
+	feedback.receivedShares = make([]*secretshare.SecretShare, participantCount)
+	feedback.receivedCommitments = make([][]*big.Int, participantCount)
+	feedback.complaints = make([][]bool, participantCount)
+	feedback.answered = make([][]bool, participantCount)
+	feedback.reconstructionShares = make([][]*secretshare.SecretShare, participantCount)
+	for i := 0; i < participantCount; i++{
+		feedback.complaints[i] = make([]bool, participantCount)
+		feedback.answered[i] = make([]bool, participantCount)
+		feedback.reconstructionShares[i] = make([]*secretshare.SecretShare, participantCount)
+	}
+	feedback.complaintsReceived = make([]bool, participantCount)
+	feedback.disqualified = make([]bool, participantCount)
+	feedback.feldmanCommitments = make([][]*big.Int, participantCount)
+	feedback.extractionComplaintsReceived = make([]bool, participantCount)
+	feedback.exposed = make([]bool, participantCount)
+	return feedback, nil
+}
+
+/**
+ * Get ID of this participant.
+ *
+ * @return ID of this participant.
+ */
+func (dkg *DistributedKeyGeneration) GetID() int{
+	return dkg.id
+}
+
+/**
+ * Get the Pedersen's secret sharing scheme, e.g. for verifying the final shares.
+ *
+ * @return The secret sharing scheme object.
+ */
+func (dkg *DistributedKeyGeneration) GetSecretSharing() *secretshare.PedersenSecretSharingBigInt{
+	return dkg.secretSharing
+}
+
+/**
+ * Deal a random value during the deal stage.
+ * <p>
+ * The sub-share of participant <i>j</i> should be sent to participant <i>j</i> privately,
+ * and the commitments should be broadcast.
+ *
+ * @return The sub-shares for all participants.
+ * @return The commitments to the polynomials.
+ * @return error If random numbers cannot be generated, or the value has been dealt.
+ */
+func (dkg *DistributedKeyGeneration) GenerateDeal() ([]*secretshare.SecretShare, []*big.Int, error){
+	if (dkg.dealtShares != nil){
+		return nil, nil, errors.New("Value has been dealt.")
+	}
+	secret, err := rand.Int(rand.Reader, dkg.secretSharing.GetModulus().(*big.Int))
+	if (err != nil) {return nil, nil, err}
+	shares, commitments, err := dkg.secretSharing.GenerateSharesWithCommitments(secret, nil)
+	if (err != nil) {return nil, nil, err}
+	dkg.dealtShares = shares
+	dkg.receivedShares[dkg.id] = shares[dkg.id] //itself
+	dkg.receivedCommitments[dkg.id] = commitments
+	return shares, commitments, nil
+}
+
+/**
+ * Add the sub-share and the commitments received from a dealer during the deal stage.
+ * <p>
+ * An invalid sub-share is kept, and the dealer will be complained during the complaint stage.
+ *
+ * @param from The id of the dealer.
+ * @param share The sub-share received privately.
+ * @param commitments The commitments broadcast by the dealer.
+ * @return error If the id of the dealer is invalid, the commitments are missing, or the deal stage is closed.
+ */
+func (dkg *DistributedKeyGeneration) AddReceivedDeal(from int, share *secretshare.SecretShare, commitments []*big.Int) error{
+	if ((from < 0) || (from >= dkg.participantCount)){
+		return errors.New("Invalid ID of the dealer.")
+	}
+	if (commitments == nil){
+		return errors.New("Commitments should not be nil.")
+	}
+	if (dkg.dealClosed){
+		return errors.New("Deal stage is closed.")
+	}
+	dkg.receivedShares[from] = share
+	dkg.receivedCommitments[from] = commitments
+	return nil
+}
+
+/**
+ * Test if the deals of all participants are received.
+ *
+ * @return True if all deals are received, otherwise return false.
+ */
+func (dkg *DistributedKeyGeneration) HasAllDealReceived() bool{
+	for i := 0; i < dkg.participantCount; i++{
+		if (dkg.receivedCommitments[i] == nil) {return false}
+	}
+	return true
+}
+
+/**
+ * Close the deal stage, e.g. when it times out before all deals are received.
+ * <p>
+ * The participants who have not dealt are disqualified, and their complaints are no longer waited for.
+ */
+func (dkg *DistributedKeyGeneration) CloseDealStage(){
+	dkg.dealClosed = true
+}
+
+/**
+ * Generate the complaints during the complaint stage, i.e. the dealers whose sub-shares are invalid.
+ *
+ * @return IDs of the dealers complained, should be broadcast.
+ * @return error If the deals of all participants are not received and the deal stage is not closed.
+ */
+func (dkg *DistributedKeyGeneration) GenerateComplaints() ([]int, error){
+	if (!dkg.HasAllDealReceived() && !dkg.dealClosed){
+		return nil, errors.New("Complaints cannot be generated before all deals are received.")
+	}
+	feedback := make([]int, 0)
+	for i := 0; i < dkg.participantCount; i++{
+		if (dkg.receivedCommitments[i] == nil) {continue} // never qualified
+		if (!dkg.isValidSubShare(i, dkg.id, dkg.receivedShares[i])){
+			feedback = append(feedback, i)
+		}
+	}
+	err := dkg.AddReceivedComplaints(dkg.id, feedback)
+	if (err != nil) {return nil, err}
+	return feedback, nil
+}
+
+/**
+ * Add the complaints received from a participant during the complaint stage.
+ *
+ * @param from The id of the participant who complains.
+ * @param accused IDs of the dealers complained.
+ * @return error If any id is invalid.
+ */
+func (dkg *DistributedKeyGeneration) AddReceivedComplaints(from int, accused []int) error{
+	if ((from < 0) || (from >= dkg.participantCount)){
+		return errors.New("Invalid ID of the complaining participant.")
+	}
+	for _, dealer := range accused{
+		if ((dealer < 0) || (dealer >= dkg.participantCount)){
+			return errors.New("Invalid ID of the accused dealer.")
+		}
+	}
+	for _, dealer := range accused{
+		dkg.complaints[dealer][from] = true
+	}
+	dkg.complaintsReceived[from] = true
+	return nil
+}
+
+/**
+ * Test if the complaints of all participants are received, except those who have not dealt before the deal stage is closed.
+ *
+ * @return True if all complaints are received, otherwise return false.
+ */
+func (dkg *DistributedKeyGeneration) HasAllComplaintsReceived() bool{
+	for i := 0; i < dkg.participantCount; i++{
+		if (!dkg.complaintsReceived[i] && dkg.isActive(i)) {return false}
+	}
+	return true
+}
+
+/**
+ * Generate the responses to the complaints against this participant during the response stage.
+ * <p>
+ * The sub-shares of the complaining participants are revealed, and should be broadcast.
+ *
+ * @return The sub-shares of the participants complaining against this participant.
+ * @return error If the complaints of all participants are not received, or the value has not been dealt.
+ */
+func (dkg *DistributedKeyGeneration) GenerateResponses() ([]*secretshare.SecretShare, error){
+	if (!dkg.HasAllComplaintsReceived()){
+		return nil, errors.New("Responses cannot be generated before all complaints are received.")
+	}
+	if (dkg.dealtShares == nil){
+		return nil, errors.New("Value has not been dealt.")
+	}
+	feedback := make([]*secretshare.SecretShare, 0)
+	for j := 0; j < dkg.participantCount; j++{
+		if (dkg.complaints[dkg.id][j]){
+			feedback = append(feedback, dkg.dealtShares[j])
+			dkg.answered[dkg.id][j] = true
+		}
+	}
+	return feedback, nil
+}
+
+/**
+ * Add a response received from a dealer during the response stage.
+ * <p>
+ * The revealed sub-share is verified against the commitments of the dealer. The dealer is disqualified
+ * if it is invalid. If it is valid and belongs to this participant, it replaces the invalid one received before.
+ *
+ * @param from The id of the dealer.
+ * @param share The revealed sub-share of a complaining participant.
+ * @return error If the id of the dealer is invalid, or the share answers no complaint.
+ */
+func (dkg *DistributedKeyGeneration) AddReceivedResponse(from int, share *secretshare.SecretShare) error{
+	if ((from < 0) || (from >= dkg.participantCount)){
+		return errors.New("Invalid ID of the dealer.")
+	}
+	if (share == nil){
+		return errors.New("Share should not be nil.")
+	}
+	complainer := share.GetParticipant()
+	if ((complainer < 0) || (complainer >= dkg.participantCount) || !dkg.complaints[from][complainer]){
+		return errors.New("The response answers no complaint.")
+	}
+	dkg.answered[from][complainer] = true
+	if (!dkg.isValidSubShare(from, complainer, share)){
+		dkg.disqualified[from] = true
+		return nil
+	}
+	if (complainer == dkg.id){
+		dkg.receivedShares[from] = share
+	}
+	return nil
+}
+
+/**
+ * Get the qualified dealers, whose values make up the shared secret.
+ * <p>
+ * A dealer is disqualified if it is complained by threshold or more participants,
+ * or any complaint against it is not answered with a valid sub-share.
+ *
+ * @return IDs of the qualified dealers.
+ */
+func (dkg *DistributedKeyGeneration) GetQualifiedSet() []int{
+	feedback := make([]int, 0)
+	for i := 0; i < dkg.participantCount; i++{
+		if (dkg.isQualified(i)) {feedback = append(feedback, i)}
+	}
+	return feedback
+}
+
+/**
+ * Get the commitments to the shared secret, i.e. the products of the commitments of the qualified dealers.
+ * The final shares of all participants can be verified against them.
+ * <p>
+ * These are Pedersen's commitments, which hide the secret. The public key is given by <code>GetPublicKey</code>.
+ *
+ * @return The commitments to the shared secret.
+ * @return error If the complaints of all participants are not received.
+ */
+func (dkg *DistributedKeyGeneration) GetCommitments() ([]*big.Int, error){
+	if (!dkg.HasAllComplaintsReceived()){
+		return nil, errors.New("Commitments cannot be calculated before all complaints are received.")
+	}
+	p := dkg.secretSharing.GetGroup().GetP()
+	feedback := make([]*big.Int, dkg.threshold)
+	for k := 0; k < dkg.threshold; k++{
+		feedback[k] = big.NewInt(1)
+	}
+	for _, i := range dkg.GetQualifiedSet(){
+		for k := 0; k < dkg.threshold; k++{
+			feedback[k].Mul(feedback[k], dkg.receivedCommitments[i][k]).Mod(feedback[k], p)
+		}
+	}
+	return feedback, nil
+}
+
+/**
+ * Calculate the share of the shared secret of this participant, i.e. the sum of the sub-shares from the qualified dealers.
+ *
+ * @return The share of this participant, with PedersenSecretShareValue.
+ * @return error If the complaints of all participants are not received, or this participant holds an invalid
+ *         sub-share from a qualified dealer.
+ */
+func (dkg *DistributedKeyGeneration) Finalize() (*secretshare.SecretShare, error){
+	if (!dkg.HasAllComplaintsReceived()){
+		return nil, errors.New("Share cannot be calculated before all complaints are received.")
+	}
+	q := dkg.secretSharing.GetModulus().(*big.Int)
+	qr := big.NewInt(0)
+	br := big.NewInt(0)
+	for _, i := range dkg.GetQualifiedSet(){
+		if (!dkg.isValidSubShare(i, dkg.id, dkg.receivedShares[i])){
+			return nil, errors.New("Invalid sub-share from a qualified dealer.")
+		}
+		value := dkg.receivedShares[i].GetValue().(*secretshare.PedersenSecretShareValue)
+		qr.Add(qr, value.GetQr().(*big.Int)).Mod(qr, q)
+		br.Add(br, value.GetBr().(*big.Int)).Mod(br, q)
+	}
+	return secretshare.NewSecretShare(dkg.id, secretshare.NewPedersenSecretShareValue(dkg.evaluationPoint(dkg.id), qr, br)), nil
+}
+
+/**
+ * Generate Feldman's commitments of this participant during the extraction stage, i.e. <i>g</i><sup><i>a<sub>k</sub></i></sup>
+ * for the coefficients of its secret polynomial, which should be broadcast.
+ *
+ * @return Feldman's commitments <i>A</i><sub>0</sub>, <i>A</i><sub>1</sub>, ..., <i>A</i><sub><i>k</i>-1</sub>.
+ * @return error If the complaints of all participants are not received, or this participant is not a qualified dealer.
+ */
+func (dkg *DistributedKeyGeneration) GenerateFeldmanCommitments() ([]*big.Int, error){
+	if (!dkg.HasAllComplaintsReceived()){
+		return nil, errors.New("Feldman's commitments cannot be generated before all complaints are received.")
+	}
+	if (dkg.dealtShares == nil || !dkg.isQualified(dkg.id)){
+		return nil, errors.New("Only qualified dealers generate Feldman's commitments.")
+	}
+	if (dkg.feldmanCommitments[dkg.id] == nil){
+		commitments, err := dkg.extractCommitments(dkg.dealtShares[:dkg.threshold])
+		if (err != nil) {return nil, err}
+		dkg.feldmanCommitments[dkg.id] = commitments
+	}
+	return dkg.feldmanCommitments[dkg.id], nil
+}
+
+/**
+ * Add Feldman's commitments received from a dealer during the extraction stage.
+ *
+ * @param from The id of the dealer.
+ * @param commitments Feldman's commitments broadcast by the dealer.
+ * @return error If the id of the dealer or the commitments are invalid, or the extraction stage is closed.
+ */
+func (dkg *DistributedKeyGeneration) AddReceivedFeldmanCommitments(from int, commitments []*big.Int) error{
+	if ((from < 0) || (from >= dkg.participantCount)){
+		return errors.New("Invalid ID of the dealer.")
+	}
+	if (len(commitments) != dkg.threshold){
+		return errors.New("Number of commitments should be equal to threshold.")
+	}
+	for k := 0; k < len(commitments); k++{
+		if (commitments[k] == nil) {return errors.New("Commitment should not be nil.")}
+	}
+	if (dkg.extractionClosed){
+		return errors.New("Extraction stage is closed.")
+	}
+	dkg.feldmanCommitments[from] = commitments
+	return nil
+}
+
+/**
+ * Test if Feldman's commitments of all qualified dealers are received.
+ *
+ * @return True if all Feldman's commitments are received, otherwise return false.
+ */
+func (dkg *DistributedKeyGeneration) HasAllFeldmanCommitmentsReceived() bool{
+	for _, i := range dkg.GetQualifiedSet(){
+		if (dkg.feldmanCommitments[i] == nil) {return false}
+	}
+	return true
+}
+
+/**
+ * Close the extraction stage, e.g. when it times out before Feldman's commitments of all qualified dealers are received.
+ * <p>
+ * The polynomials of the qualified dealers who have not published Feldman's commitments are reconstructed.
+ */
+func (dkg *DistributedKeyGeneration) CloseExtractionStage(){
+	dkg.extractionClosed = true
+	for _, i := range dkg.GetQualifiedSet(){
+		if (dkg.feldmanCommitments[i] == nil) {dkg.exposed[i] = true}
+	}
+}
+
+/**
+ * Generate the extraction complaints, i.e. the qualified dealers whose sub-shares are not on their Feldman's commitments.
+ * <p>
+ * The sub-shares are revealed as the evidence, and should be broadcast together with the dealers.
+ *
+ * @return IDs of the dealers complained.
+ * @return The sub-shares received from these dealers, one for each dealer.
+ * @return error If Feldman's commitments of all qualified dealers are not received and the extraction stage is not closed.
+ */
+func (dkg *DistributedKeyGeneration) GenerateExtractionComplaints() ([]int, []*secretshare.SecretShare, error){
+	if (!dkg.HasAllFeldmanCommitmentsReceived() && !dkg.extractionClosed){
+		return nil, nil, errors.New("Extraction complaints cannot be generated before all Feldman's commitments are received.")
+	}
+	accused := make([]int, 0)
+	shares := make([]*secretshare.SecretShare, 0)
+	for _, i := range dkg.GetQualifiedSet(){
+		if (dkg.feldmanCommitments[i] == nil) {continue}
+		if (!dkg.isValidFeldmanSubShare(i, dkg.receivedShares[i])){
+			accused = append(accused, i)
+			shares = append(shares, dkg.receivedShares[i])
+		}
+	}
+	err := dkg.AddReceivedExtractionComplaints(dkg.id, accused, shares)
+	if (err != nil) {return nil, nil, err}
+	return accused, shares, nil
+}
+
+/**
+ * Add the extraction complaints received from a participant.
+ * <p>
+ * A complaint is valid if the revealed sub-share lies on the Pedersen's commitments of the dealer but not on its Feldman's
+ * commitments, then the polynomial of the dealer is reconstructed. Invalid complaints are ignored.
+ *
+ * @param from The id of the participant who complains.
+ * @param accused IDs of the dealers complained.
+ * @param shares The sub-shares revealed, one for each dealer.
+ * @return error If any id is invalid, or the numbers of dealers and sub-shares are different.
+ */
+func (dkg *DistributedKeyGeneration) AddReceivedExtractionComplaints(from int, accused []int, shares []*secretshare.SecretShare) error{
+	err := dkg.checkRevealedShares(from, accused, shares)
+	if (err != nil) {return err}
+	for k, dealer := range accused{
+		if (!dkg.isQualified(dealer) || dkg.feldmanCommitments[dealer] == nil) {continue}
+		if (dkg.isValidSubShare(dealer, from, shares[k]) && !dkg.isValidFeldmanSubShare(dealer, shares[k])){
+			dkg.exposed[dealer] = true
+		}
+	}
+	dkg.extractionComplaintsReceived[from] = true
+	return nil
+}
+
+/**
+ * Test if the extraction complaints of all participants are received, except those who have not dealt before the deal stage is closed.
+ *
+ * @return True if all extraction complaints are received, otherwise return false.
+ */
+func (dkg *DistributedKeyGeneration) HasAllExtractionComplaintsReceived() bool{
+	for i := 0; i < dkg.participantCount; i++{
+		if (!dkg.extractionComplaintsReceived[i] && dkg.isActive(i)) {return false}
+	}
+	return true
+}
+
+/**
+ * Get the qualified dealers whose polynomials are reconstructed publicly, because of a valid extraction complaint
+ * or missing Feldman's commitments.
+ *
+ * @return IDs of the dealers.
+ */
+func (dkg *DistributedKeyGeneration) GetExposedDealers() []int{
+	feedback := make([]int, 0)
+	for _, i := range dkg.GetQualifiedSet(){
+		if (dkg.exposed[i]) {feedback = append(feedback, i)}
+	}
+	return feedback
+}
+
+/**
+ * Generate the sub-shares of this participant from the exposed dealers during the reconstruction stage, which should be broadcast.
+ *
+ * @return IDs of the exposed dealers.
+ * @return The sub-shares received from these dealers, one for each dealer.
+ * @return error If the extraction complaints of all participants are not received.
+ */
+func (dkg *DistributedKeyGeneration) GenerateReconstructionShares() ([]int, []*secretshare.SecretShare, error){
+	if (!dkg.HasAllExtractionComplaintsReceived()){
+		return nil, nil, errors.New("Reconstruction shares cannot be generated before all extraction complaints are received.")
+	}
+	dealers := dkg.GetExposedDealers()
+	shares := make([]*secretshare.SecretShare, len(dealers))
+	for k, dealer := range dealers{
+		shares[k] = dkg.receivedShares[dealer]
+	}
+	err := dkg.AddReceivedReconstructionShares(dkg.id, dealers, shares)
+	if (err != nil) {return nil, nil, err}
+	return dealers, shares, nil
+}
+
+/**
+ * Add the sub-shares of exposed dealers received from a participant during the reconstruction stage.
+ * <p>
+ * Sub-shares not on the Pedersen's commitments of the dealer are ignored.
+ *
+ * @param from The id of the participant who reveals the sub-shares.
+ * @param dealers IDs of the exposed dealers.
+ * @param shares The sub-shares revealed, one for each dealer.
+ * @return error If any id is invalid, or the numbers of dealers and sub-shares are different.
+ */
+func (dkg *DistributedKeyGeneration) AddReceivedReconstructionShares(from int, dealers []int, shares []*secretshare.SecretShare) error{
+	err := dkg.checkRevealedShares(from, dealers, shares)
+	if (err != nil) {return err}
+	for k, dealer := range dealers{
+		if (dkg.exposed[dealer] && dkg.isValidSubShare(dealer, from, shares[k])){
+			dkg.reconstructionShares[dealer][from] = shares[k]
+		}
+	}
+	return nil
+}
+
+/**
+ * Get Feldman's commitments to the shared polynomial, i.e. the products of Feldman's commitments of the qualified dealers,
+ * where the commitments of the exposed dealers are calculated from their reconstructed polynomials.
+ *
+ * @return Feldman's commitments to the shared polynomial, the first of which is the public key.
+ * @return error If the extraction complaints of all participants are not received,
+ *         or an exposed dealer has not enough valid sub-shares revealed.
+ */
+func (dkg *DistributedKeyGeneration) GetFeldmanCommitments() ([]*big.Int, error){
+	if (!dkg.HasAllExtractionComplaintsReceived()){
+		return nil, errors.New("Feldman's commitments cannot be calculated before all extraction complaints are received.")
+	}
+	p := dkg.secretSharing.GetGroup().GetP()
+	feedback := make([]*big.Int, dkg.threshold)
+	for k := 0; k < dkg.threshold; k++{
+		feedback[k] = big.NewInt(1)
+	}
+	for _, i := range dkg.GetQualifiedSet(){
+		commitments := dkg.feldmanCommitments[i]
+		if (dkg.exposed[i]){
+			shares := make([]*secretshare.SecretShare, 0, dkg.threshold)
+			for j := 0; j < dkg.participantCount && len(shares) < dkg.threshold; j++{
+				if (dkg.reconstructionShares[i][j] != nil) {shares = append(shares, dkg.reconstructionShares[i][j])}
+			}
+			if (len(shares) < dkg.threshold){
+				return nil, errors.New("Not enough sub-shares to reconstruct an exposed dealer.")
+			}
+			var err error
+			commitments, err = dkg.extractCommitments(shares)
+			if (err != nil) {return nil, err}
+		}
+		for k := 0; k < dkg.threshold; k++{
+			feedback[k].Mul(feedback[k], commitments[k]).Mod(feedback[k], p)
+		}
+	}
+	return feedback, nil
+}
+
+/**
+ * Get the public key <i>y</i> = <i>g</i><sup><i>x</i></sup> of the shared secret <i>x</i>.
+ *
+ * @return The public key.
+ * @return error If Feldman's commitments to the shared polynomial cannot be calculated.
+ */
+func (dkg *DistributedKeyGeneration) GetPublicKey() (*big.Int, error){
+	commitments, err := dkg.GetFeldmanCommitments()
+	if (err != nil) {return nil, err}
+	return commitments[0], nil
+}
+
+/**
+ * Get the public key of the final share of a participant, i.e. <i>g</i><sup><i>x<sub>j</sub></i></sup> for its share <i>x<sub>j</sub></i>,
+ * e.g. for verifying its partial signatures.
+ *
+ * @param participant ID of the participant.
+ * @return The public key of the share.
+ * @return error If the id is invalid, or Feldman's commitments to the shared polynomial cannot be calculated.
+ */
+func (dkg *DistributedKeyGeneration) GetPublicKeyShare(participant int) (*big.Int, error){
+	if ((participant < 0) || (participant >= dkg.participantCount)){
+		return nil, errors.New("Invalid ID of the participant.")
+	}
+	commitments, err := dkg.GetFeldmanCommitments()
+	if (err != nil) {return nil, err}
+	group := dkg.secretSharing.GetGroup()
+	x := dkg.evaluationPoint(participant)
+	feedback := big.NewInt(1)
+	power := big.NewInt(1)
+	for k := 0; k < len(commitments); k++{
+		tmp := big.NewInt(0)
+		tmp.Exp(commitments[k], power, group.GetP())
+		feedback.Mul(feedback, tmp).Mod(feedback, group.GetP())
+		power.Mul(power, x).Mod(power, group.GetQ())
+	}
+	return feedback, nil
+}
+
+/**
+ * Test if a participant is waited for in the following stages, i.e. the deal stage is not closed or it has dealt.
+ *
+ * @param participant ID of the participant.
+ * @return True if the participant is waited for, otherwise return false.
+ */
+func (dkg *DistributedKeyGeneration) isActive(participant int) bool{
+	return (!dkg.dealClosed || dkg.receivedCommitments[participant] != nil)
+}
+
+/**
+ * Check the dealers and the sub-shares revealed by a participant.
+ *
+ * @param from The id of the participant.
+ * @param dealers IDs of the dealers.
+ * @param shares The sub-shares, one for each dealer.
+ * @return error If any id is invalid, or the numbers of dealers and sub-shares are different.
+ */
+func (dkg *DistributedKeyGeneration) checkRevealedShares(from int, dealers []int, shares []*secretshare.SecretShare) error{
+	if ((from < 0) || (from >= dkg.participantCount)){
+		return errors.New("Invalid ID of the participant.")
+	}
+	if (len(dealers) != len(shares)){
+		return errors.New("Every dealer should come with one sub-share.")
+	}
+	for _, dealer := range dealers{
+		if ((dealer < 0) || (dealer >= dkg.participantCount)){
+			return errors.New("Invalid ID of the dealer.")
+		}
+	}
+	return nil
+}
+
+/**
+ * Calculate Feldman's commitments of a polynomial from threshold sub-shares on it.
+ *
+ * @param shares Threshold sub-shares with PedersenSecretShareValue.
+ * @return Feldman's commitments to the coefficients of the secret polynomial.
+ * @return error If the sub-shares are invalid.
+ */
+func (dkg *DistributedKeyGeneration) extractCommitments(shares []*secretshare.SecretShare) ([]*big.Int, error){
+	points := make([]*big.Int, len(shares))
+	values := make([]interface{}, len(shares))
+	for k := 0; k < len(shares); k++{
+		value, ok := shares[k].GetValue().(*secretshare.PedersenSecretShareValue)
+		if (!ok) {return nil, errors.New("Invalid type of share value, should be PedersenSecretShareValue.")}
+		points[k] = value.GetR().(*big.Int)
+		values[k] = value.GetQr()
+	}
+	interpolation, err := poly.NewLagrangeInterpolationBigInt(points, dkg.secretSharing.GetModulus().(*big.Int))
+	if (err != nil) {return nil, err}
+	polynomial, err := interpolation.InterpolatePolynomial(values)
+	if (err != nil) {return nil, err}
+	return dkg.feldmanSharing.GetGroup().Commit(polynomial.GetCoefficients())
+}
+
+/**
+ * Test if a sub-share from a dealer lies on Feldman's commitments of the dealer.
+ *
+ * @param dealer ID of the dealer.
+ * @param share The sub-share with PedersenSecretShareValue.
+ * @return True if the sub-share lies on the committed polynomial, otherwise return false.
+ */
+func (dkg *DistributedKeyGeneration) isValidFeldmanSubShare(dealer int, share *secretshare.SecretShare) bool{
+	if (share == nil) {return false}
+	value, ok := share.GetValue().(*secretshare.PedersenSecretShareValue)
+	if (!ok) {return false}
+	shamirShare := secretshare.NewSecretShare(share.GetParticipant(), value.GetShamirSecretShareValue())
+	valid, err := dkg.feldmanSharing.VerifyShare(shamirShare, dkg.feldmanCommitments[dealer])
+	return (err == nil && valid)
+}
+
+/**
+ * Test if a dealer is qualified.
+ *
+ * @param dealer ID of the dealer.
+ * @return True if the dealer is qualified, otherwise return false.
+ */
+func (dkg *DistributedKeyGeneration) isQualified(dealer int) bool{
+	if (dkg.disqualified[dealer] || len(dkg.receivedCommitments[dealer]) != dkg.threshold) {return false}
+	count := 0
+	for j := 0; j < dkg.participantCount; j++{
+		if (!dkg.complaints[dealer][j]) {continue}
+		count++
+		if (!dkg.answered[dealer][j]) {return false}
+	}
+	return count < dkg.threshold
+}
+
+/**
+ * Test if a sub-share from a dealer to a participant is valid.
+ *
+ * @param dealer ID of the dealer.
+ * @param participant ID of the participant who owns the sub-share.
+ * @param share The sub-share.
+ * @return True if the sub-share belongs to the participant and lies on the committed polynomials, otherwise return false.
+ */
+func (dkg *DistributedKeyGeneration) isValidSubShare(dealer int, participant int, share *secretshare.SecretShare) bool{
+	if (share == nil || share.GetParticipant() != participant){
+		return false
+	}
+	value, ok := share.GetValue().(*secretshare.PedersenSecretShareValue)
+	if (!ok) {return false}
+	r, ok := value.GetR().(*big.Int)
+	if (!ok || r.Cmp(dkg.evaluationPoint(participant)) != 0) {return false}
+	valid, err := dkg.secretSharing.VerifyShare(share, dkg.receivedCommitments[dealer])
+	return (err == nil && valid)
+}
+
+/**
+ * Get the evaluation point of a participant, i.e. ID + 1.
+ *
+ * @param participant ID of the participant.
+ * @return The evaluation point.
+ */
+func (dkg *DistributedKeyGeneration) evaluationPoint(participant int) *big.Int{
+	return big.NewInt(int64(participant + 1))
+}
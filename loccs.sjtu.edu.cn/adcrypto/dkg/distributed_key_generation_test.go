@@ -0,0 +1,254 @@
+package dkg
+
+import (
+	"testing"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+)
+
+func TestDistributedKeyGenerationProcedure(t *testing.T) {
+	participantCount := 7
+	threshold := 3
+	q,_ := rand.Prime(rand.Reader,128)
+	group, err := secretshare.GeneratePedersenGroup(q, 256)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating PedersenGroup: %s", err))}
+
+	dkg := make([]*DistributedKeyGeneration, participantCount)
+	for i := 0; i < participantCount; i++{
+		dkg[i], err = NewDistributedKeyGeneration(i, participantCount, threshold, group)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing DistributedKeyGeneration: %s", err))}
+	}
+
+	// deal stage, dealer 2 sends a bad sub-share to participant 4 but answers the complaint honestly,
+	// dealer 5 sends a bad sub-share to participant 1 and answers the complaint with it again
+	deals := make([][]*secretshare.SecretShare, participantCount)
+	commitments := make([][]*big.Int, participantCount)
+	badShares := map[[2]int]*secretshare.SecretShare{}
+	for i := 0; i < participantCount; i++{
+		deals[i], commitments[i], err = dkg[i].GenerateDeal()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating deal: %s", err))}
+	}
+	for _, cheat := range [][2]int{{2, 4}, {5, 1}}{
+		value := deals[cheat[0]][cheat[1]].GetValue().(*secretshare.PedersenSecretShareValue)
+		wrong := big.NewInt(1)
+		wrong.Add(wrong, value.GetQr().(*big.Int)).Mod(wrong, q)
+		badShares[cheat] = secretshare.NewSecretShare(cheat[1], secretshare.NewPedersenSecretShareValue(value.GetR(), wrong, value.GetBr()))
+	}
+	for i := 0; i < participantCount; i++{
+		for j := 0; j < participantCount; j++{
+			if (i == j) {continue}
+			share := deals[i][j]
+			if bad, ok := badShares[[2]int{i, j}]; ok {share = bad}
+			err = dkg[j].AddReceivedDeal(i, share, commitments[i])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received deal: %s", err))}
+		}
+	}
+
+	// complaint stage
+	for i := 0; i < participantCount; i++{
+		complaints, err := dkg[i].GenerateComplaints()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating complaints: %s", err))}
+		expected := "[]"
+		if (i == 4) {expected = "[2]"}
+		if (i == 1) {expected = "[5]"}
+		if (fmt.Sprint(complaints) != expected){
+			t.Error(fmt.Sprintf("Complaints of participant %d are %v, expected %s", i, complaints, expected))
+		}
+		for j := 0; j < participantCount; j++{
+			if (i == j) {continue}
+			err = dkg[j].AddReceivedComplaints(i, complaints)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received complaints: %s", err))}
+		}
+	}
+
+	// response stage
+	for i := 0; i < participantCount; i++{
+		responses, err := dkg[i].GenerateResponses()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating responses: %s", err))}
+		if (i == 5) {responses = []*secretshare.SecretShare{badShares[[2]int{5, 1}]}}
+		for _, response := range responses{
+			for j := 0; j < participantCount; j++{
+				if (i == j) {continue}
+				err = dkg[j].AddReceivedResponse(i, response)
+				if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received response: %s", err))}
+			}
+		}
+	}
+
+	// finalize, dealer 5 is disqualified by all honest participants
+	finalShares := make([]*secretshare.SecretShare, 0)
+	var finalCommitments []*big.Int
+	for i := 0; i < participantCount; i++{
+		if (i == 5) {continue}
+		qualified := dkg[i].GetQualifiedSet()
+		if (fmt.Sprint(qualified) != "[0 1 2 3 4 6]"){
+			t.Error(fmt.Sprintf("Qualified set of participant %d is %v", i, qualified))
+		}
+		share, err := dkg[i].Finalize()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when finalizing: %s", err))}
+		finalShares = append(finalShares, share)
+		finalCommitments, err = dkg[i].GetCommitments()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when getting commitments: %s", err))}
+		valid, err := dkg[i].GetSecretSharing().VerifyShare(share, finalCommitments)
+		if (err != nil || !valid) {t.Error(fmt.Sprintf("Final share of participant %d is not valid.", i))}
+	}
+
+	// the shared secret is the sum of the values of the qualified dealers
+	scheme := dkg[0].GetSecretSharing()
+	expected := big.NewInt(0)
+	for _, i := range []int{0, 1, 2, 3, 4, 6}{
+		value, err := scheme.CalculateSecret(deals[i])
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating dealt value: %s", err))}
+		expected.Add(expected, value.(*big.Int)).Mod(expected, q)
+	}
+	for start := 0; start + threshold <= len(finalShares); start++{
+		secret, err := scheme.CalculateSecret(finalShares[start:start+threshold])
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (secret.(*big.Int).Cmp(expected) != 0){
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s",secret,expected))
+		}
+	}
+
+	// extraction stage, dealer 3 publishes wrong Feldman's commitments and is reconstructed
+	group3 := dkg[0].GetSecretSharing().GetGroup()
+	for i := 0; i < participantCount; i++{
+		feldman, err := dkg[i].GenerateFeldmanCommitments()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating Feldman's commitments: %s", err))}
+		if (i == 3){
+			feldman = append([]*big.Int{}, feldman...)
+			feldman[1] = big.NewInt(0).Mul(feldman[1], group3.GetG())
+			feldman[1].Mod(feldman[1], group3.GetP())
+		}
+		for j := 0; j < participantCount; j++{
+			if (i == j) {continue}
+			err = dkg[j].AddReceivedFeldmanCommitments(i, feldman)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding Feldman's commitments: %s", err))}
+		}
+	}
+	for i := 0; i < participantCount; i++{
+		accused, shares, err := dkg[i].GenerateExtractionComplaints()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating extraction complaints: %s", err))}
+		for j := 0; j < participantCount; j++{
+			if (i == j) {continue}
+			err = dkg[j].AddReceivedExtractionComplaints(i, accused, shares)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding extraction complaints: %s", err))}
+		}
+	}
+	for i := 0; i < participantCount; i++{
+		dealers, shares, err := dkg[i].GenerateReconstructionShares()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating reconstruction shares: %s", err))}
+		for j := 0; j < participantCount; j++{
+			if (i == j) {continue}
+			err = dkg[j].AddReceivedReconstructionShares(i, dealers, shares)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding reconstruction shares: %s", err))}
+		}
+	}
+
+	// the public key is g^x for the shared secret x, and every final share matches its public key share
+	publicKey := big.NewInt(0).Exp(group3.GetG(), expected, group3.GetP())
+	for k, i := range []int{0, 1, 2, 3, 4, 6}{
+		if (i == 3) {continue} // the cheater keeps its own commitments
+		if (fmt.Sprint(dkg[i].GetExposedDealers()) != "[3]"){
+			t.Error(fmt.Sprintf("Exposed dealers of participant %d are %v, expected [3]", i, dkg[i].GetExposedDealers()))
+		}
+		key, err := dkg[i].GetPublicKey()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when getting public key: %s", err))}
+		if (key.Cmp(publicKey) != 0) {t.Error(fmt.Sprintf("Public key of participant %d is False.", i))}
+		keyShare, err := dkg[0].GetPublicKeyShare(i)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when getting public key share: %s", err))}
+		qr := finalShares[k].GetValue().(*secretshare.PedersenSecretShareValue).GetQr().(*big.Int)
+		if (keyShare.Cmp(big.NewInt(0).Exp(group3.GetG(), qr, group3.GetP())) != 0){
+			t.Error(fmt.Sprintf("Public key share of participant %d is False.", i))
+		}
+	}
+}
+
+func TestDistributedKeyGenerationDropouts(t *testing.T) {
+	participantCount := 6
+	threshold := 2
+	q,_ := rand.Prime(rand.Reader,128)
+	group, err := secretshare.GeneratePedersenGroup(q, 256)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating PedersenGroup: %s", err))}
+	dkg := make([]*DistributedKeyGeneration, participantCount)
+	for i := 0; i < participantCount; i++{
+		dkg[i], err = NewDistributedKeyGeneration(i, participantCount, threshold, group)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing DistributedKeyGeneration: %s", err))}
+	}
+	online := []int{0, 1, 2, 3, 4}
+
+	// participant 5 never deals, the others close the deal stage
+	deals := make([][]*secretshare.SecretShare, participantCount)
+	for _, i := range online{
+		shares, commitments, err := dkg[i].GenerateDeal()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating deal: %s", err))}
+		deals[i] = shares
+		for _, j := range online{
+			if (i != j) {dkg[j].AddReceivedDeal(i, shares[j], commitments)}
+		}
+	}
+	for _, i := range online{
+		if (dkg[i].HasAllDealReceived()) {t.Error("Deal of participant 5 should be missing.")}
+		_, err = dkg[i].GenerateComplaints()
+		if err == nil {t.Error("Complaints should not be generated before the deal stage is closed.")}
+		dkg[i].CloseDealStage()
+	}
+	for _, i := range online{
+		complaints, err := dkg[i].GenerateComplaints()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating complaints: %s", err))}
+		for _, j := range online{
+			if (i != j) {dkg[j].AddReceivedComplaints(i, complaints)}
+		}
+	}
+
+	// dealer 2 never publishes Feldman's commitments, the others close the extraction stage
+	for _, i := range online{
+		if (!dkg[i].HasAllComplaintsReceived()) {t.Fatal("Complaints of participant 5 should not be waited for.")}
+		if (fmt.Sprint(dkg[i].GetQualifiedSet()) != "[0 1 2 3 4]"){
+			t.Error(fmt.Sprintf("Qualified set of participant %d is %v", i, dkg[i].GetQualifiedSet()))
+		}
+		if (i == 2) {continue}
+		feldman, err := dkg[i].GenerateFeldmanCommitments()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating Feldman's commitments: %s", err))}
+		for _, j := range online{
+			if (i != j) {dkg[j].AddReceivedFeldmanCommitments(i, feldman)}
+		}
+	}
+	for _, i := range online{
+		dkg[i].CloseExtractionStage()
+	}
+	for _, i := range online{
+		accused, shares, err := dkg[i].GenerateExtractionComplaints()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating extraction complaints: %s", err))}
+		for _, j := range online{
+			if (i != j) {dkg[j].AddReceivedExtractionComplaints(i, accused, shares)}
+		}
+	}
+	_, err = dkg[0].GetPublicKey()
+	if err == nil {t.Error("Public key should not be calculated before dealer 2 is reconstructed.")}
+	for _, i := range online{
+		dealers, shares, err := dkg[i].GenerateReconstructionShares()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating reconstruction shares: %s", err))}
+		for _, j := range online{
+			if (i != j) {dkg[j].AddReceivedReconstructionShares(i, dealers, shares)}
+		}
+	}
+
+	scheme := dkg[0].GetSecretSharing()
+	expected := big.NewInt(0)
+	for _, i := range online{
+		value, err := scheme.CalculateSecret(deals[i])
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating dealt value: %s", err))}
+		expected.Add(expected, value.(*big.Int)).Mod(expected, q)
+	}
+	publicKey := big.NewInt(0).Exp(group.GetG(), expected, group.GetP())
+	for _, i := range online{
+		if (fmt.Sprint(dkg[i].GetExposedDealers()) != "[2]"){
+			t.Error(fmt.Sprintf("Exposed dealers of participant %d are %v, expected [2]", i, dkg[i].GetExposedDealers()))
+		}
+		key, err := dkg[i].GetPublicKey()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when getting public key: %s", err))}
+		if (key.Cmp(publicKey) != 0) {t.Error(fmt.Sprintf("Public key of participant %d is False.", i))}
+	}
+}
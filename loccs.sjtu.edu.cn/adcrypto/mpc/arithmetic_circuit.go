@@ -0,0 +1,356 @@
+package mpc
+
+import "errors"
+
+/**
+ * Types of the gates in an arithmetic circuit.
+ */
+type GateType int
+
+const (
+	/**
+	 * Addition of two wires.
+	 */
+	GateAdd GateType = iota
+
+	/**
+	 * Addition of a wire and a public constant.
+	 */
+	GateAddConstant
+
+	/**
+	 * Multiplication of a wire and a public constant.
+	 */
+	GateMultiplyConstant
+
+	/**
+	 * Multiplication of two wires, which requires a round of communication.
+	 */
+	GateMultiply
+)
+
+/**
+ * A gate in an arithmetic circuit.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type ArithmeticGate struct {
+	/**
+	 * Type of the gate.
+	 */
+	gateType GateType
+
+	/**
+	 * The left input wire.
+	 */
+	left int
+
+	/**
+	 * The right input wire, -1 if the gate has only one input wire.
+	 */
+	right int
+
+	/**
+	 * The public constant, nil if the gate has no constant.
+	 */
+	constant interface{}
+
+	/**
+	 * Multiplicative depth of the output wire, i.e. the communication round after which the gate can be evaluated.
+	 */
+	depth int
+}
+
+/**
+ * Get type of the gate.
+ *
+ * @return Type of the gate.
+ */
+func (gate *ArithmeticGate) GetGateType() GateType{
+	return gate.gateType
+}
+
+/**
+ * Get the left input wire.
+ *
+ * @return The left input wire.
+ */
+func (gate *ArithmeticGate) GetLeft() int{
+	return gate.left
+}
+
+/**
+ * Get the right input wire.
+ *
+ * @return The right input wire, -1 if the gate has only one input wire.
+ */
+func (gate *ArithmeticGate) GetRight() int{
+	return gate.right
+}
+
+/**
+ * Get the public constant.
+ *
+ * @return The public constant, nil if the gate has no constant.
+ */
+func (gate *ArithmeticGate) GetConstant() interface{}{
+	return gate.constant
+}
+
+/**
+ * This class implements a public arithmetic circuit over <i>Zp</i>.
+ * <p>
+ * Wires 0, 1, ..., <i>n</i>-1 carry the private inputs of participants 0, 1, ..., <i>n</i>-1, and each added gate
+ * creates a new wire carrying its result, i.e. the <i>k</i>-th gate outputs wire <i>n</i>+<i>k</i>. Since a gate can
+ * only use existing wires, the gates are always in topological order.
+ * <p>
+ * Addition, addition of a constant and multiplication by a constant are evaluated locally on the shares,
+ * while multiplications of two wires with the same multiplicative depth are evaluated together in one round of communication.
+ * Only the wires designated as outputs are reconstructed.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type ArithmeticCircuit struct {
+	/**
+	 * Number of input wires, i.e. number of participants.
+	 */
+	inputCount int
+
+	/**
+	 * Gates of the circuit in topological order.
+	 */
+	gates []*ArithmeticGate
+
+	/**
+	 * Multiplicative depth of every wire.
+	 */
+	depths []int
+
+	/**
+	 * The output wires.
+	 */
+	outputs []int
+}
+
+/**
+ * Construct an empty arithmetic circuit with the number of participants.
+ *
+ * @param participantCount Number of participants, each has one input wire.
+ * @return feedback The constructed ArithmeticCircuit
+ * @return error If the number of participants is invalid.
+ */
+func NewArithmeticCircuit(participantCount int) (*ArithmeticCircuit, error){
+	if (participantCount < 1){
+		return nil, errors.New("Invalid participant count. Should be larger than 0.")
+	}
+	feedback := new(ArithmeticCircuit)
+	feedback.inputCount = participantCount
+	feedback.gates = make([]*ArithmeticGate, 0)
+	feedback.depths = make([]int, participantCount)
+	feedback.outputs = make([]int, 0)
+	return feedback, nil
+}
+
+/**
+ * Get the input wire of a participant.
+ *
+ * @param participant ID of the participant.
+ * @return The input wire.
+ * @return error If the participant ID is invalid.
+ */
+func (circuit *ArithmeticCircuit) GetInputWire(participant int) (int, error){
+	if (participant < 0 || participant >= circuit.inputCount){
+		return -1, errors.New("Invalid participant ID.")
+	}
+	return participant, nil
+}
+
+/**
+ * Add a gate calculating the sum of two wires.
+ *
+ * @param left The left input wire.
+ * @param right The right input wire.
+ * @return The output wire of the gate.
+ * @return error If any input wire is invalid.
+ */
+func (circuit *ArithmeticCircuit) AddAddGate(left int, right int) (int, error){
+	return circuit.addGate(GateAdd, left, right, nil)
+}
+
+/**
+ * Add a gate calculating the sum of a wire and a public constant.
+ *
+ * @param wire The input wire.
+ * @param constant The public constant.
+ * @return The output wire of the gate.
+ * @return error If the input wire or the constant is invalid.
+ */
+func (circuit *ArithmeticCircuit) AddAddConstantGate(wire int, constant interface{}) (int, error){
+	if (constant == nil){
+		return -1, errors.New("Constant should not be nil.")
+	}
+	return circuit.addGate(GateAddConstant, wire, -1, constant)
+}
+
+/**
+ * Add a gate calculating the product of a wire and a public constant.
+ *
+ * @param wire The input wire.
+ * @param constant The public constant.
+ * @return The output wire of the gate.
+ * @return error If the input wire or the constant is invalid.
+ */
+func (circuit *ArithmeticCircuit) AddMultiplyConstantGate(wire int, constant interface{}) (int, error){
+	if (constant == nil){
+		return -1, errors.New("Constant should not be nil.")
+	}
+	return circuit.addGate(GateMultiplyConstant, wire, -1, constant)
+}
+
+/**
+ * Add a gate calculating the product of two wires.
+ *
+ * @param left The left input wire.
+ * @param right The right input wire.
+ * @return The output wire of the gate.
+ * @return error If any input wire is invalid.
+ */
+func (circuit *ArithmeticCircuit) AddMultiplyGate(left int, right int) (int, error){
+	return circuit.addGate(GateMultiply, left, right, nil)
+}
+
+/**
+ * Add a gate to the circuit, and calculate the multiplicative depth of its output wire.
+ *
+ * @param gateType Type of the gate.
+ * @param left The left input wire.
+ * @param right The right input wire, -1 if the gate has only one input wire.
+ * @param constant The public constant, nil if the gate has no constant.
+ * @return The output wire of the gate.
+ * @return error If any input wire is invalid.
+ */
+func (circuit *ArithmeticCircuit) addGate(gateType GateType, left int, right int, constant interface{}) (int, error){
+	if (left < 0 || left >= circuit.GetWireCount()){
+		return -1, errors.New("Invalid input wire.")
+	}
+	depth := circuit.depths[left]
+	if (gateType == GateAdd || gateType == GateMultiply){
+		if (right < 0 || right >= circuit.GetWireCount()){
+			return -1, errors.New("Invalid input wire.")
+		}
+		if (circuit.depths[right] > depth) {depth = circuit.depths[right]}
+	}
+	if (gateType == GateMultiply) {depth++}
+
+	gate := new(ArithmeticGate)
+	gate.gateType = gateType
+	gate.left = left
+	gate.right = right
+	gate.constant = constant
+	gate.depth = depth
+	circuit.gates = append(circuit.gates, gate)
+	circuit.depths = append(circuit.depths, depth)
+	return circuit.GetWireCount() - 1, nil
+}
+
+/**
+ * Designate a wire as an output of the circuit.
+ *
+ * @param wire The output wire.
+ * @return error If the wire is invalid.
+ */
+func (circuit *ArithmeticCircuit) AddOutput(wire int) error{
+	if (wire < 0 || wire >= circuit.GetWireCount()){
+		return errors.New("Invalid output wire.")
+	}
+	circuit.outputs = append(circuit.outputs, wire)
+	return nil
+}
+
+/**
+ * Get the number of input wires, i.e. number of participants.
+ *
+ * @return Number of input wires.
+ */
+func (circuit *ArithmeticCircuit) GetInputCount() int{
+	return circuit.inputCount
+}
+
+/**
+ * Get the number of wires, including input wires.
+ *
+ * @return Number of wires.
+ */
+func (circuit *ArithmeticCircuit) GetWireCount() int{
+	return circuit.inputCount + len(circuit.gates)
+}
+
+/**
+ * Get the gate whose output is the given wire.
+ *
+ * @param wire The output wire of the gate.
+ * @return The gate.
+ * @return error If the wire is invalid or it is an input wire.
+ */
+func (circuit *ArithmeticCircuit) GetGate(wire int) (*ArithmeticGate, error){
+	if (wire < circuit.inputCount || wire >= circuit.GetWireCount()){
+		return nil, errors.New("Invalid gate wire.")
+	}
+	return circuit.gates[wire - circuit.inputCount], nil
+}
+
+/**
+ * Get the output wires.
+ *
+ * @return The output wires.
+ */
+func (circuit *ArithmeticCircuit) GetOutputs() []int{
+	return circuit.outputs
+}
+
+/**
+ * Get the number of communication rounds for multiplications, i.e. the multiplicative depth of the circuit.
+ *
+ * @return Number of rounds.
+ */
+func (circuit *ArithmeticCircuit) GetRoundCount() int{
+	feedback := 0
+	for i := 0; i < len(circuit.depths); i++{
+		if (circuit.depths[i] > feedback) {feedback = circuit.depths[i]}
+	}
+	return feedback
+}
+
+/**
+ * Get the output wires of the multiplication gates evaluated in a round.
+ *
+ * @param round The round, starting from 1.
+ * @return The output wires of the multiplication gates, in topological order.
+ */
+func (circuit *ArithmeticCircuit) GetRoundMultiplications(round int) []int{
+	feedback := make([]int, 0)
+	for i := 0; i < len(circuit.gates); i++{
+		if (circuit.gates[i].gateType == GateMultiply && circuit.gates[i].depth == round){
+			feedback = append(feedback, circuit.inputCount + i)
+		}
+	}
+	return feedback
+}
+
+/**
+ * Copy the circuit, so that gates added to the original later do not change the copy.
+ * <p>
+ * The gates are never modified after being added, so they are shared by the copy.
+ *
+ * @return The copy of the circuit.
+ */
+func (circuit *ArithmeticCircuit) clone() *ArithmeticCircuit{
+	feedback := new(ArithmeticCircuit)
+	feedback.inputCount = circuit.inputCount
+	feedback.gates = append([]*ArithmeticGate(nil), circuit.gates...)
+	feedback.depths = append([]int(nil), circuit.depths...)
+	feedback.outputs = append([]int(nil), circuit.outputs...)
+	return feedback
+}
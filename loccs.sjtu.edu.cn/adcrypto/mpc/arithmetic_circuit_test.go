@@ -0,0 +1,48 @@
+package mpc
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestArithmeticCircuit(t *testing.T) {
+	circuit, err := NewArithmeticCircuit(4)
+	if err != nil {t.Error(fmt.Sprintf("Error happens when constructing ArithmeticCircuit: %s", err))}
+
+	// f = (x0 + x1) * x2 * x3 + 7 * x3 + 2
+	sum, err := circuit.AddAddGate(0, 1)
+	if err != nil {t.Error(fmt.Sprintf("Error happens when adding gates: %s", err))}
+	product, err := circuit.AddMultiplyGate(sum, 2)
+	if err != nil {t.Error(fmt.Sprintf("Error happens when adding gates: %s", err))}
+	product, err = circuit.AddMultiplyGate(product, 3)
+	if err != nil {t.Error(fmt.Sprintf("Error happens when adding gates: %s", err))}
+	scaled, err := circuit.AddMultiplyConstantGate(3, 7)
+	if err != nil {t.Error(fmt.Sprintf("Error happens when adding gates: %s", err))}
+	result, err := circuit.AddAddGate(product, scaled)
+	if err != nil {t.Error(fmt.Sprintf("Error happens when adding gates: %s", err))}
+	result, err = circuit.AddAddConstantGate(result, 2)
+	if err != nil {t.Error(fmt.Sprintf("Error happens when adding gates: %s", err))}
+	err = circuit.AddOutput(result)
+	if err != nil {t.Error(fmt.Sprintf("Error happens when adding outputs: %s", err))}
+
+	if circuit.GetWireCount() != 10 {
+		t.Error(fmt.Sprintf("Wire count is False, Result:%d ,Expected: %d", circuit.GetWireCount(), 10))
+	}
+	if circuit.GetRoundCount() != 2 {
+		t.Error(fmt.Sprintf("Round count is False, Result:%d ,Expected: %d", circuit.GetRoundCount(), 2))
+	}
+	if multiplications := circuit.GetRoundMultiplications(2); len(multiplications) != 1 || multiplications[0] != 6 {
+		t.Error(fmt.Sprintf("Multiplications of round 2 are False, Result:%d ,Expected: %d", multiplications, []int{6}))
+	}
+
+	// invalid wires should be rejected
+	if _, err = circuit.AddAddGate(0, 10); err == nil {
+		t.Error("Gate with an invalid input wire is accepted.")
+	}
+	if _, err = circuit.AddMultiplyConstantGate(0, nil); err == nil {
+		t.Error("Gate without constant is accepted.")
+	}
+	if err = circuit.AddOutput(-1); err == nil {
+		t.Error("Invalid output wire is accepted.")
+	}
+}
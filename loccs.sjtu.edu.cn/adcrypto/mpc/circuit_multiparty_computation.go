@@ -0,0 +1,496 @@
+package mpc
+
+import (
+	"errors"
+)
+
+/**
+ * Abstract class for secure multi-party computation of a public arithmetic circuit.
+ * <p>
+ * The abstract class <code>CircuitMultipartyComputation</code> generalizes <code>LinearMultipartyComputation</code> to
+ * any function described by an <code>ArithmeticCircuit</code>, following the BGW protocol:
+ * <ol>
+ *     <li> Input stage: every participant shares its secret with <code>LinearMultipartyComputation</code>.
+ *     <li> Computation stage: the circuit is evaluated gate by gate on the shares. Linear gates are evaluated locally,
+ *          and the multiplication gates of the same multiplicative depth are evaluated together in one round,
+ *          during which every participant sends one message (one re-shared product for each multiplication gate)
+ *          to every other participant.
+ *     <li> Output stage: every participant sends its shares of the output wires, and the outputs are reconstructed
+ *          with <code>LinearMultipartyComputation</code>.
+ * </ol>
+ * <p>
+ * Note 1: In the scheme, all element should be in some <i>Zp</i>, i.e. should be non-negative integers.
+ * <p>
+ * Note 2: Each participant has a unique ID, start from 0 to <i>n</i>-1.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+
+type CircuitMultipartyComputation struct {
+	/**
+	* ID of this participant.
+	*/
+	id int
+
+	/**
+	 * Number of participants.
+	 */
+	participantCount int
+
+	/**
+	 * Threshold <i>t</i>. Max number of semi-honest adversaries.
+	 */
+	threshold int
+
+	/**
+	 * The public arithmetic circuit.
+	 */
+	circuit *ArithmeticCircuit
+
+	/**
+	 * The linear mpc used for the input stage and the output stage.
+	 */
+	linearMultipartyComputation *LinearMultipartyComputation
+
+	/**
+	 * Shares of the wires held by this participant, nil if not evaluated.
+	 */
+	wireValues []interface{}
+
+	/**
+	 * Number of finished rounds.
+	 */
+	round int
+
+	/**
+	 * The multiplications of the current round, one for each multiplication gate.
+	 */
+	multiplications []MultiplicationMultipartyComputationInterface
+
+	/**
+	 * Whether the message of the current round has been received from each participant.
+	 */
+	roundMessageReceived []bool
+
+	/**
+	 * Messages received before this participant starts the round they belong to, indexed by the sender.
+	 */
+	pendingRoundMessages [][]interface{}
+
+	/**
+	 * The outputs received from other participants during the output stage, one map for each output wire.
+	 */
+	receivedOutputs []map[int]interface{}
+
+	/**
+	* Abstract Interfaces of CircuitMultipartyComputation
+	*/
+	circuitMultipartyComputationCalculator CircuitMultipartyComputationInterface
+}
+
+type CircuitMultipartyComputationInterface interface {
+
+	InitializeWithModulus(modulus interface{}) error
+
+	GetModulus() interface{}
+
+	GetCircuit() *ArithmeticCircuit
+
+	GenerateInputAuxiliary() ([]interface{},error)
+
+	GenerateInputs(secret interface{}, auxiliary []interface{}) ([]interface{},error)
+
+	AddReceivedInput(from int, input interface{}) error
+
+	HasAllInputReceived() bool
+
+	HasNextRound() bool
+
+	GenerateRoundMessages() ([][]interface{}, error)
+
+	AddReceivedRoundMessage(from int, message []interface{}) error
+
+	HasAllRoundMessageReceived() bool
+
+	FinishRound() error
+
+	GenerateOutputs() ([]interface{}, error)
+
+	AddReceivedOutputs(from int, outputs []interface{}) error
+
+	Compute() ([]interface{},error)
+
+	Reset()
+
+	/**
+	* Abstract method of getting a multiplication mpc object for a multiplication gate.
+	*
+	* @return The proper multiplication mpc object.
+	* @return error If the multiplication mpc object cannot be constructed.
+	*/
+	getMultiplicationMultipartyComputation() (MultiplicationMultipartyComputationInterface, error)
+
+	/**
+	* Abstract method of adding two elements modulo <i>p</i>.
+	*
+	* @param a The first element.
+	* @param b The second element.
+	* @return The sum modulo <i>p</i>.
+	*/
+	addElements(a interface{}, b interface{}) interface{}
+
+	/**
+	* Abstract method of multiplying two elements modulo <i>p</i>.
+	*
+	* @param a The first element.
+	* @param b The second element.
+	* @return The product modulo <i>p</i>.
+	*/
+	multiplyElements(a interface{}, b interface{}) interface{}
+
+	/**
+	* Abstract method of checking if the constant is valid, i.e. in <i>Zp</i>.
+	*
+	* @param constant The constant.
+	* @param modulus The modulus <i>p</i>.
+	* @return True if the constant is valid, otherwise return false.
+	*/
+	checkConstant(constant interface{}, modulus interface{}) bool
+
+	/**
+	* Abstract method of checking if the type of input element is valid.
+	*
+	* @param e Element to be checked.
+	* @return True if the type of input element is valid, otherwise return false.
+	*/
+	checkElement(e interface{}) bool
+}
+
+/**
+ * Set the modulus of the Shamir's scheme.
+ * <p>
+ * The modulus should be large enough so that all the wires of the circuit never overflow.
+ *
+ * @param modulus Modulus of the Shamir's scheme.
+ * @return error IllegalArgumentException If the modulus or any constant in the circuit is invalid.
+ */
+func (cmpc *CircuitMultipartyComputation) InitializeWithModulus(modulus interface{}) error{
+	if (!cmpc.circuitMultipartyComputationCalculator.checkElement(modulus)){
+		return errors.New("Invalid type of modulus.")
+	}
+	for i := cmpc.circuit.GetInputCount(); i < cmpc.circuit.GetWireCount(); i++{
+		gate, _ := cmpc.circuit.GetGate(i)
+		if (gate.gateType == GateAddConstant || gate.gateType == GateMultiplyConstant){
+			if (!cmpc.circuitMultipartyComputationCalculator.checkElement(gate.constant) ||
+				!cmpc.circuitMultipartyComputationCalculator.checkConstant(gate.constant, modulus)){
+				return errors.New("Invalid constant in the circuit.")
+			}
+		}
+	}
+	return cmpc.linearMultipartyComputation.linearMultipartyComputationCalculator.InitializeSimpleSumWithModulus(modulus)
+}
+
+/**
+ * Return the modulus in the Shamir's secret sharing scheme.
+ *
+ * @return The modulus <i>p</i>.
+ */
+func (cmpc *CircuitMultipartyComputation) GetModulus() interface{}{
+	return cmpc.linearMultipartyComputation.GetModulus()
+}
+
+/**
+ * Return a copy of the public arithmetic circuit.
+ *
+ * @return The arithmetic circuit.
+ */
+func (cmpc *CircuitMultipartyComputation) GetCircuit() *ArithmeticCircuit{
+	return cmpc.circuit.clone()
+}
+
+/**
+ * Generate random auxiliary data in Shamir's scheme.
+ *
+ * @return Random auxiliary data.
+ * @return error If Secret sharing scheme has not been set.
+ */
+func (cmpc *CircuitMultipartyComputation) GenerateInputAuxiliary() ([]interface{},error){
+	return cmpc.linearMultipartyComputation.GenerateInputAuxiliary()
+}
+
+/**
+ * Generate inputs for all participants during the input stage.
+ *
+ * @param secret The secret value of this participant.
+ * @param auxiliary The auxiliary data for generating Shamir's secret shares.
+ * @return The inputs for all participants.
+ * @return error If the secret value or the auxiliary data is invalid, or the modulus is not set.
+ */
+func (cmpc *CircuitMultipartyComputation) GenerateInputs(secret interface{}, auxiliary []interface{}) ([]interface{},error){
+	return cmpc.linearMultipartyComputation.GenerateInputs(secret, auxiliary)
+}
+
+/**
+ * Add an input when received from other participant during the input stage.
+ *
+ * @param from The id of the participant who sent the input.
+ * @param input The input value received.
+ * @return error If the id of the participant or the input value is invalid.
+ */
+func (cmpc *CircuitMultipartyComputation) AddReceivedInput(from int, input interface{}) error{
+	return cmpc.linearMultipartyComputation.AddReceivedInput(from, input)
+}
+
+/**
+ * Test if all <i>n</i>-1 inputs are received from other participants.
+ *
+ * @return True if all inputs are received, otherwise return false.
+ */
+func (cmpc *CircuitMultipartyComputation) HasAllInputReceived() bool{
+	return cmpc.linearMultipartyComputation.HasAllInputReceived()
+}
+
+/**
+ * Test if there are multiplication rounds left.
+ *
+ * @return True if there are rounds left, otherwise return false.
+ */
+func (cmpc *CircuitMultipartyComputation) HasNextRound() bool{
+	return cmpc.round < cmpc.circuit.GetRoundCount()
+}
+
+/**
+ * Start the next round, and generate the messages for all participants.
+ * <p>
+ * The message for participant <i>j</i> contains one re-shared product for each multiplication gate of the round,
+ * in the order given by <code>ArithmeticCircuit.GetRoundMultiplications</code>.
+ *
+ * @return The messages for all participants.
+ * @return error If not all inputs are received, or no round is left.
+ */
+func (cmpc *CircuitMultipartyComputation) GenerateRoundMessages() ([][]interface{}, error){
+	if (!cmpc.HasAllInputReceived()){
+		return nil, errors.New("Rounds cannot be started before all inputs are received.")
+	}
+	if (!cmpc.HasNextRound()){
+		return nil, errors.New("No round is left.")
+	}
+	if (cmpc.multiplications != nil){
+		return nil, errors.New("The current round has not been finished.")
+	}
+	cmpc.evaluateLocalGates()
+
+	gates := cmpc.circuit.GetRoundMultiplications(cmpc.round + 1)
+	multiplications := make([]MultiplicationMultipartyComputationInterface, len(gates))
+	messages := make([][]interface{}, cmpc.participantCount)
+	for j := 0; j < cmpc.participantCount; j++{
+		messages[j] = make([]interface{}, len(gates))
+	}
+	for k := 0; k < len(gates); k++{
+		gate, _ := cmpc.circuit.GetGate(gates[k])
+		multiplication, err := cmpc.circuitMultipartyComputationCalculator.getMultiplicationMultipartyComputation()
+		if (err != nil) {return nil, err}
+		err = multiplication.Initialize(cmpc.GetModulus(), cmpc.linearMultipartyComputation.auxiliary)
+		if (err != nil) {return nil, err}
+		inputs, err := multiplication.GenerateInputs(cmpc.wireValues[gate.left], cmpc.wireValues[gate.right])
+		if (err != nil) {return nil, err}
+		for j := 0; j < cmpc.participantCount; j++{
+			messages[j][k] = inputs[j]
+		}
+		multiplications[k] = multiplication
+	}
+	cmpc.multiplications = multiplications
+	cmpc.roundMessageReceived = make([]bool, cmpc.participantCount)
+
+	// apply the messages of this round which are received in advance
+	for from := 0; from < cmpc.participantCount; from++{
+		message := cmpc.pendingRoundMessages[from]
+		if (message == nil) {continue}
+		cmpc.pendingRoundMessages[from] = nil
+		err := cmpc.AddReceivedRoundMessage(from, message)
+		if (err != nil) {return nil, err}
+	}
+	return messages, nil
+}
+
+/**
+ * Add a message when received from other participant during the current round.
+ * <p>
+ * Since other participants may start the next round earlier, a message received before this participant
+ * starts the round, or after the message of the current round from the same participant, is kept
+ * until the next round is started.
+ *
+ * @param from The id of the participant who sent the message.
+ * @param message The message received.
+ * @return error If the id of the participant or the message is invalid.
+ */
+func (cmpc *CircuitMultipartyComputation) AddReceivedRoundMessage(from int, message []interface{}) error{
+	if ((from < 0) || (from >= cmpc.participantCount)){
+		return errors.New("Invalid ID of the received message.")
+	}
+	if (cmpc.multiplications == nil || cmpc.roundMessageReceived[from]){
+		if (cmpc.pendingRoundMessages[from] != nil){
+			return errors.New("Message of the next round has already been received.")
+		}
+		cmpc.pendingRoundMessages[from] = message
+		return nil
+	}
+	if (message == nil || len(message) != len(cmpc.multiplications)){
+		return errors.New("Number of values in the message should be equal to number of multiplications in the round.")
+	}
+	for k := 0; k < len(message); k++{
+		err := cmpc.multiplications[k].AddReceivedInput(from, message[k])
+		if (err != nil) {return err}
+	}
+	cmpc.roundMessageReceived[from] = true
+	return nil
+}
+
+/**
+ * Test if all <i>n</i>-1 messages of the current round are received from other participants.
+ *
+ * @return True if all messages are received, otherwise return false.
+ */
+func (cmpc *CircuitMultipartyComputation) HasAllRoundMessageReceived() bool{
+	if (cmpc.multiplications == nil) {return false}
+	for k := 0; k < len(cmpc.multiplications); k++{
+		if (!cmpc.multiplications[k].HasAllInputReceived()) {return false}
+	}
+	return true
+}
+
+/**
+ * Finish the current round, i.e. reduce the degree of the products and assign them to the output wires of the multiplication gates.
+ *
+ * @return error If not all messages of the current round are received.
+ */
+func (cmpc *CircuitMultipartyComputation) FinishRound() error{
+	if (!cmpc.HasAllRoundMessageReceived()){
+		return errors.New("Round cannot be finished before all messages are received.")
+	}
+	gates := cmpc.circuit.GetRoundMultiplications(cmpc.round + 1)
+	for k := 0; k < len(gates); k++{
+		output, err := cmpc.multiplications[k].GenerateOutput()
+		if (err != nil) {return err}
+		cmpc.wireValues[gates[k]] = output
+	}
+	cmpc.multiplications = nil
+	cmpc.roundMessageReceived = nil
+	cmpc.round++
+	return nil
+}
+
+/**
+ * Generate the outputs during the output stage, i.e. the shares of the output wires.
+ *
+ * @return The output values, one for each output wire.
+ * @return error If not all inputs are received, or some rounds are left.
+ */
+func (cmpc *CircuitMultipartyComputation) GenerateOutputs() ([]interface{}, error){
+	if (!cmpc.HasAllInputReceived()){
+		return nil, errors.New("Output cannot be generated before all inputs are received.")
+	}
+	if (cmpc.HasNextRound()){
+		return nil, errors.New("Output cannot be generated before all rounds are finished.")
+	}
+	cmpc.evaluateLocalGates()
+
+	wires := cmpc.circuit.GetOutputs()
+	outputs := make([]interface{}, len(wires))
+	cmpc.receivedOutputs = make([]map[int]interface{}, len(wires))
+	for k := 0; k < len(wires); k++{
+		outputs[k] = cmpc.wireValues[wires[k]]
+		cmpc.receivedOutputs[k] = map[int]interface{} {cmpc.id: outputs[k]}
+	}
+	return outputs, nil
+}
+
+/**
+ * Add outputs when received from other participant during the output stage.
+ *
+ * @param from The id of the participant who sent the outputs.
+ * @param outputs The output values received, one for each output wire.
+ * @return error If the id of the participant or the output values are invalid.
+ */
+func (cmpc *CircuitMultipartyComputation) AddReceivedOutputs(from int, outputs []interface{}) error{
+	if (cmpc.receivedOutputs == nil){
+		return errors.New("Outputs should be received after generating outputs.")
+	}
+	if ((from < 0) || (from >= cmpc.participantCount)){
+		return errors.New("Invalid ID of the received output.")
+	}
+	if (outputs == nil || len(outputs) != len(cmpc.receivedOutputs)){
+		return errors.New("Number of outputs should be equal to number of output wires.")
+	}
+	for k := 0; k < len(outputs); k++{
+		if (!cmpc.circuitMultipartyComputationCalculator.checkElement(outputs[k])){
+			return errors.New("Invalid type of output.")
+		}
+	}
+	for k := 0; k < len(outputs); k++{
+		cmpc.receivedOutputs[k][from] = outputs[k]
+	}
+	return nil
+}
+
+/**
+ * Compute the values of the output wires.
+ *
+ * @return The values of the output wires.
+ * @return error If not enough outputs are received.
+ */
+func (cmpc *CircuitMultipartyComputation) Compute() ([]interface{},error){
+	if (cmpc.receivedOutputs == nil){
+		return nil, errors.New("Outputs should be computed after generating outputs.")
+	}
+	feedback := make([]interface{}, len(cmpc.receivedOutputs))
+	for k := 0; k < len(cmpc.receivedOutputs); k++{
+		cmpc.linearMultipartyComputation.receivedOutputs = map[int]interface{} {}
+		for from, output := range(cmpc.receivedOutputs[k]){
+			err := cmpc.linearMultipartyComputation.AddReceivedOutput(from, output)
+			if (err != nil) {return nil, err}
+		}
+		result, err := cmpc.linearMultipartyComputation.Compute()
+		if (err != nil) {return nil, err}
+		feedback[k] = result
+	}
+	return feedback, nil
+}
+
+/**
+ * Reset to time before input stage. And ready for the next evaluation of the circuit.
+ */
+func (cmpc *CircuitMultipartyComputation) Reset(){
+	cmpc.linearMultipartyComputation.Reset()
+	cmpc.wireValues = make([]interface{}, cmpc.circuit.GetWireCount())
+	cmpc.round = 0
+	cmpc.multiplications = nil
+	cmpc.roundMessageReceived = nil
+	cmpc.pendingRoundMessages = make([][]interface{}, cmpc.participantCount)
+	cmpc.receivedOutputs = nil
+}
+
+/**
+ * Evaluate all linear gates whose input wires are known, after loading the input wires from the input stage.
+ */
+func (cmpc *CircuitMultipartyComputation) evaluateLocalGates(){
+	for i := 0; i < cmpc.circuit.GetInputCount(); i++{
+		if (cmpc.wireValues[i] == nil){
+			cmpc.wireValues[i], _ = cmpc.linearMultipartyComputation.GetReceivedInput(i)
+		}
+	}
+	for i := cmpc.circuit.GetInputCount(); i < cmpc.circuit.GetWireCount(); i++{
+		gate, _ := cmpc.circuit.GetGate(i)
+		if (cmpc.wireValues[i] != nil || gate.depth > cmpc.round || gate.gateType == GateMultiply) {continue}
+		left := cmpc.wireValues[gate.left]
+		switch gate.gateType {
+		case GateAdd:
+			cmpc.wireValues[i] = cmpc.circuitMultipartyComputationCalculator.addElements(left, cmpc.wireValues[gate.right])
+		case GateAddConstant:
+			cmpc.wireValues[i] = cmpc.circuitMultipartyComputationCalculator.addElements(left, gate.constant)
+		case GateMultiplyConstant:
+			cmpc.wireValues[i] = cmpc.circuitMultipartyComputationCalculator.multiplyElements(left, gate.constant)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package mpc
+
+import (
+	"errors"
+	"math/big"
+)
+
+/**
+ * This class implements an BigInt secure multi-party arithmetic circuit computation.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type CircuitMultipartyComputationBigInt struct {
+	CircuitMultipartyComputation
+}
+
+/**
+ * Construct arithmetic circuit MPC scheme with number of participants, threshold, the ID of the participant and the circuit.
+ * <p>
+ * The threshold is the max number of semi-honest adversaries, should be less than <i>n</i>/2.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>.
+ * @param circuit The public arithmetic circuit, copied so that gates added to it later are ignored.
+ * @return feedback the constructed CircuitMultipartyComputationBigInt
+ * @return error IllegalArgumentException If any of ID, participantCount, threshold or circuit is invalid.
+ */
+func NewCircuitMultipartyComputationBigInt(id int, participantCount int, threshold int, circuit *ArithmeticCircuit)(*CircuitMultipartyComputationBigInt,error){
+	if (circuit == nil || circuit.GetInputCount() != participantCount){
+		return nil, errors.New("Number of circuit inputs should be equal to number of participants.")
+	}
+	linearMultipartyComputation, err := NewLinearMultipartyComputationBigInt(id, participantCount, threshold)
+	if (err != nil) {return nil, err}
+	feedback := new(CircuitMultipartyComputationBigInt)
+	feedback.id = id
+	feedback.participantCount = participantCount
+	feedback.threshold = threshold
+	feedback.circuit = circuit.clone()
+	feedback.linearMultipartyComputation = &linearMultipartyComputation.LinearMultipartyComputation
+	feedback.wireValues = make([]interface{}, feedback.circuit.GetWireCount())
+	feedback.pendingRoundMessages = make([][]interface{}, participantCount)
+	feedback.circuitMultipartyComputationCalculator = feedback
+	return feedback, nil
+}
+
+/**
+ * Get a BigInt multiplication mpc object for a multiplication gate.
+ *
+ * @return The proper multiplication mpc object.
+ * @return error If the multiplication mpc object cannot be constructed.
+ */
+func (cmpcb *CircuitMultipartyComputationBigInt) getMultiplicationMultipartyComputation() (MultiplicationMultipartyComputationInterface, error){
+	return NewMultiplicationMultipartyComputationBigInt(cmpcb.id, cmpcb.participantCount, cmpcb.threshold)
+}
+
+/**
+ * Add two BigInt elements modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The sum modulo <i>p</i>.
+ */
+func (cmpcb *CircuitMultipartyComputationBigInt) addElements(a interface{}, b interface{}) interface{}{
+	sum := big.NewInt(0)
+	sum.Add(a.(*big.Int), b.(*big.Int)).Mod(sum, cmpcb.GetModulus().(*big.Int))
+	return sum
+}
+
+/**
+ * Multiply two BigInt elements modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The product modulo <i>p</i>.
+ */
+func (cmpcb *CircuitMultipartyComputationBigInt) multiplyElements(a interface{}, b interface{}) interface{}{
+	product := big.NewInt(0)
+	product.Mul(a.(*big.Int), b.(*big.Int)).Mod(product, cmpcb.GetModulus().(*big.Int))
+	return product
+}
+
+/**
+ * Check if the BigInt constant is in <i>Zp</i>.
+ *
+ * @param constant The constant.
+ * @param modulus The modulus <i>p</i>.
+ * @return True if the constant is valid, otherwise return false.
+ */
+func (cmpcb *CircuitMultipartyComputationBigInt) checkConstant(constant interface{}, modulus interface{}) bool{
+	return constant.(*big.Int).Sign() >= 0 && constant.(*big.Int).Cmp(modulus.(*big.Int)) < 0
+}
+
+/**
+ * Check if the type of input element is valid.
+ *
+ * @param e Element to be checked.
+ * @return True if the type of input element is BigInt, otherwise return false.
+ */
+func (cmpcb *CircuitMultipartyComputationBigInt) checkElement(e interface{}) bool{
+	_, ok := e.(*big.Int)
+	return ok
+}
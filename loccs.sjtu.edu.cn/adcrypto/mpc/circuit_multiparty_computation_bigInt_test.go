@@ -0,0 +1,114 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestNewCircuitMultipartyComputationBigIntProcedure(t *testing.T) {
+	participantCount := 7
+	threshold := 3
+	cmpc := make([]*CircuitMultipartyComputationBigInt, participantCount) // mpc class for every party
+	max := big.NewInt(0)
+	max.SetString("10000000000",10)  // the max probable number for secret
+	secret := make([]*big.Int,participantCount)  // secrets
+	modulus, _ := rand.Prime(rand.Reader, 160)
+	var err error
+
+	// f1 = (x0 + x1) * x2 + 3 * x3 * x4 + 5, f2 = x0 * x5 * x6
+	circuit, _ := NewArithmeticCircuit(participantCount)
+	sum, _ := circuit.AddAddGate(0, 1)
+	left, _ := circuit.AddMultiplyGate(sum, 2)
+	right, _ := circuit.AddMultiplyGate(3, 4)
+	right, _ = circuit.AddMultiplyConstantGate(right, big.NewInt(3))
+	f1, _ := circuit.AddAddGate(left, right)
+	f1, _ = circuit.AddAddConstantGate(f1, big.NewInt(5))
+	f2, _ := circuit.AddMultiplyGate(0, 5)
+	f2, _ = circuit.AddMultiplyGate(f2, 6)
+	_ = circuit.AddOutput(f1)
+	_ = circuit.AddOutput(f2)
+
+	// initialize secrets
+	for i := 0; i < participantCount; i++{
+		secret[i],err = rand.Int(rand.Reader,max)
+	}
+
+	// construct and initialize mpcs
+	for i := 0; i < participantCount; i++{
+		cmpc[i],err = NewCircuitMultipartyComputationBigInt(i,participantCount,threshold,circuit)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when constructing CircuitMultipartyComputationBigInt: %s", err))}
+		err = cmpc[i].InitializeWithModulus(modulus)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	}
+
+	// generate auxiliary
+	auxi,err := cmpc[0].GenerateInputAuxiliary()
+	if err != nil {t.Error(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+
+	// input stage
+	for i := 0 ; i <participantCount; i++{
+		inputs, err := cmpc[i].GenerateInputs(secret[i],auxi)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount ; j++{
+			err = cmpc[j].AddReceivedInput(i,inputs[j])
+			if err != nil {t.Error(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// computation stage, round by round
+	rounds := 0
+	for cmpc[0].HasNextRound(){
+		for i := 0 ; i <participantCount; i++{
+			messages, err := cmpc[i].GenerateRoundMessages()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating round messages: %s", err))}
+			for j := 0; j < participantCount ; j++{
+				err = cmpc[j].AddReceivedRoundMessage(i,messages[j])
+				if err != nil {t.Error(fmt.Sprintf("Error happens when adding round messages: %s", err))}
+			}
+		}
+		for i := 0 ; i <participantCount; i++{
+			err = cmpc[i].FinishRound()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when finishing the round: %s", err))}
+		}
+		rounds++
+	}
+	if rounds != 2 {
+		t.Error(fmt.Sprintf("Number of rounds is False, Result:%d ,Expected: %d",rounds,2))
+	}
+
+	// output stage
+	outputs := make([][]interface{},participantCount)
+	for i := 0; i<participantCount; i++{
+		outputs[i], err = cmpc[i].GenerateOutputs()
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+	}
+	for i := 0; i<participantCount; i++{
+		for j := 0; j < participantCount; j++{
+			if i == j {continue}
+			err = cmpc[i].AddReceivedOutputs(j,outputs[j])
+			if err != nil {t.Error(fmt.Sprintf("Error happens after adding received outputs: %s", err))}
+		}
+	}
+
+	// calculate the true result(never do this in a real mpc procedure)
+	expected1 := big.NewInt(0)
+	expected1.Add(secret[0],secret[1]).Mul(expected1,secret[2])
+	tmp := big.NewInt(3)
+	tmp.Mul(tmp,secret[3]).Mul(tmp,secret[4])
+	expected1.Add(expected1,tmp).Add(expected1,big.NewInt(5))
+	expected2 := big.NewInt(1)
+	expected2.Mul(secret[0],secret[5]).Mul(expected2,secret[6])
+
+	// every party calculate the final results
+	for i := 0; i<participantCount; i++{
+		calculatedResult,err := cmpc[i].Compute()
+		if err != nil {t.Error(fmt.Sprintf("Error happens when calculating the final result: %s", err)); continue}
+		if calculatedResult[0].(*big.Int).Cmp(expected1) != 0 || calculatedResult[1].(*big.Int).Cmp(expected2) != 0 {
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s %s",calculatedResult,expected1,expected2))
+		}else{
+			t.Log(fmt.Sprintf("Calculate Result is True, Result:%s ,Expected %s %s",calculatedResult,expected1,expected2))
+		}
+	}
+}
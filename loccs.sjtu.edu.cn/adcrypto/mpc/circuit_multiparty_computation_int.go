@@ -0,0 +1,103 @@
+package mpc
+
+import (
+	"errors"
+)
+
+/**
+ * This class implements an Int secure multi-party arithmetic circuit computation.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type CircuitMultipartyComputationInt struct {
+	CircuitMultipartyComputation
+}
+
+/**
+ * Construct arithmetic circuit MPC scheme with number of participants, threshold, the ID of the participant and the circuit.
+ * <p>
+ * The threshold is the max number of semi-honest adversaries, should be less than <i>n</i>/2.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>.
+ * @param circuit The public arithmetic circuit, copied so that gates added to it later are ignored.
+ * @return feedback the constructed CircuitMultipartyComputationInt
+ * @return error IllegalArgumentException If any of ID, participantCount, threshold or circuit is invalid.
+ */
+func NewCircuitMultipartyComputationInt(id int, participantCount int, threshold int, circuit *ArithmeticCircuit)(*CircuitMultipartyComputationInt,error){
+	if (circuit == nil || circuit.GetInputCount() != participantCount){
+		return nil, errors.New("Number of circuit inputs should be equal to number of participants.")
+	}
+	linearMultipartyComputation, err := NewLinearMultipartyComputationInt(id, participantCount, threshold)
+	if (err != nil) {return nil, err}
+	feedback := new(CircuitMultipartyComputationInt)
+	feedback.id = id
+	feedback.participantCount = participantCount
+	feedback.threshold = threshold
+	feedback.circuit = circuit.clone()
+	feedback.linearMultipartyComputation = &linearMultipartyComputation.LinearMultipartyComputation
+	feedback.wireValues = make([]interface{}, feedback.circuit.GetWireCount())
+	feedback.pendingRoundMessages = make([][]interface{}, participantCount)
+	feedback.circuitMultipartyComputationCalculator = feedback
+	return feedback, nil
+}
+
+/**
+ * Get an Int multiplication mpc object for a multiplication gate.
+ *
+ * @return The proper multiplication mpc object.
+ * @return error If the multiplication mpc object cannot be constructed.
+ */
+func (cmpci *CircuitMultipartyComputationInt) getMultiplicationMultipartyComputation() (MultiplicationMultipartyComputationInterface, error){
+	return NewMultiplicationMultipartyComputationInt(cmpci.id, cmpci.participantCount, cmpci.threshold)
+}
+
+/**
+ * Add two Int elements modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The sum modulo <i>p</i>.
+ */
+func (cmpci *CircuitMultipartyComputationInt) addElements(a interface{}, b interface{}) interface{}{
+	modulus := int64(cmpci.GetModulus().(int))
+	sum := (int64(a.(int)) + int64(b.(int))) % modulus
+	return int((sum + modulus) % modulus)
+}
+
+/**
+ * Multiply two Int elements modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The product modulo <i>p</i>.
+ */
+func (cmpci *CircuitMultipartyComputationInt) multiplyElements(a interface{}, b interface{}) interface{}{
+	modulus := int64(cmpci.GetModulus().(int))
+	product := (int64(a.(int)) * int64(b.(int))) % modulus
+	return int((product + modulus) % modulus)
+}
+
+/**
+ * Check if the Int constant is in <i>Zp</i>.
+ *
+ * @param constant The constant.
+ * @param modulus The modulus <i>p</i>.
+ * @return True if the constant is valid, otherwise return false.
+ */
+func (cmpci *CircuitMultipartyComputationInt) checkConstant(constant interface{}, modulus interface{}) bool{
+	return constant.(int) >= 0 && constant.(int) < modulus.(int)
+}
+
+/**
+ * Check if the type of input element is valid.
+ *
+ * @param e Element to be checked.
+ * @return True if the type of input element is Int, otherwise return false.
+ */
+func (cmpci *CircuitMultipartyComputationInt) checkElement(e interface{}) bool{
+	_, ok := e.(int)
+	return ok
+}
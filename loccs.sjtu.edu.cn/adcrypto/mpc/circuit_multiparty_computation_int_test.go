@@ -0,0 +1,100 @@
+package mpc
+
+import (
+	"fmt"
+	"testing"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestNewCircuitMultipartyComputationIntProcedure(t *testing.T) {
+	participantCount := 5
+	threshold := 2
+	cmpc := make([]*CircuitMultipartyComputationInt, participantCount) // mpc class for every party
+	secret := make([]int,participantCount)  // secrets
+	modulus := 2147483647
+	var err error
+
+	// f = (x0 * x1 + 2 * x2) * x3 + x4 + 11
+	circuit, _ := NewArithmeticCircuit(participantCount)
+	product, _ := circuit.AddMultiplyGate(0, 1)
+	scaled, _ := circuit.AddMultiplyConstantGate(2, 2)
+	sum, _ := circuit.AddAddGate(product, scaled)
+	product, _ = circuit.AddMultiplyGate(sum, 3)
+	sum, _ = circuit.AddAddGate(product, 4)
+	sum, _ = circuit.AddAddConstantGate(sum, 11)
+	_ = circuit.AddOutput(sum)
+
+	// initialize secrets
+	for i := 0; i < participantCount; i++{
+		se ,_ := rand.Int(rand.Reader,big.NewInt(1000))
+		secret[i] = int(se.Int64())
+	}
+
+	// construct and initialize mpcs
+	for i := 0; i < participantCount; i++{
+		cmpc[i],err = NewCircuitMultipartyComputationInt(i,participantCount,threshold,circuit)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when constructing CircuitMultipartyComputationInt: %s", err))}
+		err = cmpc[i].InitializeWithModulus(modulus)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	}
+
+	// gates added after construction are not part of the computation
+	extra, _ := circuit.AddMultiplyGate(sum, 0)
+	_ = circuit.AddOutput(extra)
+	if (cmpc[0].GetCircuit().GetWireCount() != extra) {t.Error("Circuit should be copied when constructing the mpc.")}
+
+	// generate auxiliary
+	auxi, err := cmpc[0].GenerateInputAuxiliary()
+	if err != nil {t.Error(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+
+	// input stage
+	for i := 0 ; i <participantCount; i++{
+		inputs, err := cmpc[i].GenerateInputs(secret[i],auxi)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount ; j++{
+			err = cmpc[j].AddReceivedInput(i,inputs[j])
+			if err != nil {t.Error(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// computation stage, round by round
+	for cmpc[0].HasNextRound(){
+		for i := 0 ; i <participantCount; i++{
+			messages, err := cmpc[i].GenerateRoundMessages()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating round messages: %s", err))}
+			for j := 0; j < participantCount ; j++{
+				err = cmpc[j].AddReceivedRoundMessage(i,messages[j])
+				if err != nil {t.Error(fmt.Sprintf("Error happens when adding round messages: %s", err))}
+			}
+		}
+		for i := 0 ; i <participantCount; i++{
+			err = cmpc[i].FinishRound()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when finishing the round: %s", err))}
+		}
+	}
+
+	// output stage, cmpc[0] collects all outputs
+	outputs := make([][]interface{},participantCount)
+	for i := 0; i<participantCount; i++{
+		outputs[i], err = cmpc[i].GenerateOutputs()
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+	}
+	for j := 1; j < participantCount; j++{
+		err = cmpc[0].AddReceivedOutputs(j,outputs[j])
+		if err != nil {t.Error(fmt.Sprintf("Error happens after adding received outputs: %s", err))}
+	}
+
+	// cmpc[0] calculate the final result
+	calculatedResult,err := cmpc[0].Compute()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+
+	// calculate the true result(never do this in a real mpc procedure)
+	expected := (int64(secret[0]) * int64(secret[1]) + 2 * int64(secret[2])) * int64(secret[3]) + int64(secret[4]) + 11
+
+	if int64(calculatedResult[0].(int)) != expected {
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: %d",calculatedResult[0],expected))
+	}else{
+		t.Log(fmt.Sprintf("Calculate Result is True, Result:%d ,Expected %d",calculatedResult[0],expected))
+	}
+}
@@ -0,0 +1,100 @@
+package mpc
+
+import (
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+)
+
+/**
+ * This class implements an uint64 secure multi-party arithmetic circuit computation, for any prime modulus <i>p</i> < 2<sup>64</sup>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type CircuitMultipartyComputationUint64 struct {
+	CircuitMultipartyComputation
+}
+
+/**
+ * Construct arithmetic circuit MPC scheme with number of participants, threshold, the ID of the participant and the circuit.
+ * <p>
+ * The threshold is the max number of semi-honest adversaries, should be less than <i>n</i>/2.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>.
+ * @param circuit The public arithmetic circuit, copied so that gates added to it later are ignored.
+ * @return feedback the constructed CircuitMultipartyComputationUint64
+ * @return error IllegalArgumentException If any of ID, participantCount, threshold or circuit is invalid.
+ */
+func NewCircuitMultipartyComputationUint64(id int, participantCount int, threshold int, circuit *ArithmeticCircuit) (*CircuitMultipartyComputationUint64, error){
+	if (circuit == nil || circuit.GetInputCount() != participantCount){
+		return nil, errors.New("Number of circuit inputs should be equal to number of participants.")
+	}
+	linearMultipartyComputation, err := NewLinearMultipartyComputationUint64(id, participantCount, threshold)
+	if (err != nil) {return nil, err}
+	feedback := new(CircuitMultipartyComputationUint64)
+	feedback.id = id
+	feedback.participantCount = participantCount
+	feedback.threshold = threshold
+	feedback.circuit = circuit.clone()
+	feedback.linearMultipartyComputation = &linearMultipartyComputation.LinearMultipartyComputation
+	feedback.wireValues = make([]interface{}, feedback.circuit.GetWireCount())
+	feedback.pendingRoundMessages = make([][]interface{}, participantCount)
+	feedback.circuitMultipartyComputationCalculator = feedback
+	return feedback, nil
+}
+
+/**
+ * Get an uint64 multiplication mpc object for a multiplication gate.
+ *
+ * @return The proper multiplication mpc object.
+ * @return error If the multiplication mpc object cannot be constructed.
+ */
+func (cmpcu *CircuitMultipartyComputationUint64) getMultiplicationMultipartyComputation() (MultiplicationMultipartyComputationInterface, error){
+	return NewMultiplicationMultipartyComputationUint64(cmpcu.id, cmpcu.participantCount, cmpcu.threshold)
+}
+
+/**
+ * Add two uint64 elements modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The sum modulo <i>p</i>.
+ */
+func (cmpcu *CircuitMultipartyComputationUint64) addElements(a interface{}, b interface{}) interface{}{
+	return poly.AddModUint64(a.(uint64), b.(uint64), cmpcu.GetModulus().(uint64))
+}
+
+/**
+ * Multiply two uint64 elements modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The product modulo <i>p</i>.
+ */
+func (cmpcu *CircuitMultipartyComputationUint64) multiplyElements(a interface{}, b interface{}) interface{}{
+	return poly.MultiplyModUint64(a.(uint64), b.(uint64), cmpcu.GetModulus().(uint64))
+}
+
+/**
+ * Check if the uint64 constant is in <i>Zp</i>.
+ *
+ * @param constant The constant.
+ * @param modulus The modulus <i>p</i>.
+ * @return True if the constant is valid, otherwise return false.
+ */
+func (cmpcu *CircuitMultipartyComputationUint64) checkConstant(constant interface{}, modulus interface{}) bool{
+	return constant.(uint64) < modulus.(uint64)
+}
+
+/**
+ * Check if the type of input element is valid.
+ *
+ * @param e Element to be checked.
+ * @return True if the type of input element is uint64, otherwise return false.
+ */
+func (cmpcu *CircuitMultipartyComputationUint64) checkElement(e interface{}) bool{
+	_, ok := e.(uint64)
+	return ok
+}
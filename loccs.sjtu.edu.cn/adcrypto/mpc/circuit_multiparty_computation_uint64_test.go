@@ -0,0 +1,99 @@
+package mpc
+
+import (
+	"fmt"
+	"testing"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestNewCircuitMultipartyComputationUint64Procedure(t *testing.T) {
+	participantCount := 5
+	threshold := 2
+	cmpc := make([]*CircuitMultipartyComputationUint64, participantCount) // mpc class for every party
+	secret := make([]uint64,participantCount)  // secrets
+	modulus := uint64(18446744073709551557) // the largest prime below 2^64
+	var err error
+
+	// f = (x0 * x1 + 2 * x2) * x3 + x4 - 11 mod p, the products wrap around p
+	circuit, _ := NewArithmeticCircuit(participantCount)
+	product, _ := circuit.AddMultiplyGate(0, 1)
+	scaled, _ := circuit.AddMultiplyConstantGate(2, uint64(2))
+	sum, _ := circuit.AddAddGate(product, scaled)
+	product, _ = circuit.AddMultiplyGate(sum, 3)
+	sum, _ = circuit.AddAddGate(product, 4)
+	sum, _ = circuit.AddAddConstantGate(sum, modulus - 11)
+	_ = circuit.AddOutput(sum)
+
+	// initialize secrets
+	for i := 0; i < participantCount; i++{
+		se ,_ := rand.Int(rand.Reader,new(big.Int).SetUint64(modulus))
+		secret[i] = se.Uint64()
+	}
+
+	// construct and initialize mpcs
+	for i := 0; i < participantCount; i++{
+		cmpc[i],err = NewCircuitMultipartyComputationUint64(i,participantCount,threshold,circuit)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when constructing CircuitMultipartyComputationUint64: %s", err))}
+		err = cmpc[i].InitializeWithModulus(modulus)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	}
+
+	// generate auxiliary
+	auxi, err := cmpc[0].GenerateInputAuxiliary()
+	if err != nil {t.Error(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+
+	// input stage
+	for i := 0 ; i <participantCount; i++{
+		inputs, err := cmpc[i].GenerateInputs(secret[i],auxi)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount ; j++{
+			err = cmpc[j].AddReceivedInput(i,inputs[j])
+			if err != nil {t.Error(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// computation stage, round by round
+	for cmpc[0].HasNextRound(){
+		for i := 0 ; i <participantCount; i++{
+			messages, err := cmpc[i].GenerateRoundMessages()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating round messages: %s", err))}
+			for j := 0; j < participantCount ; j++{
+				err = cmpc[j].AddReceivedRoundMessage(i,messages[j])
+				if err != nil {t.Error(fmt.Sprintf("Error happens when adding round messages: %s", err))}
+			}
+		}
+		for i := 0 ; i <participantCount; i++{
+			err = cmpc[i].FinishRound()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when finishing the round: %s", err))}
+		}
+	}
+
+	// output stage, cmpc[0] collects all outputs
+	outputs := make([][]interface{},participantCount)
+	for i := 0; i<participantCount; i++{
+		outputs[i], err = cmpc[i].GenerateOutputs()
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+	}
+	for j := 1; j < participantCount; j++{
+		err = cmpc[0].AddReceivedOutputs(j,outputs[j])
+		if err != nil {t.Error(fmt.Sprintf("Error happens after adding received outputs: %s", err))}
+	}
+
+	// cmpc[0] calculate the final result
+	calculatedResult,err := cmpc[0].Compute()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+
+	// calculate the true result(never do this in a real mpc procedure)
+	p := new(big.Int).SetUint64(modulus)
+	x := make([]*big.Int, participantCount)
+	for i := 0; i < participantCount; i++{
+		x[i] = new(big.Int).SetUint64(secret[i])
+	}
+	expected := big.NewInt(0).Mul(x[0], x[1])
+	expected.Add(expected, big.NewInt(0).Lsh(x[2], 1)).Mul(expected, x[3]).Add(expected, x[4]).Sub(expected, big.NewInt(11)).Mod(expected, p)
+
+	if calculatedResult[0].(uint64) != expected.Uint64() {
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: %s",calculatedResult[0],expected))
+	}
+}
@@ -0,0 +1,417 @@
+package mpc
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+	"errors"
+)
+
+/**
+ * The class implements the abstract methods of <code>LinearMultipartyComputation</code> over the field <i>Zp</i> with elements of type <i>E</i>.
+ * <p>
+ * <code>LinearMultipartyComputationInt</code>, <code>LinearMultipartyComputationUint64</code> and <code>LinearMultipartyComputationBigInt</code>
+ * are this class over <code>poly.IntField</code>, <code>poly.Uint64Field</code> and <code>poly.BigIntField</code>, which only add the way to
+ * generate a modulus from the max value of a secret, and <code>FieldLinearMultipartyComputation</code> is a typed view of it over any field.
+ * The field is constructed from the modulus when the linear function is set, and the Shamir's secret sharing scheme is
+ * <code>secretshare.FieldShamirSecretSharing</code> over it.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type linearMultipartyComputationField[E any] struct {
+	LinearMultipartyComputation
+
+	/**
+	 * The element of value 1.
+	 */
+	one E
+
+	/**
+	 * Construct the field <i>Zp</i> from the modulus <i>p</i>.
+	 */
+	newField func(modulus E) (poly.Field[E], error)
+}
+
+/**
+ * Check and set the ID of the participant, number of participants and threshold.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>, max number of semi-honest adversaries.
+ * @param one The element of value 1.
+ * @param newField Construct the field <i>Zp</i> from the modulus <i>p</i>.
+ * @return error If any of ID, participantCount or threshold is invalid.
+ */
+func (lmpc *linearMultipartyComputationField[E]) init(id int, participantCount int, threshold int, one E, newField func(modulus E) (poly.Field[E], error)) error{
+	if (participantCount < 3){
+		return errors.New("Invalid participant count. Should be larger than 2.")
+	}
+	if (id < 0 || (id >= participantCount)){
+		return errors.New("Invalid id, should be between 0 and participantCount-1")
+	}
+	if (threshold > (participantCount / 2)){
+		return errors.New("Threshold should never greater than 1/2 of the participant count.")
+	}
+	lmpc.id = id
+	lmpc.participantCount = participantCount
+	lmpc.threshold = threshold
+	lmpc.one = one
+	lmpc.newField = newField
+	lmpc.receivedInputs = make([]interface{}, participantCount)
+	lmpc.receivedOutputs = map[int]interface{} {}
+	return nil
+}
+
+/**
+ * Get the field of the Shamir's secret sharing scheme.
+ *
+ * @return The field <i>Zp</i>.
+ */
+func (lmpc *linearMultipartyComputationField[E]) getField() poly.Field[E]{
+	return lmpc.secretSharing.(interface{ GetField() poly.Field[E] }).GetField()
+}
+
+/**
+ * Get a Shamir's secret sharing object with the number of participants over the field of the modulus.
+ *
+ * @param participantCount The number of the participants.
+ * @param modulus The modulus <i>p</i>.
+ * @return The proper Shamir's secret sharing scheme object.
+ * @return error IllegalArgumentException If the number of participants or the modulus is invalid.
+ */
+func (lmpc *linearMultipartyComputationField[E]) getSecretSharing(participantCount int, modulus interface{}) (secretshare.ShamirSecretSharingInterface, error){
+	modulusValue, ok := modulus.(E)
+	if (!ok) {return nil, errors.New("Invalid type of modulus.")}
+	field, err := lmpc.newField(modulusValue)
+	if (err != nil) {return nil, err}
+	secretSharing, err := secretshare.NewFieldShamirSecretSharing(participantCount, field)
+	if (err != nil) {return nil, err}
+	return secretSharing.GetScheme(), nil
+}
+
+/**
+ * Generating a modulus from the max value of a secret is not supported over a given field.
+ *
+ * @param coefficients The coefficients of the linear function.
+ * @param max Max value of a secret.
+ * @return error Always.
+ */
+func (lmpc *linearMultipartyComputationField[E]) generateModulus(coefficients []interface{}, max interface{}) (interface{}, error){
+	return nil, errors.New("Modulus cannot be generated from the max value, should be given.")
+}
+
+/**
+ * Generate output during the output stage, i.e. the linear function on the inputs.
+ *
+ * @param inputs The inputs received from all participants, one for each.
+ * @return The output value.
+ */
+func (lmpc *linearMultipartyComputationField[E]) generateOutputImpl(inputs []interface{}) interface{}{
+	field := lmpc.getField()
+	pile := field.Zero()
+	for i := 0; i < lmpc.participantCount; i++{
+		pile = field.Add(pile, field.Multiply(lmpc.coefficients[i].(E), inputs[i].(E)))
+	}
+	return pile
+}
+
+/**
+ * Check if the coefficients and the modulus are both valid, i.e. the modulus is a prime greater than 2 and
+ * the coefficients are in [0, <i>p</i>).
+ *
+ * @param coefficients Coefficients of the linear function.
+ * @param modulus Modulus of the Shamir's scheme.
+ * @return error If the coefficients or the modulus is invalid.
+ */
+func (lmpc *linearMultipartyComputationField[E]) checkCoefficientsAndModulus(coefficients []interface{}, modulus interface{}) error{
+	field, err := lmpc.newField(modulus.(E))
+	if (err != nil) {return err}
+	modulusValue := field.ToBigInt(field.GetModulus())
+	for i := 0; i < len(coefficients); i++{
+		coefficient := field.ToBigInt(coefficients[i].(E))
+		if (coefficient.Sign() < 0 || coefficient.Cmp(modulusValue) >= 0){
+			return errors.New("One coefficient is too great or too tiny.")
+		}
+	}
+	return nil
+}
+
+/**
+ * Get the element of value 1.
+ *
+ * @return Element of value 1.
+ */
+func (lmpc *linearMultipartyComputationField[E]) getElementOne() interface{}{
+	return lmpc.one
+}
+
+/**
+ * Check if the type of input element is valid.
+ *
+ * @param e Element to be checked.
+ * @return True if the type of input element is <i>E</i>, otherwise return false.
+ */
+func (lmpc *linearMultipartyComputationField[E]) checkElement(e interface{}) bool{
+	_, ok := e.(E)
+	return ok
+}
+
+/**
+ * The class implements secure multi-party linear function computation over the field <i>Zp</i> with elements of type <i>E</i>.
+ * <p>
+ * It is a typed view of the same protocol as <code>LinearMultipartyComputationInt</code>, <code>LinearMultipartyComputationUint64</code>
+ * and <code>LinearMultipartyComputationBigInt</code>, but the field is given when the object is constructed instead of being derived
+ * from the max value of a secret, and the secrets, inputs and outputs have the element type of the field. The inputs and outputs
+ * are the same values as those of the interface{} classes over the same <i>Zp</i>, so both kinds of participants can take part in
+ * one computation.
+ * <p>
+ * Note: Each participant has a unique ID, start from 0 to <i>n</i>-1.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FieldLinearMultipartyComputation[E any] struct {
+	/**
+	 * The field <i>Zp</i>.
+	 */
+	field poly.Field[E]
+
+	/**
+	 * The linear mpc doing the work, with elements passed as interface{}.
+	 */
+	computation *linearMultipartyComputationField[E]
+}
+
+/**
+ * Construct a linear mpc participant over a field.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>, max number of semi-honest adversaries.
+ * @param field The field <i>Zp</i>.
+ * @return The constructed FieldLinearMultipartyComputation
+ * @return error If the id, the number of participants, the threshold or the field is invalid.
+ */
+func NewFieldLinearMultipartyComputation[E any](id int, participantCount int, threshold int, field poly.Field[E]) (*FieldLinearMultipartyComputation[E], error){
+	if (field == nil){
+		return nil, errors.New("Field should not be nil.")
+	}
+	computation := new(linearMultipartyComputationField[E])
+	err := computation.init(id, participantCount, threshold, field.One(), func(modulus E) (poly.Field[E], error){
+		if (field.ToBigInt(modulus).Cmp(field.ToBigInt(field.GetModulus())) != 0){
+			return nil, errors.New("Modulus should be the modulus of the field.")
+		}
+		return field, nil
+	})
+	if (err != nil) {return nil, err}
+	computation.linearMultipartyComputationCalculator = computation
+	return &FieldLinearMultipartyComputation[E]{field: field, computation: computation}, nil
+}
+
+/**
+ * Get the linear mpc with elements passed as interface{}, which shares the state with this one.
+ * <p>
+ * It can be run by <code>LinearMultipartyComputationDriver</code>, and provides the methods not typed here, e.g. <code>ComputeRobust</code>.
+ *
+ * @return The linear mpc.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) GetComputation() LinearMultipartyComputationInterface{
+	return lmpc.computation
+}
+
+/**
+ * Get ID of this participant.
+ *
+ * @return ID of this participant.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) GetID() int{
+	return lmpc.computation.GetID()
+}
+
+/**
+ * Get the number of participants.
+ *
+ * @return Number of participants.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) GetParticipantCount() int{
+	return lmpc.computation.GetParticipantCount()
+}
+
+/**
+ * Get threshold <i>t</i>, the max number of semi-honest adversaries.
+ *
+ * @return Threshold <i>t</i>.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) GetThreshold() int{
+	return lmpc.computation.GetThreshold()
+}
+
+/**
+ * Get the field.
+ *
+ * @return The field <i>Zp</i>.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) GetField() poly.Field[E]{
+	return lmpc.field
+}
+
+/**
+ * Set the linear function with coefficients.
+ *
+ * @param coefficients Coefficients of the linear function, reduced modulo <i>p</i>.
+ * @return error If the number of coefficients is invalid.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) Initialize(coefficients []E) error{
+	if (len(coefficients) != lmpc.computation.GetParticipantCount()){
+		return errors.New("Number of coefficients should be equal to number of participants.")
+	}
+	reduced := make([]interface{}, len(coefficients))
+	for i := 0; i < len(coefficients); i++{
+		reduced[i] = lmpc.field.Add(coefficients[i], lmpc.field.Zero())
+	}
+	return lmpc.computation.InitializeWithModulus(reduced, lmpc.field.GetModulus())
+}
+
+/**
+ * Set a simple sum linear function, i.e. all values of the coefficients are 1.
+ *
+ * @return error If the secret sharing scheme cannot be set.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) InitializeSimpleSum() error{
+	return lmpc.computation.InitializeSimpleSumWithModulus(lmpc.field.GetModulus())
+}
+
+/**
+ * Generate random auxiliary data in Shamir's scheme.
+ *
+ * @return Random auxiliary data.
+ * @return error If Secret sharing scheme has not been set.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) GenerateInputAuxiliary() ([]E, error){
+	auxiliary, err := lmpc.computation.GenerateInputAuxiliary()
+	if (err != nil) {return nil, err}
+	return fromInterfaces[E](auxiliary), nil
+}
+
+/**
+ * Generate inputs for all participants during the input stage.
+ *
+ * @param secret The secret value of this participant.
+ * @param auxiliary The auxiliary data for generating Shamir's secret shares, should not be 0.
+ * @return The inputs for all participants.
+ * @return error If the auxiliary data is invalid, or the linear function is not set.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) GenerateInputs(secret E, auxiliary []E) ([]E, error){
+	for i := 0; i < len(auxiliary); i++{
+		if (lmpc.field.IsZero(auxiliary[i])) {return nil, errors.New("Auxiliary data should not be 0.")}
+	}
+	inputs, err := lmpc.computation.GenerateInputs(secret, toInterfaces(auxiliary))
+	if (err != nil) {return nil, err}
+	return fromInterfaces[E](inputs), nil
+}
+
+/**
+ * Add an input when received from other participant during the input stage.
+ *
+ * @param from The id of the participant who sent the input.
+ * @param input The input value received.
+ * @return error If the id of the participant is invalid.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) AddReceivedInput(from int, input E) error{
+	return lmpc.computation.AddReceivedInput(from, input)
+}
+
+/**
+ * Test if all <i>n</i>-1 inputs are received from other participants.
+ *
+ * @return True if all inputs are received, otherwise return false.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) HasAllInputReceived() bool{
+	return lmpc.computation.HasAllInputReceived()
+}
+
+/**
+ * Get the input received from a participant during the input stage.
+ *
+ * @param from The id of the participant who sent the input.
+ * @return The input value received.
+ * @return error If the id of the participant is invalid, or the input has not been received.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) GetReceivedInput(from int) (E, error){
+	input, err := lmpc.computation.GetReceivedInput(from)
+	if (err != nil) {return lmpc.field.Zero(), err}
+	return input.(E), nil
+}
+
+/**
+ * Generate the output during the output stage.
+ * <p>
+ * The output is <i>c</i><sub>1</sub><i>s</i><sub>1</sub> + ... + <i>c<sub>n</sub></i><i>s<sub>n</sub></i> mod <i>p</i>, where
+ * <i>s<sub>i</sub></i> is the input received from participant <i>i</i>.
+ *
+ * @return The output value.
+ * @return error If not all inputs are received or the linear function is not set.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) GenerateOutput() (E, error){
+	output, err := lmpc.computation.GenerateOutput()
+	if (err != nil) {return lmpc.field.Zero(), err}
+	return output.(E), nil
+}
+
+/**
+ * Add an output when received from other participant during the output stage.
+ *
+ * @param from The id of the participant who sent the output.
+ * @param output The output value received.
+ * @return error If the id of the participant is invalid.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) AddReceivedOutput(from int, output E) error{
+	return lmpc.computation.AddReceivedOutput(from, output)
+}
+
+/**
+ * Compute the linear function from <i>t</i>+1 received outputs.
+ *
+ * @return The result value of the linear function.
+ * @return error If not enough outputs are received or the secret sharing scheme in not set properly.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) Compute() (E, error){
+	result, err := lmpc.computation.Compute()
+	if (err != nil) {return lmpc.field.Zero(), err}
+	return result.(E), nil
+}
+
+/**
+ * Reset to time before input stage. And ready for the next round of MPC.
+ */
+func (lmpc *FieldLinearMultipartyComputation[E]) Reset(){
+	lmpc.computation.Reset()
+}
+
+/**
+ * Convert elements to interface{}.
+ *
+ * @param elements The elements.
+ * @return The elements as interface{}.
+ */
+func toInterfaces[E any](elements []E) []interface{}{
+	feedback := make([]interface{}, len(elements))
+	for i := 0; i < len(elements); i++{
+		feedback[i] = elements[i]
+	}
+	return feedback
+}
+
+/**
+ * Convert interface{} known to have type <i>E</i> to elements.
+ *
+ * @param elements The elements as interface{}.
+ * @return The elements.
+ */
+func fromInterfaces[E any](elements []interface{}) []E{
+	feedback := make([]E, len(elements))
+	for i := 0; i < len(elements); i++{
+		feedback[i] = elements[i].(E)
+	}
+	return feedback
+}
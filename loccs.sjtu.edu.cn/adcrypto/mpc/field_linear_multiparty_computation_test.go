@@ -0,0 +1,83 @@
+package mpc
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"fmt"
+	"testing"
+	"math/big"
+)
+
+func TestFieldLinearMultipartyComputationProcedure(t *testing.T) {
+	intField, _ := poly.NewIntField(2147483647)
+	uint64Field, _ := poly.NewUint64Field(18446744073709551557)
+	bigIntField, _ := poly.NewBigIntField(big.NewInt(2305843009213693951))
+	t.Run("TestFieldLinearMultipartyComputationInt", testFieldLinearMultipartyComputationProcedure[int](intField, 15, 6))
+	t.Run("TestFieldLinearMultipartyComputationUint64", testFieldLinearMultipartyComputationProcedure[uint64](uint64Field, 15, 6))
+	t.Run("TestFieldLinearMultipartyComputationBigInt", testFieldLinearMultipartyComputationProcedure[*big.Int](bigIntField, 15, 6))
+}
+
+func testFieldLinearMultipartyComputationProcedure[E any](field poly.Field[E], participantCount int, threshold int) func(t *testing.T) {
+	return func(t *testing.T) {
+		mpc := make([]*FieldLinearMultipartyComputation[E], participantCount)
+		secret := make([]E, participantCount)
+		coefficients := make([]E, participantCount)
+		expected := field.Zero()
+		var err error
+		for i := 0; i < participantCount; i++{
+			secret[i], _ = field.Random()
+			coefficients[i] = field.FromInt(-i - 1)
+			expected = field.Add(expected, field.Multiply(coefficients[i], secret[i]))
+		}
+		for i := 0; i < participantCount; i++{
+			mpc[i], err = NewFieldLinearMultipartyComputation(i, participantCount, threshold, field)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing FieldLinearMultipartyComputation: %s", err))}
+			err = mpc[i].Initialize(coefficients)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+		}
+
+		auxi, err := mpc[0].GenerateInputAuxiliary()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+		for i := 0; i < participantCount; i++{
+			inputs, err := mpc[i].GenerateInputs(secret[i], auxi)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+			for j := 0; j < participantCount; j++{
+				if (j == i) {continue}
+				err = mpc[j].AddReceivedInput(i, inputs[j])
+				if err != nil {t.Error(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+			}
+		}
+
+		outputs := make([]E, participantCount)
+		for i := 0; i < participantCount; i++{
+			outputs[i], err = mpc[i].GenerateOutput()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+		}
+		_, err = mpc[0].Compute()
+		if err == nil {t.Error("Result should not be computed with too few outputs.")}
+		for _, from := range []int{1, 4, 6, 8, 11, 14}{
+			_ = mpc[0].AddReceivedOutput(from, outputs[from])
+		}
+		calculatedResult, err := mpc[0].Compute()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+		if (!field.Equal(calculatedResult, expected)){
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%v ,Expected: %v", calculatedResult, expected))
+		}
+
+		mpc[0].Reset()
+		if (mpc[0].HasAllInputReceived()){
+			t.Error("Inputs should be cleared after reset.")
+		}
+	}
+}
+
+func TestNewFieldLinearMultipartyComputation(t *testing.T) {
+	field, _ := poly.NewIntField(2147483647)
+	_, err := NewFieldLinearMultipartyComputation[int](0, 2, 1, field)
+	if err == nil {t.Error("Invalid participant count should not be accepted.")}
+	_, err = NewFieldLinearMultipartyComputation[int](5, 5, 2, field)
+	if err == nil {t.Error("Invalid id should not be accepted.")}
+	_, err = NewFieldLinearMultipartyComputation[int](0, 5, 3, field)
+	if err == nil {t.Error("Invalid threshold should not be accepted.")}
+	_, err = NewFieldLinearMultipartyComputation[int](0, 5, 2, nil)
+	if err == nil {t.Error("Nil field should not be accepted.")}
+}
@@ -28,6 +28,10 @@ import (
  * Note 1: In the scheme, all element should be in some <i>Zp</i>, i.e. should be non-negative integers.
  * <p>
  * Note 2: Each participant has a unique ID, start from 0 to <i>n</i>-1.
+ * <p>
+ * Note 3: In the batched mode, every participant contributes a vector of secrets ([]interface{}) of the same length, and the linear
+ * function is computed element-wise. Each input and output is then a vector, so one message per participant carries all elements,
+ * and <code>Compute</code> returns the vector of results.
  *
  * @author 		LoCCS
  * @version		1.0
@@ -69,6 +73,11 @@ type LinearMultipartyComputation struct {
 	 */
 	auxiliary []interface{}
 
+	/**
+	 * Length of the secret vector in the batched mode, 0 if the secret is a single element.
+	 */
+	vectorLength int
+
 	/**
 	 * The outputs received from other participants during the output stage.
 	 */
@@ -82,6 +91,14 @@ type LinearMultipartyComputation struct {
 
 type LinearMultipartyComputationInterface interface {
 
+	GetID() int
+
+	GetParticipantCount() int
+
+	GetThreshold() int
+
+	GetPublicParameters() (*PublicParameters, error)
+
 	InitializeWithMaxValue(coefficients []interface{}, max interface{}) error
 
 	InitializeWithModulus(coefficients []interface{}, modulus interface{}) error
@@ -100,6 +117,8 @@ type LinearMultipartyComputationInterface interface {
 
 	HasAllInputReceived() bool
 
+	GetReceivedInput(from int) (interface{}, error)
+
 	GenerateOutput() (interface{}, error)
 
 	AddReceivedOutput(from int, output interface{}) error
@@ -108,6 +127,8 @@ type LinearMultipartyComputationInterface interface {
 
 	Compute() (interface{},error)
 
+	ComputeRobust() (interface{}, []int, error)
+
 	Reset()
 
 	/**
@@ -131,11 +152,12 @@ type LinearMultipartyComputationInterface interface {
 	generateModulus(coefficients []interface{}, max interface{})(interface{},error)
 
 	/**
- 	* Abstract method of generating output during the output stage.
+ 	* Abstract method of generating output during the output stage, i.e. the linear function on the inputs.
  	*
+ 	* @param inputs The inputs received from all participants, one for each.
  	* @return The output value.
  	*/
-	generateOutputImpl() interface{}
+	generateOutputImpl(inputs []interface{}) interface{}
 
 	/**
  	* Abstract method of checking if the coefficients and the modulus are both valid.
@@ -162,6 +184,47 @@ type LinearMultipartyComputationInterface interface {
 	checkElement(e interface{}) bool
 }
 
+/**
+ * Get ID of this participant.
+ *
+ * @return ID of this participant.
+ */
+func (lmpc *LinearMultipartyComputation) GetID() int{
+	return lmpc.id
+}
+
+/**
+ * Get the number of participants.
+ *
+ * @return Number of participants.
+ */
+func (lmpc *LinearMultipartyComputation) GetParticipantCount() int{
+	return lmpc.participantCount
+}
+
+/**
+ * Get threshold <i>t</i>, the max number of semi-honest adversaries.
+ *
+ * @return Threshold <i>t</i>.
+ */
+func (lmpc *LinearMultipartyComputation) GetThreshold() int{
+	return lmpc.threshold
+}
+
+/**
+ * Get the public parameters, i.e. the modulus, the coefficients of the linear function and the auxiliary data
+ * used during the input stage, which can be encoded and sent to other participants.
+ *
+ * @return The public parameters, auxiliary data is nil if inputs have not been generated.
+ * @return error If the linear function or the secret sharing scheme is not set.
+ */
+func (lmpc *LinearMultipartyComputation) GetPublicParameters() (*PublicParameters, error){
+	if (lmpc.coefficients == nil || lmpc.secretSharing == nil){
+		return nil, errors.New("Coefficients or secret sharing scheme not set.")
+	}
+	return NewPublicParameters(lmpc.participantCount, lmpc.threshold, lmpc.secretSharing.GetModulus(), lmpc.coefficients, lmpc.auxiliary), nil
+}
+
 /**
  * Set the linear function and try to find a proper modulus <i>p</i> by the max value of a secret.
  *
@@ -316,53 +379,117 @@ func (lmpc *LinearMultipartyComputation) GenerateInputAuxiliary() ([]interface{}
 
 /**
  * Generate inputs for all participants during the input stage.
+ * <p>
+ * If the secret is a vector ([]interface{}), every element is shared separately and the input for every participant
+ * is the vector of its shares, i.e. the batched mode.
  *
- * @param secret The secret value of this participant.
+ * @param secret The secret value of this participant, or a non-empty vector of secret values.
  * @param auxiliary The auxiliary data for generating Shamir's secret shares.
  * @return The inputs for all participants.
  * @return error IllegalArgumentException If the secret value or the auxiliary data is invalid.
  *         or IllegalStateException If the linear function or the secret sharing scheme in not set properly.
  */
 func (lmpc *LinearMultipartyComputation) GenerateInputs(secret interface{}, auxiliary []interface{}) ([]interface{},error){
+	secrets, ok := secret.([]interface{})
+	if (ok) {return lmpc.generateVectorInputs(secrets, auxiliary)}
+	err := lmpc.checkInputParameters(secret, auxiliary)
+	if (err != nil) {return nil, err}
+
+	shares,err := lmpc.secretSharing.GenerateShares(secret,auxiliary)
+	if (err != nil) {return nil, err}
+	return lmpc.acceptGeneratedShares(shares, auxiliary), nil
+}
+
+/**
+ * Generate inputs for all participants from a vector of secrets in the batched mode.
+ *
+ * @param secrets The secret values of this participant.
+ * @param auxiliary The auxiliary data for generating Shamir's secret shares, same for all elements.
+ * @return The inputs for all participants, each a vector of one share for every secret value.
+ * @return error IllegalArgumentException If the vector, a secret value or the auxiliary data is invalid.
+ *         or IllegalStateException If the linear function or the secret sharing scheme in not set properly.
+ */
+func (lmpc *LinearMultipartyComputation) generateVectorInputs(secrets []interface{}, auxiliary []interface{}) ([]interface{},error){
+	if (len(secrets) == 0){
+		return nil, errors.New("Secret vector should not be empty.")
+	}
+	for d := 0; d < len(secrets); d++{
+		err := lmpc.checkInputParameters(secrets[d], auxiliary)
+		if (err != nil) {return nil, err}
+	}
+	inputs := make([]interface{}, lmpc.participantCount)
+	for i := 0; i < lmpc.participantCount; i++{
+		inputs[i] = make([]interface{}, len(secrets))
+	}
+	for d := 0; d < len(secrets); d++{
+		shares, err := lmpc.secretSharing.GenerateShares(secrets[d], auxiliary)
+		if (err != nil) {return nil, err}
+		for i := 0; i < lmpc.participantCount; i++{
+			inputs[i].([]interface{})[d] = shares[i].GetValue().(*secretshare.ShamirSecretShareValue).GetQr()
+		}
+	}
+	lmpc.auxiliary = auxiliary
+	lmpc.vectorLength = len(secrets)
+	lmpc.receivedInputs[lmpc.id] = inputs[lmpc.id] //itself
+	return inputs, nil
+}
+
+/**
+ * Check the secret value and the auxiliary data before generating inputs.
+ *
+ * @param secret The secret value of this participant.
+ * @param auxiliary The auxiliary data for generating Shamir's secret shares.
+ * @return error IllegalArgumentException If the secret value or the auxiliary data is invalid.
+ *         or IllegalStateException If the linear function or the secret sharing scheme in not set properly.
+ */
+func (lmpc *LinearMultipartyComputation) checkInputParameters(secret interface{}, auxiliary []interface{}) error{
 	if (lmpc.coefficients == nil || lmpc.secretSharing == nil){
-		return nil, errors.New("Coefficients or secret sharing scheme not set.")
+		return errors.New("Coefficients or secret sharing scheme not set.")
 	}
 	if (auxiliary == nil || len(auxiliary) != lmpc.participantCount){
-		return nil, errors.New("Number of auxiliaries should be equal to number of participants.")
+		return errors.New("Number of auxiliaries should be equal to number of participants.")
 	}
 	for i:=0; i < len(auxiliary);i++{
 		if (!lmpc.linearMultipartyComputationCalculator.checkElement(auxiliary[i])){
-			return nil, errors.New("Invalid type of an auxiliary.")
+			return errors.New("Invalid type of an auxiliary.")
 		}
 	}
 	if (!lmpc.linearMultipartyComputationCalculator.checkElement(secret)){
-		return nil, errors.New("Invalid type a secret.")
+		return errors.New("Invalid type a secret.")
 	}
+	return nil
+}
 
-	shares,err := lmpc.secretSharing.GenerateShares(secret,auxiliary)
-	if (err != nil) {return nil, err}
-
+/**
+ * Turn the generated shares into inputs, and keep the input of this participant itself.
+ *
+ * @param shares The shares of the secret value of this participant.
+ * @param auxiliary The auxiliary data used for generating the shares.
+ * @return The inputs for all participants.
+ */
+func (lmpc *LinearMultipartyComputation) acceptGeneratedShares(shares []*secretshare.SecretShare, auxiliary []interface{}) []interface{}{
 	inputs := make([]interface{}, lmpc.participantCount)
     for i := 0; i< lmpc.participantCount;i++{
     	inputs[i] = shares[i].GetValue().(*secretshare.ShamirSecretShareValue).GetQr()
 	}
     lmpc.auxiliary = auxiliary
+    lmpc.vectorLength = 0
     lmpc.receivedInputs[lmpc.id] = inputs[lmpc.id] //itself
-    return inputs, nil
+    return inputs
 }
 
 /**
  * Add an input when received from other participant during the input stage.
  *
  * @param from The id of the participant who sent the input.
- * @param input The input value received.
+ * @param input The input value received, or the vector of input values in the batched mode.
  * @return error IllegalArgumentException If the id of the participant or the input value is invalid.
  */
 func (lmpc *LinearMultipartyComputation) AddReceivedInput(from int, input interface{}) error{
 	if ((from < 0) || (from >= lmpc.participantCount)){
 		return errors.New("Invalid ID of the received input.")
 	}
-	if (!lmpc.linearMultipartyComputationCalculator.checkElement(input)){
+	if (!lmpc.checkElementOrVector(input)){
 		return errors.New("Invalid type of input.")
 	}
 	lmpc.receivedInputs[from] = input
@@ -383,13 +510,35 @@ func (lmpc *LinearMultipartyComputation) HasAllInputReceived() bool{
 	return true
 }
 
+/**
+ * Get the input received from a participant during the input stage.
+ * <p>
+ * The input is this participant's share of the secret of participant <code>from</code>,
+ * and can be used for further computations such as BGW multiplication. In the batched mode it is a vector of shares.
+ *
+ * @param from The id of the participant who sent the input.
+ * @return The input value received.
+ * @return error IllegalArgumentException If the id of the participant is invalid,
+ *         or IllegalStateException If the input has not been received.
+ */
+func (lmpc *LinearMultipartyComputation) GetReceivedInput(from int) (interface{}, error){
+	if ((from < 0) || (from >= lmpc.participantCount)){
+		return nil, errors.New("Invalid ID of the received input.")
+	}
+	if (lmpc.receivedInputs[from] == nil){
+		return nil, errors.New("Input has not been received.")
+	}
+	return lmpc.receivedInputs[from], nil
+}
+
 /**
  * Generate the output during the output stage.
  * <p>
- * Call implemented <code>generateOutputImpl</code> to do the actually generating job.
+ * Call implemented <code>generateOutputImpl</code> to do the actually generating job, once for every element in the batched mode.
  *
- * @return The output value.
- * @return error IllegalStateException If not all inputs are received or the secret sharing scheme in not set properly.
+ * @return The output value, or the vector of output values in the batched mode.
+ * @return error IllegalStateException If not all inputs are received, the inputs have different lengths,
+ *         or the secret sharing scheme in not set properly.
  */
 func (lmpc *LinearMultipartyComputation) GenerateOutput() (interface{}, error){
 	if (lmpc.coefficients == nil || lmpc.secretSharing == nil){
@@ -398,23 +547,37 @@ func (lmpc *LinearMultipartyComputation) GenerateOutput() (interface{}, error){
 	if (!lmpc.HasAllInputReceived()){
 		return nil, errors.New("Output cannot be generated before all inputs are received.")
 	}
-    output := lmpc.linearMultipartyComputationCalculator.generateOutputImpl()
-    lmpc.receivedOutputs[lmpc.id] = output
-    return output, nil
+	length, err := lmpc.getVectorLength(lmpc.receivedInputs)
+	if (err != nil) {return nil, err}
+	if (length == 0){
+		output := lmpc.linearMultipartyComputationCalculator.generateOutputImpl(lmpc.receivedInputs)
+		lmpc.receivedOutputs[lmpc.id] = output
+		return output, nil
+	}
+	output := make([]interface{}, length)
+	column := make([]interface{}, lmpc.participantCount)
+	for d := 0; d < length; d++{
+		for i := 0; i < lmpc.participantCount; i++{
+			column[i] = lmpc.receivedInputs[i].([]interface{})[d]
+		}
+		output[d] = lmpc.linearMultipartyComputationCalculator.generateOutputImpl(column)
+	}
+	lmpc.receivedOutputs[lmpc.id] = output
+	return output, nil
 }
 
 /**
  * Add an output when received from other participant during the output stage.
  *
  * @param from The id of the participant who sent the output.
- * @param output The output value received.
+ * @param output The output value received, or the vector of output values in the batched mode.
  * @return error IllegalArgumentException If the id of the participant or the output value is invalid.
  */
 func (lmpc *LinearMultipartyComputation) AddReceivedOutput(from int, output interface{}) error{
 	if ((from < 0) || (from >= lmpc.participantCount)){
 		return errors.New("Invalid ID of the received output.")
 	}
-	if (!lmpc.linearMultipartyComputationCalculator.checkElement(output)){
+	if (!lmpc.checkElementOrVector(output)){
 		return errors.New("Invalid type of output.")
 	}
 	lmpc.receivedOutputs[from] = output
@@ -433,7 +596,7 @@ func (lmpc *LinearMultipartyComputation) isReadyForCompute() bool{
 /**
  * Compute the linear function.
  *
- * @return The result value of the linear function. If some output value is wrong, return null.
+ * @return The result value of the linear function, or the vector of results in the batched mode. If some output value is wrong, return null.
  * @return error IllegalStateException If not enough outputs are received or the secret sharing scheme in not set properly.
  */
 func (lmpc *LinearMultipartyComputation) Compute() (interface{},error){
@@ -446,16 +609,138 @@ func (lmpc *LinearMultipartyComputation) Compute() (interface{},error){
 	if (!lmpc.isReadyForCompute()){
 		return nil, errors.New("Not enough outputs received.")
 	}
-	shares := make([]*secretshare.SecretShare, lmpc.threshold+1)
-	i := 0
-	for k,v := range(lmpc.receivedOutputs){
-		shareValue := secretshare.NewShamirSecretShareValue(lmpc.auxiliary[k], v)
-		secretShare := secretshare.NewSecretShare(k,shareValue)
-		shares[i] = secretShare
-		i++
-		if (i > lmpc.threshold) {break}
+	from := make([]int, 0, lmpc.threshold+1)
+	for k := range(lmpc.receivedOutputs){
+		from = append(from, k)
+		if (len(from) > lmpc.threshold) {break}
+	}
+	if (lmpc.vectorLength == 0){
+		shares, err := lmpc.getOutputShares(from, -1)
+		if (err != nil) {return nil, err}
+		return lmpc.secretSharing.CalculateSecret(shares)
+	}
+	feedback := make([]interface{}, lmpc.vectorLength)
+	for d := 0; d < lmpc.vectorLength; d++{
+		shares, err := lmpc.getOutputShares(from, d)
+		if (err != nil) {return nil, err}
+		feedback[d], err = lmpc.secretSharing.CalculateSecret(shares)
+		if (err != nil) {return nil, err}
+	}
+	return feedback, nil
+}
+
+/**
+ * Compute the linear function from all received outputs, tolerating wrong outputs.
+ * <p>
+ * Unlike <code>Compute</code>, which uses <i>t</i>+1 outputs, all received outputs are decoded together,
+ * so the result is correct as long as at most (<i>m</i>-<i>t</i>-1)/2 of the <i>m</i> received outputs are wrong.
+ * The participants who sent wrong outputs are also reported.
+ *
+ * @return The result value of the linear function, or the vector of results in the batched mode.
+ * @return IDs of the participants whose outputs are wrong.
+ * @return error IllegalStateException If not enough outputs are received, the secret sharing scheme in not set properly,
+ *         or too many outputs are wrong.
+ */
+func (lmpc *LinearMultipartyComputation) ComputeRobust() (interface{}, []int, error){
+	if (lmpc.secretSharing == nil){
+		return nil, nil, errors.New("Secret sharing scheme not inialized.")
+	}
+	if (lmpc.auxiliary == nil){
+		return nil, nil, errors.New("Secure MPC should start after generating input.")
+	}
+	if (!lmpc.isReadyForCompute()){
+		return nil, nil, errors.New("Not enough outputs received.")
+	}
+	from := make([]int, 0, len(lmpc.receivedOutputs))
+	for k := 0; k < lmpc.participantCount; k++{
+		_, ok := lmpc.receivedOutputs[k]
+		if (ok) {from = append(from, k)}
+	}
+	if (lmpc.vectorLength == 0){
+		shares, err := lmpc.getOutputShares(from, -1)
+		if (err != nil) {return nil, nil, err}
+		return lmpc.secretSharing.CalculateSecretRobust(shares)
+	}
+	feedback := make([]interface{}, lmpc.vectorLength)
+	wrong := map[int]bool{}
+	for d := 0; d < lmpc.vectorLength; d++{
+		shares, err := lmpc.getOutputShares(from, d)
+		if (err != nil) {return nil, nil, err}
+		result, wrongFrom, err := lmpc.secretSharing.CalculateSecretRobust(shares)
+		if (err != nil) {return nil, nil, err}
+		feedback[d] = result
+		for _, k := range(wrongFrom){
+			wrong[k] = true
+		}
+	}
+	wrongFrom := make([]int, 0, len(wrong))
+	for k := 0; k < lmpc.participantCount; k++{
+		if (wrong[k]) {wrongFrom = append(wrongFrom, k)}
 	}
-	return lmpc.secretSharing.CalculateSecret(shares)
+	return feedback, wrongFrom, nil
+}
+
+/**
+ * Turn the received outputs of the given participants into Shamir's secret shares.
+ *
+ * @param from IDs of the participants whose outputs are used.
+ * @param index Index of the element in the batched mode, -1 if the outputs are single elements.
+ * @return The shares, one for each participant.
+ * @return error IllegalStateException If an output is not a single element, or not a vector as long as the secret vector.
+ */
+func (lmpc *LinearMultipartyComputation) getOutputShares(from []int, index int) ([]*secretshare.SecretShare, error){
+	shares := make([]*secretshare.SecretShare, len(from))
+	for i := 0; i < len(from); i++{
+		output := lmpc.receivedOutputs[from[i]]
+		vector, ok := output.([]interface{})
+		if (index < 0 && ok){
+			return nil, errors.New("Output should be a single element.")
+		}
+		if (index >= 0){
+			if (!ok || len(vector) != lmpc.vectorLength) {return nil, errors.New("Output should be a vector as long as the secret vector.")}
+			output = vector[index]
+		}
+		shareValue := secretshare.NewShamirSecretShareValue(lmpc.auxiliary[from[i]], output)
+		shares[i] = secretshare.NewSecretShare(from[i], shareValue)
+	}
+	return shares, nil
+}
+
+/**
+ * Get the common length of the inputs or outputs.
+ *
+ * @param values The inputs or outputs.
+ * @return 0 if all values are single elements, otherwise the length of the vectors.
+ * @return error IllegalStateException If single elements and vectors are mixed, or the vectors have different lengths.
+ */
+func (lmpc *LinearMultipartyComputation) getVectorLength(values []interface{}) (int, error){
+	feedback := -1
+	for i := 0; i < len(values); i++{
+		length := 0
+		vector, ok := values[i].([]interface{})
+		if (ok) {length = len(vector)}
+		if (feedback >= 0 && length != feedback){
+			return 0, errors.New("Inputs should be all single elements or vectors of the same length.")
+		}
+		feedback = length
+	}
+	return feedback, nil
+}
+
+/**
+ * Check if an input or output is a single element, or a non-empty vector of elements in the batched mode.
+ *
+ * @param e Input or output to be checked.
+ * @return True if the type is valid, otherwise return false.
+ */
+func (lmpc *LinearMultipartyComputation) checkElementOrVector(e interface{}) bool{
+	vector, ok := e.([]interface{})
+	if (!ok) {return lmpc.linearMultipartyComputationCalculator.checkElement(e)}
+	if (len(vector) == 0) {return false}
+	for i := 0; i < len(vector); i++{
+		if (!lmpc.linearMultipartyComputationCalculator.checkElement(vector[i])) {return false}
+	}
+	return true
 }
 
 /**
@@ -467,5 +752,6 @@ func (lmpc *LinearMultipartyComputation) Reset(){
 		lmpc.receivedInputs[i] = nil
 	}
 	lmpc.auxiliary = nil
+	lmpc.vectorLength = 0
 	lmpc.receivedOutputs = map[int]interface{} {}
 }
\ No newline at end of file
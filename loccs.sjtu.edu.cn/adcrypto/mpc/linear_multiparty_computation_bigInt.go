@@ -1,20 +1,31 @@
 package mpc
 
 import (
-	"errors"
-	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+	"loccs.sjtu.edu.cn/adcrypto/poly"
 	"math/big"
 	"crypto/rand"
 )
 
 /**
  * This class implements an BigInt secure multi-party linear function computation.
+ * <p>
+ * It is <code>LinearMultipartyComputation</code> over <code>poly.BigIntField</code>, see <code>FieldLinearMultipartyComputation</code> for the typed view.
  *
  * @author 		LoCCS
  * @version		1.0
  */
 type LinearMultipartyComputationBigInt struct {
-	LinearMultipartyComputation
+	/**
+	 * Number of secrets packed in one polynomial for vector inputs, 0 if packing is not set.
+	 */
+	packingCount int
+
+	/**
+	 * Length of the vector inputs generated during the input stage.
+	 */
+	packedLength int
+
+	linearMultipartyComputationField[*big.Int]
 }
 
 /**
@@ -29,40 +40,15 @@ type LinearMultipartyComputationBigInt struct {
  * @return error IllegalArgumentException If any of ID, participantCount or threshold is invalid.
  */
 func NewLinearMultipartyComputationBigInt(id int, participantCount int, threshold int)(*LinearMultipartyComputationBigInt,error){
-	if (participantCount < 3){
-		return nil, errors.New("Invalid participant count. Should be larger than 2.")
-	}
-	if (id < 0 || (id >= participantCount)){
-		return nil, errors.New("Invalid id, should be between 0 and participantCount-1")
-	}
-	if (threshold > (participantCount / 2)){
-		return nil, errors.New("Threshold should never greater than 1/2 of the participant count.")
-	}
 	feedback := new(LinearMultipartyComputationBigInt)
-	feedback.id = id
-	feedback.participantCount = participantCount
-	feedback.threshold = threshold
+	err := feedback.init(id, participantCount, threshold, big.NewInt(1), func(modulus *big.Int) (poly.Field[*big.Int], error){
+		return poly.NewBigIntField(modulus)
+	})
+	if (err != nil) {return nil, err}
 	feedback.linearMultipartyComputationCalculator = feedback
-	feedback.receivedInputs = make([]interface{},participantCount)
-	feedback.receivedOutputs = map[int]interface{} {}
 	return feedback, nil
 }
 
-/**
- * Get a BigInteger Shamir's secret sharing object with the number of participants and the modulus.
- *
- * @param participantCount The number of the participants.
- * @param modulus The modulus <i>p</i>.
- * @return The proper Shamir's secret sharing scheme object.
- * @return error IllegalArgumentException If the number of participants or the modulus is invalid.
- */
-func (lmpcb *LinearMultipartyComputationBigInt)getSecretSharing(participantCount int, modulus interface{})(secretshare.
-	ShamirSecretSharingInterface,error){
-	modulusValue ,ok := modulus.(*big.Int)
-	if (!ok) {return nil, errors.New("Invalid type of modulus.")}
-	return secretshare.NewShamirSecretSharingBigInt(participantCount,modulusValue)
-}
-
 /**
  * Generate a proper BigInteger modulus for Shamir's secret sharing from the coefficients of the linear function and the max value of the secret.
  *
@@ -90,60 +76,3 @@ func (lmpcb *LinearMultipartyComputationBigInt) generateModulus(coefficients []i
 	}
 	return modulus, nil
 }
-
-/**
- * Generate output during the output stage.
- *
- * @return The output value.
- */
-func (lmpcb *LinearMultipartyComputationBigInt) generateOutputImpl() interface{}{
-	modulus := lmpcb.secretSharing.GetModulus()
-	pile := big.NewInt(0)
-	for i:=0; i < lmpcb.participantCount; i++{
-		tmp := big.NewInt(1)
-		tmp.Mul(lmpcb.receivedInputs[i].(*big.Int), lmpcb.coefficients[i].(*big.Int))
-		tmp.Mod(tmp,modulus.(*big.Int))
-		pile.Add(pile,tmp).Mod(pile,modulus.(*big.Int))
-	}
-	return pile
-}
-/**
- * Abstract method of checking if the coefficients and the modulus are both valid.
- *
- * @param coefficients Coefficients of the linear function.
- * @param modulus Modulus of the Shamir's scheme.
- * @throws IllegalArgumentException if the coefficients or the modulus is invalid.
- */
-func (lmpcb *LinearMultipartyComputationBigInt)checkCoefficientsAndModulus(coefficients []interface{}, modulus interface{}) error{
-    if (modulus.(*big.Int).Cmp(big.NewInt(2)) <= 0){
-    	return errors.New("Modulus should be greater than 2.")
-	}
-	if(!modulus.(*big.Int).ProbablyPrime(20)){
-    	return errors.New("Modulus is not a Prime.")
-	}
-	for i := 0; i < len(coefficients); i++{
-		if (coefficients[i].(*big.Int).Cmp(big.NewInt(0)) < 0 || coefficients[i].(*big.Int).Cmp(modulus.(*big.Int)) >=0 ){
-			return errors.New("One coefficient is too great or too tiny.")
-		}
-	}
-	return nil
-}
-
-/**
- * Get BigInteger of value 1.
- *
- * @return BigInteger of value 1.
- */
-func (lmpcb *LinearMultipartyComputationBigInt) getElementOne() interface{}{
-	return big.NewInt(1)
-}
-/**
- * Check if the type of input element is valid.
- *
- * @param e Element to be checked.
- * @return True if the type of input element is BigInt, otherwise return false.
- */
-func (lmpcb *LinearMultipartyComputationBigInt) checkElement(e interface{}) bool{
-	_, ok := e.(*big.Int)
-	return ok
-}
\ No newline at end of file
@@ -0,0 +1,208 @@
+package mpc
+
+import (
+	"errors"
+)
+
+/**
+ * This class runs a <code>LinearMultipartyComputation</code> of one participant over a <code>Transport</code>.
+ * <p>
+ * During the input stage, the driver sends the generated inputs to all other participants and adds the
+ * inputs received from them. During the output stage, the driver broadcasts the generated output, adds the
+ * outputs received from all other participants and computes the linear function.
+ * <p>
+ * Since other participants may finish the input stage earlier, outputs received during the input stage
+ * are kept until the output stage.
+ * <p>
+ * In the batched mode, i.e. the secret is a vector, every message carries the whole vector of inputs or outputs,
+ * so each participant sends one input and one output message to every other participant whatever the length is.
+ * <p>
+ * In the packed mode, i.e. the packing count of a <code>LinearMultipartyComputationBigInt</code> is set and the secret is a vector,
+ * every message carries one element for every <i>l</i> secrets, and <code>RunOutputStageWithDropouts</code> waits for <i>t</i>+<i>l</i> outputs.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type LinearMultipartyComputationDriver struct {
+	/**
+	 * The linear mpc of this participant.
+	 */
+	computation LinearMultipartyComputationInterface
+
+	/**
+	 * The transport connecting this participant with all others.
+	 */
+	transport Transport
+
+	/**
+	 * Outputs received before the output stage.
+	 */
+	pendingOutputs []*Message
+}
+
+/**
+ * Construct a driver with the linear mpc and the transport of a participant.
+ * <p>
+ * The linear mpc should have been initialized with the linear function and the modulus.
+ *
+ * @param computation The linear mpc of this participant.
+ * @param transport The transport of this participant.
+ * @return feedback the constructed LinearMultipartyComputationDriver
+ * @return error If the linear mpc or the transport is nil.
+ */
+func NewLinearMultipartyComputationDriver(computation LinearMultipartyComputationInterface, transport Transport) (*LinearMultipartyComputationDriver, error){
+	if (computation == nil){
+		return nil, errors.New("Linear mpc should not be nil.")
+	}
+	if (transport == nil){
+		return nil, errors.New("Transport should not be nil.")
+	}
+	feedback := new(LinearMultipartyComputationDriver)
+	feedback.computation = computation
+	feedback.transport = transport
+	feedback.pendingOutputs = make([]*Message, 0)
+	return feedback, nil
+}
+
+/**
+ * Run both the input stage and the output stage, and compute the linear function.
+ *
+ * @param secret The secret value of this participant, or the vector of secret values in the batched mode.
+ * @param auxiliary The auxiliary data for generating Shamir's secret shares, same for all participants.
+ * @return The result value of the linear function, or the vector of results in the batched mode.
+ * @return error If any stage fails.
+ */
+func (driver *LinearMultipartyComputationDriver) Run(secret interface{}, auxiliary []interface{}) (interface{}, error){
+	err := driver.RunInputStage(secret, auxiliary)
+	if (err != nil) {return nil, err}
+	return driver.RunOutputStage()
+}
+
+/**
+ * Run the input stage, i.e. send the inputs to all other participants and wait for their inputs.
+ *
+ * @param secret The secret value of this participant.
+ * @param auxiliary The auxiliary data for generating Shamir's secret shares, same for all participants.
+ * @return error If the inputs cannot be generated, sent or received.
+ */
+func (driver *LinearMultipartyComputationDriver) RunInputStage(secret interface{}, auxiliary []interface{}) error{
+	id := driver.computation.GetID()
+	inputs, err := driver.computation.GenerateInputs(secret, auxiliary)
+	if (err != nil) {return err}
+	for to := 0; to < driver.computation.GetParticipantCount(); to++{
+		if (to == id) {continue}
+		err = driver.transport.Send(NewMessage(MessageInput, id, to, inputs[to]))
+		if (err != nil) {return err}
+	}
+	for (!driver.computation.HasAllInputReceived()){
+		message, err := driver.transport.Receive()
+		if (err != nil) {return err}
+		switch message.messageType {
+		case MessageInput:
+			err = driver.computation.AddReceivedInput(message.from, message.value)
+			if (err != nil) {return err}
+		case MessageOutput:
+			driver.pendingOutputs = append(driver.pendingOutputs, message)
+		default:
+			return errors.New("Invalid type of message.")
+		}
+	}
+	return nil
+}
+
+/**
+ * Run the output stage, i.e. broadcast the output, wait for the outputs of all other participants,
+ * and compute the linear function.
+ *
+ * @return The result value of the linear function.
+ * @return error If the outputs cannot be generated, sent or received, or the result cannot be computed.
+ */
+func (driver *LinearMultipartyComputationDriver) RunOutputStage() (interface{}, error){
+	id := driver.computation.GetID()
+	participantCount := driver.computation.GetParticipantCount()
+	output, err := driver.computation.GenerateOutput()
+	if (err != nil) {return nil, err}
+	for to := 0; to < participantCount; to++{
+		if (to == id) {continue}
+		err = driver.transport.Send(NewMessage(MessageOutput, id, to, output))
+		if (err != nil) {return nil, err}
+	}
+
+	received := make([]bool, participantCount)
+	received[id] = true
+	count := 1
+	for (count < participantCount){
+		var message *Message
+		if (len(driver.pendingOutputs) > 0){
+			message = driver.pendingOutputs[0]
+			driver.pendingOutputs = driver.pendingOutputs[1:]
+		} else {
+			message, err = driver.transport.Receive()
+			if (err != nil) {return nil, err}
+		}
+		if (message.messageType != MessageOutput){
+			return nil, errors.New("Invalid type of message during the output stage.")
+		}
+		err = driver.computation.AddReceivedOutput(message.from, message.value)
+		if (err != nil) {return nil, err}
+		if (!received[message.from]){
+			received[message.from] = true
+			count++
+		}
+	}
+	return driver.computation.Compute()
+}
+
+/**
+ * Run both stages like <code>Run</code>, but tolerate participants who drop out after the input stage.
+ *
+ * @param secret The secret value of this participant, or the vector of secret values in the batched mode.
+ * @param auxiliary The auxiliary data for generating Shamir's secret shares, same for all participants.
+ * @return The result value of the linear function, or the vector of results in the batched mode.
+ * @return error If the input stage fails, or the result cannot be computed.
+ */
+func (driver *LinearMultipartyComputationDriver) RunWithDropouts(secret interface{}, auxiliary []interface{}) (interface{}, error){
+	err := driver.RunInputStage(secret, auxiliary)
+	if (err != nil) {return nil, err}
+	return driver.RunOutputStageWithDropouts()
+}
+
+/**
+ * Run the output stage while tolerating participants who drop out after the input stage.
+ * <p>
+ * Since every participant holds a share of every input once the input stage is finished, the inputs of the dropped
+ * participants still count. The output is sent to all other participants concurrently, so that the dropped ones do not
+ * hold up the others, ignoring those who cannot be reached, and the linear function is computed as soon as <i>t</i>+1
+ * outputs, including its own, are received. If fewer than <i>t</i>+1 participants stay, this blocks until the transport
+ * is closed. Outputs arriving later are left in the transport, so it should not be reused for another computation.
+ *
+ * @return The result value of the linear function, or the vector of results in the batched mode.
+ * @return error If the output cannot be generated, the outputs cannot be received, or the result cannot be computed.
+ */
+func (driver *LinearMultipartyComputationDriver) RunOutputStageWithDropouts() (interface{}, error){
+	id := driver.computation.GetID()
+	output, err := driver.computation.GenerateOutput()
+	if (err != nil) {return nil, err}
+	for to := 0; to < driver.computation.GetParticipantCount(); to++{
+		if (to == id) {continue}
+		// sending to a dropped receiver may block until the transport gives up dialing it
+		go driver.transport.Send(NewMessage(MessageOutput, id, to, output))
+	}
+
+	for (!driver.computation.isReadyForCompute()){
+		var message *Message
+		if (len(driver.pendingOutputs) > 0){
+			message = driver.pendingOutputs[0]
+			driver.pendingOutputs = driver.pendingOutputs[1:]
+		} else {
+			message, err = driver.transport.Receive()
+			if (err != nil) {return nil, err}
+		}
+		if (message.messageType != MessageOutput){
+			return nil, errors.New("Invalid type of message during the output stage.")
+		}
+		err = driver.computation.AddReceivedOutput(message.from, message.value)
+		if (err != nil) {return nil, err}
+	}
+	return driver.computation.Compute()
+}
@@ -0,0 +1,278 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"crypto/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+func TestLinearMultipartyComputationDriverChannel(t *testing.T) {
+	participantCount := 7
+	threshold := 3
+	network, err := NewChannelNetwork(participantCount, participantCount)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ChannelNetwork: %s", err))}
+	transports := make([]Transport, participantCount)
+	for i := 0; i < participantCount; i++{
+		transports[i], _ = network.GetTransport(i)
+	}
+	t.Run("testLinearMultipartyComputationDriverBigInt",
+		testLinearMultipartyComputationDriverBigInt(participantCount, threshold, transports))
+	t.Run("testLinearMultipartyComputationDriverPacked",
+		testLinearMultipartyComputationDriverPacked(participantCount, threshold, 3, 10, transports))
+}
+
+func TestLinearMultipartyComputationDriverTCP(t *testing.T) {
+	participantCount := 5
+	threshold := 2
+	listeners := make([]net.Listener, participantCount)
+	addresses := make([]string, participantCount)
+	for i := 0; i < participantCount; i++{
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when listening on loopback: %s", err))}
+		listeners[i] = listener
+		addresses[i] = listener.Addr().String()
+	}
+	transports := make([]Transport, participantCount)
+	for i := 0; i < participantCount; i++{
+		transport, err := NewTCPTransportWithListener(i, addresses, listeners[i])
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing TCPTransport: %s", err))}
+		transports[i] = transport
+		defer transport.Close()
+	}
+	t.Run("testLinearMultipartyComputationDriverBigInt",
+		testLinearMultipartyComputationDriverBigInt(participantCount, threshold, transports))
+	t.Run("testLinearMultipartyComputationDriverInt",
+		testLinearMultipartyComputationDriverInt(participantCount, threshold, transports))
+	t.Run("testLinearMultipartyComputationDriverPacked",
+		testLinearMultipartyComputationDriverPacked(participantCount, threshold, 2, 7, transports))
+}
+
+func TestLinearMultipartyComputationDriverTCPDropouts(t *testing.T) {
+	participantCount := 5
+	threshold := 2
+	dropped := map[int]bool{1: true, 3: true}
+	transports, _ := newTCPTransports(t, participantCount)
+	for i := 0; i < participantCount; i++{
+		defer transports[i].Close()
+		// as cmd/mpcparty does, the whole time budget is given for reaching the peers
+		transports[i].SetDialTimeout(time.Minute)
+	}
+	setup, _ := NewLinearMultipartyComputationInt(0, participantCount, threshold)
+	_ = setup.InitializeSimpleSumWithModulus(2147483647)
+	auxi, _ := setup.GenerateInputAuxiliary()
+
+	start := time.Now()
+	var left sync.WaitGroup
+	left.Add(len(dropped))
+	results := make(chan interface{}, participantCount)
+	errs := make(chan error, participantCount)
+	for i := 0; i < participantCount; i++{
+		go func(i int) {
+			lmpc, err := NewLinearMultipartyComputationInt(i, participantCount, threshold)
+			if err != nil {errs <- err; return}
+			err = lmpc.InitializeSimpleSumWithModulus(2147483647)
+			if err != nil {errs <- err; return}
+			driver, err := NewLinearMultipartyComputationDriver(lmpc, transports[i])
+			if err != nil {errs <- err; return}
+			err = driver.RunInputStage(i + 1, auxi)
+			if (dropped[i]){
+				transports[i].Close()
+				left.Done()
+				if err != nil {errs <- err}
+				return
+			}
+			if err != nil {errs <- err; return}
+
+			// wait until the connections to the dropped participants are found dead, so that they are dialed again
+			left.Wait()
+			for to := range dropped{
+				for j := 0; j < 100 && transports[i].Send(NewMessage(MessageInput, i, to, 0)) == nil; j++{
+					time.Sleep(10 * time.Millisecond)
+				}
+			}
+			result, err := driver.RunOutputStageWithDropouts()
+			if err != nil {errs <- err; return}
+			results <- result
+		}(i)
+	}
+
+	// 1 + 2 + 3 + 4 + 5, the inputs of the dropped participants are still in the sum
+	for i := 0; i < participantCount - len(dropped); i++{
+		select {
+		case err := <-errs:
+			t.Fatal(fmt.Sprintf("Error happens when running the driver: %s", err))
+		case result := <-results:
+			if result.(int) != 15 {
+				t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: 15", result))
+			}
+		}
+	}
+	if (time.Since(start) > 20 * time.Second) {t.Error("Dropped participants should not block the output stage until the dial timeout.")}
+}
+
+func testLinearMultipartyComputationDriverBigInt(participantCount int, threshold int, transports []Transport) func(t *testing.T) {
+	return func(t *testing.T) {
+		max := big.NewInt(1000000000)
+		secret := make([]*big.Int, participantCount)
+		coefficients := make([]interface{}, participantCount)
+		for i := 0; i < participantCount; i++{
+			secret[i], _ = rand.Int(rand.Reader, max)
+			coefficients[i], _ = rand.Int(rand.Reader, big.NewInt(1000))
+		}
+
+		// the modulus and the auxiliary are public parameters agreed before running
+		setup, _ := NewLinearMultipartyComputationBigInt(0, participantCount, threshold)
+		err := setup.InitializeWithMaxValue(coefficients, max)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+		modulus := setup.GetModulus()
+		auxi, _ := setup.GenerateInputAuxiliary()
+
+		results := make(chan interface{}, participantCount)
+		errs := make(chan error, participantCount)
+		for i := 0; i < participantCount; i++{
+			go func(i int) {
+				lmpc, err := NewLinearMultipartyComputationBigInt(i, participantCount, threshold)
+				if err != nil {errs <- err; return}
+				err = lmpc.InitializeWithModulus(coefficients, modulus)
+				if err != nil {errs <- err; return}
+				driver, err := NewLinearMultipartyComputationDriver(lmpc, transports[i])
+				if err != nil {errs <- err; return}
+				result, err := driver.Run(secret[i], auxi)
+				if err != nil {errs <- err; return}
+				results <- result
+			}(i)
+		}
+
+		// calculate the true result(never do this in a real mpc procedure)
+		pile := big.NewInt(0)
+		for i := 0; i < participantCount; i++{
+			tmp := big.NewInt(0)
+			tmp.Mul(coefficients[i].(*big.Int), secret[i])
+			pile.Add(pile, tmp)
+		}
+		for i := 0; i < participantCount; i++{
+			select {
+			case err := <-errs:
+				t.Fatal(fmt.Sprintf("Error happens when running the driver: %s", err))
+			case result := <-results:
+				if result.(*big.Int).Cmp(pile) != 0 {
+					t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s", result, pile))
+				}
+			}
+		}
+	}
+}
+
+func testLinearMultipartyComputationDriverInt(participantCount int, threshold int, transports []Transport) func(t *testing.T) {
+	return func(t *testing.T) {
+		max := 100000
+		modulus := 2147483647
+		secret := make([]int, participantCount)
+		for i := 0; i < participantCount; i++{
+			se, _ := rand.Int(rand.Reader, big.NewInt(int64(max)))
+			secret[i] = int(se.Int64())
+		}
+		setup, _ := NewLinearMultipartyComputationInt(0, participantCount, threshold)
+		_ = setup.InitializeSimpleSumWithModulus(modulus)
+		auxi, _ := setup.GenerateInputAuxiliary()
+
+		results := make(chan interface{}, participantCount)
+		errs := make(chan error, participantCount)
+		for i := 0; i < participantCount; i++{
+			go func(i int) {
+				lmpc, err := NewLinearMultipartyComputationInt(i, participantCount, threshold)
+				if err != nil {errs <- err; return}
+				err = lmpc.InitializeSimpleSumWithModulus(modulus)
+				if err != nil {errs <- err; return}
+				driver, err := NewLinearMultipartyComputationDriver(lmpc, transports[i])
+				if err != nil {errs <- err; return}
+				result, err := driver.Run(secret[i], auxi)
+				if err != nil {errs <- err; return}
+				results <- result
+			}(i)
+		}
+
+		pile := 0
+		for i := 0; i < participantCount; i++{
+			pile += secret[i]
+		}
+		for i := 0; i < participantCount; i++{
+			select {
+			case err := <-errs:
+				t.Fatal(fmt.Sprintf("Error happens when running the driver: %s", err))
+			case result := <-results:
+				if result.(int) != pile {
+					t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: %d", result, pile))
+				}
+			}
+		}
+	}
+}
+
+func testLinearMultipartyComputationDriverPacked(participantCount int, threshold int, packingCount int, length int, transports []Transport) func(t *testing.T) {
+	return func(t *testing.T) {
+		max := big.NewInt(1000000)
+		secrets := make([][]interface{}, participantCount)
+		coefficients := make([]interface{}, participantCount)
+		for i := 0; i < participantCount; i++{
+			coefficients[i], _ = rand.Int(rand.Reader, big.NewInt(1000))
+			secrets[i] = make([]interface{}, length)
+			for j := 0; j < length; j++{
+				secrets[i][j], _ = rand.Int(rand.Reader, max)
+			}
+		}
+		setup, _ := NewLinearMultipartyComputationBigInt(0, participantCount, threshold)
+		err := setup.InitializeWithMaxValue(coefficients, max)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+		modulus := setup.GetModulus()
+		err = setup.SetPackingCount(packingCount)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when setting packing count: %s", err))}
+		auxi, _ := setup.GeneratePackedInputAuxiliary()
+
+		results := make(chan interface{}, participantCount)
+		errs := make(chan error, participantCount)
+		for i := 0; i < participantCount; i++{
+			go func(i int) {
+				lmpc, err := NewLinearMultipartyComputationBigInt(i, participantCount, threshold)
+				if err != nil {errs <- err; return}
+				err = lmpc.InitializeWithModulus(coefficients, modulus)
+				if err != nil {errs <- err; return}
+				err = lmpc.SetPackingCount(packingCount)
+				if err != nil {errs <- err; return}
+				driver, err := NewLinearMultipartyComputationDriver(lmpc, transports[i])
+				if err != nil {errs <- err; return}
+				result, err := driver.Run(secrets[i], auxi)
+				if err != nil {errs <- err; return}
+				results <- result
+			}(i)
+		}
+
+		// calculate the true result(never do this in a real mpc procedure)
+		pile := make([]*big.Int, length)
+		for j := 0; j < length; j++{
+			pile[j] = big.NewInt(0)
+			for i := 0; i < participantCount; i++{
+				tmp := big.NewInt(0)
+				tmp.Mul(coefficients[i].(*big.Int), secrets[i][j].(*big.Int))
+				pile[j].Add(pile[j], tmp)
+			}
+		}
+		for i := 0; i < participantCount; i++{
+			select {
+			case err := <-errs:
+				t.Fatal(fmt.Sprintf("Error happens when running the driver: %s", err))
+			case result := <-results:
+				if len(result.([]interface{})) != length {t.Fatal("Result vector should be as long as the secret vector.")}
+				for j := 0; j < length; j++{
+					if result.([]interface{})[j].(*big.Int).Cmp(pile[j]) != 0 {
+						t.Error(fmt.Sprintf("Calculate Result %d is False, Result:%s ,Expected: %s", j, result.([]interface{})[j], pile[j]))
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package mpc
+
+import (
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+	"math/big"
+)
+
+/**
+ * Use Feldman's verifiable secret sharing during the input stage, so that every participant can check the
+ * input it receives lies on the polynomial committed by the sender.
+ * <p>
+ * The group should be set after the linear function and the modulus are set, and its subgroup order
+ * should be equal to the modulus. Initializing again falls back to the plain Shamir's scheme.
+ *
+ * @param group The group of commitments, same for all participants.
+ * @return error IllegalArgumentException If the group does not match the modulus,
+ *         or IllegalStateException If the secret sharing scheme is not set.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) SetFeldmanGroup(group *secretshare.FeldmanGroup) error{
+	if (lmpcb.secretSharing == nil){
+		return errors.New("Secret sharing scheme not set.")
+	}
+	if (group == nil || group.GetQ().Cmp(lmpcb.secretSharing.GetModulus().(*big.Int)) != 0){
+		return errors.New("Order of the subgroup should be equal to the modulus.")
+	}
+	feldman, err := secretshare.NewFeldmanSecretSharingBigInt(lmpcb.participantCount, group)
+	if (err != nil) {return err}
+	err = feldman.SetAccessStructure(lmpcb.secretSharing.GetAccessStructure())
+	if (err != nil) {return err}
+	lmpcb.secretSharing = feldman
+	return nil
+}
+
+/**
+ * Generate inputs for all participants during the input stage, together with the commitments which should be
+ * sent to all participants along with the inputs.
+ *
+ * @param secret The secret value of this participant.
+ * @param auxiliary The auxiliary data for generating Shamir's secret shares.
+ * @return The inputs for all participants.
+ * @return The commitments to the polynomial.
+ * @return error IllegalArgumentException If the secret value or the auxiliary data is invalid.
+ *         or IllegalStateException If the group of commitments is not set.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) GenerateVerifiableInputs(secret interface{}, auxiliary []interface{}) ([]interface{}, []*big.Int, error){
+	err := lmpcb.checkInputParameters(secret, auxiliary)
+	if (err != nil) {return nil, nil, err}
+	feldman, ok := lmpcb.secretSharing.(*secretshare.FeldmanSecretSharingBigInt)
+	if (!ok) {return nil, nil, errors.New("Group of commitments not set.")}
+	shares, commitments, err := feldman.GenerateSharesWithCommitments(secret, auxiliary)
+	if (err != nil) {return nil, nil, err}
+	return lmpcb.acceptGeneratedShares(shares, auxiliary), commitments, nil
+}
+
+/**
+ * Verify an input received from other participant against its commitments, and add it if it is valid.
+ * <p>
+ * The input is checked on the evaluation point of this participant, so the inputs of this participant
+ * should have been generated before.
+ *
+ * @param from The id of the participant who sent the input.
+ * @param input The input value received.
+ * @param commitments The commitments received from the same participant.
+ * @return error IllegalArgumentException If the id, the input or the commitments are invalid, or the input is
+ *         not consistent with the commitments, or IllegalStateException If the group of commitments is not set.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) AddReceivedVerifiableInput(from int, input interface{}, commitments []*big.Int) error{
+	feldman, ok := lmpcb.secretSharing.(*secretshare.FeldmanSecretSharingBigInt)
+	if (!ok) {return errors.New("Group of commitments not set.")}
+	if (lmpcb.auxiliary == nil){
+		return errors.New("Inputs should be verified after generating input.")
+	}
+	if (!lmpcb.checkElement(input)){
+		return errors.New("Invalid type of input.")
+	}
+	share := secretshare.NewSecretShare(lmpcb.id, secretshare.NewShamirSecretShareValue(lmpcb.auxiliary[lmpcb.id], input))
+	valid, err := feldman.VerifyShare(share, commitments)
+	if (err != nil) {return err}
+	if (!valid) {return errors.New("Input is not consistent with the commitments.")}
+	return lmpcb.AddReceivedInput(from, input)
+}
@@ -0,0 +1,70 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+)
+
+func TestLinearMultipartyComputationFeldman(t *testing.T) {
+	participantCount := 5
+	threshold := 2
+	mpc := make([]*LinearMultipartyComputationBigInt, participantCount)
+	max := big.NewInt(1000000)
+	var err error
+	for i := 0; i < participantCount; i++{
+		mpc[i], err = NewLinearMultipartyComputationBigInt(i, participantCount, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationBigInt: %s", err))}
+	}
+	err = mpc[0].InitializeSimpleSumWithMax(max)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	modulus := mpc[0].GetModulus().(*big.Int)
+	group, err := secretshare.GenerateFeldmanGroup(modulus, 256)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating FeldmanGroup: %s", err))}
+	for i := 0; i < participantCount; i++{
+		if (i > 0){
+			err = mpc[i].InitializeSimpleSumWithModulus(modulus)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+		}
+		err = mpc[i].SetFeldmanGroup(group)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when setting FeldmanGroup: %s", err))}
+	}
+	auxi, err := mpc[0].GenerateInputAuxiliary()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+
+	inputs := make([][]interface{}, participantCount)
+	commitments := make([][]*big.Int, participantCount)
+	expected := big.NewInt(0)
+	for i := 0; i < participantCount; i++{
+		secret := big.NewInt(int64(1000 * i + 3))
+		expected.Add(expected, secret)
+		inputs[i], commitments[i], err = mpc[i].GenerateVerifiableInputs(secret, auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+	}
+
+	// participant 1 cheats on the input for participant 3
+	cheat := big.NewInt(1)
+	cheat.Add(cheat, inputs[1][3].(*big.Int)).Mod(cheat, modulus)
+	err = mpc[3].AddReceivedVerifiableInput(1, cheat, commitments[1])
+	if err == nil {t.Error("Inconsistent input is accepted.")}
+
+	for i := 0; i < participantCount; i++{
+		for j := 0; j < participantCount; j++{
+			if (i == j) {continue}
+			err = mpc[j].AddReceivedVerifiableInput(i, inputs[i][j], commitments[i])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+	for i := 0; i < participantCount; i++{
+		output, err := mpc[i].GenerateOutput()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+		err = mpc[0].AddReceivedOutput(i, output)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens after adding received output: %s", err))}
+	}
+	calculatedResult, err := mpc[0].Compute()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+	if (calculatedResult.(*big.Int).Cmp(expected) != 0){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s",calculatedResult,expected))
+	}
+}
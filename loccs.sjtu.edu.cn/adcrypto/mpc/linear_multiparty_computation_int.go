@@ -2,18 +2,19 @@ package mpc
 
 import (
 	"errors"
-	"math/big"
-	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+	"loccs.sjtu.edu.cn/adcrypto/poly"
 )
 
 /**
  * This class implements an Int secure multi-party linear function computation.
+ * <p>
+ * It is <code>LinearMultipartyComputation</code> over <code>poly.IntField</code>, see <code>FieldLinearMultipartyComputation</code> for the typed view.
  *
  * @author 		LoCCS
  * @version		1.0
  */
 type LinearMultipartyComputationInt struct {
-	LinearMultipartyComputation
+	linearMultipartyComputationField[int]
 }
 
 /**
@@ -28,40 +29,15 @@ type LinearMultipartyComputationInt struct {
  * @return error IllegalArgumentException If any of ID, participantCount or threshold is invalid.
  */
 func NewLinearMultipartyComputationInt(id int, participantCount int, threshold int)(*LinearMultipartyComputationInt,error){
-	if (participantCount < 3){
-		return nil, errors.New("Invalid participant count. Should be larger than 2.")
-	}
-	if (id < 0 || (id >= participantCount)){
-		return nil, errors.New("Invalid id, should be between 0 and participantCount-1")
-	}
-	if (threshold > (participantCount / 2)){
-		return nil, errors.New("Threshold should never greater than 1/2 of the participant count.")
-	}
 	feedback := new(LinearMultipartyComputationInt)
-	feedback.id = id
-	feedback.participantCount = participantCount
-	feedback.threshold = threshold
+	err := feedback.init(id, participantCount, threshold, 1, func(modulus int) (poly.Field[int], error){
+		return poly.NewIntField(modulus)
+	})
+	if (err != nil) {return nil, err}
 	feedback.linearMultipartyComputationCalculator = feedback
-	feedback.receivedInputs = make([]interface{},participantCount)
-	feedback.receivedOutputs = map[int]interface{} {}
 	return feedback, nil
 }
 
-/**
- * Get a Int Shamir's secret sharing object with the number of participants and the modulus.
- *
- * @param participantCount The number of the participants.
- * @param modulus The modulus <i>p</i>.
- * @return The proper Shamir's secret sharing scheme object.
- * @return error IllegalArgumentException If the number of participants or the modulus is invalid.
- */
-func (lmpcb *LinearMultipartyComputationInt)getSecretSharing(participantCount int, modulus interface{})(secretshare.
-ShamirSecretSharingInterface,error){
-	modulusValue ,ok := modulus.(int)
-	if (!ok) {return nil, errors.New("Invalid type of modulus.")}
-	return secretshare.NewShamirSecretSharingInt(participantCount,modulusValue)
-}
-
 /**
  * Generate a proper Int modulus for Shamir's secret sharing from the coefficients of the linear function and the max value of the secret.
  *
@@ -86,58 +62,3 @@ func (lmpcb *LinearMultipartyComputationInt) generateModulus(coefficients []inte
 	}
 	return modulus, nil
 }
-
-/**
- * Generate output during the output stage.
- *
- * @return The output value.
- */
-func (lmpcb *LinearMultipartyComputationInt) generateOutputImpl() interface{}{
-	modulus := int64(lmpcb.GetModulus().(int))
-	var pile int64 = 0
-	for i := 0; i < lmpcb.participantCount; i++{
-		pile += (int64(lmpcb.coefficients[i].(int)) * int64(lmpcb.receivedInputs[i].(int)))
-		pile = (pile % modulus + modulus) % modulus
-	}
-	return int(pile)
-}
-/**
- * Abstract method of checking if the coefficients and the modulus are both valid.
- *
- * @param coefficients Coefficients of the linear function.
- * @param modulus Modulus of the Shamir's scheme.
- * @throws IllegalArgumentException if the coefficients or the modulus is invalid.
- */
-func (lmpcb *LinearMultipartyComputationInt)checkCoefficientsAndModulus(coefficients []interface{}, modulus interface{}) error{
-	if (modulus.(int) <= 2){
-		return errors.New("Modulus should be greater than 2.")
-	}
-	if(!big.NewInt(int64(modulus.(int))).ProbablyPrime(20)){
-		return errors.New("Modulus is not a Prime.")
-	}
-	for i := 0; i < len(coefficients); i++{
-		if (coefficients[i].(int) < 0 || coefficients[i].(int) >= modulus.(int) ){
-			return errors.New("One coefficient is too great or too tiny.")
-		}
-	}
-	return nil
-}
-
-/**
- * Get Int of value 1.
- *
- * @return Int of value 1.
- */
-func (lmpcb *LinearMultipartyComputationInt) getElementOne() interface{}{
-	return 1
-}
-/**
- * Check if the type of input element is valid.
- *
- * @param e Element to be checked.
- * @return True if the type of input element is Int, otherwise return false.
- */
-func (lmpcb *LinearMultipartyComputationInt) checkElement(e interface{}) bool{
-	_, ok := e.(int)
-	return ok
-}
\ No newline at end of file
@@ -0,0 +1,318 @@
+package mpc
+
+import (
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+	"math/big"
+	"sort"
+)
+
+/**
+ * Use packed secret sharing (Franklin-Yung) for vector inputs, so that every participant contributes a vector
+ * <i>x<sub>i</sub></i> and the linear function <i>c</i><sub>1</sub><i>x</i><sub>1</sub> + ... + <i>c<sub>n</sub></i><i>x<sub>n</sub></i>
+ * is computed element-wise, with <i>l</i> elements packed in every polynomial.
+ * <p>
+ * A vector of length <i>L</i> is split into &lceil;<i>L</i>/<i>l</i>&rceil; blocks, the last one padded with 0, so the input sent to every
+ * participant and the output are vectors of &lceil;<i>L</i>/<i>l</i>&rceil; elements instead of <i>L</i>. The polynomials have degree
+ * <i>t</i>+<i>l</i>-1, so up to <i>t</i> participants learn nothing, and <i>t</i>+<i>l</i> outputs are needed to compute the result.
+ * <p>
+ * The packing count should be set after the linear function and the modulus are set, and stays across initializations.
+ * Once it is set, vector secrets given to <code>GenerateInputs</code> are packed as well, and <code>Compute</code> returns the
+ * result vector from <i>t</i>+<i>l</i> outputs, so <code>LinearMultipartyComputationDriver</code> runs the packed mode unchanged.
+ *
+ * @param packingCount The number of elements <i>l</i> packed in one polynomial, <i>t</i>+<i>l</i> should be no more than <i>n</i>.
+ * @return error IllegalArgumentException If the packing count is invalid or the modulus is not larger than <i>n</i>+<i>l</i>-1,
+ *         or IllegalStateException If the secret sharing scheme is not set.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) SetPackingCount(packingCount int) error{
+	if (lmpcb.secretSharing == nil){
+		return errors.New("Secret sharing scheme not set.")
+	}
+	if (packingCount < 1 || lmpcb.threshold + packingCount > lmpcb.participantCount){
+		return errors.New("Invalid packing count. Should be larger than 0 and no more than participantCount-threshold.")
+	}
+	_, err := secretshare.NewPackedSecretSharingBigInt(lmpcb.participantCount, packingCount, lmpcb.secretSharing.GetModulus().(*big.Int))
+	if (err != nil) {return err}
+	lmpcb.packingCount = packingCount
+	return nil
+}
+
+/**
+ * Get the number of elements packed in one polynomial.
+ *
+ * @return The packing count <i>l</i>, 0 if packing is not set.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) GetPackingCount() int{
+	return lmpcb.packingCount
+}
+
+/**
+ * Generate random auxiliary data for packed inputs, i.e. distinct random evaluation points which are not secret points.
+ *
+ * @return Random auxiliary data.
+ * @return error If the packing count or the secret sharing scheme is not set.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) GeneratePackedInputAuxiliary() ([]interface{}, error){
+	packing, err := lmpcb.getPackedSharing()
+	if (err != nil) {return nil, err}
+	return packing.GenerateRandomAuxiliary(), nil
+}
+
+/**
+ * Generate packed inputs for all participants during the input stage.
+ *
+ * @param secrets The secret vector of this participant, BigInt elements, same length for all participants.
+ * @param auxiliary The auxiliary data for generating the shares, should not be secret points 0, -1, ..., -(<i>l</i>-1).
+ * @return The inputs for all participants, each a []interface{} of one BigInt for every block.
+ * @return error IllegalArgumentException If the secret vector or the auxiliary data is invalid,
+ *         or IllegalStateException If the linear function or the packing count is not set.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) GeneratePackedInputs(secrets []interface{}, auxiliary []interface{}) ([]interface{}, error){
+	if (len(secrets) == 0){
+		return nil, errors.New("Secret vector should not be empty.")
+	}
+	for i := 0; i < len(secrets); i++{
+		value, ok := secrets[i].(*big.Int)
+		if (!ok || value == nil) {return nil, errors.New("Invalid type of a secret.")}
+	}
+	err := lmpcb.checkInputParameters(secrets[0], auxiliary)
+	if (err != nil) {return nil, err}
+	packing, err := lmpcb.getPackedSharing()
+	if (err != nil) {return nil, err}
+
+	blockCount := (len(secrets) + lmpcb.packingCount - 1) / lmpcb.packingCount
+	inputs := make([]interface{}, lmpcb.participantCount)
+	for j := 0; j < lmpcb.participantCount; j++{
+		inputs[j] = make([]interface{}, blockCount)
+	}
+	for b := 0; b < blockCount; b++{
+		block := make([]interface{}, lmpcb.packingCount)
+		for l := 0; l < lmpcb.packingCount; l++{
+			if (b * lmpcb.packingCount + l < len(secrets)){
+				block[l] = secrets[b * lmpcb.packingCount + l]
+			} else {
+				block[l] = big.NewInt(0)
+			}
+		}
+		shares, err := packing.GenerateShares(block, auxiliary)
+		if (err != nil) {return nil, err}
+		for j := 0; j < lmpcb.participantCount; j++{
+			inputs[j].([]interface{})[b] = shares[j].GetValue().(*secretshare.ShamirSecretShareValue).GetQr()
+		}
+	}
+	lmpcb.auxiliary = auxiliary
+	lmpcb.packedLength = len(secrets)
+	lmpcb.receivedInputs[lmpcb.id] = inputs[lmpcb.id] //itself
+	return inputs, nil
+}
+
+/**
+ * Add a packed input when received from other participant during the input stage.
+ *
+ * @param from The id of the participant who sent the input.
+ * @param input The input received, a []interface{} of BigInt.
+ * @return error IllegalArgumentException If the id of the participant or the input is invalid.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) AddReceivedPackedInput(from int, input interface{}) error{
+	if ((from < 0) || (from >= lmpcb.participantCount)){
+		return errors.New("Invalid ID of the received input.")
+	}
+	if (!lmpcb.checkPackedElement(input)){
+		return errors.New("Invalid type of input, should be a []interface{} of BigInt.")
+	}
+	lmpcb.receivedInputs[from] = input
+	return nil
+}
+
+/**
+ * Generate the packed output during the output stage, i.e. the linear function on the received inputs, block by block.
+ *
+ * @return The output, a []interface{} of one BigInt for every block.
+ * @return error IllegalStateException If not all inputs are received, the inputs have different lengths,
+ *         or the secret sharing scheme in not set properly.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) GeneratePackedOutput() (interface{}, error){
+	if (lmpcb.coefficients == nil || lmpcb.secretSharing == nil){
+		return nil, errors.New("Coefficients or secret sharing scheme not set.")
+	}
+	if (!lmpcb.HasAllInputReceived()){
+		return nil, errors.New("Output cannot be generated before all inputs are received.")
+	}
+	modulus := lmpcb.secretSharing.GetModulus().(*big.Int)
+	blockCount := -1
+	for i := 0; i < lmpcb.participantCount; i++{
+		input, ok := lmpcb.receivedInputs[i].([]interface{})
+		if (!ok || (blockCount >= 0 && len(input) != blockCount)){
+			return nil, errors.New("Packed inputs of all participants should have the same length.")
+		}
+		blockCount = len(input)
+	}
+	output := make([]interface{}, blockCount)
+	for b := 0; b < blockCount; b++{
+		pile := big.NewInt(0)
+		for i := 0; i < lmpcb.participantCount; i++{
+			tmp := big.NewInt(0)
+			tmp.Mul(lmpcb.receivedInputs[i].([]interface{})[b].(*big.Int), lmpcb.coefficients[i].(*big.Int))
+			pile.Add(pile, tmp).Mod(pile, modulus)
+		}
+		output[b] = pile
+	}
+	lmpcb.receivedOutputs[lmpcb.id] = output
+	return output, nil
+}
+
+/**
+ * Add a packed output when received from other participant during the output stage.
+ *
+ * @param from The id of the participant who sent the output.
+ * @param output The output received, a []interface{} of BigInt.
+ * @return error IllegalArgumentException If the id of the participant or the output is invalid.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) AddReceivedPackedOutput(from int, output interface{}) error{
+	if ((from < 0) || (from >= lmpcb.participantCount)){
+		return errors.New("Invalid ID of the received output.")
+	}
+	if (!lmpcb.checkPackedElement(output)){
+		return errors.New("Invalid type of output, should be a []interface{} of BigInt.")
+	}
+	lmpcb.receivedOutputs[from] = output
+	return nil
+}
+
+/**
+ * Compute the linear function element-wise from <i>t</i>+<i>l</i> packed outputs.
+ *
+ * @return The result vector, as long as the secret vector of this participant.
+ * @return error IllegalStateException If not enough outputs are received, the outputs are invalid,
+ *         or the packed inputs of this participant have not been generated.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) ComputePacked() ([]interface{}, error){
+	if (lmpcb.auxiliary == nil){
+		return nil, errors.New("Secure MPC should start after generating input.")
+	}
+	packing, err := lmpcb.getPackedSharing()
+	if (err != nil) {return nil, err}
+	threshold := lmpcb.threshold + lmpcb.packingCount
+	if (len(lmpcb.receivedOutputs) < threshold){
+		return nil, errors.New("Not enough outputs received.")
+	}
+	from := make([]int, 0, len(lmpcb.receivedOutputs))
+	for k := range(lmpcb.receivedOutputs){
+		from = append(from, k)
+	}
+	sort.Ints(from)
+	from = from[:threshold]
+	blockCount := (lmpcb.packedLength + lmpcb.packingCount - 1) / lmpcb.packingCount
+	feedback := make([]interface{}, 0, blockCount * lmpcb.packingCount)
+	for b := 0; b < blockCount; b++{
+		shares := make([]*secretshare.SecretShare, threshold)
+		for i := 0; i < threshold; i++{
+			output, ok := lmpcb.receivedOutputs[from[i]].([]interface{})
+			if (!ok || len(output) != blockCount){
+				return nil, errors.New("Packed outputs should be as long as the packed inputs.")
+			}
+			shareValue := secretshare.NewShamirSecretShareValue(lmpcb.auxiliary[from[i]], output[b])
+			shares[i] = secretshare.NewSecretShare(from[i], shareValue)
+		}
+		block, err := packing.CalculateSecret(shares)
+		if (err != nil) {return nil, err}
+		feedback = append(feedback, block.([]interface{})...)
+	}
+	return feedback[:lmpcb.packedLength], nil
+}
+
+/**
+ * Generate inputs for all participants during the input stage, packing a vector secret if the packing count is set.
+ *
+ * @param secret The secret value of this participant, or a non-empty vector of secret values.
+ * @param auxiliary The auxiliary data for generating the shares.
+ * @return The inputs for all participants, each a vector of one BigInt for every block in the packed mode.
+ * @return error IllegalArgumentException If the secret value or the auxiliary data is invalid,
+ *         or IllegalStateException If the linear function or the secret sharing scheme in not set properly.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) GenerateInputs(secret interface{}, auxiliary []interface{}) ([]interface{}, error){
+	lmpcb.packedLength = 0
+	secrets, ok := secret.([]interface{})
+	if (ok && lmpcb.packingCount > 0) {return lmpcb.GeneratePackedInputs(secrets, auxiliary)}
+	return lmpcb.linearMultipartyComputationField.GenerateInputs(secret, auxiliary)
+}
+
+/**
+ * Test if enough outputs are received to compute the linear function, i.e. <i>t</i>+<i>l</i> in the packed mode.
+ *
+ * @return True if enough outputs are received, otherwise return false.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) isReadyForCompute() bool{
+	if (lmpcb.packedLength == 0) {return lmpcb.linearMultipartyComputationField.isReadyForCompute()}
+	return len(lmpcb.receivedOutputs) >= lmpcb.threshold + lmpcb.packingCount
+}
+
+/**
+ * Compute the linear function, element-wise by <code>ComputePacked</code> in the packed mode.
+ *
+ * @return The result value of the linear function, or the vector of results in the batched or the packed mode.
+ * @return error IllegalStateException If not enough outputs are received or the outputs are invalid.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) Compute() (interface{}, error){
+	if (lmpcb.packedLength == 0) {return lmpcb.linearMultipartyComputationField.Compute()}
+	feedback, err := lmpcb.ComputePacked()
+	if (err != nil) {return nil, err}
+	return feedback, nil
+}
+
+/**
+ * Compute the linear function from all received outputs, tolerating wrong outputs.
+ *
+ * @return The result value of the linear function, or the vector of results in the batched mode.
+ * @return IDs of the participants whose outputs are wrong.
+ * @return error IllegalStateException If not enough outputs are received, too many outputs are wrong,
+ *         or the inputs are packed, which is not supported.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) ComputeRobust() (interface{}, []int, error){
+	if (lmpcb.packedLength > 0){
+		return nil, nil, errors.New("Robust computation is not supported in the packed mode.")
+	}
+	return lmpcb.linearMultipartyComputationField.ComputeRobust()
+}
+
+/**
+ * Reset to time before input stage, keeping the packing count. And ready for the next round of MPC.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) Reset(){
+	lmpcb.linearMultipartyComputationField.Reset()
+	lmpcb.packedLength = 0
+}
+
+/**
+ * Get the packed secret sharing scheme with the current modulus, whose threshold is <i>t</i>+<i>l</i>.
+ *
+ * @return The packed secret sharing scheme.
+ * @return error If the packing count or the secret sharing scheme is not set.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) getPackedSharing() (*secretshare.PackedSecretSharingBigInt, error){
+	if (lmpcb.packingCount == 0 || lmpcb.secretSharing == nil){
+		return nil, errors.New("Packing count or secret sharing scheme not set.")
+	}
+	packing, err := secretshare.NewPackedSecretSharingBigInt(lmpcb.participantCount, lmpcb.packingCount, lmpcb.secretSharing.GetModulus().(*big.Int))
+	if (err != nil) {return nil, err}
+	access, err := secretshare.NewThresholdAccessStructure(lmpcb.participantCount, lmpcb.threshold + lmpcb.packingCount)
+	if (err != nil) {return nil, err}
+	err = packing.SetAccessStructure(access)
+	if (err != nil) {return nil, err}
+	return packing, nil
+}
+
+/**
+ * Check if a packed input or output is a non-empty []interface{} of BigInt.
+ *
+ * @param e Element to be checked.
+ * @return True if the element is valid, otherwise return false.
+ */
+func (lmpcb *LinearMultipartyComputationBigInt) checkPackedElement(e interface{}) bool{
+	values, ok := e.([]interface{})
+	if (!ok || len(values) == 0) {return false}
+	for i := 0; i < len(values); i++{
+		if (!lmpcb.checkElement(values[i])) {return false}
+	}
+	return true
+}
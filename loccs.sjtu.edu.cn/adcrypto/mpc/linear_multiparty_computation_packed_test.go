@@ -0,0 +1,122 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestLinearMultipartyComputationPackedProcedure(t *testing.T) {
+	t.Run("TestLinearMultipartyComputationPackedProcedure1", testLinearMultipartyComputationPackedProcedure(7, 3, 4, 10, false))
+	t.Run("TestLinearMultipartyComputationPackedProcedure2", testLinearMultipartyComputationPackedProcedure(7, 3, 4, 8, true))
+	t.Run("TestLinearMultipartyComputationPackedProcedure3", testLinearMultipartyComputationPackedProcedure(5, 2, 1, 3, false))
+	t.Run("TestLinearMultipartyComputationPackedProcedure4", testLinearMultipartyComputationPackedProcedure(9, 2, 7, 1000, true))
+}
+
+func testLinearMultipartyComputationPackedProcedure(participantCount int, threshold int, packingCount int, length int, randomAuxiliary bool) func(t *testing.T) {
+	return func(t *testing.T) {
+		mpc := make([]*LinearMultipartyComputationBigInt, participantCount)
+		max := big.NewInt(1000000)
+		secrets := make([][]interface{}, participantCount)
+		coefficients := make([]interface{}, participantCount)
+		var err error
+		for i := 0; i < participantCount; i++{
+			coefficients[i], _ = rand.Int(rand.Reader, big.NewInt(1000))
+			secrets[i] = make([]interface{}, length)
+			for j := 0; j < length; j++{
+				secrets[i][j], _ = rand.Int(rand.Reader, max)
+			}
+		}
+
+		var modulus *big.Int
+		for i := 0; i < participantCount; i++{
+			mpc[i], err = NewLinearMultipartyComputationBigInt(i, participantCount, threshold)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationBigInt: %s", err))}
+			if (i == 0){
+				err = mpc[i].InitializeWithMaxValue(coefficients, max)
+				modulus = mpc[i].GetModulus().(*big.Int)
+			} else {
+				err = mpc[i].InitializeWithModulus(coefficients, modulus)
+			}
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+			err = mpc[i].SetPackingCount(packingCount)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when setting packing count: %s", err))}
+		}
+
+		var auxi []interface{}
+		if (randomAuxiliary){
+			auxi, err = mpc[0].GeneratePackedInputAuxiliary()
+		} else {
+			auxi, err = mpc[0].GenerateInputAuxiliary()
+		}
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+
+		blockCount := (length + packingCount - 1) / packingCount
+		for i := 0; i < participantCount; i++{
+			inputs, err := mpc[i].GeneratePackedInputs(secrets[i], auxi)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+			for j := 0; j < participantCount; j++{
+				if (len(inputs[j].([]interface{})) != blockCount) {t.Fatal("Packed input should have one element for every block.")}
+				err = mpc[j].AddReceivedPackedInput(i, inputs[j])
+				if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+			}
+		}
+
+		outputs := make([]interface{}, participantCount)
+		for i := 0; i < participantCount; i++{
+			outputs[i], err = mpc[i].GeneratePackedOutput()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+		}
+
+		// the last participant computes with t+l-1 outputs of others and its own
+		last := participantCount - 1
+		for from := 0; from < threshold + packingCount - 1; from++{
+			_, err = mpc[last].ComputePacked()
+			if err == nil {t.Error("Result should not be computed from less than t+l outputs.")}
+			err = mpc[last].AddReceivedPackedOutput(from, outputs[from])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens after adding received output: %s", err))}
+		}
+		result, err := mpc[last].ComputePacked()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+		if (len(result) != length) {t.Fatal("Result should be as long as the secret vector.")}
+
+		// calculate the true result(never do this in a real mpc procedure)
+		for j := 0; j < length; j++{
+			pile := big.NewInt(0)
+			for i := 0; i < participantCount; i++{
+				tmp := big.NewInt(0)
+				tmp.Mul(coefficients[i].(*big.Int), secrets[i][j].(*big.Int))
+				pile.Add(pile, tmp)
+			}
+			if (result[j].(*big.Int).Cmp(pile) != 0){
+				t.Error(fmt.Sprintf("Calculate Result %d is False, Result:%s ,Expected: %s", j, result[j], pile))
+			}
+		}
+	}
+}
+
+func TestLinearMultipartyComputationPackedInvalid(t *testing.T) {
+	mpc, _ := NewLinearMultipartyComputationBigInt(0, 5, 2)
+	err := mpc.SetPackingCount(2)
+	if err == nil {t.Error("Packing count should not be set before the modulus.")}
+	mpc.InitializeSimpleSumWithModulus(big.NewInt(7))
+	err = mpc.SetPackingCount(4)
+	if err == nil {t.Error("Packing count larger than n-t should not be accepted.")}
+	err = mpc.SetPackingCount(3)
+	if err == nil {t.Error("Modulus not larger than n+l-1 should not be accepted.")}
+	mpc.InitializeSimpleSumWithModulus(big.NewInt(101))
+	auxi, _ := mpc.GenerateInputAuxiliary()
+	_, err = mpc.GeneratePackedInputs([]interface{}{big.NewInt(1)}, auxi)
+	if err == nil {t.Error("Packed inputs should not be generated before the packing count is set.")}
+	err = mpc.SetPackingCount(3)
+	if err != nil {t.Fatal(err)}
+	_, err = mpc.GeneratePackedInputs([]interface{}{}, auxi)
+	if err == nil {t.Error("Empty secret vector should not be accepted.")}
+	_, err = mpc.GeneratePackedInputs([]interface{}{big.NewInt(1), 2}, auxi)
+	if err == nil {t.Error("Secret vector with an int should not be accepted.")}
+	err = mpc.AddReceivedPackedInput(1, big.NewInt(1))
+	if err == nil {t.Error("Single BigInt should not be accepted as packed input.")}
+	_, err = mpc.ComputePacked()
+	if err == nil {t.Error("Result should not be computed before generating inputs.")}
+}
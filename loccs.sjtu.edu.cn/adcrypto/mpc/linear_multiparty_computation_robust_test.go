@@ -0,0 +1,57 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+)
+
+func TestLinearMultipartyComputationRobust(t *testing.T) {
+	participantCount := 7
+	threshold := 2
+	mpc := make([]*LinearMultipartyComputationInt, participantCount)
+	coefficients := make([]interface{}, participantCount)
+	expected := 0
+	var err error
+	for i := 0; i < participantCount; i++{
+		mpc[i], err = NewLinearMultipartyComputationInt(i, participantCount, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationInt: %s", err))}
+		coefficients[i] = i + 2
+		expected += (i + 2) * (100 * i + 1)
+	}
+	err = mpc[0].InitializeWithMaxValue(coefficients, 100000)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	modulus := mpc[0].GetModulus().(int)
+	for i := 1; i < participantCount; i++{
+		err = mpc[i].InitializeWithModulus(coefficients, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	}
+	auxi, err := mpc[0].GenerateInputAuxiliary()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+
+	for i := 0; i < participantCount; i++{
+		inputs, err := mpc[i].GenerateInputs(100 * i + 1, auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount; j++{
+			err = mpc[j].AddReceivedInput(i, inputs[j])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// participants 3 and 5 send wrong outputs, (n-t-1)/2 = 2 wrong outputs can be corrected
+	for i := 0; i < participantCount; i++{
+		output, err := mpc[i].GenerateOutput()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+		if (i == 3 || i == 5) {output = (output.(int) + i) % modulus}
+		err = mpc[0].AddReceivedOutput(i, output)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens after adding received output: %s", err))}
+	}
+
+	calculatedResult, corrupted, err := mpc[0].ComputeRobust()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+	if (calculatedResult.(int) != expected){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: %d",calculatedResult,expected))
+	}
+	if (fmt.Sprint(corrupted) != "[3 5]"){
+		t.Error(fmt.Sprintf("Wrong outputs are not located, Result:%v ,Expected: [3 5]",corrupted))
+	}
+}
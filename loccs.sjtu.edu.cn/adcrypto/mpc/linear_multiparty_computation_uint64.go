@@ -0,0 +1,70 @@
+package mpc
+
+import (
+	"errors"
+	"math/bits"
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+)
+
+/**
+ * This class implements an uint64 secure multi-party linear function computation, for any prime modulus <i>p</i> < 2<sup>64</sup>.
+ * <p>
+ * It is <code>LinearMultipartyComputation</code> over <code>poly.Uint64Field</code>, see <code>FieldLinearMultipartyComputation</code> for the typed view.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type LinearMultipartyComputationUint64 struct {
+	linearMultipartyComputationField[uint64]
+}
+
+/**
+ * Construct linear function MPC scheme with number of participants, threshold and the ID of the participant.
+ * <p>
+ * The threshold is the max number of semi-honest adversaries, should be less than <i>n</i>/2.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>.
+ * @return feedback the constructed LinearMultipartyComputationUint64
+ * @return error IllegalArgumentException If any of ID, participantCount or threshold is invalid.
+ */
+func NewLinearMultipartyComputationUint64(id int, participantCount int, threshold int) (*LinearMultipartyComputationUint64, error){
+	feedback := new(LinearMultipartyComputationUint64)
+	err := feedback.init(id, participantCount, threshold, uint64(1), func(modulus uint64) (poly.Field[uint64], error){
+		return poly.NewUint64Field(modulus)
+	})
+	if (err != nil) {return nil, err}
+	feedback.linearMultipartyComputationCalculator = feedback
+	return feedback, nil
+}
+
+/**
+ * Generate a proper uint64 modulus for Shamir's secret sharing from the coefficients of the linear function and the max value of the secret.
+ * <p>
+ * The probable maximum sum is accumulated with the carries of math/bits, so it is rejected instead of wrapping around if it does not fit in 64 bits.
+ *
+ * @param coefficients The coefficients of the linear function.
+ * @param max Max value of a secret.
+ * @return The modulus for Shamir's secret sharing scheme.
+ * @return error IllegalArgumentException If the coefficients or the max value is invalid.
+ */
+func (lmpcu *LinearMultipartyComputationUint64) generateModulus(coefficients []interface{}, max interface{}) (interface{}, error){
+	tooGreat := errors.New("Probable maximum sum is too great, cannot generate an enough modulus")
+	pile := uint64(0)  // probably max value of the sum
+	for i := 0; i < len(coefficients); i++{
+		high, product := bits.Mul64(coefficients[i].(uint64), max.(uint64))
+		if (high != 0) {return nil, tooGreat}
+		var carry uint64
+		pile, carry = bits.Add64(pile, product, 0)
+		if (carry != 0) {return nil, tooGreat}
+	}
+	modulus := uint64(0)
+	modulusList := []uint64{4294967311, 1099511627791, 281474976710677, 72057594037928017, 2305843009213693951,
+		9223372036854775783, 9223372036854775837, 18446744073709551557} // a list of prime that can be represented with uint64
+	for i := 0; i < len(modulusList); i++{
+		if (pile < modulusList[i]) {modulus = modulusList[i]}
+	}
+	if (modulus == 0) {return nil, tooGreat}
+	return modulus, nil
+}
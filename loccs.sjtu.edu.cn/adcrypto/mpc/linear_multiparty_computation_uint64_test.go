@@ -0,0 +1,105 @@
+package mpc
+
+import (
+	"fmt"
+	"testing"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestNewLinearMultipartyComputationUint64Procedure(t *testing.T) {
+	participantCount := 15
+	threshold := 6
+	mpc := make([]*LinearMultipartyComputationUint64, participantCount) // mpc class for every party
+	max := uint64(1) << 57  // the max probable number for secret, the sum needs a modulus above 2^63
+	secret := make([]uint64,participantCount)  // secrets
+	coeffcients := make([]interface{}, participantCount)  // coefficients
+	var err error
+	computeFrom := []int{1,4,6,8,11,14} // computing parties except zero itself
+
+	// initialize secrets and coefficients
+	for i := 0; i < participantCount; i++{
+		se ,_ := rand.Int(rand.Reader,new(big.Int).SetUint64(max))
+		secret[i] = se.Uint64()
+		coeffcients[i] = uint64(i + 1)
+	}
+
+	// constrcut class mpcs
+	for i := 0; i < participantCount; i++{
+		mpc[i],err = NewLinearMultipartyComputationUint64(i,participantCount,threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationUint64: %s", err))}
+	}
+
+	modulus := uint64(1)
+	for i := 0 ; i < participantCount; i++{
+		// initialize mpcs
+		if i == 0 {
+			err = mpc[i].InitializeWithMaxValue(coeffcients,max)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+			modulus = mpc[i].GetModulus().(uint64)
+		}else{
+			err = mpc[i].InitializeWithModulus(coeffcients,modulus)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+		}
+	}
+	if (modulus != 18446744073709551557) {t.Error(fmt.Sprintf("Generated modulus is False, Result:%d", modulus))}
+
+	// generate auxiliary
+	auxi, err := mpc[0].GenerateInputAuxiliary()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+
+	for i := 0 ; i <participantCount; i++{
+		// every party generate inputs
+		inputs, err := mpc[i].GenerateInputs(secret[i],auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		// every party receive and add inputs
+		for j := 0; j < participantCount ; j++{
+			err = mpc[j].AddReceivedInput(i,inputs[j])
+			if err != nil {t.Error(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// every party generate outputs
+	outputs := make([]interface{},participantCount)
+	for i := 0; i<participantCount; i++{
+		outputs[i],err = mpc[i].GenerateOutput()
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+	}
+
+	// mpc[0] add other outputs (number: threshold)
+	for _,from := range computeFrom{
+		_ = mpc[0].AddReceivedOutput(from,outputs[from])
+	}
+
+	// mpc[0] calculate the final result
+	calculatedResult,err := mpc[0].Compute()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+
+	// calculate the true result(never do this in a real mpc procedure)
+	pile := big.NewInt(0)
+	for i:=0; i<participantCount;i++{
+		term := new(big.Int).SetUint64(coeffcients[i].(uint64))
+		pile.Add(pile, term.Mul(term, new(big.Int).SetUint64(secret[i])))
+	}
+
+	// test whether the mpc-calculated result is true
+	if !pile.IsUint64() || calculatedResult.(uint64) != pile.Uint64() {
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: %s",calculatedResult,pile))
+	}
+}
+
+func TestLinearMultipartyComputationUint64_GenerateModulus(t *testing.T) {
+	mpc, err := NewLinearMultipartyComputationUint64(0, 3, 1)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationUint64: %s", err))}
+	// the sum 2^64 - 79 is above 2^63, but still below the largest prime 2^64 - 59
+	err = mpc.InitializeWithMaxValue([]interface{}{uint64(1), uint64(1), uint64(1)}, uint64(6148914691236517179))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	if (mpc.GetModulus().(uint64) != 18446744073709551557) {t.Error("Generated modulus is False.")}
+
+	mpc, _ = NewLinearMultipartyComputationUint64(0, 3, 1)
+	err = mpc.InitializeWithMaxValue([]interface{}{uint64(1), uint64(1), uint64(1)}, uint64(1) << 63)
+	if err == nil {t.Error("Sum overflowing 64 bits should not be accepted.")}
+	mpc, _ = NewLinearMultipartyComputationUint64(0, 3, 1)
+	err = mpc.InitializeWithMaxValue([]interface{}{uint64(3), uint64(1), uint64(1)}, uint64(1) << 63)
+	if err == nil {t.Error("Product overflowing 64 bits should not be accepted.")}
+}
@@ -0,0 +1,252 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"crypto/rand"
+	"net"
+	"sync"
+)
+
+func TestLinearMultipartyComputationVectorInt(t *testing.T) {
+	participantCount := 5
+	threshold := 2
+	length := 6
+	mpc := make([]*LinearMultipartyComputationInt, participantCount)
+	coefficients := make([]interface{}, participantCount)
+	expected := make([]int, length)
+	var err error
+	for i := 0; i < participantCount; i++{
+		mpc[i], err = NewLinearMultipartyComputationInt(i, participantCount, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationInt: %s", err))}
+		coefficients[i] = i + 1
+		for d := 0; d < length; d++{
+			expected[d] += (i + 1) * (10 * i + d)
+		}
+	}
+	err = mpc[0].InitializeWithMaxValue(coefficients, 100)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	modulus := mpc[0].GetModulus().(int)
+	for i := 1; i < participantCount; i++{
+		err = mpc[i].InitializeWithModulus(coefficients, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	}
+	auxi, _ := mpc[0].GenerateInputAuxiliary()
+
+	for i := 0; i < participantCount; i++{
+		secrets := make([]interface{}, length)
+		for d := 0; d < length; d++{
+			secrets[d] = 10 * i + d
+		}
+		inputs, err := mpc[i].GenerateInputs(secrets, auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount; j++{
+			if (len(inputs[j].([]interface{})) != length) {t.Fatal("Input should be a vector as long as the secrets.")}
+			err = mpc[j].AddReceivedInput(i, inputs[j])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+	for i := 0; i < participantCount; i++{
+		output, err := mpc[i].GenerateOutput()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+		err = mpc[0].AddReceivedOutput(i, output)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens after adding received output: %s", err))}
+	}
+	result, err := mpc[0].Compute()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+	for d := 0; d < length; d++{
+		if (result.([]interface{})[d].(int) != expected[d]){
+			t.Error(fmt.Sprintf("Calculate Result %d is False, Result:%d ,Expected: %d", d, result.([]interface{})[d], expected[d]))
+		}
+	}
+
+	// the same participants can go back to single secrets after Reset
+	for i := 0; i < participantCount; i++{
+		mpc[i].Reset()
+	}
+	for i := 0; i < participantCount; i++{
+		inputs, _ := mpc[i].GenerateInputs(i, auxi)
+		for j := 0; j < participantCount; j++{
+			mpc[j].AddReceivedInput(i, inputs[j])
+		}
+	}
+	for i := 0; i < participantCount; i++{
+		output, _ := mpc[i].GenerateOutput()
+		mpc[0].AddReceivedOutput(i, output)
+	}
+	single, err := mpc[0].Compute()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+	if (single.(int) != 40) {t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: 40", single))}
+}
+
+func TestLinearMultipartyComputationVectorRobust(t *testing.T) {
+	participantCount := 7
+	threshold := 2
+	mpc := make([]*LinearMultipartyComputationUint64, participantCount)
+	var err error
+	for i := 0; i < participantCount; i++{
+		mpc[i], err = NewLinearMultipartyComputationUint64(i, participantCount, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationUint64: %s", err))}
+		err = mpc[i].InitializeSimpleSumWithModulus(uint64(4294967291))
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	}
+	auxi, _ := mpc[0].GenerateInputAuxiliary()
+	for i := 0; i < participantCount; i++{
+		inputs, err := mpc[i].GenerateInputs([]interface{}{uint64(i), uint64(100 * i), uint64(7)}, auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount; j++{
+			err = mpc[j].AddReceivedInput(i, inputs[j])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// participant 2 corrupts its first element, participant 6 its last one
+	for i := 0; i < participantCount; i++{
+		output, err := mpc[i].GenerateOutput()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+		vector := output.([]interface{})
+		if (i == 2) {vector[0] = vector[0].(uint64) + 1}
+		if (i == 6) {vector[2] = vector[2].(uint64) + 1}
+		err = mpc[0].AddReceivedOutput(i, vector)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens after adding received output: %s", err))}
+	}
+	result, corrupted, err := mpc[0].ComputeRobust()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+	if (fmt.Sprint(result) != "[21 2100 49]"){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%v ,Expected: [21 2100 49]", result))
+	}
+	if (fmt.Sprint(corrupted) != "[2 6]"){
+		t.Error(fmt.Sprintf("Wrong outputs are not located, Result:%v ,Expected: [2 6]", corrupted))
+	}
+}
+
+func TestLinearMultipartyComputationVectorInvalid(t *testing.T) {
+	mpc, _ := NewLinearMultipartyComputationBigInt(0, 3, 1)
+	mpc.InitializeSimpleSumWithModulus(big.NewInt(101))
+	auxi, _ := mpc.GenerateInputAuxiliary()
+	_, err := mpc.GenerateInputs([]interface{}{}, auxi)
+	if err == nil {t.Error("Empty secret vector should not be accepted.")}
+	_, err = mpc.GenerateInputs([]interface{}{big.NewInt(1), 2}, auxi)
+	if err == nil {t.Error("Secret vector with an int should not be accepted.")}
+	err = mpc.AddReceivedInput(1, []interface{}{})
+	if err == nil {t.Error("Empty input vector should not be accepted.")}
+
+	// inputs of different lengths
+	_, err = mpc.GenerateInputs([]interface{}{big.NewInt(1), big.NewInt(2)}, auxi)
+	if err != nil {t.Fatal(err)}
+	mpc.AddReceivedInput(1, []interface{}{big.NewInt(1), big.NewInt(2)})
+	mpc.AddReceivedInput(2, big.NewInt(1))
+	_, err = mpc.GenerateOutput()
+	if err == nil {t.Error("Single input should not be mixed with vector inputs.")}
+	mpc.AddReceivedInput(2, []interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	_, err = mpc.GenerateOutput()
+	if err == nil {t.Error("Vector inputs of different lengths should not be accepted.")}
+
+	// outputs of a wrong length
+	mpc.AddReceivedInput(2, []interface{}{big.NewInt(1), big.NewInt(2)})
+	_, err = mpc.GenerateOutput()
+	if err != nil {t.Fatal(err)}
+	mpc.AddReceivedOutput(1, []interface{}{big.NewInt(1)})
+	_, err = mpc.Compute()
+	if err == nil {t.Error("Output vector of a wrong length should not be accepted.")}
+}
+
+/**
+ * A transport counting the messages sent through it.
+ */
+type countingTransport struct {
+	Transport
+	mutex sync.Mutex
+	sent int
+}
+
+func (transport *countingTransport) Send(message *Message) error{
+	transport.mutex.Lock()
+	transport.sent++
+	transport.mutex.Unlock()
+	return transport.Transport.Send(message)
+}
+
+func TestLinearMultipartyComputationDriverVector(t *testing.T) {
+	participantCount := 5
+	threshold := 2
+	length := 50
+	listeners := make([]net.Listener, participantCount)
+	addresses := make([]string, participantCount)
+	for i := 0; i < participantCount; i++{
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when listening on loopback: %s", err))}
+		listeners[i] = listener
+		addresses[i] = listener.Addr().String()
+	}
+	transports := make([]*countingTransport, participantCount)
+	for i := 0; i < participantCount; i++{
+		transport, err := NewTCPTransportWithListener(i, addresses, listeners[i])
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing TCPTransport: %s", err))}
+		transports[i] = &countingTransport{Transport: transport}
+		defer transport.Close()
+	}
+
+	max := big.NewInt(1000000000)
+	secrets := make([][]interface{}, participantCount)
+	coefficients := make([]interface{}, participantCount)
+	for i := 0; i < participantCount; i++{
+		coefficients[i], _ = rand.Int(rand.Reader, big.NewInt(1000))
+		secrets[i] = make([]interface{}, length)
+		for d := 0; d < length; d++{
+			secrets[i][d], _ = rand.Int(rand.Reader, max)
+		}
+	}
+	setup, _ := NewLinearMultipartyComputationBigInt(0, participantCount, threshold)
+	err := setup.InitializeWithMaxValue(coefficients, max)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	modulus := setup.GetModulus()
+	auxi, _ := setup.GenerateInputAuxiliary()
+
+	results := make(chan interface{}, participantCount)
+	errs := make(chan error, participantCount)
+	for i := 0; i < participantCount; i++{
+		go func(i int) {
+			lmpc, err := NewLinearMultipartyComputationBigInt(i, participantCount, threshold)
+			if err != nil {errs <- err; return}
+			err = lmpc.InitializeWithModulus(coefficients, modulus)
+			if err != nil {errs <- err; return}
+			driver, err := NewLinearMultipartyComputationDriver(lmpc, transports[i])
+			if err != nil {errs <- err; return}
+			result, err := driver.Run(secrets[i], auxi)
+			if err != nil {errs <- err; return}
+			results <- result
+		}(i)
+	}
+
+	// calculate the true result(never do this in a real mpc procedure)
+	expected := make([]*big.Int, length)
+	for d := 0; d < length; d++{
+		expected[d] = big.NewInt(0)
+		for i := 0; i < participantCount; i++{
+			tmp := big.NewInt(0)
+			tmp.Mul(coefficients[i].(*big.Int), secrets[i][d].(*big.Int))
+			expected[d].Add(expected[d], tmp)
+		}
+	}
+	for i := 0; i < participantCount; i++{
+		select {
+		case err := <-errs:
+			t.Fatal(fmt.Sprintf("Error happens when running the driver: %s", err))
+		case result := <-results:
+			for d := 0; d < length; d++{
+				if (result.([]interface{})[d].(*big.Int).Cmp(expected[d]) != 0){
+					t.Error(fmt.Sprintf("Calculate Result %d is False, Result:%s ,Expected: %s", d, result.([]interface{})[d], expected[d]))
+				}
+			}
+		}
+	}
+
+	// one input and one output message for every peer, whatever the length is
+	for i := 0; i < participantCount; i++{
+		if (transports[i].sent != 2 * (participantCount - 1)){
+			t.Error(fmt.Sprintf("Participant %d sent %d messages, Expected: %d", i, transports[i].sent, 2 * (participantCount - 1)))
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package mpc
+
+import (
+	"encoding/json"
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/wire"
+)
+
+/**
+ * The JSON form of a message, for debugging.
+ */
+type messageJSON struct {
+	Type string `json:"type"`
+	From int `json:"from"`
+	To int `json:"to"`
+	Value *wire.JSONElement `json:"value"`
+}
+
+/**
+ * Names of the message types in the JSON form.
+ */
+var messageTypeNames = map[MessageType]string{
+	MessageInput: "input",
+	MessageOutput: "output",
+}
+
+/**
+ * Encode the message in the binary wire format.
+ * <p>
+ * The body contains the type (1 byte), the sender, the receiver and the value as an element.
+ *
+ * @return The encoded frame.
+ * @return error If the type, the IDs or the value cannot be encoded.
+ */
+func (message *Message) MarshalBinary() ([]byte, error){
+	if (message.messageType < 0 || message.messageType > 0xFF){
+		return nil, errors.New("Invalid type of message.")
+	}
+	body := wire.NewWriter()
+	body.WriteUint8(byte(message.messageType))
+	err := body.WriteIndex(message.from)
+	if (err != nil) {return nil, err}
+	err = body.WriteIndex(message.to)
+	if (err != nil) {return nil, err}
+	err = body.WriteElement(message.value)
+	if (err != nil) {return nil, err}
+	writer := wire.NewWriter()
+	writer.WriteFrame(wire.KindMessage, body.Bytes())
+	return writer.Bytes(), nil
+}
+
+/**
+ * Decode the message from the binary wire format.
+ *
+ * @param data The encoded frame.
+ * @return error If the data is invalid.
+ */
+func (message *Message) UnmarshalBinary(data []byte) error{
+	reader := wire.NewReader(data)
+	body, err := reader.ReadFrame(wire.KindMessage)
+	if (err != nil) {return err}
+	if (reader.Remaining() != 0) {return errors.New("Unexpected data after frame.")}
+	bodyReader := wire.NewReader(body)
+	messageType, err := bodyReader.ReadUint8()
+	if (err != nil) {return err}
+	from, err := bodyReader.ReadIndex()
+	if (err != nil) {return err}
+	to, err := bodyReader.ReadIndex()
+	if (err != nil) {return err}
+	value, err := bodyReader.ReadElement()
+	if (err != nil) {return err}
+	if (bodyReader.Remaining() != 0) {return errors.New("Unexpected data after message.")}
+	message.messageType = MessageType(messageType)
+	message.from = from
+	message.to = to
+	message.value = value
+	return nil
+}
+
+/**
+ * Encode the message in the JSON form.
+ *
+ * @return The JSON form.
+ * @return error If the type or the value cannot be encoded.
+ */
+func (message *Message) MarshalJSON() ([]byte, error){
+	name, ok := messageTypeNames[message.messageType]
+	if (!ok) {return nil, errors.New("Invalid type of message.")}
+	value, err := wire.ToJSONElement(message.value)
+	if (err != nil) {return nil, err}
+	return json.Marshal(&messageJSON{name, message.from, message.to, value})
+}
+
+/**
+ * Decode the message from the JSON form.
+ *
+ * @param data The JSON form.
+ * @return error If the data is invalid.
+ */
+func (message *Message) UnmarshalJSON(data []byte) error{
+	var j messageJSON
+	err := json.Unmarshal(data, &j)
+	if (err != nil) {return err}
+	messageType := MessageType(-1)
+	for t, name := range messageTypeNames {
+		if (name == j.Type) {messageType = t}
+	}
+	if (messageType < 0) {return errors.New("Invalid type of message.")}
+	if (j.From < 0 || j.To < 0) {return errors.New("Invalid participant ID.")}
+	value, err := wire.FromJSONElement(j.Value)
+	if (err != nil) {return err}
+	message.messageType = messageType
+	message.from = j.From
+	message.to = j.To
+	message.value = value
+	return nil
+}
+
+/**
+ * The public parameters of a linear mpc, which all participants should agree on before the input stage.
+ * <p>
+ * They include the number of participants, the threshold, the modulus, the coefficients of the linear function,
+ * and the auxiliary data (evaluation points) for generating Shamir's secret shares.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type PublicParameters struct {
+	/**
+	 * Number of participants.
+	 */
+	participantCount int
+
+	/**
+	 * Threshold <i>t</i>.
+	 */
+	threshold int
+
+	/**
+	 * The modulus <i>p</i>.
+	 */
+	modulus interface{}
+
+	/**
+	 * Coefficients of the linear function.
+	 */
+	coefficients []interface{}
+
+	/**
+	 * The auxiliary data, nil if not generated yet.
+	 */
+	auxiliary []interface{}
+}
+
+/**
+ * The JSON form of the public parameters, for debugging.
+ */
+type publicParametersJSON struct {
+	ParticipantCount int `json:"participantCount"`
+	Threshold int `json:"threshold"`
+	Modulus *wire.JSONElement `json:"modulus"`
+	Coefficients []*wire.JSONElement `json:"coefficients"`
+	Auxiliary []*wire.JSONElement `json:"auxiliary,omitempty"`
+}
+
+/**
+ * Construct the public parameters.
+ *
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>.
+ * @param modulus The modulus <i>p</i>.
+ * @param coefficients Coefficients of the linear function.
+ * @param auxiliary The auxiliary data, can be nil.
+ * @return newPublicParameters the new constructed PublicParameters
+ */
+func NewPublicParameters(participantCount int, threshold int, modulus interface{}, coefficients []interface{}, auxiliary []interface{}) *PublicParameters{
+	newPublicParameters := new(PublicParameters)
+	newPublicParameters.participantCount = participantCount
+	newPublicParameters.threshold = threshold
+	newPublicParameters.modulus = modulus
+	newPublicParameters.coefficients = coefficients
+	newPublicParameters.auxiliary = auxiliary
+	return newPublicParameters
+}
+
+/**
+ * Get number of participants.
+ *
+ * @return Number of participants.
+ */
+func (pp *PublicParameters) GetParticipantCount() int{
+	return pp.participantCount
+}
+
+/**
+ * Get the threshold.
+ *
+ * @return Threshold <i>t</i>.
+ */
+func (pp *PublicParameters) GetThreshold() int{
+	return pp.threshold
+}
+
+/**
+ * Get the modulus.
+ *
+ * @return The modulus <i>p</i>.
+ */
+func (pp *PublicParameters) GetModulus() interface{}{
+	return pp.modulus
+}
+
+/**
+ * Get coefficients of the linear function.
+ *
+ * @return The coefficients.
+ */
+func (pp *PublicParameters) GetCoefficients() []interface{}{
+	return pp.coefficients
+}
+
+/**
+ * Get the auxiliary data.
+ *
+ * @return The auxiliary data, nil if not generated yet.
+ */
+func (pp *PublicParameters) GetAuxiliary() []interface{}{
+	return pp.auxiliary
+}
+
+/**
+ * Encode the public parameters in the binary wire format.
+ * <p>
+ * The body contains the number of participants, the threshold, the modulus, a flag (1 byte) telling whether
+ * the auxiliary data is present, the coefficients and the auxiliary data.
+ *
+ * @return The encoded frame.
+ * @return error If any field cannot be encoded.
+ */
+func (pp *PublicParameters) MarshalBinary() ([]byte, error){
+	body := wire.NewWriter()
+	err := body.WriteIndex(pp.participantCount)
+	if (err != nil) {return nil, err}
+	err = body.WriteIndex(pp.threshold)
+	if (err != nil) {return nil, err}
+	err = body.WriteElement(pp.modulus)
+	if (err != nil) {return nil, err}
+	err = body.WriteElements(pp.coefficients)
+	if (err != nil) {return nil, err}
+	if (pp.auxiliary == nil){
+		body.WriteUint8(0)
+	} else {
+		body.WriteUint8(1)
+		err = body.WriteElements(pp.auxiliary)
+		if (err != nil) {return nil, err}
+	}
+	writer := wire.NewWriter()
+	writer.WriteFrame(wire.KindPublicParameters, body.Bytes())
+	return writer.Bytes(), nil
+}
+
+/**
+ * Decode the public parameters from the binary wire format.
+ *
+ * @param data The encoded frame.
+ * @return error If the data is invalid.
+ */
+func (pp *PublicParameters) UnmarshalBinary(data []byte) error{
+	reader := wire.NewReader(data)
+	body, err := reader.ReadFrame(wire.KindPublicParameters)
+	if (err != nil) {return err}
+	if (reader.Remaining() != 0) {return errors.New("Unexpected data after frame.")}
+	bodyReader := wire.NewReader(body)
+	participantCount, err := bodyReader.ReadIndex()
+	if (err != nil) {return err}
+	threshold, err := bodyReader.ReadIndex()
+	if (err != nil) {return err}
+	modulus, err := bodyReader.ReadElement()
+	if (err != nil) {return err}
+	coefficients, err := bodyReader.ReadElements()
+	if (err != nil) {return err}
+	hasAuxiliary, err := bodyReader.ReadUint8()
+	if (err != nil) {return err}
+	var auxiliary []interface{}
+	switch hasAuxiliary {
+	case 0:
+	case 1:
+		auxiliary, err = bodyReader.ReadElements()
+		if (err != nil) {return err}
+	default:
+		return errors.New("Invalid flag of auxiliary data.")
+	}
+	if (bodyReader.Remaining() != 0) {return errors.New("Unexpected data after public parameters.")}
+	pp.participantCount = participantCount
+	pp.threshold = threshold
+	pp.modulus = modulus
+	pp.coefficients = coefficients
+	pp.auxiliary = auxiliary
+	return nil
+}
+
+/**
+ * Encode the public parameters in the JSON form.
+ *
+ * @return The JSON form.
+ * @return error If any field cannot be encoded.
+ */
+func (pp *PublicParameters) MarshalJSON() ([]byte, error){
+	modulus, err := wire.ToJSONElement(pp.modulus)
+	if (err != nil) {return nil, err}
+	coefficients, err := wire.ToJSONElements(pp.coefficients)
+	if (err != nil) {return nil, err}
+	auxiliary, err := wire.ToJSONElements(pp.auxiliary)
+	if (err != nil) {return nil, err}
+	return json.Marshal(&publicParametersJSON{pp.participantCount, pp.threshold, modulus, coefficients, auxiliary})
+}
+
+/**
+ * Decode the public parameters from the JSON form.
+ *
+ * @param data The JSON form.
+ * @return error If the data is invalid.
+ */
+func (pp *PublicParameters) UnmarshalJSON(data []byte) error{
+	var j publicParametersJSON
+	err := json.Unmarshal(data, &j)
+	if (err != nil) {return err}
+	if (j.ParticipantCount < 0 || j.Threshold < 0) {return errors.New("Invalid number of participants or threshold.")}
+	modulus, err := wire.FromJSONElement(j.Modulus)
+	if (err != nil) {return err}
+	coefficients, err := wire.FromJSONElements(j.Coefficients)
+	if (err != nil) {return err}
+	if (coefficients == nil) {coefficients = make([]interface{}, 0)}
+	auxiliary, err := wire.FromJSONElements(j.Auxiliary)
+	if (err != nil) {return err}
+	pp.participantCount = j.ParticipantCount
+	pp.threshold = j.Threshold
+	pp.modulus = modulus
+	pp.coefficients = coefficients
+	pp.auxiliary = auxiliary
+	return nil
+}
@@ -0,0 +1,143 @@
+package mpc
+
+import (
+	"testing"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+func TestMessageEncodingInt(t *testing.T) {
+	participantCount := 6
+	threshold := 3
+	mpc := make([]LinearMultipartyComputationInterface, participantCount)
+	secrets := make([]interface{}, participantCount)
+	coefficients := make([]interface{}, participantCount)
+	expected := 0
+	for i := 0; i < participantCount; i++{
+		computation, err := NewLinearMultipartyComputationInt(i, participantCount, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationInt: %s", err))}
+		mpc[i] = computation
+		secrets[i] = 1000 * i + 7
+		coefficients[i] = i + 1
+		expected += (1000 * i + 7) * (i + 1)
+	}
+	testMessageEncoding(t, mpc, secrets, coefficients, 100000, expected)
+}
+
+func TestMessageEncodingBigInt(t *testing.T) {
+	participantCount := 6
+	threshold := 3
+	mpc := make([]LinearMultipartyComputationInterface, participantCount)
+	secrets := make([]interface{}, participantCount)
+	coefficients := make([]interface{}, participantCount)
+	max, _ := new(big.Int).SetString("100000000000000000000", 10)
+	expected := big.NewInt(0)
+	for i := 0; i < participantCount; i++{
+		computation, err := NewLinearMultipartyComputationBigInt(i, participantCount, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationBigInt: %s", err))}
+		mpc[i] = computation
+		secret := new(big.Int).Sub(max, big.NewInt(int64(i)))
+		secrets[i] = secret
+		coefficients[i] = big.NewInt(int64(i + 1))
+		expected.Add(expected, new(big.Int).Mul(secret, big.NewInt(int64(i + 1))))
+	}
+	testMessageEncoding(t, mpc, secrets, coefficients, max, expected)
+}
+
+/**
+ * Run a linear mpc where the public parameters and all messages are encoded and decoded before use,
+ * alternating between the binary form and the JSON form.
+ */
+func testMessageEncoding(t *testing.T, mpc []LinearMultipartyComputationInterface, secrets []interface{}, coefficients []interface{}, max interface{}, expected interface{}) {
+	participantCount := len(mpc)
+	err := mpc[0].InitializeWithMaxValue(coefficients, max)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	auxiliary, err := mpc[0].GenerateInputAuxiliary()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+	_, err = mpc[0].GenerateInputs(secrets[0], auxiliary)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+
+	// public parameters are sent by participant 0
+	params, err := mpc[0].GetPublicParameters()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when getting public parameters: %s", err))}
+	data, err := params.MarshalBinary()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding public parameters: %s", err))}
+	binaryParams := new(PublicParameters)
+	err = binaryParams.UnmarshalBinary(data)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding public parameters: %s", err))}
+	data, err = json.Marshal(params)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding public parameters as JSON: %s", err))}
+	jsonParams := new(PublicParameters)
+	err = json.Unmarshal(data, jsonParams)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding public parameters from JSON: %s", err))}
+	for _, decoded := range []*PublicParameters{binaryParams, jsonParams}{
+		if (decoded.GetParticipantCount() != params.GetParticipantCount() || decoded.GetThreshold() != params.GetThreshold() ||
+			fmt.Sprintf("%T %v %v %v", decoded.GetModulus(), decoded.GetModulus(), decoded.GetCoefficients(), decoded.GetAuxiliary()) !=
+			fmt.Sprintf("%T %v %v %v", params.GetModulus(), params.GetModulus(), params.GetCoefficients(), params.GetAuxiliary())){
+			t.Fatal("Public parameters mismatch.")
+		}
+	}
+
+	// other participants initialize from the decoded parameters
+	mpc[0].Reset()
+	for i := 0; i < participantCount; i++{
+		decoded := binaryParams
+		if (i % 2 == 1) {decoded = jsonParams}
+		err = mpc[i].InitializeWithModulus(decoded.GetCoefficients(), decoded.GetModulus())
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	}
+
+	for i := 0; i < participantCount; i++{
+		decoded := binaryParams
+		if (i % 2 == 1) {decoded = jsonParams}
+		inputs, err := mpc[i].GenerateInputs(secrets[i], decoded.GetAuxiliary())
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount; j++{
+			if (j == i) {continue}
+			message := transcodeMessage(t, NewMessage(MessageInput, i, j, inputs[j]), i + j)
+			err = mpc[j].AddReceivedInput(message.GetFrom(), message.GetValue())
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	for i := 0; i < participantCount; i++{
+		output, err := mpc[i].GenerateOutput()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+		if (i == 0) {continue}
+		message := transcodeMessage(t, NewMessage(MessageOutput, i, 0, output), i)
+		if (message.GetMessageType() != MessageOutput || message.GetTo() != 0){
+			t.Fatal("Message header mismatch.")
+		}
+		err = mpc[0].AddReceivedOutput(message.GetFrom(), message.GetValue())
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens after adding received output: %s", err))}
+	}
+
+	calculatedResult, err := mpc[0].Compute()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+	if (fmt.Sprint(calculatedResult) != fmt.Sprint(expected)){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%v ,Expected: %v",calculatedResult,expected))
+	}else{
+		t.Log(fmt.Sprintf("Calculate Result is True, Result:%v ,Expected %v",calculatedResult,expected))
+	}
+}
+
+/**
+ * Encode and decode a message, in the binary form if selector is even, otherwise in the JSON form.
+ */
+func transcodeMessage(t *testing.T, message *Message, selector int) *Message {
+	var data []byte
+	var err error
+	decoded := new(Message)
+	if (selector % 2 == 0){
+		data, err = message.MarshalBinary()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding message: %s", err))}
+		err = decoded.UnmarshalBinary(data)
+	} else {
+		data, err = json.Marshal(message)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding message as JSON: %s", err))}
+		err = json.Unmarshal(data, decoded)
+	}
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding message: %s", err))}
+	return decoded
+}
@@ -0,0 +1,342 @@
+package mpc
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+	"errors"
+)
+
+/**
+ * Abstract class for secure multi-party multiplication of two shared values.
+ * <p>
+ * The abstract class <code>MultiplicationMultipartyComputation</code> implements the multiplication gate of the BGW
+ * protocol in "Ben-Or M, Goldwasser S, Wigderson A. Completeness theorems for non-cryptographic
+ * fault-tolerant distributed computation. InProceedings of the twentieth annual ACM symposium
+ * on Theory of computing 1988 Jan 1 (pp. 1-10). ACM.", with the degree reduction step described in
+ * "Asharov G, Lindell Y. A Full Proof of the BGW Protocol for Perfectly Secure Multiparty Computation."
+ * <p>
+ * Each participant <i>p<sub>i</sub></i> holds shares <i>a</i>(<i>x<sub>i</sub></i>) and <i>b</i>(<i>x<sub>i</sub></i>)
+ * of two values <i>a</i>(0) and <i>b</i>(0). The product <i>d<sub>i</sub></i> = <i>a</i>(<i>x<sub>i</sub></i>)<i>b</i>(<i>x<sub>i</sub></i>)
+ * is a share of <i>a</i>(0)<i>b</i>(0) on a polynomial with doubled degree. To reduce the degree:
+ * <ol>
+ *     <li> Input stage: every participant shares <i>d<sub>i</sub></i> with a fresh random polynomial <i>h<sub>i</sub></i>,
+ *          and sends <i>h<sub>i</sub></i>(<i>x<sub>j</sub></i>) to participant <i>p<sub>j</sub></i>.
+ *     <li> Output stage: every participant computes <i>r</i><sub>1</sub><i>h</i><sub>1</sub>(<i>x<sub>j</sub></i>) + ... +
+ *          <i>r<sub>n</sub></i><i>h<sub>n</sub></i>(<i>x<sub>j</sub></i>), where (<i>r</i><sub>1</sub>, ..., <i>r<sub>n</sub></i>) is the
+ *          first row of the inverse Vandermonde matrix of <i>x</i><sub>1</sub>, ..., <i>x<sub>n</sub></i>. The result is a share
+ *          of <i>a</i>(0)<i>b</i>(0) on a polynomial with the original degree.
+ * </ol>
+ * The output can either be kept as a share for further computations, or be sent to others to reconstruct the product.
+ * <p>
+ * Note 1: The auxiliary data must be the same as that used to generate the shares of the two factors.
+ * <p>
+ * Note 2: Each participant has a unique ID, start from 0 to <i>n</i>-1.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+
+type MultiplicationMultipartyComputation struct {
+	/**
+	* ID of this participant.
+	*/
+	id int
+
+	/**
+	 * Number of participants.
+	 */
+	participantCount int
+
+	/**
+	 * Threshold <i>t</i>. Max number of semi-honest adversaries.
+	 */
+	threshold int
+
+	/**
+	 * Shamir's secret sharing scheme object.
+	 */
+	secretSharing secretshare.ShamirSecretSharingInterface
+
+	/**
+	 * The auxiliary data of Shamir's secret shares, shared by the factors and the product.
+	 */
+	auxiliary []interface{}
+
+	/**
+	 * The recombination vector for degree reduction.
+	 */
+	recombinationVector []interface{}
+
+	/**
+	 * The re-shared products received from other participants during the input stage.
+	 */
+	receivedInputs []interface{}
+
+	/**
+	 * The outputs received from other participants during the output stage.
+	 */
+	receivedOutputs map[int]interface{}
+
+	/**
+	* Abstract Interfaces of MultiplicationMultipartyComputation
+	*/
+	multiplicationMultipartyComputationCalculator MultiplicationMultipartyComputationInterface
+}
+
+type MultiplicationMultipartyComputationInterface interface {
+
+	Initialize(modulus interface{}, auxiliary []interface{}) error
+
+	GetModulus() interface{}
+
+	GetAuxiliary() []interface{}
+
+	GenerateInputs(left interface{}, right interface{}) ([]interface{},error)
+
+	AddReceivedInput(from int, input interface{}) error
+
+	HasAllInputReceived() bool
+
+	GenerateOutput() (interface{}, error)
+
+	AddReceivedOutput(from int, output interface{}) error
+
+	isReadyForCompute() bool
+
+	Compute() (interface{},error)
+
+	Reset()
+
+	/**
+	* Abstract method of getting a Shamir's secret sharing object with the number of participants and the modulus.
+	*
+	* @param participantCount The number of the participants.
+	* @param modulus The modulus <i>p</i>.
+	* @return The proper Shamir's secret sharing scheme object.
+	* @return error IllegalArgumentException If the number of participants or the modulus is invalid.
+	*/
+	getSecretSharing(participantCount int, modulus interface{}) (secretshare.ShamirSecretSharingInterface,error)
+
+	/**
+	* Abstract method of multiplying two shares locally.
+	*
+	* @param left Share of the left factor.
+	* @param right Share of the right factor.
+	* @return The product modulo <i>p</i>.
+	*/
+	multiplyShares(left interface{}, right interface{}) interface{}
+
+	/**
+	* Abstract method of generating output during the output stage.
+	*
+	* @return The output value.
+	*/
+	generateOutputImpl() interface{}
+
+	/**
+	* Abstract method of checking if the type of input element is valid.
+	*
+	* @param e Element to be checked.
+	* @return True if the type of input element is valid, otherwise return false.
+	*/
+	checkElement(e interface{}) bool
+}
+
+/**
+ * Set the modulus and the auxiliary data, and calculate the recombination vector.
+ *
+ * @param modulus Modulus of the Shamir's scheme.
+ * @param auxiliary The auxiliary data used to generate the shares of the factors.
+ * @return error IllegalArgumentException If the modulus or the auxiliary data is invalid.
+ */
+func (mmpc *MultiplicationMultipartyComputation) Initialize(modulus interface{}, auxiliary []interface{}) error{
+	if (!mmpc.multiplicationMultipartyComputationCalculator.checkElement(modulus)){
+		return errors.New("Invalid type of modulus.")
+	}
+	if (auxiliary == nil || len(auxiliary) != mmpc.participantCount){
+		return errors.New("Number of auxiliaries should be equal to number of participants.")
+	}
+	for i:=0; i < len(auxiliary);i++{
+		if (!mmpc.multiplicationMultipartyComputationCalculator.checkElement(auxiliary[i])){
+			return errors.New("Invalid type of an auxiliary.")
+		}
+	}
+
+	secretSharing, err := mmpc.multiplicationMultipartyComputationCalculator.getSecretSharing(mmpc.participantCount, modulus)
+	if (err != nil) {return err}
+
+	access,err := secretshare.NewThresholdAccessStructure(mmpc.participantCount,mmpc.threshold)
+	if (err != nil) {return err}
+	err = secretSharing.SetAccessStructure(access)
+	if (err != nil) {return err}
+
+	recombinationVector, err := secretSharing.GetRecombinationVector(auxiliary)
+	if (err != nil) {return err}
+
+	mmpc.secretSharing = secretSharing
+	mmpc.auxiliary = auxiliary
+	mmpc.recombinationVector = recombinationVector
+	return nil
+}
+
+/**
+ * Return the modulus in the Shamir's secret sharing scheme.
+ *
+ * @return The modulus <i>p</i>.
+ */
+func (mmpc *MultiplicationMultipartyComputation) GetModulus() interface{}{
+	return mmpc.secretSharing.GetModulus()
+}
+
+/**
+ * Return the auxiliary data of the Shamir's secret shares.
+ *
+ * @return The auxiliary data.
+ */
+func (mmpc *MultiplicationMultipartyComputation) GetAuxiliary() []interface{}{
+	return mmpc.auxiliary
+}
+
+/**
+ * Multiply the shares of the two factors locally, and re-share the product for all participants during the input stage.
+ *
+ * @param left Share of the left factor held by this participant.
+ * @param right Share of the right factor held by this participant.
+ * @return The inputs for all participants.
+ * @return error IllegalArgumentException If the shares are invalid,
+ *         or IllegalStateException If the secret sharing scheme in not set properly.
+ */
+func (mmpc *MultiplicationMultipartyComputation) GenerateInputs(left interface{}, right interface{}) ([]interface{},error){
+	if (mmpc.secretSharing == nil){
+		return nil, errors.New("Secret sharing scheme not set.")
+	}
+	if (!mmpc.multiplicationMultipartyComputationCalculator.checkElement(left) ||
+		!mmpc.multiplicationMultipartyComputationCalculator.checkElement(right)){
+		return nil, errors.New("Invalid type of a share.")
+	}
+
+	product := mmpc.multiplicationMultipartyComputationCalculator.multiplyShares(left, right)
+	shares, err := mmpc.secretSharing.GenerateShares(product, mmpc.auxiliary)
+	if (err != nil) {return nil, err}
+
+	inputs := make([]interface{}, mmpc.participantCount)
+	for i := 0; i< mmpc.participantCount;i++{
+		inputs[i] = shares[i].GetValue().(*secretshare.ShamirSecretShareValue).GetQr()
+	}
+	mmpc.receivedInputs[mmpc.id] = inputs[mmpc.id] //itself
+	return inputs, nil
+}
+
+/**
+ * Add an input when received from other participant during the input stage.
+ *
+ * @param from The id of the participant who sent the input.
+ * @param input The input value received.
+ * @return error IllegalArgumentException If the id of the participant or the input value is invalid.
+ */
+func (mmpc *MultiplicationMultipartyComputation) AddReceivedInput(from int, input interface{}) error{
+	if ((from < 0) || (from >= mmpc.participantCount)){
+		return errors.New("Invalid ID of the received input.")
+	}
+	if (!mmpc.multiplicationMultipartyComputationCalculator.checkElement(input)){
+		return errors.New("Invalid type of input.")
+	}
+	mmpc.receivedInputs[from] = input
+	return nil
+}
+
+/**
+ * Test if all <i>n</i>-1 inputs are received from other participants.
+ *
+ * @return True if all inputs are received, otherwise return false.
+ */
+func (mmpc *MultiplicationMultipartyComputation) HasAllInputReceived() bool{
+	for i := 0; i< mmpc.participantCount; i++{
+		if (mmpc.receivedInputs[i] == nil){
+			return false
+		}
+	}
+	return true
+}
+
+/**
+ * Generate the output during the output stage, i.e. the degree-reduced share of the product.
+ * <p>
+ * Call implemented <code>generateOutputImpl</code> to do the actually generating job.
+ *
+ * @return The output value.
+ * @return error IllegalStateException If not all inputs are received or the secret sharing scheme in not set properly.
+ */
+func (mmpc *MultiplicationMultipartyComputation) GenerateOutput() (interface{}, error){
+	if (mmpc.secretSharing == nil){
+		return nil, errors.New("Secret sharing scheme not set.")
+	}
+	if (!mmpc.HasAllInputReceived()){
+		return nil, errors.New("Output cannot be generated before all inputs are received.")
+	}
+	output := mmpc.multiplicationMultipartyComputationCalculator.generateOutputImpl()
+	mmpc.receivedOutputs[mmpc.id] = output
+	return output, nil
+}
+
+/**
+ * Add an output when received from other participant during the output stage.
+ *
+ * @param from The id of the participant who sent the output.
+ * @param output The output value received.
+ * @return error IllegalArgumentException If the id of the participant or the output value is invalid.
+ */
+func (mmpc *MultiplicationMultipartyComputation) AddReceivedOutput(from int, output interface{}) error{
+	if ((from < 0) || (from >= mmpc.participantCount)){
+		return errors.New("Invalid ID of the received output.")
+	}
+	if (!mmpc.multiplicationMultipartyComputationCalculator.checkElement(output)){
+		return errors.New("Invalid type of output.")
+	}
+	mmpc.receivedOutputs[from] = output
+	return nil
+}
+
+/**
+ * Test if enough outputs are received to compute the product.
+ *
+ * @return True if enough outputs are received, otherwise return false.
+ */
+func (mmpc *MultiplicationMultipartyComputation) isReadyForCompute() bool{
+	return len(mmpc.receivedOutputs) > mmpc.threshold
+}
+
+/**
+ * Compute the product.
+ *
+ * @return The product of the two shared values.
+ * @return error IllegalStateException If not enough outputs are received or the secret sharing scheme in not set properly.
+ */
+func (mmpc *MultiplicationMultipartyComputation) Compute() (interface{},error){
+	if (mmpc.secretSharing == nil){
+		return nil,errors.New("Secret sharing scheme not inialized.")
+	}
+	if (!mmpc.isReadyForCompute()){
+		return nil, errors.New("Not enough outputs received.")
+	}
+	shares := make([]*secretshare.SecretShare, mmpc.threshold+1)
+	i := 0
+	for k,v := range(mmpc.receivedOutputs){
+		shareValue := secretshare.NewShamirSecretShareValue(mmpc.auxiliary[k], v)
+		secretShare := secretshare.NewSecretShare(k,shareValue)
+		shares[i] = secretShare
+		i++
+		if (i > mmpc.threshold) {break}
+	}
+	return mmpc.secretSharing.CalculateSecret(shares)
+}
+
+/**
+ * Reset to time before input stage. And ready for the next multiplication.
+ */
+func (mmpc *MultiplicationMultipartyComputation) Reset(){
+	if (mmpc.receivedInputs == nil) {return}
+	for i := 0; i< len(mmpc.receivedInputs);i++{
+		mmpc.receivedInputs[i] = nil
+	}
+	mmpc.receivedOutputs = map[int]interface{} {}
+}
@@ -0,0 +1,104 @@
+package mpc
+
+import (
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+	"math/big"
+)
+
+/**
+ * This class implements an BigInt secure multi-party multiplication.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type MultiplicationMultipartyComputationBigInt struct {
+	MultiplicationMultipartyComputation
+}
+
+/**
+ * Construct multiplication MPC scheme with number of participants, threshold and the ID of the participant.
+ * <p>
+ * The threshold is the max number of semi-honest adversaries, should be less than <i>n</i>/2.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>.
+ * @return feedback the constructed MultiplicationMultipartyComputationBigInt
+ * @return error IllegalArgumentException If any of ID, participantCount or threshold is invalid.
+ */
+func NewMultiplicationMultipartyComputationBigInt(id int, participantCount int, threshold int)(*MultiplicationMultipartyComputationBigInt,error){
+	if (participantCount < 3){
+		return nil, errors.New("Invalid participant count. Should be larger than 2.")
+	}
+	if (id < 0 || (id >= participantCount)){
+		return nil, errors.New("Invalid id, should be between 0 and participantCount-1")
+	}
+	if (threshold > (participantCount / 2)){
+		return nil, errors.New("Threshold should never greater than 1/2 of the participant count.")
+	}
+	feedback := new(MultiplicationMultipartyComputationBigInt)
+	feedback.id = id
+	feedback.participantCount = participantCount
+	feedback.threshold = threshold
+	feedback.multiplicationMultipartyComputationCalculator = feedback
+	feedback.receivedInputs = make([]interface{},participantCount)
+	feedback.receivedOutputs = map[int]interface{} {}
+	return feedback, nil
+}
+
+/**
+ * Get a BigInteger Shamir's secret sharing object with the number of participants and the modulus.
+ *
+ * @param participantCount The number of the participants.
+ * @param modulus The modulus <i>p</i>.
+ * @return The proper Shamir's secret sharing scheme object.
+ * @return error IllegalArgumentException If the number of participants or the modulus is invalid.
+ */
+func (mmpcb *MultiplicationMultipartyComputationBigInt)getSecretSharing(participantCount int, modulus interface{})(secretshare.
+	ShamirSecretSharingInterface,error){
+	modulusValue ,ok := modulus.(*big.Int)
+	if (!ok) {return nil, errors.New("Invalid type of modulus.")}
+	return secretshare.NewShamirSecretSharingBigInt(participantCount,modulusValue)
+}
+
+/**
+ * Multiply two BigInt shares locally.
+ *
+ * @param left Share of the left factor.
+ * @param right Share of the right factor.
+ * @return The product modulo <i>p</i>.
+ */
+func (mmpcb *MultiplicationMultipartyComputationBigInt) multiplyShares(left interface{}, right interface{}) interface{}{
+	product := big.NewInt(0)
+	product.Mul(left.(*big.Int), right.(*big.Int)).Mod(product, mmpcb.GetModulus().(*big.Int))
+	return product
+}
+
+/**
+ * Generate output during the output stage.
+ *
+ * @return The output value.
+ */
+func (mmpcb *MultiplicationMultipartyComputationBigInt) generateOutputImpl() interface{}{
+	modulus := mmpcb.secretSharing.GetModulus()
+	pile := big.NewInt(0)
+	for i:=0; i < mmpcb.participantCount; i++{
+		tmp := big.NewInt(1)
+		tmp.Mul(mmpcb.receivedInputs[i].(*big.Int), mmpcb.recombinationVector[i].(*big.Int))
+		tmp.Mod(tmp,modulus.(*big.Int))
+		pile.Add(pile,tmp).Mod(pile,modulus.(*big.Int))
+	}
+	return pile
+}
+
+/**
+ * Check if the type of input element is valid.
+ *
+ * @param e Element to be checked.
+ * @return True if the type of input element is BigInt, otherwise return false.
+ */
+func (mmpcb *MultiplicationMultipartyComputationBigInt) checkElement(e interface{}) bool{
+	_, ok := e.(*big.Int)
+	return ok
+}
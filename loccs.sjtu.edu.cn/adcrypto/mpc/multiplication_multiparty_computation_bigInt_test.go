@@ -0,0 +1,106 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestNewMultiplicationMultipartyComputationBigIntProcedure(t *testing.T) {
+	participantCount := 11
+	threshold := 5
+	lmpc := make([]*LinearMultipartyComputationBigInt, participantCount) // input stage for every party
+	mmpc := make([]*MultiplicationMultipartyComputationBigInt, participantCount) // multiplication for every party
+	max := big.NewInt(0)
+	max.SetString("100000000000000000",10)  // the max probable number for secret
+	secret := make([]*big.Int,participantCount)  // secrets
+	left, right := 2, 7 // the product of secret[left] and secret[right] is calculated
+	var err error
+	computeFrom := []int{1,3,5,8,10} // computing parties except zero itself
+
+	// initialize secrets
+	for i := 0; i < participantCount; i++{
+		secret[i],err = rand.Int(rand.Reader,max)
+	}
+
+	// construct and initialize linear mpcs for sharing the secrets
+	modulus := big.NewInt(1)
+	maxProduct := big.NewInt(0)
+	maxProduct.Mul(max,max)
+	for i := 0; i < participantCount; i++{
+		lmpc[i],err = NewLinearMultipartyComputationBigInt(i,participantCount,threshold)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationBigInt: %s", err))}
+		if i == 0 {
+			err = lmpc[i].InitializeSimpleSumWithMax(maxProduct)
+			if err != nil {t.Error(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+			modulus = lmpc[i].GetModulus().(*big.Int)
+		}else{
+			err = lmpc[i].InitializeSimpleSumWithModulus(modulus)
+			if err != nil {t.Error(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+		}
+	}
+
+	// generate auxiliary
+	auxi,err := lmpc[0].GenerateInputAuxiliary()
+	if err != nil {t.Error(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+
+	// input stage of the secrets
+	for i := 0 ; i <participantCount; i++{
+		inputs, err := lmpc[i].GenerateInputs(secret[i],auxi)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount ; j++{
+			err = lmpc[j].AddReceivedInput(i,inputs[j])
+			if err != nil {t.Error(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// construct and initialize multiplication mpcs
+	for i := 0; i < participantCount; i++{
+		mmpc[i],err = NewMultiplicationMultipartyComputationBigInt(i,participantCount,threshold)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when constructing MultiplicationMultipartyComputationBigInt: %s", err))}
+		err = mmpc[i].Initialize(modulus,auxi)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	}
+
+	// every party multiplies its shares locally and re-shares the product
+	for i := 0 ; i <participantCount; i++{
+		leftShare, err := lmpc[i].GetReceivedInput(left)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when getting received inputs: %s", err))}
+		rightShare, err := lmpc[i].GetReceivedInput(right)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when getting received inputs: %s", err))}
+		inputs, err := mmpc[i].GenerateInputs(leftShare,rightShare)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount ; j++{
+			err = mmpc[j].AddReceivedInput(i,inputs[j])
+			if err != nil {t.Error(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// every party generate the degree-reduced shares of the product
+	outputs := make([]interface{},participantCount)
+	for i := 0; i<participantCount; i++{
+		outputs[i], err = mmpc[i].GenerateOutput()
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+	}
+
+	// mmpc[0] add other outputs(number: threshold)
+	for _,from := range computeFrom{
+		err = mmpc[0].AddReceivedOutput(from,outputs[from])
+		if err != nil {t.Error(fmt.Sprintf("Error happens after adding received output: %s", err))}
+	}
+
+	// mmpc[0] calculate the product
+	calculatedResult,err := mmpc[0].Compute()
+	if err != nil {t.Error(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+
+	// calculate the true result(never do this in a real mpc procedure)
+	pile := big.NewInt(0)
+	pile.Mul(secret[left],secret[right])
+
+	if calculatedResult.(*big.Int).Cmp(pile) != 0 {
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s",calculatedResult,pile))
+	}else{
+		t.Log(fmt.Sprintf("Calculate Result is True, Result:%s ,Expected %s",calculatedResult,pile))
+	}
+}
@@ -0,0 +1,101 @@
+package mpc
+
+import (
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+)
+
+/**
+ * This class implements an Int secure multi-party multiplication.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type MultiplicationMultipartyComputationInt struct {
+	MultiplicationMultipartyComputation
+}
+
+/**
+ * Construct multiplication MPC scheme with number of participants, threshold and the ID of the participant.
+ * <p>
+ * The threshold is the max number of semi-honest adversaries, should be less than <i>n</i>/2.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>.
+ * @return feedback the constructed MultiplicationMultipartyComputationInt
+ * @return error IllegalArgumentException If any of ID, participantCount or threshold is invalid.
+ */
+func NewMultiplicationMultipartyComputationInt(id int, participantCount int, threshold int)(*MultiplicationMultipartyComputationInt,error){
+	if (participantCount < 3){
+		return nil, errors.New("Invalid participant count. Should be larger than 2.")
+	}
+	if (id < 0 || (id >= participantCount)){
+		return nil, errors.New("Invalid id, should be between 0 and participantCount-1")
+	}
+	if (threshold > (participantCount / 2)){
+		return nil, errors.New("Threshold should never greater than 1/2 of the participant count.")
+	}
+	feedback := new(MultiplicationMultipartyComputationInt)
+	feedback.id = id
+	feedback.participantCount = participantCount
+	feedback.threshold = threshold
+	feedback.multiplicationMultipartyComputationCalculator = feedback
+	feedback.receivedInputs = make([]interface{},participantCount)
+	feedback.receivedOutputs = map[int]interface{} {}
+	return feedback, nil
+}
+
+/**
+ * Get a Int Shamir's secret sharing object with the number of participants and the modulus.
+ *
+ * @param participantCount The number of the participants.
+ * @param modulus The modulus <i>p</i>.
+ * @return The proper Shamir's secret sharing scheme object.
+ * @return error IllegalArgumentException If the number of participants or the modulus is invalid.
+ */
+func (mmpci *MultiplicationMultipartyComputationInt)getSecretSharing(participantCount int, modulus interface{})(secretshare.
+ShamirSecretSharingInterface,error){
+	modulusValue ,ok := modulus.(int)
+	if (!ok) {return nil, errors.New("Invalid type of modulus.")}
+	return secretshare.NewShamirSecretSharingInt(participantCount,modulusValue)
+}
+
+/**
+ * Multiply two Int shares locally.
+ *
+ * @param left Share of the left factor.
+ * @param right Share of the right factor.
+ * @return The product modulo <i>p</i>.
+ */
+func (mmpci *MultiplicationMultipartyComputationInt) multiplyShares(left interface{}, right interface{}) interface{}{
+	modulus := int64(mmpci.GetModulus().(int))
+	product := (int64(left.(int)) * int64(right.(int))) % modulus
+	return int((product + modulus) % modulus)
+}
+
+/**
+ * Generate output during the output stage.
+ *
+ * @return The output value.
+ */
+func (mmpci *MultiplicationMultipartyComputationInt) generateOutputImpl() interface{}{
+	modulus := int64(mmpci.GetModulus().(int))
+	var pile int64 = 0
+	for i := 0; i < mmpci.participantCount; i++{
+		pile += (int64(mmpci.recombinationVector[i].(int)) * int64(mmpci.receivedInputs[i].(int)))
+		pile = (pile % modulus + modulus) % modulus
+	}
+	return int(pile)
+}
+
+/**
+ * Check if the type of input element is valid.
+ *
+ * @param e Element to be checked.
+ * @return True if the type of input element is Int, otherwise return false.
+ */
+func (mmpci *MultiplicationMultipartyComputationInt) checkElement(e interface{}) bool{
+	_, ok := e.(int)
+	return ok
+}
@@ -0,0 +1,99 @@
+package mpc
+
+import (
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+)
+
+/**
+ * This class implements an uint64 secure multi-party multiplication, for any prime modulus <i>p</i> < 2<sup>64</sup>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type MultiplicationMultipartyComputationUint64 struct {
+	MultiplicationMultipartyComputation
+}
+
+/**
+ * Construct multiplication MPC scheme with number of participants, threshold and the ID of the participant.
+ * <p>
+ * The threshold is the max number of semi-honest adversaries, should be less than <i>n</i>/2.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>.
+ * @return feedback the constructed MultiplicationMultipartyComputationUint64
+ * @return error IllegalArgumentException If any of ID, participantCount or threshold is invalid.
+ */
+func NewMultiplicationMultipartyComputationUint64(id int, participantCount int, threshold int) (*MultiplicationMultipartyComputationUint64, error){
+	if (participantCount < 3){
+		return nil, errors.New("Invalid participant count. Should be larger than 2.")
+	}
+	if (id < 0 || (id >= participantCount)){
+		return nil, errors.New("Invalid id, should be between 0 and participantCount-1")
+	}
+	if (threshold > (participantCount / 2)){
+		return nil, errors.New("Threshold should never greater than 1/2 of the participant count.")
+	}
+	feedback := new(MultiplicationMultipartyComputationUint64)
+	feedback.id = id
+	feedback.participantCount = participantCount
+	feedback.threshold = threshold
+	feedback.multiplicationMultipartyComputationCalculator = feedback
+	feedback.receivedInputs = make([]interface{}, participantCount)
+	feedback.receivedOutputs = map[int]interface{} {}
+	return feedback, nil
+}
+
+/**
+ * Get an uint64 Shamir's secret sharing object with the number of participants and the modulus.
+ *
+ * @param participantCount The number of the participants.
+ * @param modulus The modulus <i>p</i>.
+ * @return The proper Shamir's secret sharing scheme object.
+ * @return error IllegalArgumentException If the number of participants or the modulus is invalid.
+ */
+func (mmpcu *MultiplicationMultipartyComputationUint64) getSecretSharing(participantCount int, modulus interface{}) (secretshare.
+ShamirSecretSharingInterface, error){
+	modulusValue, ok := modulus.(uint64)
+	if (!ok) {return nil, errors.New("Invalid type of modulus.")}
+	return secretshare.NewShamirSecretSharingUint64(participantCount, modulusValue)
+}
+
+/**
+ * Multiply two uint64 shares locally.
+ *
+ * @param left Share of the left factor.
+ * @param right Share of the right factor.
+ * @return The product modulo <i>p</i>.
+ */
+func (mmpcu *MultiplicationMultipartyComputationUint64) multiplyShares(left interface{}, right interface{}) interface{}{
+	return poly.MultiplyModUint64(left.(uint64), right.(uint64), mmpcu.GetModulus().(uint64))
+}
+
+/**
+ * Generate output during the output stage.
+ *
+ * @return The output value.
+ */
+func (mmpcu *MultiplicationMultipartyComputationUint64) generateOutputImpl() interface{}{
+	modulus := mmpcu.GetModulus().(uint64)
+	pile := uint64(0)
+	for i := 0; i < mmpcu.participantCount; i++{
+		pile = poly.AddModUint64(pile, poly.MultiplyModUint64(mmpcu.recombinationVector[i].(uint64), mmpcu.receivedInputs[i].(uint64), modulus), modulus)
+	}
+	return pile
+}
+
+/**
+ * Check if the type of input element is valid.
+ *
+ * @param e Element to be checked.
+ * @return True if the type of input element is uint64, otherwise return false.
+ */
+func (mmpcu *MultiplicationMultipartyComputationUint64) checkElement(e interface{}) bool{
+	_, ok := e.(uint64)
+	return ok
+}
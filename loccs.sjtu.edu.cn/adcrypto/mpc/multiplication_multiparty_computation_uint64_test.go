@@ -0,0 +1,102 @@
+package mpc
+
+import (
+	"fmt"
+	"testing"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestNewMultiplicationMultipartyComputationUint64Procedure(t *testing.T) {
+	participantCount := 9
+	threshold := 4
+	lmpc := make([]*LinearMultipartyComputationUint64, participantCount) // input stage for every party
+	mmpc := make([]*MultiplicationMultipartyComputationUint64, participantCount) // multiplication for every party
+	max := uint64(1) << 32 - 1  // the max probable number for secret, the product needs a modulus above 2^63
+	secret := make([]uint64,participantCount)  // secrets
+	left, right := 0, 5 // the product of secret[left] and secret[right] is calculated
+	var err error
+	computeFrom := []int{2,3,6,8} // computing parties except zero itself
+
+	// initialize secrets
+	for i := 0; i < participantCount; i++{
+		se ,_ := rand.Int(rand.Reader,new(big.Int).SetUint64(max))
+		secret[i] = se.Uint64()
+	}
+
+	// construct and initialize linear mpcs for sharing the secrets
+	modulus := uint64(1)
+	for i := 0; i < participantCount; i++{
+		lmpc[i],err = NewLinearMultipartyComputationUint64(i,participantCount,threshold)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when constructing LinearMultipartyComputationUint64: %s", err))}
+		if i == 0 {
+			err = lmpc[i].InitializeSimpleSumWithMax(max * max / uint64(participantCount))
+			if err != nil {t.Error(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+			modulus = lmpc[i].GetModulus().(uint64)
+		}else{
+			err = lmpc[i].InitializeSimpleSumWithModulus(modulus)
+			if err != nil {t.Error(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+		}
+	}
+
+	// generate auxiliary
+	auxi, err := lmpc[0].GenerateInputAuxiliary()
+	if err != nil {t.Error(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+
+	// input stage of the secrets
+	for i := 0 ; i <participantCount; i++{
+		inputs, err := lmpc[i].GenerateInputs(secret[i],auxi)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount ; j++{
+			err = lmpc[j].AddReceivedInput(i,inputs[j])
+			if err != nil {t.Error(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// construct and initialize multiplication mpcs
+	for i := 0; i < participantCount; i++{
+		mmpc[i],err = NewMultiplicationMultipartyComputationUint64(i,participantCount,threshold)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when constructing MultiplicationMultipartyComputationUint64: %s", err))}
+		err = mmpc[i].Initialize(modulus,auxi)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when initializing mpcs: %s", err))}
+	}
+
+	// every party multiplies its shares locally and re-shares the product
+	for i := 0 ; i <participantCount; i++{
+		leftShare, err := lmpc[i].GetReceivedInput(left)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when getting received inputs: %s", err))}
+		rightShare, err := lmpc[i].GetReceivedInput(right)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when getting received inputs: %s", err))}
+		inputs, err := mmpc[i].GenerateInputs(leftShare,rightShare)
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount ; j++{
+			err = mmpc[j].AddReceivedInput(i,inputs[j])
+			if err != nil {t.Error(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+
+	// every party generate the degree-reduced shares of the product
+	outputs := make([]interface{},participantCount)
+	for i := 0; i<participantCount; i++{
+		outputs[i],err = mmpc[i].GenerateOutput()
+		if err != nil {t.Error(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+	}
+
+	// mmpc[0] add other outputs (number: threshold)
+	for _,from := range computeFrom{
+		_ = mmpc[0].AddReceivedOutput(from,outputs[from])
+	}
+
+	// mmpc[0] calculate the product
+	calculatedResult,err := mmpc[0].Compute()
+	if err != nil {t.Error(fmt.Sprintf("Error happens when calculating the final result: %s", err))}
+
+	// calculate the true result(never do this in a real mpc procedure)
+	pile := secret[left] * secret[right]
+
+	if calculatedResult.(uint64) != pile {
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: %d",calculatedResult,pile))
+	}else{
+		t.Log(fmt.Sprintf("Calculate Result is True, Result:%d ,Expected %d",calculatedResult,pile))
+	}
+}
@@ -0,0 +1,159 @@
+package mpc
+
+import (
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+)
+
+/**
+ * This class implements the proactive refresh of Shamir's secret shares of one participant.
+ * <p>
+ * The protocol follows "Herzberg A, Jarecki S, Krawczyk H, Yung M. Proactive secret sharing or: How to cope with
+ * perpetual leakage. In Annual International Cryptology Conference 1995 Aug 27 (pp. 339-352). Springer."
+ * Every participant shares zero with a random polynomial whose constant term is 0, sends the sub-share to each
+ * participant, and adds all received sub-shares to its existing share. The shared secret is unchanged, while the new
+ * shares are independent of the old ones, so shares leaked in different epochs cannot be combined.
+ * <p>
+ * Note 1: The refreshed share has the epoch of the old share plus 1, and <code>CalculateSecret</code> rejects shares
+ * from different epochs.
+ * <p>
+ * Note 2: Each participant has a unique ID, start from 0 to <i>n</i>-1.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type ProactiveRefresh struct {
+	/**
+	 * ID of this participant.
+	 */
+	id int
+
+	/**
+	 * Number of participants.
+	 */
+	participantCount int
+
+	/**
+	 * Shamir's secret sharing scheme object, same as the one generating the shares.
+	 */
+	secretSharing secretshare.ShamirSecretSharingInterface
+
+	/**
+	 * The share of this participant to be refreshed.
+	 */
+	share *secretshare.SecretShare
+
+	/**
+	 * The evaluation points of all participants, used for generating the sub-shares.
+	 */
+	auxiliary []interface{}
+
+	/**
+	 * The sub-shares received from other participants.
+	 */
+	receivedSubShares []*secretshare.SecretShare
+}
+
+/**
+ * Construct the proactive refresh of a participant.
+ *
+ * @param id ID of this participant.
+ * @param secretSharing Shamir's secret sharing scheme object with access structure set.
+ * @param share The share of this participant, whose participant should be the ID.
+ * @param auxiliary The evaluation points of all participants. Can be nil(use default auxiliary).
+ * @return feedback the constructed ProactiveRefresh
+ * @return error If any of ID, scheme, share or auxiliary is invalid.
+ */
+func NewProactiveRefresh(id int, secretSharing secretshare.ShamirSecretSharingInterface, share *secretshare.SecretShare, auxiliary []interface{}) (*ProactiveRefresh, error){
+	if (secretSharing == nil || !secretSharing.IsInitialized()){
+		return nil, errors.New("Secret sharing scheme should be initialized.")
+	}
+	participantCount := secretSharing.GetParticipantCount()
+	if (id < 0 || (id >= participantCount)){
+		return nil, errors.New("Invalid id, should be between 0 and participantCount-1")
+	}
+	if (share == nil || share.GetParticipant() != id){
+		return nil, errors.New("Share should belong to this participant.")
+	}
+	if (auxiliary == nil){
+		auxiliary = secretSharing.CreateDefaultAuxiliary()
+	} else if (len(auxiliary) != participantCount){
+		return nil, errors.New("Invalid number of auxiliary data, should be equal to number of participants.")
+	}
+	feedback := new(ProactiveRefresh)
+	feedback.id = id
+	feedback.participantCount = participantCount
+	feedback.secretSharing = secretSharing
+	feedback.share = share
+	feedback.auxiliary = auxiliary
+	feedback.receivedSubShares = make([]*secretshare.SecretShare, participantCount)
+	return feedback, nil
+}
+
+/**
+ * Get ID of this participant.
+ *
+ * @return ID of this participant.
+ */
+func (pr *ProactiveRefresh) GetID() int{
+	return pr.id
+}
+
+/**
+ * Generate the sub-shares of zero for all participants. The sub-share for this participant is added directly.
+ *
+ * @return The sub-shares, sub-shares[i] should be sent to participant i.
+ * @return error If the sub-shares cannot be generated.
+ */
+func (pr *ProactiveRefresh) GenerateSubShares() ([]*secretshare.SecretShare, error){
+	subShares, err := pr.secretSharing.GenerateZeroShares(pr.auxiliary)
+	if (err != nil) {return nil, err}
+	pr.receivedSubShares[pr.id] = subShares[pr.id]
+	return subShares, nil
+}
+
+/**
+ * Add a sub-share received from other participant.
+ *
+ * @param from The id of the participant who sent the sub-share.
+ * @param subShare The sub-share received.
+ * @return error If the id or the sub-share is invalid.
+ */
+func (pr *ProactiveRefresh) AddReceivedSubShare(from int, subShare *secretshare.SecretShare) error{
+	if (from < 0 || from >= pr.participantCount){
+		return errors.New("Invalid id, should be between 0 and participantCount-1")
+	}
+	if (subShare == nil || subShare.GetParticipant() != pr.id){
+		return errors.New("Sub-share should be generated for this participant.")
+	}
+	if (pr.receivedSubShares[from] != nil){
+		return errors.New("Sub-share from this participant has been received.")
+	}
+	pr.receivedSubShares[from] = subShare
+	return nil
+}
+
+/**
+ * Determine if sub-shares from all participants are received.
+ *
+ * @return True if all sub-shares are received, otherwise return false.
+ */
+func (pr *ProactiveRefresh) HasAllSubSharesReceived() bool{
+	for i := 0; i < pr.participantCount; i++{
+		if (pr.receivedSubShares[i] == nil) {return false}
+	}
+	return true
+}
+
+/**
+ * Refresh the share of this participant with the sub-shares from all participants.
+ *
+ * @return The refreshed share, of the next epoch.
+ * @return error If some sub-shares are not received or invalid.
+ */
+func (pr *ProactiveRefresh) Refresh() (*secretshare.SecretShare, error){
+	if (!pr.HasAllSubSharesReceived()){
+		return nil, errors.New("Sub-shares from all participants should be received.")
+	}
+	return pr.secretSharing.RefreshShare(pr.share, pr.receivedSubShares)
+}
@@ -0,0 +1,61 @@
+package mpc
+
+import (
+	"testing"
+	"crypto/rand"
+	"fmt"
+	"loccs.sjtu.edu.cn/adcrypto/secretshare"
+)
+
+func TestProactiveRefresh(t *testing.T) {
+	participantCount := 7
+	threshold := 4
+	modulusBigInt,_ := rand.Prime(rand.Reader,256)
+	secret,_ := rand.Int(rand.Reader,modulusBigInt)
+	scheme, err := secretshare.NewShamirSecretSharingBigInt(participantCount, modulusBigInt)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingBigInt: %s", err))}
+	access, err := secretshare.NewThresholdAccessStructure(participantCount, threshold)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+	err = scheme.SetAccessStructure(access)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+	auxi := scheme.GenerateRandomAuxiliary()
+	shares, err := scheme.GenerateShares(secret, auxi)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+
+	// refresh twice
+	for epoch := 1; epoch <= 2; epoch++{
+		refresh := make([]*ProactiveRefresh, participantCount)
+		for i := 0; i < participantCount; i++{
+			refresh[i], err = NewProactiveRefresh(i, scheme, shares[i], auxi)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ProactiveRefresh: %s", err))}
+		}
+		for i := 0; i < participantCount; i++{
+			subShares, err := refresh[i].GenerateSubShares()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating sub-shares: %s", err))}
+			for j := 0; j < participantCount; j++{
+				if (j == i) {continue}
+				err = refresh[j].AddReceivedSubShare(i, subShares[j])
+				if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received sub-shares: %s", err))}
+			}
+		}
+		oldShares := shares
+		shares = make([]*secretshare.SecretShare, participantCount)
+		for i := 0; i < participantCount; i++{
+			shares[i], err = refresh[i].Refresh()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when refreshing share: %s", err))}
+			if (shares[i].GetValue().(*secretshare.ShamirSecretShareValue).GetEpoch() != epoch){
+				t.Error(fmt.Sprintf("Wrong epoch of refreshed share, Result:%d ,Expected: %d",
+					shares[i].GetValue().(*secretshare.ShamirSecretShareValue).GetEpoch(), epoch))
+			}
+		}
+
+		calculatedSecret, err := scheme.CalculateSecret(shares[participantCount-threshold:])
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (fmt.Sprint(calculatedSecret) != fmt.Sprint(secret)){
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%v ,Expected: %v",calculatedSecret,secret))
+		}
+		mixed := []*secretshare.SecretShare{oldShares[0], shares[1], shares[2], shares[3]}
+		_, err = scheme.CalculateSecret(mixed)
+		if err == nil {t.Error("Shares from different epochs should not be combined.")}
+	}
+}
@@ -0,0 +1,300 @@
+package mpc
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+/**
+ * This class implements secure aggregation of float vectors, e.g. the model updates of federated learning,
+ * on top of the BigInt linear mpc in the batched mode.
+ * <p>
+ * Every participant (client) contributes a []float32 or []float64 update of the same length, and all participants learn the
+ * element-wise sum without learning any single update, if there are no more than <i>t</i> semi-honest adversaries.
+ * <p>
+ * With bound <i>B</i> and <i>f</i> fractional bits, an element <i>x</i> is clipped to [-<i>B</i>, <i>B</i>] and quantized to the fixed point
+ * integer round((<i>x</i>+<i>B</i>)2<sup><i>f</i></sup>) in [0, <i>max</i>], <i>max</i> = round(2<i>B</i>2<sup><i>f</i></sup>). The modulus is chosen by
+ * <code>InitializeSimpleSumWithMax</code> with <i>max</i>, so the sum of the <i>n</i> quantized updates never wraps around, and it is
+ * dequantized to <i>sum</i>/2<sup><i>f</i></sup> - <i>nB</i>. Apart from clipping, every element of the result is off by at most <i>n</i>/2<sup><i>f</i>+1</sup>.
+ * <p>
+ * The object is a <code>LinearMultipartyComputationInterface</code>, so it can be run by <code>LinearMultipartyComputationDriver</code>;
+ * <code>RunWithDropouts</code> of the driver tolerates clients who drop out after the input stage, whose updates still count.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type SecureAggregation struct {
+	/**
+	 * Bound <i>B</i> of the absolute value of an element.
+	 */
+	bound float64
+
+	/**
+	 * Number of fractional bits <i>f</i> of the fixed point integers.
+	 */
+	fractionalBits int
+
+	/**
+	 * Max value of a quantized element, i.e. round(2<i>B</i>2<sup><i>f</i></sup>).
+	 */
+	maxQuantized *big.Int
+
+	/**
+	 * Whether the update of this participant is []float32, so the result is []float32 as well.
+	 */
+	float32Update bool
+
+	LinearMultipartyComputationBigInt
+}
+
+/**
+ * Construct secure aggregation with the ID of the participant, number of participants, threshold and the fixed point format.
+ * <p>
+ * The threshold is the max number of semi-honest adversaries, should be no more than <i>n</i>/2.
+ *
+ * @param id ID of this participant.
+ * @param participantCount Number of participants.
+ * @param threshold Threshold <i>t</i>.
+ * @param bound Bound <i>B</i> of the absolute value of an element, larger elements are clipped.
+ * @param fractionalBits Number of fractional bits <i>f</i>, 2<i>B</i>2<sup><i>f</i></sup> should be no more than 2<sup>53</sup>.
+ * @return feedback the constructed SecureAggregation
+ * @return error IllegalArgumentException If any of ID, participantCount, threshold, bound or fractionalBits is invalid.
+ */
+func NewSecureAggregation(id int, participantCount int, threshold int, bound float64, fractionalBits int) (*SecureAggregation, error){
+	if (math.IsNaN(bound) || math.IsInf(bound, 0) || bound <= 0){
+		return nil, errors.New("Bound should be a positive number.")
+	}
+	if (fractionalBits < 0 || fractionalBits > 52){
+		return nil, errors.New("Invalid number of fractional bits. Should be between 0 and 52.")
+	}
+	max := math.Round(2 * bound * math.Ldexp(1, fractionalBits))
+	if (max < 1 || max > math.Ldexp(1, 53)){
+		return nil, errors.New("2*bound*2^fractionalBits should be between 1 and 2^53.")
+	}
+	computation, err := NewLinearMultipartyComputationBigInt(id, participantCount, threshold)
+	if (err != nil) {return nil, err}
+	feedback := new(SecureAggregation)
+	feedback.bound = bound
+	feedback.fractionalBits = fractionalBits
+	feedback.maxQuantized = big.NewInt(int64(max))
+	feedback.LinearMultipartyComputationBigInt = *computation
+	feedback.linearMultipartyComputationCalculator = &feedback.LinearMultipartyComputationBigInt
+	return feedback, nil
+}
+
+/**
+ * Get the bound of the absolute value of an element.
+ *
+ * @return Bound <i>B</i>.
+ */
+func (sa *SecureAggregation) GetBound() float64{
+	return sa.bound
+}
+
+/**
+ * Get the number of fractional bits of the fixed point integers.
+ *
+ * @return Number of fractional bits <i>f</i>.
+ */
+func (sa *SecureAggregation) GetFractionalBits() int{
+	return sa.fractionalBits
+}
+
+/**
+ * Get the max value of a quantized element.
+ *
+ * @return round(2<i>B</i>2<sup><i>f</i></sup>).
+ */
+func (sa *SecureAggregation) GetMaxQuantized() *big.Int{
+	return new(big.Int).Set(sa.maxQuantized)
+}
+
+/**
+ * Set the simple sum and find a proper modulus <i>p</i> by the max value of a quantized element.
+ * <p>
+ * The modulus should then be given to the other participants, who call <code>InitializeSimpleSumWithModulus</code>.
+ *
+ * @return error If the modulus cannot be generated.
+ */
+func (sa *SecureAggregation) Initialize() error{
+	return sa.LinearMultipartyComputationBigInt.InitializeSimpleSumWithMax(sa.GetMaxQuantized())
+}
+
+/**
+ * Set the simple sum with the modulus, which should be larger than <i>n</i> times the max value of a quantized element.
+ *
+ * @param modulus Modulus of the Shamir's scheme, should be BigInt.
+ * @return error IllegalArgumentException If the modulus is invalid or too small for the sum.
+ */
+func (sa *SecureAggregation) InitializeSimpleSumWithModulus(modulus interface{}) error{
+	modulusValue, ok := modulus.(*big.Int)
+	if (!ok) {return errors.New("Invalid type of modulus.")}
+	pile := new(big.Int).Mul(sa.maxQuantized, big.NewInt(int64(sa.participantCount)))
+	if (modulusValue.Cmp(pile) <= 0){
+		return errors.New("Modulus should be larger than participantCount times the max quantized value.")
+	}
+	return sa.LinearMultipartyComputationBigInt.InitializeSimpleSumWithModulus(modulus)
+}
+
+/**
+ * Not supported, the max value is given by the bound and the fractional bits. Use <code>Initialize</code> instead.
+ *
+ * @return error Always.
+ */
+func (sa *SecureAggregation) InitializeSimpleSumWithMax(max interface{}) error{
+	return errors.New("Secure aggregation chooses the max value itself, use Initialize instead.")
+}
+
+/**
+ * Not supported, secure aggregation always computes the simple sum.
+ *
+ * @return error Always.
+ */
+func (sa *SecureAggregation) InitializeWithMaxValue(coefficients []interface{}, max interface{}) error{
+	return errors.New("Secure aggregation only computes the simple sum.")
+}
+
+/**
+ * Not supported, secure aggregation always computes the simple sum.
+ *
+ * @return error Always.
+ */
+func (sa *SecureAggregation) InitializeWithModulus(coefficients []interface{}, modulus interface{}) error{
+	return errors.New("Secure aggregation only computes the simple sum.")
+}
+
+/**
+ * Quantize an update to fixed point integers.
+ *
+ * @param update The update, should be a non-empty []float32 or []float64 without NaN or infinity.
+ * @return The quantized elements (BigInt) in [0, <i>max</i>].
+ * @return error If the update is invalid.
+ */
+func (sa *SecureAggregation) Quantize(update interface{}) ([]interface{}, error){
+	values, err := sa.getFloat64Update(update)
+	if (err != nil) {return nil, err}
+	scale := math.Ldexp(1, sa.fractionalBits)
+	feedback := make([]interface{}, len(values))
+	for i := 0; i < len(values); i++{
+		if (math.IsNaN(values[i]) || math.IsInf(values[i], 0)){
+			return nil, errors.New("Update should not contain NaN or infinity.")
+		}
+		value := math.Max(-sa.bound, math.Min(sa.bound, values[i]))
+		quantized := big.NewInt(int64(math.Round((value + sa.bound) * scale)))
+		if (quantized.Cmp(sa.maxQuantized) > 0) {quantized.Set(sa.maxQuantized)}
+		feedback[i] = quantized
+	}
+	return feedback, nil
+}
+
+/**
+ * Dequantize the sum of the quantized updates of all <i>n</i> participants.
+ *
+ * @param sum The sum of the quantized updates (BigInt).
+ * @return The sum of the updates, <i>sum</i>/2<sup><i>f</i></sup> - <i>nB</i>.
+ * @return error If the sum is invalid.
+ */
+func (sa *SecureAggregation) Dequantize(sum []interface{}) ([]float64, error){
+	offset := new(big.Float).SetPrec(128).SetFloat64(sa.bound)
+	offset.Mul(offset, new(big.Float).SetInt64(int64(sa.participantCount)))
+	feedback := make([]float64, len(sum))
+	for i := 0; i < len(sum); i++{
+		value, ok := sum[i].(*big.Int)
+		if (!ok || value == nil) {return nil, errors.New("Invalid type of sum, should be BigInt.")}
+		element := new(big.Float).SetPrec(128).SetInt(value)
+		element.SetMantExp(element, -sa.fractionalBits)
+		element.Sub(element, offset)
+		feedback[i], _ = element.Float64()
+	}
+	return feedback, nil
+}
+
+/**
+ * Generate inputs for all participants during the input stage from the quantized update.
+ *
+ * @param update The update of this participant, []float32 or []float64.
+ * @param auxiliary The auxiliary data for generating Shamir's secret shares.
+ * @return The inputs for all participants, each a vector of BigInt.
+ * @return error IllegalArgumentException If the update or the auxiliary data is invalid,
+ *         or IllegalStateException If the modulus is not set.
+ */
+func (sa *SecureAggregation) GenerateInputs(update interface{}, auxiliary []interface{}) ([]interface{}, error){
+	secrets, err := sa.Quantize(update)
+	if (err != nil) {return nil, err}
+	inputs, err := sa.LinearMultipartyComputationBigInt.GenerateInputs(secrets, auxiliary)
+	if (err != nil) {return nil, err}
+	_, sa.float32Update = update.([]float32)
+	return inputs, nil
+}
+
+/**
+ * Compute the sum of the updates from <i>t</i>+1 outputs.
+ *
+ * @return The sum, []float32 if the update of this participant is []float32, otherwise []float64.
+ * @return error IllegalStateException If not enough outputs are received or the outputs are invalid.
+ */
+func (sa *SecureAggregation) Compute() (interface{}, error){
+	sum, err := sa.LinearMultipartyComputationBigInt.Compute()
+	if (err != nil) {return nil, err}
+	return sa.dequantizeResult(sum)
+}
+
+/**
+ * Compute the sum of the updates from all received outputs, tolerating wrong outputs.
+ *
+ * @return The sum, []float32 if the update of this participant is []float32, otherwise []float64.
+ * @return IDs of the participants whose outputs are wrong.
+ * @return error IllegalStateException If not enough outputs are received, or too many outputs are wrong.
+ */
+func (sa *SecureAggregation) ComputeRobust() (interface{}, []int, error){
+	sum, wrongFrom, err := sa.LinearMultipartyComputationBigInt.ComputeRobust()
+	if (err != nil) {return nil, nil, err}
+	feedback, err := sa.dequantizeResult(sum)
+	if (err != nil) {return nil, nil, err}
+	return feedback, wrongFrom, nil
+}
+
+/**
+ * Dequantize the computed sum into the float type of the update of this participant.
+ *
+ * @param sum The computed sum, should be a vector of BigInt.
+ * @return The sum of the updates.
+ * @return error If the sum is not a vector.
+ */
+func (sa *SecureAggregation) dequantizeResult(sum interface{}) (interface{}, error){
+	vector, ok := sum.([]interface{})
+	if (!ok) {return nil, errors.New("Sum should be a vector.")}
+	feedback, err := sa.Dequantize(vector)
+	if (err != nil) {return nil, err}
+	if (!sa.float32Update) {return feedback, nil}
+	feedback32 := make([]float32, len(feedback))
+	for i := 0; i < len(feedback); i++{
+		feedback32[i] = float32(feedback[i])
+	}
+	return feedback32, nil
+}
+
+/**
+ * Turn an update into []float64.
+ *
+ * @param update The update, should be a non-empty []float32 or []float64.
+ * @return The update in float64.
+ * @return error If the type of the update is invalid or it is empty.
+ */
+func (sa *SecureAggregation) getFloat64Update(update interface{}) ([]float64, error){
+	var feedback []float64
+	switch value := update.(type) {
+	case []float64:
+		feedback = value
+	case []float32:
+		feedback = make([]float64, len(value))
+		for i := 0; i < len(value); i++{
+			feedback[i] = float64(value[i])
+		}
+	default:
+		return nil, errors.New("Invalid type of update, should be []float32 or []float64.")
+	}
+	if (len(feedback) == 0) {return nil, errors.New("Update should not be empty.")}
+	return feedback, nil
+}
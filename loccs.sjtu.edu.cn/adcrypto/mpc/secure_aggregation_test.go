@@ -0,0 +1,159 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+	"math"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestSecureAggregationProcedure(t *testing.T) {
+	participantCount := 6
+	threshold := 2
+	length := 100
+	fractionalBits := 16
+	aggregation := make([]*SecureAggregation, participantCount)
+	updates := make([][]float64, participantCount)
+	expected := make([]float64, length)
+	var err error
+	for i := 0; i < participantCount; i++{
+		updates[i] = make([]float64, length)
+		for d := 0; d < length; d++{
+			random, _ := rand.Int(rand.Reader, big.NewInt(2000001))
+			updates[i][d] = float64(random.Int64() - 1000000) / 1000000
+			expected[d] += updates[i][d]
+		}
+		aggregation[i], err = NewSecureAggregation(i, participantCount, threshold, 1, fractionalBits)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing SecureAggregation: %s", err))}
+	}
+	err = aggregation[0].Initialize()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing secure aggregation: %s", err))}
+	modulus := aggregation[0].GetModulus()
+	for i := 1; i < participantCount; i++{
+		err = aggregation[i].InitializeSimpleSumWithModulus(modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing secure aggregation: %s", err))}
+	}
+	auxi, _ := aggregation[0].GenerateInputAuxiliary()
+
+	for i := 0; i < participantCount; i++{
+		inputs, err := aggregation[i].GenerateInputs(updates[i], auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating inputs: %s", err))}
+		for j := 0; j < participantCount; j++{
+			err = aggregation[j].AddReceivedInput(i, inputs[j])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding received inputs: %s", err))}
+		}
+	}
+	for i := 0; i < participantCount; i++{
+		output, err := aggregation[i].GenerateOutput()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating outputs: %s", err))}
+		err = aggregation[0].AddReceivedOutput(i, output)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens after adding received output: %s", err))}
+	}
+	result, err := aggregation[0].Compute()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the sum: %s", err))}
+	tolerance := float64(participantCount) / math.Ldexp(1, fractionalBits + 1)
+	for d := 0; d < length; d++{
+		if (math.Abs(result.([]float64)[d] - expected[d]) > tolerance){
+			t.Error(fmt.Sprintf("Sum %d is False, Result:%v ,Expected: %v", d, result.([]float64)[d], expected[d]))
+		}
+	}
+	robust, wrongFrom, err := aggregation[0].ComputeRobust()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the sum: %s", err))}
+	if (len(wrongFrom) != 0 || robust.([]float64)[0] != result.([]float64)[0]) {t.Error("Robust sum is False.")}
+}
+
+func TestSecureAggregationQuantize(t *testing.T) {
+	aggregation, err := NewSecureAggregation(0, 3, 1, 2, 4)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing SecureAggregation: %s", err))}
+	if (aggregation.GetMaxQuantized().Int64() != 64) {t.Error("Max quantized value should be 2*2*2^4.")}
+	quantized, err := aggregation.Quantize([]float32{-2, 0, 0.5, 1.03, 5, -7})
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when quantizing: %s", err))}
+	if (fmt.Sprint(quantized) != "[0 32 40 48 64 0]"){
+		t.Error(fmt.Sprintf("Quantized update is False, Result:%v ,Expected: [0 32 40 48 64 0]", quantized))
+	}
+	// the sum of 3 updates, each with offset 2
+	sum, err := aggregation.Dequantize([]interface{}{big.NewInt(96), big.NewInt(100), big.NewInt(0)})
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when dequantizing: %s", err))}
+	if (fmt.Sprint(sum) != "[0 0.25 -6]"){
+		t.Error(fmt.Sprintf("Dequantized sum is False, Result:%v ,Expected: [0 0.25 -6]", sum))
+	}
+
+	_, err = aggregation.Quantize([]float64{1, math.NaN()})
+	if err == nil {t.Error("NaN should not be accepted.")}
+	_, err = aggregation.Quantize([]float64{})
+	if err == nil {t.Error("Empty update should not be accepted.")}
+	_, err = aggregation.Quantize([]int{1})
+	if err == nil {t.Error("Update of ints should not be accepted.")}
+	_, err = NewSecureAggregation(0, 3, 1, 1, 53)
+	if err == nil {t.Error("Fractional bits more than 52 should not be accepted.")}
+	_, err = NewSecureAggregation(0, 3, 1, math.Inf(1), 8)
+	if err == nil {t.Error("Infinite bound should not be accepted.")}
+	err = aggregation.InitializeSimpleSumWithModulus(big.NewInt(191))
+	if err == nil {t.Error("Modulus not larger than n*max should not be accepted.")}
+	err = aggregation.InitializeWithMaxValue([]interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3)}, big.NewInt(64))
+	if err == nil {t.Error("Linear functions other than the sum should not be accepted.")}
+}
+
+func TestSecureAggregationDropouts(t *testing.T) {
+	participantCount := 5
+	threshold := 2
+	length := 20
+	fractionalBits := 20
+	dropped := map[int]bool{1: true, 4: true}
+	network, err := NewChannelNetwork(participantCount, 2 * participantCount)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ChannelNetwork: %s", err))}
+	updates := make([][]float32, participantCount)
+	expected := make([]float64, length)
+	for i := 0; i < participantCount; i++{
+		updates[i] = make([]float32, length)
+		for d := 0; d < length; d++{
+			updates[i][d] = float32(i * d) / 64 - 0.5
+			expected[d] += float64(updates[i][d])
+		}
+	}
+	setup, _ := NewSecureAggregation(0, participantCount, threshold, 4, fractionalBits)
+	err = setup.Initialize()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when initializing secure aggregation: %s", err))}
+	modulus := setup.GetModulus()
+	auxi, _ := setup.GenerateInputAuxiliary()
+
+	results := make(chan interface{}, participantCount)
+	errs := make(chan error, participantCount)
+	for i := 0; i < participantCount; i++{
+		go func(i int) {
+			transport, _ := network.GetTransport(i)
+			aggregation, err := NewSecureAggregation(i, participantCount, threshold, 4, fractionalBits)
+			if err != nil {errs <- err; return}
+			err = aggregation.InitializeSimpleSumWithModulus(modulus)
+			if err != nil {errs <- err; return}
+			driver, err := NewLinearMultipartyComputationDriver(aggregation, transport)
+			if err != nil {errs <- err; return}
+			if (dropped[i]){
+				// the client leaves right after sending its update
+				err = driver.RunInputStage(updates[i], auxi)
+				transport.Close()
+				if err != nil {errs <- err}
+				return
+			}
+			result, err := driver.RunWithDropouts(updates[i], auxi)
+			if err != nil {errs <- err; return}
+			results <- result
+		}(i)
+	}
+
+	// the updates of the dropped clients are still in the sum
+	tolerance := float64(participantCount) / math.Ldexp(1, fractionalBits + 1) + 1e-5
+	for i := 0; i < participantCount - len(dropped); i++{
+		select {
+		case err := <-errs:
+			t.Fatal(fmt.Sprintf("Error happens when running the driver: %s", err))
+		case result := <-results:
+			for d := 0; d < length; d++{
+				if (math.Abs(float64(result.([]float32)[d]) - expected[d]) > tolerance){
+					t.Error(fmt.Sprintf("Sum %d is False, Result:%v ,Expected: %v", d, result.([]float32)[d], expected[d]))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package mpc
+
+/**
+ * Types of the messages exchanged between participants.
+ */
+type MessageType int
+
+const (
+	/**
+	 * Message carrying an input during the input stage.
+	 */
+	MessageInput MessageType = iota
+
+	/**
+	 * Message carrying an output during the output stage.
+	 */
+	MessageOutput
+)
+
+/**
+ * A typed message sent from one participant to another.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type Message struct {
+	/**
+	 * Type of the message.
+	 */
+	messageType MessageType
+
+	/**
+	 * ID of the participant who sends the message.
+	 */
+	from int
+
+	/**
+	 * ID of the participant who receives the message.
+	 */
+	to int
+
+	/**
+	 * The value carried by the message.
+	 */
+	value interface{}
+}
+
+/**
+ * Construct a message.
+ *
+ * @param messageType Type of the message.
+ * @param from ID of the participant who sends the message.
+ * @param to ID of the participant who receives the message.
+ * @param value The value carried by the message.
+ * @return newMessage the new constructed Message
+ */
+func NewMessage(messageType MessageType, from int, to int, value interface{}) *Message{
+	newMessage := new(Message)
+	newMessage.messageType = messageType
+	newMessage.from = from
+	newMessage.to = to
+	newMessage.value = value
+	return newMessage
+}
+
+/**
+ * Get type of the message.
+ *
+ * @return Type of the message.
+ */
+func (message *Message) GetMessageType() MessageType{
+	return message.messageType
+}
+
+/**
+ * Get ID of the participant who sends the message.
+ *
+ * @return ID of the sender.
+ */
+func (message *Message) GetFrom() int{
+	return message.from
+}
+
+/**
+ * Get ID of the participant who receives the message.
+ *
+ * @return ID of the receiver.
+ */
+func (message *Message) GetTo() int{
+	return message.to
+}
+
+/**
+ * Get the value carried by the message.
+ *
+ * @return The value.
+ */
+func (message *Message) GetValue() interface{}{
+	return message.value
+}
+
+/**
+ * Interface for the transport connecting a participant with all others.
+ * <p>
+ * A transport belongs to one participant. It sends messages to the participants given by <code>Message.GetTo</code>,
+ * and receives the messages sent to its own participant, in the order they arrive.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type Transport interface {
+	/**
+	* Send a message to the participant given by the message.
+	*
+	* @param message The message to be sent.
+	* @return error If the receiver is invalid or the message cannot be delivered.
+	*/
+	Send(message *Message) error
+
+	/**
+	* Receive the next message sent to this participant, block until one arrives.
+	*
+	* @return The message received.
+	* @return error If the transport is closed.
+	*/
+	Receive() (*Message, error)
+
+	/**
+	* Close the transport and release the resources.
+	*
+	* @return error If error happens when closing.
+	*/
+	Close() error
+}
@@ -0,0 +1,166 @@
+package mpc
+
+import (
+	"errors"
+	"sync"
+)
+
+/**
+ * This class implements an in-memory network connecting all participants in the same process with channels.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type ChannelNetwork struct {
+	/**
+	 * The inboxes of all participants.
+	 */
+	inboxes []chan *Message
+
+	/**
+	 * The transports of all participants, whose closed channels tell the senders which participants have left.
+	 */
+	transports []*ChannelTransport
+}
+
+/**
+ * This class implements the transport of one participant in a <code>ChannelNetwork</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type ChannelTransport struct {
+	/**
+	 * ID of the participant.
+	 */
+	id int
+
+	/**
+	 * The network the transport belongs to.
+	 */
+	network *ChannelNetwork
+
+	/**
+	 * Closed when the transport is closed.
+	 */
+	closed chan struct{}
+
+	/**
+	 * Makes sure the transport is closed only once.
+	 */
+	closeOnce sync.Once
+}
+
+/**
+ * Construct an in-memory network with the number of participants.
+ *
+ * @param participantCount Number of participants.
+ * @param bufferSize Number of messages each inbox can hold without blocking the senders.
+ * @return feedback the constructed ChannelNetwork
+ * @return error If the number of participants or the buffer size is invalid.
+ */
+func NewChannelNetwork(participantCount int, bufferSize int) (*ChannelNetwork, error){
+	if (participantCount < 1){
+		return nil, errors.New("Invalid participant count. Should be larger than 0.")
+	}
+	if (bufferSize < 0){
+		return nil, errors.New("Invalid buffer size. Should not be less than 0.")
+	}
+	feedback := new(ChannelNetwork)
+	feedback.inboxes = make([]chan *Message, participantCount)
+	feedback.transports = make([]*ChannelTransport, participantCount)
+	for i := 0; i < participantCount; i++{
+		feedback.inboxes[i] = make(chan *Message, bufferSize)
+		transport := new(ChannelTransport)
+		transport.id = i
+		transport.network = feedback
+		transport.closed = make(chan struct{})
+		feedback.transports[i] = transport
+	}
+	return feedback, nil
+}
+
+/**
+ * Get the transport of a participant.
+ *
+ * @param id ID of the participant.
+ * @return The transport of the participant.
+ * @return error If the ID is invalid.
+ */
+func (network *ChannelNetwork) GetTransport(id int) (*ChannelTransport, error){
+	if (id < 0 || id >= len(network.transports)){
+		return nil, errors.New("Invalid participant ID.")
+	}
+	return network.transports[id], nil
+}
+
+/**
+ * Test if the transport of a participant is closed.
+ *
+ * @param id ID of the participant.
+ * @return True if the transport is closed, otherwise return false.
+ */
+func (network *ChannelNetwork) isClosed(id int) bool{
+	select {
+	case <-network.transports[id].closed:
+		return true
+	default:
+		return false
+	}
+}
+
+/**
+ * Send a message to the inbox of the receiver.
+ * <p>
+ * As a TCP connection to a closed peer, sending to a closed transport fails instead of blocking when its inbox is full.
+ *
+ * @param message The message to be sent.
+ * @return error If the receiver is invalid, or this transport or the transport of the receiver is closed.
+ */
+func (transport *ChannelTransport) Send(message *Message) error{
+	if (message == nil){
+		return errors.New("Message should not be nil.")
+	}
+	if (message.from != transport.id){
+		return errors.New("Sender of the message should be the owner of the transport.")
+	}
+	if (message.to < 0 || message.to >= len(transport.network.inboxes)){
+		return errors.New("Invalid receiver of the message.")
+	}
+	if (transport.network.isClosed(message.to)){
+		return errors.New("Transport of the receiver closed.")
+	}
+	select {
+	case transport.network.inboxes[message.to] <- message:
+		return nil
+	case <-transport.closed:
+		return errors.New("Transport closed.")
+	case <-transport.network.transports[message.to].closed:
+		return errors.New("Transport of the receiver closed.")
+	}
+}
+
+/**
+ * Receive the next message from the inbox of this participant.
+ *
+ * @return The message received.
+ * @return error If the transport is closed.
+ */
+func (transport *ChannelTransport) Receive() (*Message, error){
+	select {
+	case message := <-transport.network.inboxes[transport.id]:
+		return message, nil
+	case <-transport.closed:
+		return nil, errors.New("Transport closed.")
+	}
+}
+
+/**
+ * Close the transport.
+ *
+ * @return Always nil.
+ */
+func (transport *ChannelTransport) Close() error{
+	transport.closeOnce.Do(func(){ close(transport.closed) })
+	return nil
+}
@@ -0,0 +1,31 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+func TestChannelTransportClosedReceiver(t *testing.T) {
+	network, err := NewChannelNetwork(2, 1)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ChannelNetwork: %s", err))}
+	sender, _ := network.GetTransport(0)
+	receiver, _ := network.GetTransport(1)
+	err = sender.Send(NewMessage(MessageInput, 0, 1, big.NewInt(1)))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when sending: %s", err))}
+
+	// the inbox of the receiver is full, sending to it fails once it is closed
+	errs := make(chan error, 1)
+	go func() {errs <- sender.Send(NewMessage(MessageInput, 0, 1, big.NewInt(2)))}()
+	time.Sleep(50 * time.Millisecond)
+	receiver.Close()
+	select {
+	case err = <-errs:
+		if err == nil {t.Error("Sending to a closed receiver should fail.")}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sending to a closed receiver should not block.")
+	}
+	err = sender.Send(NewMessage(MessageInput, 0, 1, big.NewInt(3)))
+	if err == nil {t.Error("Sending to a closed receiver should fail.")}
+}
@@ -0,0 +1,456 @@
+package mpc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/wire"
+	"net"
+	"sync"
+	"time"
+)
+
+/**
+ * Max time for the dialer of a connection to answer the handshake.
+ */
+const handshakeTimeout = 10 * time.Second
+
+/**
+ * This class implements the transport of one participant over TCP.
+ * <p>
+ * Every participant listens on its own address. A connection to a peer is dialed when the first message is sent
+ * to it, and kept for the following messages, so the messages from one participant to another arrive in order.
+ * Since the peers may start later, dialing is retried until the dial timeout. Dialing a peer does not block
+ * sending to other peers, and a connection is dialed again after a failed write.
+ * <p>
+ * Every incoming connection starts with a handshake binding it to one peer: the receiver sends a random challenge,
+ * and the dialer answers with its ID, the ID of the receiver and HMAC-SHA256 of the challenge and both IDs under the
+ * handshake key. Messages whose sender is not the bound peer are dropped. Without a handshake key the MAC is empty,
+ * so the connection is bound to the claimed ID but the peer is not authenticated; set the same key for all participants
+ * with <code>SetHandshakeKey</code> unless only participants can reach the listening addresses.
+ * <p>
+ * Messages are sent as frames of the binary wire format, see <code>Message.MarshalBinary</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type TCPTransport struct {
+	/**
+	 * ID of the participant.
+	 */
+	id int
+
+	/**
+	 * Addresses of all participants, indexed by ID.
+	 */
+	addresses []string
+
+	/**
+	 * The listener accepting connections from peers.
+	 */
+	listener net.Listener
+
+	/**
+	 * Max time to keep dialing a peer.
+	 */
+	dialTimeout time.Duration
+
+	/**
+	 * Key shared by all participants to authenticate the handshake, nil if peers are not authenticated.
+	 */
+	handshakeKey []byte
+
+	/**
+	 * Outgoing connections, indexed by ID.
+	 */
+	outgoing []net.Conn
+
+	/**
+	 * All connections, closed with the transport.
+	 */
+	connections []net.Conn
+
+	/**
+	 * Messages received from all incoming connections.
+	 */
+	inbox chan *Message
+
+	/**
+	 * Closed when the transport is closed.
+	 */
+	closed chan struct{}
+
+	/**
+	 * Protects the connections.
+	 */
+	lock sync.Mutex
+
+	/**
+	 * Makes sure the transport is closed only once.
+	 */
+	closeOnce sync.Once
+}
+
+/**
+ * Construct a TCP transport listening on the address of this participant.
+ *
+ * @param id ID of this participant.
+ * @param addresses Addresses of all participants, indexed by ID, e.g. "127.0.0.1:9000".
+ * @return feedback the constructed TCPTransport
+ * @return error If the ID or the addresses are invalid, or the address cannot be listened on.
+ */
+func NewTCPTransport(id int, addresses []string) (*TCPTransport, error){
+	if (id < 0 || id >= len(addresses)){
+		return nil, errors.New("Invalid participant ID.")
+	}
+	listener, err := net.Listen("tcp", addresses[id])
+	if (err != nil) {return nil, err}
+	return NewTCPTransportWithListener(id, addresses, listener)
+}
+
+/**
+ * Construct a TCP transport accepting connections from an existing listener.
+ *
+ * @param id ID of this participant.
+ * @param addresses Addresses of all participants, indexed by ID.
+ * @param listener The listener on the address of this participant.
+ * @return feedback the constructed TCPTransport
+ * @return error If the ID, the addresses or the listener is invalid.
+ */
+func NewTCPTransportWithListener(id int, addresses []string, listener net.Listener) (*TCPTransport, error){
+	if (id < 0 || id >= len(addresses)){
+		return nil, errors.New("Invalid participant ID.")
+	}
+	if (listener == nil){
+		return nil, errors.New("Listener should not be nil.")
+	}
+	feedback := new(TCPTransport)
+	feedback.id = id
+	feedback.addresses = addresses
+	feedback.listener = listener
+	feedback.dialTimeout = 10 * time.Second
+	feedback.outgoing = make([]net.Conn, len(addresses))
+	feedback.connections = make([]net.Conn, 0)
+	feedback.inbox = make(chan *Message, len(addresses))
+	feedback.closed = make(chan struct{})
+	go feedback.accept()
+	return feedback, nil
+}
+
+/**
+ * Set the max time to keep dialing a peer.
+ *
+ * @param timeout The dial timeout.
+ */
+func (transport *TCPTransport) SetDialTimeout(timeout time.Duration){
+	transport.dialTimeout = timeout
+}
+
+/**
+ * Set the key shared by all participants to authenticate the handshake of every connection.
+ * <p>
+ * It should be set before any message is sent or received.
+ *
+ * @param key The handshake key, nil to accept any peer claiming a valid ID.
+ */
+func (transport *TCPTransport) SetHandshakeKey(key []byte){
+	if (key == nil){
+		transport.handshakeKey = nil
+		return
+	}
+	transport.handshakeKey = append([]byte{}, key...)
+}
+
+/**
+ * Get the address this participant listens on.
+ *
+ * @return The listening address.
+ */
+func (transport *TCPTransport) GetAddress() net.Addr{
+	return transport.listener.Addr()
+}
+
+/**
+ * Send a message to the receiver, dial it if not connected.
+ *
+ * @param message The message to be sent.
+ * @return error If the receiver is invalid or cannot be reached.
+ */
+func (transport *TCPTransport) Send(message *Message) error{
+	if (message == nil){
+		return errors.New("Message should not be nil.")
+	}
+	if (message.from != transport.id){
+		return errors.New("Sender of the message should be the owner of the transport.")
+	}
+	if (message.to < 0 || message.to >= len(transport.addresses)){
+		return errors.New("Invalid receiver of the message.")
+	}
+	frame, err := message.MarshalBinary()
+	if (err != nil) {return err}
+	transport.lock.Lock()
+	connection := transport.outgoing[message.to]
+	transport.lock.Unlock()
+	if (connection == nil){
+		connection, err = transport.connect(message.to)
+		if (err != nil) {return err}
+	}
+	_, err = connection.Write(frame)
+	if (err != nil){
+		// dial again on the next message
+		transport.lock.Lock()
+		if (transport.outgoing[message.to] == connection) {transport.outgoing[message.to] = nil}
+		transport.removeConnection(connection)
+		transport.lock.Unlock()
+		connection.Close()
+	}
+	return err
+}
+
+/**
+ * Receive the next message sent to this participant.
+ *
+ * @return The message received.
+ * @return error If the transport is closed.
+ */
+func (transport *TCPTransport) Receive() (*Message, error){
+	select {
+	case message := <-transport.inbox:
+		return message, nil
+	case <-transport.closed:
+		return nil, errors.New("Transport closed.")
+	}
+}
+
+/**
+ * Close the listener and all connections.
+ *
+ * @return error If error happens when closing the listener.
+ */
+func (transport *TCPTransport) Close() error{
+	var err error
+	transport.closeOnce.Do(func(){
+		close(transport.closed)
+		err = transport.listener.Close()
+		transport.lock.Lock()
+		for i := 0; i < len(transport.connections); i++{
+			transport.connections[i].Close()
+		}
+		transport.lock.Unlock()
+	})
+	return err
+}
+
+/**
+ * Dial a peer and answer its handshake, without holding the lock, then keep the connection for the following messages.
+ * <p>
+ * If another message to the same peer has connected in the meantime, the existing connection is used.
+ *
+ * @param to ID of the peer.
+ * @return The outgoing connection to the peer.
+ * @return error If the peer cannot be reached in time, the handshake fails or the transport is closed.
+ */
+func (transport *TCPTransport) connect(to int) (net.Conn, error){
+	connection, err := transport.dial(transport.addresses[to])
+	if (err != nil) {return nil, err}
+	err = transport.answerHandshake(connection, to)
+	if (err != nil){
+		connection.Close()
+		return nil, err
+	}
+	transport.lock.Lock()
+	defer transport.lock.Unlock()
+	select {
+	case <-transport.closed:
+		connection.Close()
+		return nil, errors.New("Transport closed.")
+	default:
+	}
+	if (transport.outgoing[to] != nil){
+		connection.Close()
+		return transport.outgoing[to], nil
+	}
+	transport.connections = append(transport.connections, connection)
+	transport.outgoing[to] = connection
+	return connection, nil
+}
+
+/**
+ * Dial an address until it succeeds or the dial timeout is reached.
+ *
+ * @param address The address to dial.
+ * @return The connection.
+ * @return error If the address cannot be reached in time.
+ */
+func (transport *TCPTransport) dial(address string) (net.Conn, error){
+	deadline := time.Now().Add(transport.dialTimeout)
+	for {
+		connection, err := net.DialTimeout("tcp", address, transport.dialTimeout)
+		if (err == nil) {return connection, nil}
+		if (time.Now().After(deadline)) {return nil, err}
+		select {
+		case <-transport.closed:
+			return nil, errors.New("Transport closed.")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+/**
+ * Answer the handshake of the receiver on an outgoing connection.
+ *
+ * @param connection The outgoing connection.
+ * @param to ID of the receiver.
+ * @return error If the challenge is not received in time or the response cannot be sent.
+ */
+func (transport *TCPTransport) answerHandshake(connection net.Conn, to int) error{
+	connection.SetDeadline(time.Now().Add(handshakeTimeout))
+	defer connection.SetDeadline(time.Time{})
+	kind, frame, err := wire.ReadFrame(connection)
+	if (err != nil) {return err}
+	if (kind != wire.KindHandshakeChallenge){
+		return errors.New("Invalid handshake of the receiver.")
+	}
+	challenge, err := wire.NewReader(frame).ReadFrame(wire.KindHandshakeChallenge)
+	if (err != nil) {return err}
+	response := wire.NewWriter()
+	response.WriteIndex(transport.id)
+	response.WriteIndex(to)
+	response.WriteBytes(transport.getHandshakeMAC(challenge, transport.id, to))
+	return wire.WriteFrame(connection, wire.KindHandshakeResponse, response.Bytes())
+}
+
+/**
+ * Challenge the dialer of an incoming connection and check its response.
+ *
+ * @param connection The incoming connection.
+ * @return ID of the peer bound to the connection.
+ * @return error If the response is not received in time, or the IDs or the MAC are invalid.
+ */
+func (transport *TCPTransport) challengeHandshake(connection net.Conn) (int, error){
+	connection.SetDeadline(time.Now().Add(handshakeTimeout))
+	defer connection.SetDeadline(time.Time{})
+	challenge := make([]byte, 32)
+	_, err := rand.Read(challenge)
+	if (err != nil) {return -1, err}
+	err = wire.WriteFrame(connection, wire.KindHandshakeChallenge, challenge)
+	if (err != nil) {return -1, err}
+	kind, frame, err := wire.ReadFrame(connection)
+	if (err != nil) {return -1, err}
+	if (kind != wire.KindHandshakeResponse){
+		return -1, errors.New("Invalid handshake of the dialer.")
+	}
+	body, err := wire.NewReader(frame).ReadFrame(wire.KindHandshakeResponse)
+	if (err != nil) {return -1, err}
+	reader := wire.NewReader(body)
+	from, err := reader.ReadIndex()
+	if (err != nil) {return -1, err}
+	to, err := reader.ReadIndex()
+	if (err != nil) {return -1, err}
+	mac, err := reader.ReadBytes()
+	if (err != nil) {return -1, err}
+	if (from >= len(transport.addresses) || from == transport.id || to != transport.id){
+		return -1, errors.New("Invalid IDs in the handshake.")
+	}
+	if (!hmac.Equal(mac, transport.getHandshakeMAC(challenge, from, to))){
+		return -1, errors.New("Invalid MAC in the handshake.")
+	}
+	return from, nil
+}
+
+/**
+ * Calculate the MAC of a handshake, i.e. HMAC-SHA256 of the challenge and both IDs under the handshake key.
+ *
+ * @param challenge The challenge of the receiver.
+ * @param from ID of the dialer.
+ * @param to ID of the receiver.
+ * @return The MAC, empty if there is no handshake key.
+ */
+func (transport *TCPTransport) getHandshakeMAC(challenge []byte, from int, to int) []byte{
+	if (transport.handshakeKey == nil) {return []byte{}}
+	data := wire.NewWriter()
+	data.WriteBytes(challenge)
+	data.WriteIndex(from)
+	data.WriteIndex(to)
+	mac := hmac.New(sha256.New, transport.handshakeKey)
+	mac.Write(data.Bytes())
+	return mac.Sum(nil)
+}
+
+/**
+ * Remove a connection from the connections closed with the transport, the lock should be held.
+ *
+ * @param connection The connection.
+ */
+func (transport *TCPTransport) removeConnection(connection net.Conn){
+	for i := 0; i < len(transport.connections); i++{
+		if (transport.connections[i] == connection){
+			transport.connections = append(transport.connections[:i], transport.connections[i + 1:]...)
+			return
+		}
+	}
+}
+
+/**
+ * Remove a connection from the connections closed with the transport and close it.
+ *
+ * @param connection The connection.
+ */
+func (transport *TCPTransport) dropConnection(connection net.Conn){
+	transport.lock.Lock()
+	transport.removeConnection(connection)
+	transport.lock.Unlock()
+	connection.Close()
+}
+
+/**
+ * Accept connections from peers until the transport is closed.
+ */
+func (transport *TCPTransport) accept(){
+	for {
+		connection, err := transport.listener.Accept()
+		if (err != nil) {return}
+		transport.lock.Lock()
+		select {
+		case <-transport.closed:
+			// Close has already closed the connections, so the new one would leak
+			transport.lock.Unlock()
+			connection.Close()
+			return
+		default:
+		}
+		transport.connections = append(transport.connections, connection)
+		transport.lock.Unlock()
+		go transport.read(connection)
+	}
+}
+
+/**
+ * Check the handshake of an incoming connection, then decode the messages from the bound peer and put them into the inbox.
+ *
+ * @param connection The incoming connection.
+ */
+func (transport *TCPTransport) read(connection net.Conn){
+	peer, err := transport.challengeHandshake(connection)
+	if (err != nil){
+		transport.dropConnection(connection)
+		return
+	}
+	for {
+		kind, frame, err := wire.ReadFrame(connection)
+		if (err != nil){
+			transport.dropConnection(connection)
+			return
+		}
+		if (kind != wire.KindMessage) {continue}
+		message := new(Message)
+		err = message.UnmarshalBinary(frame)
+		if (err != nil || message.from != peer || message.to != transport.id) {continue}
+		select {
+		case transport.inbox <- message:
+		case <-transport.closed:
+			return
+		}
+	}
+}
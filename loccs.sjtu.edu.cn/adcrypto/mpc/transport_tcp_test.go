@@ -0,0 +1,142 @@
+package mpc
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+func TestTCPTransportHandshake(t *testing.T) {
+	transports, addresses := newTCPTransports(t, 3)
+	for i := 0; i < 3; i++{
+		defer transports[i].Close()
+		transports[i].SetHandshakeKey([]byte("shared key"))
+	}
+
+	// a peer with a wrong key is disconnected after the handshake
+	connection, err := net.Dial("tcp", addresses[0])
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when dialing: %s", err))}
+	defer connection.Close()
+	stranger := &TCPTransport{id: 1, handshakeKey: []byte("wrong key")}
+	err = stranger.answerHandshake(connection, 0)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when answering the handshake: %s", err))}
+	connection.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = connection.Read(make([]byte, 1))
+	if err == nil {t.Error("Connection with a wrong MAC should be closed.")}
+
+	// a peer bound to ID 1 cannot send messages as participant 2
+	connection, err = net.Dial("tcp", addresses[0])
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when dialing: %s", err))}
+	defer connection.Close()
+	err = transports[1].answerHandshake(connection, 0)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when answering the handshake: %s", err))}
+	for _, from := range([]int{2, 1}){
+		frame, _ := NewMessage(MessageInput, from, 0, big.NewInt(int64(from))).MarshalBinary()
+		_, err = connection.Write(frame)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when writing: %s", err))}
+	}
+	message, err := transports[0].Receive()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when receiving: %s", err))}
+	if (message.GetFrom() != 1) {t.Error("Message sent as another participant should be dropped.")}
+
+	// the transports with the same key talk to each other
+	err = transports[2].Send(NewMessage(MessageOutput, 2, 0, big.NewInt(7)))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when sending: %s", err))}
+	message, err = transports[0].Receive()
+	if (err != nil || message.GetFrom() != 2 || message.GetValue().(*big.Int).Int64() != 7) {t.Error("Message is received wrongly.")}
+}
+
+func TestTCPTransportUnreachablePeer(t *testing.T) {
+	transports, addresses := newTCPTransports(t, 2)
+	for i := 0; i < 2; i++{
+		defer transports[i].Close()
+	}
+	// nobody listens on the address of participant 2
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	addresses = append(addresses, listener.Addr().String())
+	listener.Close()
+	sender, err := NewTCPTransportWithListener(0, addresses, mustListen(t))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing TCPTransport: %s", err))}
+	defer sender.Close()
+	sender.SetDialTimeout(3 * time.Second)
+
+	go sender.Send(NewMessage(MessageInput, 0, 2, big.NewInt(1)))
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	err = sender.Send(NewMessage(MessageInput, 0, 1, big.NewInt(1)))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when sending: %s", err))}
+	if (time.Since(start) > time.Second) {t.Error("Dialing an unreachable peer should not block sending to others.")}
+	_, err = transports[1].Receive()
+	if err != nil {t.Error(fmt.Sprintf("Error happens when receiving: %s", err))}
+}
+
+func TestTCPTransportRedial(t *testing.T) {
+	transports, addresses := newTCPTransports(t, 2)
+	defer transports[0].Close()
+	err := transports[0].Send(NewMessage(MessageInput, 0, 1, big.NewInt(1)))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when sending: %s", err))}
+	transports[1].Receive()
+
+	// the peer restarts, the dead connection is dropped after a failed write
+	transports[1].Close()
+	for i := 0; i < 100 && err == nil; i++{
+		err = transports[0].Send(NewMessage(MessageInput, 0, 1, big.NewInt(1)))
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err == nil {t.Fatal("Sending to a closed peer should fail.")}
+	restarted, err := NewTCPTransport(1, addresses)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing TCPTransport: %s", err))}
+	defer restarted.Close()
+	err = transports[0].Send(NewMessage(MessageOutput, 0, 1, big.NewInt(2)))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when sending to the restarted peer: %s", err))}
+	message, err := restarted.Receive()
+	if (err != nil || message.GetMessageType() != MessageOutput) {t.Error("Message is not received by the restarted peer.")}
+}
+
+func TestTCPTransportDroppedConnection(t *testing.T) {
+	transports, addresses := newTCPTransports(t, 2)
+	defer transports[0].Close()
+	defer transports[1].Close()
+
+	// the incoming connection is forgotten once the peer hangs up
+	connection, err := net.Dial("tcp", addresses[0])
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when dialing: %s", err))}
+	err = transports[1].answerHandshake(connection, 0)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when answering the handshake: %s", err))}
+	connection.Close()
+	count := 1
+	for i := 0; i < 100 && count != 0; i++{
+		time.Sleep(10 * time.Millisecond)
+		transports[0].lock.Lock()
+		count = len(transports[0].connections)
+		transports[0].lock.Unlock()
+	}
+	if (count != 0) {t.Error("Connection closed by the peer should be removed.")}
+}
+
+/**
+ * Construct TCP transports listening on free loopback ports.
+ */
+func newTCPTransports(t *testing.T, participantCount int) ([]*TCPTransport, []string) {
+	listeners := make([]net.Listener, participantCount)
+	addresses := make([]string, participantCount)
+	for i := 0; i < participantCount; i++{
+		listeners[i] = mustListen(t)
+		addresses[i] = listeners[i].Addr().String()
+	}
+	transports := make([]*TCPTransport, participantCount)
+	for i := 0; i < participantCount; i++{
+		transport, err := NewTCPTransportWithListener(i, addresses, listeners[i])
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing TCPTransport: %s", err))}
+		transports[i] = transport
+	}
+	return transports, addresses
+}
+
+func mustListen(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when listening on loopback: %s", err))}
+	return listener
+}
@@ -0,0 +1,128 @@
+package poly
+
+import (
+	"math/big"
+)
+
+/**
+ * Interface for the prime field <i>Zp</i> whose elements have type <i>E</i>.
+ * <p>
+ * The polynomials, Lagrange interpolations and linear equation systems over <i>Zp</i> in this package are implemented once
+ * by the generic classes (<code>FieldPolynomial</code>, <code>FieldLagrangeInterpolation</code>, <code>FieldLinearEquationSystem</code>
+ * and <code>FieldSubproductTree</code>), which are parametrised by the element type, so passing an int to a BigInt field is
+ * rejected by the compiler. The classes taking elements as interface{} (<code>PolynomialInt</code>, <code>LagrangeInterpolationBigInt</code>,
+ * ...) are views of the generic classes over <code>IntField</code>, <code>Uint64Field</code> or <code>BigIntField</code>, which
+ * only check the types of elements at runtime. All arithmetic on the elements goes through the field.
+ * <p>
+ * The results of the operations are always in [0, <i>p</i>), and the operands may be any representatives.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type Field[E any] interface {
+	/**
+	 * Get the modulus.
+	 *
+	 * @return Modulus <i>p</i>.
+	 */
+	GetModulus() E
+
+	/**
+	 * Get the element of value 0.
+	 *
+	 * @return 0.
+	 */
+	Zero() E
+
+	/**
+	 * Get the element of value 1.
+	 *
+	 * @return 1.
+	 */
+	One() E
+
+	/**
+	 * Convert an int to an element.
+	 *
+	 * @param n The int, may be negative.
+	 * @return <i>n</i> mod <i>p</i>.
+	 */
+	FromInt(n int) E
+
+	/**
+	 * Convert an element to a big integer, e.g. for comparing it with bounds or using it as an exponent.
+	 *
+	 * @param a The element.
+	 * @return <i>a</i> as a new big.Int, not reduced modulo <i>p</i>.
+	 */
+	ToBigInt(a E) *big.Int
+
+	/**
+	 * Calculate <i>a</i> + <i>b</i> mod <i>p</i>.
+	 *
+	 * @param a The first element.
+	 * @param b The second element.
+	 * @return The sum.
+	 */
+	Add(a E, b E) E
+
+	/**
+	 * Calculate <i>a</i> - <i>b</i> mod <i>p</i>.
+	 *
+	 * @param a The first element.
+	 * @param b The second element.
+	 * @return The difference.
+	 */
+	Subtract(a E, b E) E
+
+	/**
+	 * Calculate <i>a</i> * <i>b</i> mod <i>p</i>.
+	 *
+	 * @param a The first element.
+	 * @param b The second element.
+	 * @return The product.
+	 */
+	Multiply(a E, b E) E
+
+	/**
+	 * Calculate -<i>a</i> mod <i>p</i>.
+	 *
+	 * @param a The element.
+	 * @return The negation.
+	 */
+	Negate(a E) E
+
+	/**
+	 * Calculate <i>a</i><sup>-1</sup> mod <i>p</i>.
+	 *
+	 * @param a The element.
+	 * @return The inverse.
+	 * @return error If <i>a</i> = 0 mod <i>p</i>.
+	 */
+	Inverse(a E) (E, error)
+
+	/**
+	 * Test if two elements are equal modulo <i>p</i>.
+	 *
+	 * @param a The first element.
+	 * @param b The second element.
+	 * @return True if <i>a</i> = <i>b</i> mod <i>p</i>, otherwise return false.
+	 */
+	Equal(a E, b E) bool
+
+	/**
+	 * Test if an element is 0 modulo <i>p</i>.
+	 *
+	 * @param a The element.
+	 * @return True if <i>a</i> = 0 mod <i>p</i>, otherwise return false.
+	 */
+	IsZero(a E) bool
+
+	/**
+	 * Choose a uniformly random element.
+	 *
+	 * @return The element in [0, <i>p</i>).
+	 * @return error If random numbers cannot be generated.
+	 */
+	Random() (E, error)
+}
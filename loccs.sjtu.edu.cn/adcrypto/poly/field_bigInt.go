@@ -0,0 +1,181 @@
+package poly
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+/**
+ * This class implements the prime field <i>Zp</i> with math/big elements.
+ * <p>
+ * Every operation returns a new BigInt, the operands are never modified.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type BigIntField struct {
+	/**
+	 * Modulus <i>p</i>, must be prime.
+	 */
+	modulus *big.Int
+}
+
+/**
+ * Construct the prime field with a BigInt modulus.
+ *
+ * @param modulus Modulus <i>p</i>.
+ * @return The constructed BigIntField
+ * @return error If the modulus is not a prime greater than 2.
+ */
+func NewBigIntField(modulus *big.Int) (*BigIntField, error){
+	if (modulus == nil || modulus.Cmp(big.NewInt(2)) <= 0){
+		return nil, errors.New("Modulus should be greater than 2.")
+	}
+	if (!modulus.ProbablyPrime(20)){
+		return nil, errors.New("Modulus should be prime.")
+	}
+	return &BigIntField{modulus: new(big.Int).Set(modulus)}, nil
+}
+
+/**
+ * Get the modulus.
+ *
+ * @return A copy of modulus <i>p</i>.
+ */
+func (field *BigIntField) GetModulus() *big.Int{
+	return new(big.Int).Set(field.modulus)
+}
+
+/**
+ * Get the element of value 0.
+ *
+ * @return A new big.Int of value 0.
+ */
+func (field *BigIntField) Zero() *big.Int{
+	return big.NewInt(0)
+}
+
+/**
+ * Get the element of value 1.
+ *
+ * @return A new big.Int of value 1.
+ */
+func (field *BigIntField) One() *big.Int{
+	return big.NewInt(1)
+}
+
+/**
+ * Convert an int to an element.
+ *
+ * @param n The int, may be negative.
+ * @return <i>n</i> mod <i>p</i>.
+ */
+func (field *BigIntField) FromInt(n int) *big.Int{
+	feedback := big.NewInt(int64(n))
+	return feedback.Mod(feedback, field.modulus)
+}
+
+/**
+ * Convert an element to a big integer.
+ *
+ * @param a The element.
+ * @return <i>a</i> as a new big.Int, not reduced modulo <i>p</i>.
+ */
+func (field *BigIntField) ToBigInt(a *big.Int) *big.Int{
+	return new(big.Int).Set(a)
+}
+
+/**
+ * Calculate <i>a</i> + <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The sum.
+ */
+func (field *BigIntField) Add(a *big.Int, b *big.Int) *big.Int{
+	feedback := big.NewInt(0)
+	return feedback.Add(a, b).Mod(feedback, field.modulus)
+}
+
+/**
+ * Calculate <i>a</i> - <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The difference.
+ */
+func (field *BigIntField) Subtract(a *big.Int, b *big.Int) *big.Int{
+	feedback := big.NewInt(0)
+	return feedback.Sub(a, b).Mod(feedback, field.modulus)
+}
+
+/**
+ * Calculate <i>a</i> * <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The product.
+ */
+func (field *BigIntField) Multiply(a *big.Int, b *big.Int) *big.Int{
+	feedback := big.NewInt(0)
+	return feedback.Mul(a, b).Mod(feedback, field.modulus)
+}
+
+/**
+ * Calculate -<i>a</i> mod <i>p</i>.
+ *
+ * @param a The element.
+ * @return The negation.
+ */
+func (field *BigIntField) Negate(a *big.Int) *big.Int{
+	feedback := big.NewInt(0)
+	return feedback.Neg(a).Mod(feedback, field.modulus)
+}
+
+/**
+ * Calculate <i>a</i><sup>-1</sup> mod <i>p</i>.
+ *
+ * @param a The element.
+ * @return The inverse.
+ * @return error If <i>a</i> = 0 mod <i>p</i>.
+ */
+func (field *BigIntField) Inverse(a *big.Int) (*big.Int, error){
+	feedback := big.NewInt(0)
+	feedback.Mod(a, field.modulus)
+	if (feedback.Sign() == 0 || feedback.ModInverse(feedback, field.modulus) == nil){
+		return nil, errors.New("Error happens when calculating an inverse.")
+	}
+	return feedback, nil
+}
+
+/**
+ * Test if two elements are equal modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return True if <i>a</i> = <i>b</i> mod <i>p</i>, otherwise return false.
+ */
+func (field *BigIntField) Equal(a *big.Int, b *big.Int) bool{
+	return field.Subtract(a, b).Sign() == 0
+}
+
+/**
+ * Test if an element is 0 modulo <i>p</i>.
+ *
+ * @param a The element.
+ * @return True if <i>a</i> = 0 mod <i>p</i>, otherwise return false.
+ */
+func (field *BigIntField) IsZero(a *big.Int) bool{
+	return big.NewInt(0).Mod(a, field.modulus).Sign() == 0
+}
+
+/**
+ * Choose a uniformly random element.
+ *
+ * @return The element in [0, <i>p</i>).
+ * @return error If random numbers cannot be generated.
+ */
+func (field *BigIntField) Random() (*big.Int, error){
+	return rand.Int(rand.Reader, field.modulus)
+}
@@ -0,0 +1,187 @@
+package poly
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+/**
+ * This class implements the prime field <i>Zp</i> with int elements.
+ * <p>
+ * The products are calculated by <code>MultiplyModUint64</code>, so any prime modulus that fits in int is supported.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type IntField struct {
+	/**
+	 * Modulus <i>p</i>, must be prime.
+	 */
+	modulus int
+}
+
+/**
+ * Construct the prime field with an int modulus.
+ *
+ * @param modulus Modulus <i>p</i>.
+ * @return The constructed IntField
+ * @return error If the modulus is not a prime greater than 2.
+ */
+func NewIntField(modulus int) (*IntField, error){
+	if (modulus <= 2){
+		return nil, errors.New("Modulus should be greater than 2.")
+	}
+	if (!big.NewInt(int64(modulus)).ProbablyPrime(20)){
+		return nil, errors.New("Modulus should be prime.")
+	}
+	return &IntField{modulus: modulus}, nil
+}
+
+/**
+ * Get the modulus.
+ *
+ * @return Modulus <i>p</i>.
+ */
+func (field *IntField) GetModulus() int{
+	return field.modulus
+}
+
+/**
+ * Get the element of value 0.
+ *
+ * @return 0.
+ */
+func (field *IntField) Zero() int{
+	return 0
+}
+
+/**
+ * Get the element of value 1.
+ *
+ * @return 1.
+ */
+func (field *IntField) One() int{
+	return 1
+}
+
+/**
+ * Convert an int to an element.
+ *
+ * @param n The int, may be negative and anywhere in the range of int.
+ * @return <i>n</i> mod <i>p</i>.
+ */
+func (field *IntField) FromInt(n int) int{
+	feedback := n % field.modulus
+	if (feedback < 0) {feedback += field.modulus}
+	return feedback
+}
+
+/**
+ * Convert an element to a big integer.
+ *
+ * @param a The element.
+ * @return <i>a</i> as a new big.Int, not reduced modulo <i>p</i>.
+ */
+func (field *IntField) ToBigInt(a int) *big.Int{
+	return big.NewInt(int64(a))
+}
+
+/**
+ * Calculate <i>a</i> + <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The sum.
+ */
+func (field *IntField) Add(a int, b int) int{
+	return int(AddModUint64(field.toUint64(a), field.toUint64(b), uint64(field.modulus)))
+}
+
+/**
+ * Calculate <i>a</i> - <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The difference.
+ */
+func (field *IntField) Subtract(a int, b int) int{
+	return int(SubtractModUint64(field.toUint64(a), field.toUint64(b), uint64(field.modulus)))
+}
+
+/**
+ * Calculate <i>a</i> * <i>b</i> mod <i>p</i> with a 128-bit intermediate product, so it does not overflow for any int modulus.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The product.
+ */
+func (field *IntField) Multiply(a int, b int) int{
+	return int(MultiplyModUint64(field.toUint64(a), field.toUint64(b), uint64(field.modulus)))
+}
+
+/**
+ * Calculate -<i>a</i> mod <i>p</i>.
+ *
+ * @param a The element.
+ * @return The negation.
+ */
+func (field *IntField) Negate(a int) int{
+	return field.Subtract(0, a)
+}
+
+/**
+ * Calculate <i>a</i><sup>-1</sup> mod <i>p</i>.
+ *
+ * @param a The element.
+ * @return The inverse.
+ * @return error If <i>a</i> = 0 mod <i>p</i>.
+ */
+func (field *IntField) Inverse(a int) (int, error){
+	feedback, err := InverseModUint64(field.toUint64(a), uint64(field.modulus))
+	if (err != nil) {return 0, err}
+	return int(feedback), nil
+}
+
+/**
+ * Test if two elements are equal modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return True if <i>a</i> = <i>b</i> mod <i>p</i>, otherwise return false.
+ */
+func (field *IntField) Equal(a int, b int) bool{
+	return field.FromInt(a) == field.FromInt(b)
+}
+
+/**
+ * Test if an element is 0 modulo <i>p</i>.
+ *
+ * @param a The element.
+ * @return True if <i>a</i> = 0 mod <i>p</i>, otherwise return false.
+ */
+func (field *IntField) IsZero(a int) bool{
+	return a % field.modulus == 0
+}
+
+/**
+ * Choose a uniformly random element.
+ *
+ * @return The element in [0, <i>p</i>).
+ * @return error If random numbers cannot be generated.
+ */
+func (field *IntField) Random() (int, error){
+	feedback, err := rand.Int(rand.Reader, big.NewInt(int64(field.modulus)))
+	if (err != nil) {return 0, err}
+	return int(feedback.Int64()), nil
+}
+
+/**
+ * Convert an element to its representative in [0, <i>p</i>) as uint64.
+ *
+ * @param a The element.
+ * @return <i>a</i> mod <i>p</i>.
+ */
+func (field *IntField) toUint64(a int) uint64{
+	return uint64(field.FromInt(a))
+}
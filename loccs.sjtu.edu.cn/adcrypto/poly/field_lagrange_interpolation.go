@@ -0,0 +1,190 @@
+package poly
+
+import (
+	"errors"
+)
+
+/**
+ * This class implements the Lagrange interpolation over the field <i>Zp</i> with elements of type <i>E</i>.
+ * <p>
+ * It implements the Lagrange interpolation described in <code>LagrangeInterpolation</code> for all element types, and
+ * <code>LagrangeInterpolationInt</code>, <code>LagrangeInterpolationBigInt</code> and <code>LagrangeInterpolationUint64</code> are
+ * views of it, see <code>FieldLagrangeInterpolationCalculator</code>. The barycentric weights
+ * <i>w<sub>i</sub></i> = 1 / &prod;<sub><i>j</i>&ne;<i>i</i></sub>(<i>x<sub>i</sub></i> - <i>x<sub>j</sub></i>) are precomputed when the object is constructed.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FieldLagrangeInterpolation[E any] struct {
+	/**
+	 * The field <i>Zp</i>.
+	 */
+	field Field[E]
+
+	/**
+	 * The distinct evaluation points <i>x</i><sub>1</sub>, <i>x</i><sub>2</sub>, ..., <i>x<sub>k</sub></i> in [0, <i>p</i>).
+	 */
+	points []E
+
+	/**
+	 * The barycentric weights <i>w</i><sub>1</sub>, <i>w</i><sub>2</sub>, ..., <i>w<sub>k</sub></i>.
+	 */
+	weights []E
+}
+
+/**
+ * Construct the Lagrange interpolation on the evaluation points, and precompute the barycentric weights.
+ *
+ * @param field The field <i>Zp</i>.
+ * @param points The evaluation points, should be distinct modulo <i>p</i>.
+ * @return liFeedback The constructed FieldLagrangeInterpolation
+ * @return error If the field or the points are invalid.
+ */
+func NewFieldLagrangeInterpolation[E any](field Field[E], points []E) (*FieldLagrangeInterpolation[E], error){
+	if (field == nil){
+		return nil, errors.New("Field should not be nil.")
+	}
+	if (len(points) == 0){
+		return nil, errors.New("At least one evaluation point should be provided.")
+	}
+	liFeedback := &FieldLagrangeInterpolation[E]{field: field}
+	liFeedback.points = make([]E, len(points))
+	liFeedback.weights = make([]E, len(points))
+	for i := 0; i < len(points); i++{
+		liFeedback.points[i] = field.Add(points[i], field.Zero())
+	}
+	for i := 0; i < len(points); i++{
+		denominator := field.One()
+		for j := 0; j < len(points); j++{
+			if (j == i) {continue}
+			difference := field.Subtract(liFeedback.points[i], liFeedback.points[j])
+			if (field.IsZero(difference)) {return nil, errors.New("Evaluation points should be distinct.")}
+			denominator = field.Multiply(denominator, difference)
+		}
+		inverse, err := field.Inverse(denominator)
+		if (err != nil) {return nil, err}
+		liFeedback.weights[i] = inverse
+	}
+	return liFeedback, nil
+}
+
+/**
+ * Get the evaluation points.
+ *
+ * @return The evaluation points in [0, <i>p</i>).
+ */
+func (li *FieldLagrangeInterpolation[E]) GetPoints() []E{
+	feedback := make([]E, len(li.points))
+	copy(feedback, li.points)
+	return feedback
+}
+
+/**
+ * Calculate the Lagrange coefficients <i>L</i><sub>1</sub>(<i>x</i>), ..., <i>L<sub>k</sub></i>(<i>x</i>) at a point.
+ * <p>
+ * The products are calculated with prefix and suffix products, so no inverse is needed and <i>x</i> may be one of the
+ * evaluation points.
+ *
+ * @param x The point.
+ * @return The Lagrange coefficients in [0, <i>p</i>).
+ */
+func (li *FieldLagrangeInterpolation[E]) GetLagrangeCoefficients(x E) []E{
+	count := len(li.points)
+	differences := make([]E, count)
+	for i := 0; i < count; i++{
+		differences[i] = li.field.Subtract(x, li.points[i])
+	}
+	// suffix[i] = (x - x_i) ... (x - x_k)
+	suffix := make([]E, count + 1)
+	suffix[count] = li.field.One()
+	for i := count - 1; i >= 0; i--{
+		suffix[i] = li.field.Multiply(suffix[i + 1], differences[i])
+	}
+	feedback := make([]E, count)
+	prefix := li.field.One()
+	for i := 0; i < count; i++{
+		feedback[i] = li.field.Multiply(li.field.Multiply(prefix, suffix[i + 1]), li.weights[i])
+		prefix = li.field.Multiply(prefix, differences[i])
+	}
+	return feedback
+}
+
+/**
+ * Calculate <i>f</i>(<i>x</i>) of the polynomial passing through the values.
+ *
+ * @param x The point.
+ * @param values The values <i>y</i><sub>1</sub>, <i>y</i><sub>2</sub>, ..., <i>y<sub>k</sub></i> on the evaluation points.
+ * @return <i>f</i>(<i>x</i>).
+ * @return error If the number of values is not the number of points.
+ */
+func (li *FieldLagrangeInterpolation[E]) Interpolate(x E, values []E) (E, error){
+	if (len(values) != len(li.points)){
+		return li.field.Zero(), errors.New("Number of values should be equal to number of evaluation points.")
+	}
+	coefficients := li.GetLagrangeCoefficients(x)
+	feedback := li.field.Zero()
+	for i := 0; i < len(values); i++{
+		feedback = li.field.Add(feedback, li.field.Multiply(coefficients[i], values[i]))
+	}
+	return feedback, nil
+}
+
+/**
+ * Calculate the polynomial <i>f</i> of degree less than <i>k</i> passing through the values.
+ *
+ * @param values The values <i>y</i><sub>1</sub>, <i>y</i><sub>2</sub>, ..., <i>y<sub>k</sub></i> on the evaluation points.
+ * @return The polynomial, without leading zeros.
+ * @return error If the number of values is not the number of points.
+ */
+func (li *FieldLagrangeInterpolation[E]) InterpolatePolynomial(values []E) (*FieldPolynomial[E], error){
+	coefficients, err := li.interpolateCoefficients(values)
+	if (err != nil) {return nil, err}
+	return newFieldPolynomial(li.field, coefficients), nil
+}
+
+/**
+ * Calculate the coefficients of the polynomial passing through the values.
+ * <p>
+ * With <i>l</i>(<i>x</i>) = &prod;(<i>x</i> - <i>x<sub>j</sub></i>), <i>f</i> is the sum of <i>w<sub>i</sub></i><i>y<sub>i</sub></i><i>l</i>(<i>x</i>)/(<i>x</i> - <i>x<sub>i</sub></i>),
+ * where each quotient is calculated by synthetic division in O(<i>k</i>).
+ *
+ * @param values The values on the evaluation points.
+ * @return The <i>k</i> coefficients of <i>f</i>, leading coefficients may be 0.
+ * @return error If the number of values is not the number of points.
+ */
+func (li *FieldLagrangeInterpolation[E]) interpolateCoefficients(values []E) ([]E, error){
+	count := len(li.points)
+	if (len(values) != count){
+		return nil, errors.New("Number of values should be equal to number of evaluation points.")
+	}
+	// master[i] is the coefficient of x^i in l(x)
+	master := make([]E, count + 1)
+	master[0] = li.field.One()
+	for i := 1; i <= count; i++{
+		master[i] = li.field.Zero()
+	}
+	for j := 0; j < count; j++{
+		for i := j + 1; i > 0; i--{
+			master[i] = li.field.Subtract(master[i - 1], li.field.Multiply(li.points[j], master[i]))
+		}
+		master[0] = li.field.Negate(li.field.Multiply(li.points[j], master[0]))
+	}
+
+	coefficients := make([]E, count)
+	quotient := make([]E, count)
+	for i := 0; i < count; i++{
+		coefficients[i] = li.field.Zero()
+	}
+	for i := 0; i < count; i++{
+		scale := li.field.Multiply(li.weights[i], values[i])
+		if (li.field.IsZero(scale)) {continue}
+		quotient[count - 1] = master[count]
+		for j := count - 1; j > 0; j--{
+			quotient[j - 1] = li.field.Add(master[j], li.field.Multiply(li.points[i], quotient[j]))
+		}
+		for j := 0; j < count; j++{
+			coefficients[j] = li.field.Add(coefficients[j], li.field.Multiply(scale, quotient[j]))
+		}
+	}
+	return coefficients, nil
+}
@@ -0,0 +1,42 @@
+package poly
+
+import (
+	"testing"
+	"math/big"
+	"fmt"
+)
+
+func TestFieldLagrangeInterpolation(t *testing.T) {
+	intField, _ := NewIntField(2147483647)
+	uint64Field, _ := NewUint64Field(primesUint64[2])
+	bigIntField, _ := NewBigIntField(new(big.Int).SetUint64(primesUint64[2]))
+	t.Run("TestFieldLagrangeInterpolationInt", testFieldLagrangeInterpolationFunc[int](intField, 10))
+	t.Run("TestFieldLagrangeInterpolationUint64", testFieldLagrangeInterpolationFunc[uint64](uint64Field, 10))
+	t.Run("TestFieldLagrangeInterpolationBigInt", testFieldLagrangeInterpolationFunc[*big.Int](bigIntField, 10))
+}
+
+func testFieldLagrangeInterpolationFunc[E any](field Field[E], degree int) func(t *testing.T) {
+	return func(t *testing.T) {
+		f := randomFieldPolynomial(field, degree)
+		points := make([]E, degree + 1)
+		values := make([]E, degree + 1)
+		for i := 0; i <= degree; i++{
+			points[i] = field.FromInt(-i - 1)
+			values[i] = f.Calculate(points[i])
+		}
+		interpolation, err := NewFieldLagrangeInterpolation(field, points)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a FieldLagrangeInterpolation: %s", err))}
+		for _, n := range []int{0, 1, -1, 123456}{
+			x := field.FromInt(n)
+			result, err := interpolation.Interpolate(x, values)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when interpolating: %s", err))}
+			if (!field.Equal(result, f.Calculate(x))){
+				t.Error(fmt.Sprintf("Interpolated Result is False on %d.", n))
+			}
+		}
+		_, err = interpolation.Interpolate(field.Zero(), values[1:])
+		if err == nil {t.Error("Values of invalid number should not be accepted.")}
+		_, err = NewFieldLagrangeInterpolation(field, []E{field.One(), field.Add(field.GetModulus(), field.One())})
+		if err == nil {t.Error("Points which are not distinct should not be accepted.")}
+	}
+}
@@ -0,0 +1,138 @@
+package poly
+
+import (
+	"errors"
+)
+
+/**
+ * This class implements a system of linear equations over the field <i>Zp</i> with elements of type <i>E</i>.
+ * <p>
+ * It implements the system described in <code>LinearEquationSystem</code> for all element types, and is solved by Gauss
+ * elimination when a single solution exists. <code>LinearEquationSystemInt</code>, <code>LinearEquationSystemBigInt</code> and
+ * <code>LinearEquationSystemUint64</code> are views of it, see <code>FieldLinearEquationSystemCalculator</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FieldLinearEquationSystem[E any] struct {
+	/**
+	 * The field <i>Zp</i>.
+	 */
+	field Field[E]
+
+	/**
+	 * Number of variables.
+	 */
+	variableCount int
+
+	/**
+	 * Coefficients of the equations, reduced modulo <i>p</i>.
+	 */
+	coefficients [][]E
+
+	/**
+	 * Constant terms of the equations, reduced modulo <i>p</i>.
+	 */
+	constants []E
+}
+
+/**
+ * Construct a system of linear equations with number of variable count over a field.
+ *
+ * @param field The field <i>Zp</i>.
+ * @param variableCount Number of variables.
+ * @return The constructed FieldLinearEquationSystem
+ * @return error If the field or number of variables is invalid.
+ */
+func NewFieldLinearEquationSystem[E any](field Field[E], variableCount int) (*FieldLinearEquationSystem[E], error){
+	if (field == nil){
+		return nil, errors.New("Field should not be nil.")
+	}
+	if (variableCount < 1){
+		return nil, errors.New("Number of variables should be greater than 0.")
+	}
+	return &FieldLinearEquationSystem[E]{field: field, variableCount: variableCount}, nil
+}
+
+/**
+ * Add a linear equation to the system.
+ *
+ * @param coefficients Coefficients of the equation.
+ * @param constant Constant term of the equation.
+ * @return error If the number of coefficients is not the number of variables.
+ */
+func (les *FieldLinearEquationSystem[E]) AddEquation(coefficients []E, constant E) error{
+	if (len(coefficients) != les.variableCount){
+		return errors.New("Number of coefficients should be equals to Number of variables.")
+	}
+	reduced := make([]E, les.variableCount)
+	for i := 0; i < les.variableCount; i++{
+		reduced[i] = les.field.Add(coefficients[i], les.field.Zero())
+	}
+	les.coefficients = append(les.coefficients, reduced)
+	les.constants = append(les.constants, les.field.Add(constant, les.field.Zero()))
+	return nil
+}
+
+/**
+ * Clear all equations in the system.
+ */
+func (les *FieldLinearEquationSystem[E]) Clear(){
+	les.coefficients = nil
+	les.constants = nil
+}
+
+/**
+ * Solve system of linear equation by Gauss elimination.
+ * <p>
+ * All equations are used, the redundant ones must be consistent with the others.
+ *
+ * @return The solution if there is single solution exists.
+ * @return error If the equations are not enough, or no solution or infinite solutions exist.
+ */
+func (les *FieldLinearEquationSystem[E]) Solve() ([]E, error){
+	if (len(les.constants) < les.variableCount){
+		return nil, errors.New("Linear Equations are not enough for solving")
+	}
+	field := les.field
+	rows := len(les.constants)
+	count := les.variableCount
+	// copy the equations, the last column is the constant term
+	matrix := make([][]E, rows)
+	for i := 0; i < rows; i++{
+		matrix[i] = make([]E, count + 1)
+		copy(matrix[i], les.coefficients[i])
+		matrix[i][count] = les.constants[i]
+	}
+
+	// Gauss-Jordan elimination
+	for i := 0; i < count; i++{
+		k := i
+		for ; k < rows && field.IsZero(matrix[k][i]); k++{
+		}
+		if (k == rows) {return nil, errors.New("Infinite solutions for this LinearEquationSystem.")}
+		matrix[i], matrix[k] = matrix[k], matrix[i]
+		inverse, err := field.Inverse(matrix[i][i])
+		if (err != nil) {return nil, err}
+		for j := i; j <= count; j++{
+			matrix[i][j] = field.Multiply(matrix[i][j], inverse)
+		}
+		for k := 0; k < rows; k++{
+			if (k == i || field.IsZero(matrix[k][i])) {continue}
+			multiple := matrix[k][i]
+			for j := i; j <= count; j++{
+				matrix[k][j] = field.Subtract(matrix[k][j], field.Multiply(multiple, matrix[i][j]))
+			}
+		}
+	}
+
+	// redundant equations are reduced to 0 = b
+	for i := count; i < rows; i++{
+		if (!field.IsZero(matrix[i][count])) {return nil, errors.New("No solutions for this LinearEquationSystem.")}
+	}
+	feedback := make([]E, count)
+	for i := 0; i < count; i++{
+		feedback[i] = matrix[i][count]
+	}
+	return feedback, nil
+}
@@ -0,0 +1,60 @@
+package poly
+
+import (
+	"testing"
+	"math/big"
+	"fmt"
+)
+
+func TestFieldLinearEquationSystem(t *testing.T) {
+	intField, _ := NewIntField(2147483647)
+	uint64Field, _ := NewUint64Field(primesUint64[2])
+	bigIntField, _ := NewBigIntField(new(big.Int).SetUint64(primesUint64[2]))
+	t.Run("TestFieldLinearEquationSystemInt", testFieldLinearEquationSystemFunc[int](intField, 8))
+	t.Run("TestFieldLinearEquationSystemUint64", testFieldLinearEquationSystemFunc[uint64](uint64Field, 8))
+	t.Run("TestFieldLinearEquationSystemBigInt", testFieldLinearEquationSystemFunc[*big.Int](bigIntField, 8))
+}
+
+func testFieldLinearEquationSystemFunc[E any](field Field[E], variableCount int) func(t *testing.T) {
+	return func(t *testing.T) {
+		les, err := NewFieldLinearEquationSystem(field, variableCount)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a FieldLinearEquationSystem: %s", err))}
+		solution := make([]E, variableCount)
+		for i := 0; i < variableCount; i++{
+			solution[i], _ = field.Random()
+		}
+		// one more equation than variables, the first coefficient of the first equation is 0
+		for i := 0; i <= variableCount; i++{
+			coefficients := make([]E, variableCount)
+			constant := field.Zero()
+			for j := 0; j < variableCount; j++{
+				coefficients[j], _ = field.Random()
+				if (i == 0 && j == 0) {coefficients[j] = field.Zero()}
+				constant = field.Add(constant, field.Multiply(coefficients[j], solution[j]))
+			}
+			err = les.AddEquation(coefficients, constant)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding an equation: %s", err))}
+		}
+		result, err := les.Solve()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when solving: %s", err))}
+		if (fmt.Sprint(result) != fmt.Sprint(solution)){
+			t.Error(fmt.Sprintf("Solution is False, Result:%v ,Expected: %v", result, solution))
+		}
+
+		// an inconsistent equation
+		coefficients := make([]E, variableCount)
+		for j := 0; j < variableCount; j++{
+			coefficients[j] = field.Zero()
+		}
+		err = les.AddEquation(coefficients, field.One())
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding an equation: %s", err))}
+		_, err = les.Solve()
+		if err == nil {t.Error("Inconsistent system should not be solved.")}
+
+		les.Clear()
+		_, err = les.Solve()
+		if err == nil {t.Error("Empty system should not be solved.")}
+		err = les.AddEquation(coefficients[1:], field.One())
+		if err == nil {t.Error("Equation of invalid number of coefficients should not be accepted.")}
+	}
+}
@@ -0,0 +1,342 @@
+package poly
+
+import (
+	"errors"
+)
+
+/**
+ * This class implements the polynomial over the field <i>Zp</i> with elements of type <i>E</i>.
+ * <p>
+ * It implements the polynomial arithmetic for all element types: the coefficients and the variable have the element type
+ * of the field, so no type check of elements is needed at runtime. <code>PolynomialInt</code>, <code>PolynomialBigInt</code>
+ * and <code>PolynomialUint64</code> are views of it, see <code>FieldPolynomialCalculator</code>. The coefficients are kept
+ * without leading zeros, and the zero polynomial has degree 0.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FieldPolynomial[E any] struct {
+	/**
+	 * The field <i>Zp</i> of the coefficients.
+	 */
+	field Field[E]
+
+	/**
+	 * coefficients of the polynomial in [0, <i>p</i>), coefficients[i] contains <i>a<sub>i</sub></i>.
+	 */
+	coefficients []E
+}
+
+/**
+ * Construct a polynomial over a field from its coefficients.
+ *
+ * @param field The field <i>Zp</i>.
+ * @param coefficients Coefficients of the polynomial, coefficients[i] contains <i>a<sub>i</sub></i>.
+ * @return polyFeedback The constructed FieldPolynomial
+ * @return error If the field is nil or no coefficient is given.
+ */
+func NewFieldPolynomial[E any](field Field[E], coefficients []E) (*FieldPolynomial[E], error){
+	if (field == nil){
+		return nil, errors.New("Field should not be nil.")
+	}
+	if (len(coefficients) == 0){
+		return nil, errors.New("At least one coefficient should be provided.")
+	}
+	reduced := make([]E, len(coefficients))
+	for i := 0; i < len(coefficients); i++{
+		reduced[i] = field.Add(coefficients[i], field.Zero())
+	}
+	return newFieldPolynomial(field, reduced), nil
+}
+
+/**
+ * Construct a polynomial from reduced coefficients, the leading zeros are removed.
+ *
+ * @param field The field <i>Zp</i>.
+ * @param coefficients Coefficients in [0, <i>p</i>), which are owned by the polynomial afterwards.
+ * @return The polynomial.
+ */
+func newFieldPolynomial[E any](field Field[E], coefficients []E) *FieldPolynomial[E]{
+	count := len(coefficients)
+	for (count > 1 && field.IsZero(coefficients[count - 1])){
+		count--
+	}
+	if (count == 0) {coefficients, count = []E{field.Zero()}, 1}
+	return &FieldPolynomial[E]{field: field, coefficients: coefficients[:count]}
+}
+
+/**
+ * Get the field of the coefficients.
+ *
+ * @return The field <i>Zp</i>.
+ */
+func (poly *FieldPolynomial[E]) GetField() Field[E]{
+	return poly.field
+}
+
+/**
+ * Get the degree of the polynomial.
+ *
+ * @return The degree, 0 for the zero polynomial.
+ */
+func (poly *FieldPolynomial[E]) GetDegree() int{
+	return len(poly.coefficients) - 1
+}
+
+/**
+ * Get the coefficients of the polynomial.
+ *
+ * @return A copy of the coefficients, coefficients[i] contains <i>a<sub>i</sub></i>.
+ */
+func (poly *FieldPolynomial[E]) GetCoefficients() []E{
+	feedback := make([]E, len(poly.coefficients))
+	copy(feedback, poly.coefficients)
+	return feedback
+}
+
+/**
+ * Determine if the polynomial is the zero polynomial.
+ *
+ * @return True if all coefficients are 0, otherwise return false.
+ */
+func (poly *FieldPolynomial[E]) IsZero() bool{
+	return len(poly.coefficients) == 1 && poly.field.IsZero(poly.coefficients[0])
+}
+
+/**
+ * Calculate the result of the polynomial by Horner's method.
+ *
+ * @param x The value of variable <i>x</i>.
+ * @return <i>f</i>(<i>x</i>) mod <i>p</i>.
+ */
+func (poly *FieldPolynomial[E]) Calculate(x E) E{
+	feedback := poly.coefficients[len(poly.coefficients) - 1]
+	for i := len(poly.coefficients) - 2; i >= 0; i--{
+		feedback = poly.field.Add(poly.field.Multiply(feedback, x), poly.coefficients[i])
+	}
+	return poly.field.Add(feedback, poly.field.Zero())
+}
+
+/**
+ * Calculate the sum of this polynomial and another polynomial over the same field.
+ *
+ * @param other The other polynomial.
+ * @return The sum.
+ * @return error If the other polynomial is invalid.
+ */
+func (poly *FieldPolynomial[E]) Add(other *FieldPolynomial[E]) (*FieldPolynomial[E], error){
+	err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	return newFieldPolynomial(poly.field, poly.combine(other.coefficients, poly.field.Add)), nil
+}
+
+/**
+ * Calculate the difference of this polynomial and another polynomial over the same field.
+ *
+ * @param other The other polynomial, the subtrahend.
+ * @return The difference.
+ * @return error If the other polynomial is invalid.
+ */
+func (poly *FieldPolynomial[E]) Subtract(other *FieldPolynomial[E]) (*FieldPolynomial[E], error){
+	err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	return newFieldPolynomial(poly.field, poly.combine(other.coefficients, poly.field.Subtract)), nil
+}
+
+/**
+ * Calculate the product of this polynomial and another polynomial over the same field.
+ * <p>
+ * The number-theoretic transform is used if both polynomials are large and <i>p</i> is NTT-friendly,
+ * otherwise the schoolbook method is used.
+ *
+ * @param other The other polynomial.
+ * @return The product.
+ * @return error If the other polynomial is invalid.
+ */
+func (poly *FieldPolynomial[E]) Multiply(other *FieldPolynomial[E]) (*FieldPolynomial[E], error){
+	err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	if (len(poly.coefficients) >= nttThreshold && len(other.coefficients) >= nttThreshold &&
+		poly.isNTTSupported(len(poly.coefficients) + len(other.coefficients) - 1)){
+		return poly.MultiplyNTT(other)
+	}
+	coefficients := poly.zeros(len(poly.coefficients) + len(other.coefficients) - 1)
+	for i := 0; i < len(poly.coefficients); i++{
+		if (poly.field.IsZero(poly.coefficients[i])) {continue}
+		for j := 0; j < len(other.coefficients); j++{
+			coefficients[i + j] = poly.field.Add(coefficients[i + j], poly.field.Multiply(poly.coefficients[i], other.coefficients[j]))
+		}
+	}
+	return newFieldPolynomial(poly.field, coefficients), nil
+}
+
+/**
+ * Calculate the product of this polynomial and a scalar.
+ *
+ * @param scalar The scalar.
+ * @return The product.
+ */
+func (poly *FieldPolynomial[E]) ScalarMultiply(scalar E) *FieldPolynomial[E]{
+	coefficients := make([]E, len(poly.coefficients))
+	for i := 0; i < len(poly.coefficients); i++{
+		coefficients[i] = poly.field.Multiply(poly.coefficients[i], scalar)
+	}
+	return newFieldPolynomial(poly.field, coefficients)
+}
+
+/**
+ * Divide this polynomial by another polynomial over the same field.
+ * <p>
+ * The quotient <i>q</i> and the remainder <i>r</i> satisfy <i>f</i> = <i>qg</i> + <i>r</i>, where the degree of <i>r</i> is
+ * less than the degree of the divisor <i>g</i>, or <i>r</i> = 0.
+ * <p>
+ * Newton's iteration is used if both the quotient and the divisor are large and <i>p</i> is NTT-friendly,
+ * otherwise the schoolbook method is used.
+ *
+ * @param divisor The divisor.
+ * @return The quotient.
+ * @return The remainder.
+ * @return error If the divisor is invalid or zero, or its leading coefficient is not invertible.
+ */
+func (poly *FieldPolynomial[E]) Divide(divisor *FieldPolynomial[E]) (*FieldPolynomial[E], *FieldPolynomial[E], error){
+	err := poly.checkPolynomial(divisor)
+	if (err != nil) {return nil, nil, err}
+	if (divisor.IsZero()){
+		return nil, nil, errors.New("Divisor should not be zero polynomial.")
+	}
+	divisorDegree := divisor.GetDegree()
+	inverse, err := poly.field.Inverse(divisor.coefficients[divisorDegree])
+	if (err != nil) {return nil, nil, err}
+	remainder := poly.GetCoefficients()
+	if (len(remainder) - 1 < divisorDegree){
+		return newFieldPolynomial(poly.field, poly.zeros(1)), newFieldPolynomial(poly.field, remainder), nil
+	}
+	if (len(remainder) - divisorDegree >= nttThreshold && divisorDegree >= nttThreshold &&
+		poly.isNTTSupported(2 * len(remainder))){
+		return poly.divideNewton(remainder, divisor.coefficients)
+	}
+	quotient := poly.zeros(len(remainder) - divisorDegree)
+	for i := len(remainder) - 1; i >= divisorDegree; i--{
+		if (poly.field.IsZero(remainder[i])) {continue}
+		factor := poly.field.Multiply(remainder[i], inverse)
+		quotient[i - divisorDegree] = factor
+		for j := 0; j <= divisorDegree; j++{
+			remainder[i - divisorDegree + j] = poly.field.Subtract(remainder[i - divisorDegree + j],
+				poly.field.Multiply(factor, divisor.coefficients[j]))
+		}
+	}
+	return newFieldPolynomial(poly.field, quotient), newFieldPolynomial(poly.field, remainder[:divisorDegree]), nil
+}
+
+/**
+ * Calculate the greatest common divisor of this polynomial and another polynomial over the same field
+ * by the Euclidean algorithm.
+ *
+ * @param other The other polynomial.
+ * @return The monic GCD, or the zero polynomial if both polynomials are zero.
+ * @return error If the other polynomial is invalid, or some leading coefficient is not invertible.
+ */
+func (poly *FieldPolynomial[E]) GCD(other *FieldPolynomial[E]) (*FieldPolynomial[E], error){
+	err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	a, b := poly, other
+	for (!b.IsZero()){
+		_, remainder, err := a.Divide(b)
+		if (err != nil) {return nil, err}
+		a, b = b, remainder
+	}
+	if (a.IsZero()) {return a, nil}
+	inverse, err := poly.field.Inverse(a.coefficients[len(a.coefficients) - 1])
+	if (err != nil) {return nil, err}
+	return a.ScalarMultiply(inverse), nil
+}
+
+/**
+ * Calculate the formal derivative of this polynomial.
+ *
+ * @return The derivative <i>a</i><sub>1</sub> + 2<i>a</i><sub>2</sub><i>x</i> + ... + <i>ka<sub>k</sub></i><i>x</i><sup><i>k</i>-1</sup>.
+ */
+func (poly *FieldPolynomial[E]) Derivative() *FieldPolynomial[E]{
+	if (len(poly.coefficients) == 1){
+		return newFieldPolynomial(poly.field, poly.zeros(1))
+	}
+	coefficients := make([]E, len(poly.coefficients) - 1)
+	for i := 1; i < len(poly.coefficients); i++{
+		coefficients[i - 1] = poly.field.Multiply(poly.coefficients[i], poly.field.FromInt(i))
+	}
+	return newFieldPolynomial(poly.field, coefficients)
+}
+
+/**
+ * Calculate the composition <i>f</i>(<i>g</i>(<i>x</i>)) of this polynomial <i>f</i> and another polynomial <i>g</i>
+ * over the same field by Horner's method.
+ *
+ * @param inner The inner polynomial <i>g</i>.
+ * @return The composition.
+ * @return error If the inner polynomial is invalid.
+ */
+func (poly *FieldPolynomial[E]) Compose(inner *FieldPolynomial[E]) (*FieldPolynomial[E], error){
+	err := poly.checkPolynomial(inner)
+	if (err != nil) {return nil, err}
+	feedback := newFieldPolynomial(poly.field, poly.coefficients[len(poly.coefficients) - 1:])
+	for i := len(poly.coefficients) - 2; i >= 0; i--{
+		feedback, err = feedback.Multiply(inner)
+		if (err != nil) {return nil, err}
+		feedback, err = feedback.Add(newFieldPolynomial(poly.field, poly.coefficients[i:i + 1]))
+		if (err != nil) {return nil, err}
+	}
+	return feedback, nil
+}
+
+/**
+ * Check if another polynomial can be used in arithmetic with this polynomial.
+ *
+ * @param other The other polynomial.
+ * @return error If the other polynomial is nil or over a different field.
+ */
+func (poly *FieldPolynomial[E]) checkPolynomial(other *FieldPolynomial[E]) error{
+	if (other == nil){
+		return errors.New("Polynomial should not be nil.")
+	}
+	// each modulus is a multiple of the other one
+	if (!poly.field.Equal(poly.field.GetModulus(), other.field.GetModulus()) ||
+		!other.field.Equal(poly.field.GetModulus(), other.field.GetModulus())){
+		return errors.New("Polynomials should be over the same Zp.")
+	}
+	return nil
+}
+
+/**
+ * Combine the coefficients of this polynomial and another coefficient array one by one.
+ *
+ * @param other The other coefficient array.
+ * @param operation The operation on the coefficients.
+ * @return The combined coefficient array.
+ */
+func (poly *FieldPolynomial[E]) combine(other []E, operation func(E, E) E) []E{
+	count := len(poly.coefficients)
+	if (len(other) > count) {count = len(other)}
+	feedback := make([]E, count)
+	zero := poly.field.Zero()
+	for i := 0; i < count; i++{
+		a, b := zero, zero
+		if (i < len(poly.coefficients)) {a = poly.coefficients[i]}
+		if (i < len(other)) {b = other[i]}
+		feedback[i] = operation(a, b)
+	}
+	return feedback
+}
+
+/**
+ * Create a coefficient array of zeros.
+ *
+ * @param count Number of coefficients.
+ * @return The coefficient array.
+ */
+func (poly *FieldPolynomial[E]) zeros(count int) []E{
+	feedback := make([]E, count)
+	for i := 0; i < count; i++{
+		feedback[i] = poly.field.Zero()
+	}
+	return feedback
+}
@@ -0,0 +1,98 @@
+package poly
+
+import (
+	"testing"
+	"math/big"
+	"fmt"
+)
+
+func TestNewFieldPolynomial(t *testing.T) {
+	field, _ := NewIntField(7)
+	f, err := NewFieldPolynomial[int](field, []int{78, 4, 71, 7000})
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a FieldPolynomial: %s", err))}
+	// 7000 = 0 mod 7, so the leading coefficient is removed
+	if (f.GetDegree() != 2 || fmt.Sprint(f.GetCoefficients()) != "[1 4 1]"){
+		t.Error(fmt.Sprintf("Constructed FieldPolynomial is False, Coefficients:%v", f.GetCoefficients()))
+	}
+	_, err = NewFieldPolynomial[int](field, []int{})
+	if err == nil {t.Error("Polynomial without coefficients should not be constructed.")}
+	_, err = NewFieldPolynomial[int](nil, []int{1})
+	if err == nil {t.Error("Polynomial without field should not be constructed.")}
+}
+
+func TestFieldPolynomial_Arithmetic(t *testing.T) {
+	intField, _ := NewIntField(2147483647)
+	uint64Field, _ := NewUint64Field(primesUint64[0])
+	bigIntField, _ := NewBigIntField(new(big.Int).Lsh(big.NewInt(1), 127).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1)))
+	t.Run("TestFieldPolynomialInt_Arithmetic", testFieldPolynomial_ArithmeticFunc[int](intField))
+	t.Run("TestFieldPolynomialUint64_Arithmetic", testFieldPolynomial_ArithmeticFunc[uint64](uint64Field))
+	t.Run("TestFieldPolynomialBigInt_Arithmetic", testFieldPolynomial_ArithmeticFunc[*big.Int](bigIntField))
+}
+
+func testFieldPolynomial_ArithmeticFunc[E any](field Field[E]) func(t *testing.T) {
+	return func(t *testing.T) {
+		f := randomFieldPolynomial(field, 20)
+		g := randomFieldPolynomial(field, 13)
+		product, err := f.Multiply(g)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when multiplying: %s", err))}
+		if (product.GetDegree() != 33){
+			t.Error(fmt.Sprintf("Degree of product is False, Result:%d", product.GetDegree()))
+		}
+		quotient, remainder, err := product.Divide(g)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when dividing: %s", err))}
+		if (fmt.Sprint(quotient.GetCoefficients()) != fmt.Sprint(f.GetCoefficients()) || !remainder.IsZero()){
+			t.Error("Division is False.")
+		}
+		sum, err := f.Add(g)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding: %s", err))}
+		difference, err := sum.Subtract(g)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when subtracting: %s", err))}
+		if (fmt.Sprint(difference.GetCoefficients()) != fmt.Sprint(f.GetCoefficients())){
+			t.Error("Addition and subtraction are False.")
+		}
+		zero, _ := f.Subtract(f)
+		if (!zero.IsZero() || zero.GetDegree() != 0){
+			t.Error("Difference of equal polynomials should be the zero polynomial.")
+		}
+		_, _, err = f.Divide(zero)
+		if err == nil {t.Error("Division by the zero polynomial should not be accepted.")}
+
+		x := field.FromInt(-3)
+		fx, gx, px := f.Calculate(x), g.Calculate(x), product.Calculate(x)
+		if (!field.Equal(px, field.Multiply(fx, gx))){
+			t.Error("Calculated product is False.")
+		}
+		if (!field.Equal(f.ScalarMultiply(x).Calculate(x), field.Multiply(fx, x))){
+			t.Error("Calculated scalar product is False.")
+		}
+		// d/dx (f * g) = f' * g + f * g'
+		left := product.Derivative()
+		right1, _ := f.Derivative().Multiply(g)
+		right2, _ := f.Multiply(g.Derivative())
+		right, _ := right1.Add(right2)
+		if (fmt.Sprint(left.GetCoefficients()) != fmt.Sprint(right.GetCoefficients())){
+			t.Error("Derivative is False.")
+		}
+	}
+}
+
+func TestFieldPolynomial_DifferentField(t *testing.T) {
+	field7, _ := NewIntField(7)
+	field11, _ := NewIntField(11)
+	f, _ := NewFieldPolynomial[int](field7, []int{1, 2})
+	g, _ := NewFieldPolynomial[int](field11, []int{1, 2})
+	_, err := f.Add(g)
+	if err == nil {t.Error("Polynomials over different Zp should not be added.")}
+	_, err = f.Multiply(nil)
+	if err == nil {t.Error("Nil polynomial should not be accepted.")}
+}
+
+func randomFieldPolynomial[E any](field Field[E], degree int) *FieldPolynomial[E]{
+	coefficients := make([]E, degree + 1)
+	for i := 0; i <= degree; i++{
+		coefficients[i], _ = field.Random()
+	}
+	if (field.IsZero(coefficients[degree])) {coefficients[degree] = field.One()}
+	feedback, _ := NewFieldPolynomial(field, coefficients)
+	return feedback
+}
@@ -0,0 +1,88 @@
+package poly
+
+import (
+	"testing"
+	"math"
+	"math/big"
+	"fmt"
+)
+
+func TestNewField(t *testing.T) {
+	if _, err := NewIntField(2147483647); err != nil {t.Error(fmt.Sprintf("Error happens when constructing an IntField: %s", err))}
+	if _, err := NewIntField(2147483649); err == nil {t.Error("Modulus which is not prime should not be accepted.")}
+	if _, err := NewIntField(2); err == nil {t.Error("Modulus 2 should not be accepted.")}
+	if _, err := NewUint64Field(primesUint64[2]); err != nil {t.Error(fmt.Sprintf("Error happens when constructing an Uint64Field: %s", err))}
+	if _, err := NewUint64Field(1 << 63 + 1); err == nil {t.Error("Modulus which is not prime should not be accepted.")}
+	if _, err := NewBigIntField(nil); err == nil {t.Error("Nil modulus should not be accepted.")}
+	if _, err := NewBigIntField(big.NewInt(91)); err == nil {t.Error("Modulus which is not prime should not be accepted.")}
+}
+
+func TestField(t *testing.T) {
+	// the largest prime below 2^62, products of int elements overflow without MultiplyModUint64
+	intField, _ := NewIntField(4611686018427387847)
+	uint64Field, _ := NewUint64Field(primesUint64[2])
+	bigIntField, _ := NewBigIntField(new(big.Int).SetUint64(primesUint64[2]))
+	t.Run("TestIntField", testFieldFunc[int](intField, func(e int) *big.Int {return big.NewInt(int64(e))}))
+	// a prime just below 2^63, n + p overflows int
+	largeIntField, _ := NewIntField(9223372036854775783)
+	t.Run("TestIntFieldLargeModulus", testFieldFunc[int](largeIntField, func(e int) *big.Int {return big.NewInt(int64(e))}))
+	t.Run("TestUint64Field", testFieldFunc[uint64](uint64Field, func(e uint64) *big.Int {return new(big.Int).SetUint64(e)}))
+	t.Run("TestBigIntField", testFieldFunc[*big.Int](bigIntField, func(e *big.Int) *big.Int {return e}))
+}
+
+func TestIntField_LargeModulus(t *testing.T) {
+	m := 9223372036854775783
+	field, err := NewIntField(m)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing an IntField: %s", err))}
+	if (field.FromInt(m - 1) != m - 1 || field.FromInt(m) != 0 || field.FromInt(-m + 1) != 1){
+		t.Error("Converted int is False near 2^63.")
+	}
+	if (field.FromInt(math.MaxInt) != math.MaxInt - m || field.FromInt(math.MinInt) != m - 1 - (math.MaxInt - m)){
+		t.Error("Converted int is False at the bounds of int.")
+	}
+	if (!field.Equal(m - 1, -1) || !field.IsZero(field.Add(m - 1, 1)) || field.Multiply(m - 1, m - 1) != 1){
+		t.Error("Arithmetic is False near 2^63.")
+	}
+}
+
+func testFieldFunc[E any](field Field[E], toBig func(E) *big.Int) func(t *testing.T) {
+	return func(t *testing.T) {
+		p := toBig(field.GetModulus())
+		elements := []E{field.Zero(), field.One(), field.FromInt(-1), field.FromInt(-7), field.FromInt(12345)}
+		for i := 0; i < 10; i++{
+			random, err := field.Random()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when choosing a random element: %s", err))}
+			elements = append(elements, random)
+		}
+		if (toBig(field.FromInt(-1)).Cmp(new(big.Int).Sub(p, big.NewInt(1))) != 0){
+			t.Error("Converted negative int is False.")
+		}
+		for _, a := range elements{
+			for _, b := range elements{
+				bigA, bigB := toBig(a), toBig(b)
+				expected := big.NewInt(0)
+				if (toBig(field.Add(a, b)).Cmp(expected.Add(bigA, bigB).Mod(expected, p)) != 0){
+					t.Fatal(fmt.Sprintf("Sum is False, %s + %s mod %s", bigA, bigB, p))
+				}
+				if (toBig(field.Subtract(a, b)).Cmp(expected.Sub(bigA, bigB).Mod(expected, p)) != 0){
+					t.Fatal(fmt.Sprintf("Difference is False, %s - %s mod %s", bigA, bigB, p))
+				}
+				if (toBig(field.Multiply(a, b)).Cmp(expected.Mul(bigA, bigB).Mod(expected, p)) != 0){
+					t.Fatal(fmt.Sprintf("Product is False, %s * %s mod %s", bigA, bigB, p))
+				}
+			}
+			if (!field.IsZero(field.Add(a, field.Negate(a)))){
+				t.Error(fmt.Sprintf("Negation is False, -%s mod %s", toBig(a), p))
+			}
+			inverse, err := field.Inverse(a)
+			if (field.IsZero(a)){
+				if err == nil {t.Error("0 should not be invertible.")}
+				continue
+			}
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating an inverse: %s", err))}
+			if (!field.Equal(field.Multiply(a, inverse), field.One())){
+				t.Error(fmt.Sprintf("Inverse is False, %s ^ -1 mod %s", toBig(a), p))
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package poly
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+/**
+ * This class implements the prime field <i>Zp</i> with uint64 elements, for any prime <i>p</i> < 2<sup>64</sup>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type Uint64Field struct {
+	/**
+	 * Modulus <i>p</i>, must be prime.
+	 */
+	modulus uint64
+}
+
+/**
+ * Construct the prime field with an uint64 modulus.
+ *
+ * @param modulus Modulus <i>p</i>.
+ * @return The constructed Uint64Field
+ * @return error If the modulus is not a prime greater than 2.
+ */
+func NewUint64Field(modulus uint64) (*Uint64Field, error){
+	if (modulus <= 2){
+		return nil, errors.New("Modulus should be greater than 2.")
+	}
+	if (!new(big.Int).SetUint64(modulus).ProbablyPrime(20)){
+		return nil, errors.New("Modulus should be prime.")
+	}
+	return &Uint64Field{modulus: modulus}, nil
+}
+
+/**
+ * Get the modulus.
+ *
+ * @return Modulus <i>p</i>.
+ */
+func (field *Uint64Field) GetModulus() uint64{
+	return field.modulus
+}
+
+/**
+ * Get the element of value 0.
+ *
+ * @return 0.
+ */
+func (field *Uint64Field) Zero() uint64{
+	return 0
+}
+
+/**
+ * Get the element of value 1.
+ *
+ * @return 1.
+ */
+func (field *Uint64Field) One() uint64{
+	return 1
+}
+
+/**
+ * Convert an int to an element.
+ *
+ * @param n The int, may be negative.
+ * @return <i>n</i> mod <i>p</i>.
+ */
+func (field *Uint64Field) FromInt(n int) uint64{
+	if (n < 0) {return SubtractModUint64(0, uint64(-int64(n)), field.modulus)}
+	return uint64(n) % field.modulus
+}
+
+/**
+ * Convert an element to a big integer.
+ *
+ * @param a The element.
+ * @return <i>a</i> as a new big.Int, not reduced modulo <i>p</i>.
+ */
+func (field *Uint64Field) ToBigInt(a uint64) *big.Int{
+	return new(big.Int).SetUint64(a)
+}
+
+/**
+ * Calculate <i>a</i> + <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The sum.
+ */
+func (field *Uint64Field) Add(a uint64, b uint64) uint64{
+	return AddModUint64(a, b, field.modulus)
+}
+
+/**
+ * Calculate <i>a</i> - <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The difference.
+ */
+func (field *Uint64Field) Subtract(a uint64, b uint64) uint64{
+	return SubtractModUint64(a, b, field.modulus)
+}
+
+/**
+ * Calculate <i>a</i> * <i>b</i> mod <i>p</i> with a 128-bit intermediate product, so it does not overflow for any uint64 modulus.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The product.
+ */
+func (field *Uint64Field) Multiply(a uint64, b uint64) uint64{
+	return MultiplyModUint64(a, b, field.modulus)
+}
+
+/**
+ * Calculate -<i>a</i> mod <i>p</i>.
+ *
+ * @param a The element.
+ * @return The negation.
+ */
+func (field *Uint64Field) Negate(a uint64) uint64{
+	return SubtractModUint64(0, a, field.modulus)
+}
+
+/**
+ * Calculate <i>a</i><sup>-1</sup> mod <i>p</i>.
+ *
+ * @param a The element.
+ * @return The inverse.
+ * @return error If <i>a</i> = 0 mod <i>p</i>.
+ */
+func (field *Uint64Field) Inverse(a uint64) (uint64, error){
+	return InverseModUint64(a, field.modulus)
+}
+
+/**
+ * Test if two elements are equal modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return True if <i>a</i> = <i>b</i> mod <i>p</i>, otherwise return false.
+ */
+func (field *Uint64Field) Equal(a uint64, b uint64) bool{
+	return a % field.modulus == b % field.modulus
+}
+
+/**
+ * Test if an element is 0 modulo <i>p</i>.
+ *
+ * @param a The element.
+ * @return True if <i>a</i> = 0 mod <i>p</i>, otherwise return false.
+ */
+func (field *Uint64Field) IsZero(a uint64) bool{
+	return a % field.modulus == 0
+}
+
+/**
+ * Choose a uniformly random element.
+ *
+ * @return The element in [0, <i>p</i>).
+ * @return error If random numbers cannot be generated.
+ */
+func (field *Uint64Field) Random() (uint64, error){
+	feedback, err := rand.Int(rand.Reader, new(big.Int).SetUint64(field.modulus))
+	if (err != nil) {return 0, err}
+	return feedback.Uint64(), nil
+}
@@ -0,0 +1,104 @@
+package poly
+
+import (
+	"errors"
+)
+
+/**
+ * Arithmetic in the finite field GF(2<sup>8</sup>) = GF(2)[<i>x</i>]/(<i>x</i><sup>8</sup> + <i>x</i><sup>4</sup> + <i>x</i><sup>3</sup> + <i>x</i> + 1),
+ * the field of AES, which is also used by the byte-wise Shamir's scheme of HashiCorp Vault.
+ * <p>
+ * A byte is the element whose coefficients are its bits. The addition is XOR, and the multiplication uses the
+ * log/exp tables of the generator 3, i.e. <i>a</i> * <i>b</i> = exp[(log[<i>a</i>] + log[<i>b</i>]) mod 255].
+ * Unlike <i>Zp</i>, every byte is an element, so arbitrary binary secrets can be shared byte by byte.
+ * <p>
+ * Note: The table lookups depend on the operands, so the operations are not constant-time.
+ */
+
+/**
+ * gf256Exp[i] = 3<sup><i>i</i></sup>, the table is doubled so that the sum of two logarithms needs no reduction.
+ */
+var gf256Exp [510]byte
+
+/**
+ * gf256Log[<i>a</i>] = log<sub>3</sub> <i>a</i> for <i>a</i> &ne; 0, gf256Log[0] is unused.
+ */
+var gf256Log [256]byte
+
+func init(){
+	x := byte(1)
+	for i := 0; i < 255; i++{
+		gf256Exp[i] = x
+		gf256Exp[i + 255] = x
+		gf256Log[x] = byte(i)
+		// x = x * 3 = x * 2 + x, with reduction by 0x11b
+		doubled := x << 1
+		if (x & 0x80 != 0) {doubled ^= 0x1b}
+		x = doubled ^ x
+	}
+}
+
+/**
+ * Calculate <i>a</i> + <i>b</i> in GF(2<sup>8</sup>), which is also <i>a</i> - <i>b</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The sum.
+ */
+func AddGF256(a byte, b byte) byte{
+	return a ^ b
+}
+
+/**
+ * Calculate <i>a</i> * <i>b</i> in GF(2<sup>8</sup>).
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The product.
+ */
+func MultiplyGF256(a byte, b byte) byte{
+	if (a == 0 || b == 0) {return 0}
+	return gf256Exp[int(gf256Log[a]) + int(gf256Log[b])]
+}
+
+/**
+ * Calculate <i>a</i><sup>-1</sup> in GF(2<sup>8</sup>).
+ *
+ * @param a The element.
+ * @return The inverse.
+ * @return error If <i>a</i> = 0.
+ */
+func InverseGF256(a byte) (byte, error){
+	if (a == 0) {return 0, errors.New("0 is not invertible in GF(256).")}
+	return gf256Exp[255 - int(gf256Log[a])], nil
+}
+
+/**
+ * Calculate <i>a</i> / <i>b</i> in GF(2<sup>8</sup>).
+ *
+ * @param a The dividend.
+ * @param b The divisor.
+ * @return The quotient.
+ * @return error If <i>b</i> = 0.
+ */
+func DivideGF256(a byte, b byte) (byte, error){
+	if (b == 0) {return 0, errors.New("Division by 0 in GF(256).")}
+	if (a == 0) {return 0, nil}
+	return gf256Exp[int(gf256Log[a]) + 255 - int(gf256Log[b])], nil
+}
+
+/**
+ * Calculate <i>f</i>(<i>x</i>) = <i>a</i><sub>0</sub> + <i>a</i><sub>1</sub><i>x</i> + ... + <i>a<sub>k</sub></i><i>x<sup>k</sup></i>
+ * in GF(2<sup>8</sup>) by Horner's method.
+ *
+ * @param coefficients Coefficients of the polynomial, coefficients[i] contains <i>a<sub>i</sub></i>.
+ * @param x The value of variable <i>x</i>.
+ * @return <i>f</i>(<i>x</i>), 0 for an empty polynomial.
+ */
+func CalculateGF256(coefficients []byte, x byte) byte{
+	feedback := byte(0)
+	for i := len(coefficients) - 1; i >= 0; i--{
+		feedback = MultiplyGF256(feedback, x) ^ coefficients[i]
+	}
+	return feedback
+}
@@ -0,0 +1,56 @@
+package poly
+
+import (
+	"testing"
+	"fmt"
+)
+
+func TestGF256(t *testing.T) {
+	// vectors of the field tests in HashiCorp Vault's shamir package
+	if (AddGF256(16, 16) != 0 || AddGF256(3, 4) != 7){
+		t.Error("Sum is False in GF(256).")
+	}
+	if (MultiplyGF256(3, 7) != 9 || MultiplyGF256(3, 0) != 0 || MultiplyGF256(0, 3) != 0){
+		t.Error("Product is False in GF(256).")
+	}
+	for _, vector := range [][3]byte{{0, 7, 0}, {3, 3, 1}, {6, 3, 2}}{
+		quotient, err := DivideGF256(vector[0], vector[1])
+		if (err != nil || quotient != vector[2]){
+			t.Error(fmt.Sprintf("Quotient is False in GF(256), %d / %d", vector[0], vector[1]))
+		}
+	}
+	_, err := DivideGF256(1, 0)
+	if err == nil {t.Error("Division by 0 should not be accepted.")}
+
+	// compare the tables with the multiplication by shifts
+	for a := 0; a < 256; a++{
+		for b := 0; b < 256; b++{
+			if (MultiplyGF256(byte(a), byte(b)) != multiplyGF256Slow(byte(a), byte(b))){
+				t.Fatal(fmt.Sprintf("Product is False in GF(256), %d * %d", a, b))
+			}
+		}
+		inverse, err := InverseGF256(byte(a))
+		if (a == 0){
+			if err == nil {t.Error("0 should not be invertible.")}
+			continue
+		}
+		if (err != nil || MultiplyGF256(byte(a), inverse) != 1){
+			t.Error(fmt.Sprintf("Inverse is False in GF(256), %d ^ -1", a))
+		}
+	}
+	if (CalculateGF256([]byte{0x42, 0x99}, 1) != 0xdb || CalculateGF256([]byte{0x42, 0x99}, 2) != 0x6b){
+		t.Error("Calculated polynomial is False in GF(256).")
+	}
+}
+
+func multiplyGF256Slow(a byte, b byte) byte{
+	feedback := byte(0)
+	for (b != 0){
+		if (b & 1 != 0) {feedback ^= a}
+		carry := a & 0x80
+		a <<= 1
+		if (carry != 0) {a ^= 0x1b}
+		b >>= 1
+	}
+	return feedback
+}
@@ -0,0 +1,206 @@
+package poly
+
+import (
+	"errors"
+)
+
+/**
+ * Abstract class for Lagrange interpolation over <i>Zp</i> on a fixed set of evaluation points.
+ * <p>
+ * Given <i>k</i> distinct evaluation points <i>x</i><sub>1</sub>, <i>x</i><sub>2</sub>, ..., <i>x<sub>k</sub></i>, the unique polynomial
+ * <i>f</i> of degree less than <i>k</i> with <i>f</i>(<i>x<sub>i</sub></i>) = <i>y<sub>i</sub></i> can be written as
+ * <i>f</i>(<i>x</i>) = <i>L</i><sub>1</sub>(<i>x</i>)<i>y</i><sub>1</sub> + ... + <i>L<sub>k</sub></i>(<i>x</i>)<i>y<sub>k</sub></i>, where
+ * <i>L<sub>i</sub></i>(<i>x</i>) = <i>w<sub>i</sub></i> &prod;<sub><i>j</i>&ne;<i>i</i></sub>(<i>x</i> - <i>x<sub>j</sub></i>) and
+ * <i>w<sub>i</sub></i> = 1 / &prod;<sub><i>j</i>&ne;<i>i</i></sub>(<i>x<sub>i</sub></i> - <i>x<sub>j</sub></i>) are the barycentric weights.
+ * <p>
+ * The weights only depend on the evaluation points, so they are precomputed in O(<i>k</i><sup>2</sup>) when the object is constructed.
+ * Afterwards, the Lagrange coefficients <i>L<sub>i</sub></i>(<i>x</i>) at any point are calculated in O(<i>k</i>), and the
+ * coefficients of <i>f</i> are calculated in O(<i>k</i><sup>2</sup>), compared with O(<i>k</i><sup>3</sup>) of solving the Vandermonde system.
+ * The Lagrange coefficients at a fixed point (e.g. 0 for secret recovery) can be kept and reused for any values.
+ * <p>
+ * The algorithms are implemented once by <code>FieldLagrangeInterpolation</code>, and <code>FieldLagrangeInterpolationCalculator</code>
+ * is the subclass delegating to it, of which <code>LagrangeInterpolationInt</code>, <code>LagrangeInterpolationBigInt</code> and
+ * <code>LagrangeInterpolationUint64</code> are instances.
+ * <p>
+ * Note: The modulus <i>p</i> should be prime, so that differences of distinct points are invertible.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type LagrangeInterpolation struct {
+	/**
+	 * The distinct evaluation points <i>x</i><sub>1</sub>, <i>x</i><sub>2</sub>, ..., <i>x<sub>k</sub></i> in [0, <i>p</i>).
+	 */
+	points []interface{}
+
+	/**
+	 * Modulus <i>p</i>.
+	 */
+	modulus interface{}
+
+	/**
+	 * Abstract Interfaces of LagrangeInterpolation
+	 */
+	LagrangeInterpolationITF LagrangeInterpolationCalculator
+}
+
+type LagrangeInterpolationCalculator interface {
+	GetPoints() []interface{}
+
+	GetModulus() interface{}
+
+	/**
+	 * Abstract method of calculating the Lagrange coefficients <i>L</i><sub>1</sub>(<i>x</i>), ..., <i>L<sub>k</sub></i>(<i>x</i>) at a point.
+	 *
+	 * @param x The point.
+	 * @return The Lagrange coefficients.
+	 * @return error If <i>x</i> is invalid.
+	 */
+	GetLagrangeCoefficients(x interface{}) ([]interface{}, error)
+
+	/**
+	 * Abstract method of calculating <i>f</i>(<i>x</i>) of the polynomial passing through the values.
+	 *
+	 * @param x The point.
+	 * @param values The values <i>y</i><sub>1</sub>, <i>y</i><sub>2</sub>, ..., <i>y<sub>k</sub></i> on the evaluation points.
+	 * @return <i>f</i>(<i>x</i>).
+	 * @return error If <i>x</i> or the values are invalid.
+	 */
+	Interpolate(x interface{}, values []interface{}) (interface{}, error)
+
+	/**
+	 * Abstract method of calculating the polynomial <i>f</i> of degree less than <i>k</i> passing through the values.
+	 *
+	 * @param values The values <i>y</i><sub>1</sub>, <i>y</i><sub>2</sub>, ..., <i>y<sub>k</sub></i> on the evaluation points.
+	 * @return The polynomial object, whose degree is <i>k</i>-1 (leading coefficients may be 0).
+	 * @return error If the values are invalid.
+	 */
+	InterpolatePolynomial(values []interface{}) (PolynomialCalculator, error)
+}
+
+/**
+ * Get the evaluation points.
+ *
+ * @return The evaluation points in [0, <i>p</i>).
+ */
+func (li *LagrangeInterpolation) GetPoints() []interface{}{
+	return li.points
+}
+
+/**
+ * Get the modulus.
+ *
+ * @return Modulus <i>p</i>.
+ */
+func (li *LagrangeInterpolation) GetModulus() interface{}{
+	return li.modulus
+}
+
+/**
+ * This class implements the Lagrange interpolation over the field <i>Zp</i> with elements of type <i>E</i> as a
+ * <code>LagrangeInterpolationCalculator</code>.
+ * <p>
+ * The calculation is delegated to a <code>FieldLagrangeInterpolation</code>, and the elements passed as interface{} are
+ * checked to have type <i>E</i>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FieldLagrangeInterpolationCalculator[E any] struct {
+	LagrangeInterpolation
+
+	/**
+	 * The Lagrange interpolation over the field.
+	 */
+	interpolation *FieldLagrangeInterpolation[E]
+}
+
+/**
+ * Construct the Lagrange interpolation over a field on the evaluation points, and precompute the barycentric weights.
+ *
+ * @param field The field <i>Zp</i>.
+ * @param points The evaluation points, should be distinct modulo <i>p</i>.
+ * @return liFeedback The constructed FieldLagrangeInterpolationCalculator
+ * @return error If the field or the points are invalid.
+ */
+func NewFieldLagrangeInterpolationCalculator[E any](field Field[E], points []E) (*FieldLagrangeInterpolationCalculator[E], error){
+	interpolation, err := NewFieldLagrangeInterpolation(field, points)
+	if (err != nil) {return nil, err}
+	liFeedback := new(FieldLagrangeInterpolationCalculator[E])
+	liFeedback.points = toInterfaces(interpolation.points)
+	liFeedback.modulus = field.GetModulus()
+	liFeedback.interpolation = interpolation
+	liFeedback.LagrangeInterpolationITF = liFeedback
+	return liFeedback, nil
+}
+
+/**
+ * Get the Lagrange interpolation over the field, which does the calculation.
+ *
+ * @return The Lagrange interpolation over the field.
+ */
+func (li *FieldLagrangeInterpolationCalculator[E]) GetFieldLagrangeInterpolation() *FieldLagrangeInterpolation[E]{
+	return li.interpolation
+}
+
+/**
+ * Calculate the Lagrange coefficients at a point.
+ *
+ * @param x The point, should have type <i>E</i>.
+ * @return The Lagrange coefficients in [0, <i>p</i>).
+ * @return error If the type of <i>x</i> is invalid.
+ */
+func (li *FieldLagrangeInterpolationCalculator[E]) GetLagrangeCoefficients(x interface{}) ([]interface{}, error){
+	xValue, ok := x.(E)
+	if (!ok) {return nil, errors.New("Invalid type of point.")}
+	return toInterfaces(li.interpolation.GetLagrangeCoefficients(xValue)), nil
+}
+
+/**
+ * Calculate <i>f</i>(<i>x</i>) of the polynomial passing through the values.
+ *
+ * @param x The point, should have type <i>E</i>.
+ * @param values The values on the evaluation points, should have type <i>E</i>.
+ * @return <i>f</i>(<i>x</i>) in [0, <i>p</i>).
+ * @return error If <i>x</i> or the values are invalid.
+ */
+func (li *FieldLagrangeInterpolationCalculator[E]) Interpolate(x interface{}, values []interface{}) (interface{}, error){
+	y, err := li.checkValues(values)
+	if (err != nil) {return nil, err}
+	xValue, ok := x.(E)
+	if (!ok) {return nil, errors.New("Invalid type of point.")}
+	return li.interpolation.Interpolate(xValue, y)
+}
+
+/**
+ * Calculate the polynomial passing through the values.
+ *
+ * @param values The values on the evaluation points, should have type <i>E</i>.
+ * @return The polynomial object(FieldPolynomialCalculator), whose degree is <i>k</i>-1.
+ * @return error If the values are invalid.
+ */
+func (li *FieldLagrangeInterpolationCalculator[E]) InterpolatePolynomial(values []interface{}) (PolynomialCalculator, error){
+	y, err := li.checkValues(values)
+	if (err != nil) {return nil, err}
+	coefficients, err := li.interpolation.interpolateCoefficients(y)
+	if (err != nil) {return nil, err}
+	polyFeedback := newFieldPolynomialCalculator(newFieldPolynomial(li.interpolation.field, append([]E{}, coefficients...)), coefficients)
+	polyFeedback.modulus = li.modulus
+	return polyFeedback, nil
+}
+
+/**
+ * Check the values on the evaluation points.
+ *
+ * @param values The values.
+ * @return The values of type <i>E</i>.
+ * @return error If the number or the type of values is invalid.
+ */
+func (li *FieldLagrangeInterpolationCalculator[E]) checkValues(values []interface{}) ([]E, error){
+	if (values == nil || len(values) != len(li.points)){
+		return nil, errors.New("Number of values should be equal to number of evaluation points.")
+	}
+	feedback, ok := fromInterfaces[E](values)
+	if (!ok) {return nil, errors.New("Invalid type of values.")}
+	return feedback, nil
+}
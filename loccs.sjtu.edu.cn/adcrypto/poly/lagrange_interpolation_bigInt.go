@@ -0,0 +1,39 @@
+package poly
+
+import (
+	"errors"
+	"math/big"
+)
+
+/**
+ * This class implements the math/big Lagrange interpolation over <i>Zp</i>, i.e. the Lagrange interpolation over <code>BigIntField</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type LagrangeInterpolationBigInt = FieldLagrangeInterpolationCalculator[*big.Int]
+
+/**
+ * Construct the Lagrange interpolation on the evaluation points, and precompute the barycentric weights.
+ *
+ * @param points The evaluation points, should be distinct modulo <i>p</i>.
+ * @param modulus Modulus <i>p</i>, should be prime.
+ * @return liFeedback The constructed LagrangeInterpolationBigInt
+ * @return error If the points or the modulus is invalid.
+ */
+func NewLagrangeInterpolationBigInt(points []*big.Int, modulus *big.Int) (*LagrangeInterpolationBigInt, error){
+	if (points == nil || len(points) == 0){
+		return nil, errors.New("At least one evaluation point should be provided.")
+	}
+	if (modulus == nil || modulus.Cmp(big.NewInt(2)) <= 0){
+		return nil, errors.New("Modulus should be greater than 2.")
+	}
+	for i := 0; i < len(points); i++{
+		if (points[i] == nil) {return nil, errors.New("Evaluation point should not be nil.")}
+	}
+	// primality is not tested here, a composite modulus fails when some weight is not invertible
+	liFeedback, err := NewFieldLagrangeInterpolationCalculator[*big.Int](&BigIntField{modulus: new(big.Int).Set(modulus)}, points)
+	if (err != nil) {return nil, err}
+	liFeedback.modulus = modulus
+	return liFeedback, nil
+}
@@ -0,0 +1,73 @@
+package poly
+
+import (
+	"testing"
+	"crypto/rand"
+	"math/big"
+	"fmt"
+)
+
+func TestNewLagrangeInterpolationBigInt(t *testing.T) {
+	t.Run("TestNewLagrangeInterpolationBigInt1", testNewLagrangeInterpolationBigIntFunc(
+		[]*big.Int{big.NewInt(1),big.NewInt(2),big.NewInt(3)}, big.NewInt(7), false))
+	t.Run("TestNewLagrangeInterpolationBigInt2", testNewLagrangeInterpolationBigIntFunc(
+		[]*big.Int{big.NewInt(1),big.NewInt(2),big.NewInt(-5)}, big.NewInt(7), true))
+	t.Run("TestNewLagrangeInterpolationBigInt3", testNewLagrangeInterpolationBigIntFunc(
+		[]*big.Int{big.NewInt(1),big.NewInt(2),big.NewInt(3)}, nil, true))
+}
+
+func testNewLagrangeInterpolationBigIntFunc(points []*big.Int, modulus *big.Int, errorExpected bool) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, err := NewLagrangeInterpolationBigInt(points, modulus)
+		if errorExpected == false && err != nil {
+			t.Error(fmt.Sprintf("Error happens when constructing a LagrangeInterpolationBigInt: %s", err))
+		} else if errorExpected == true && err == nil {
+			t.Error("Invalid LagrangeInterpolationBigInt should not be constructed.")
+		}
+	}
+}
+
+func TestLagrangeInterpolationBigInt_Interpolate(t *testing.T) {
+	modulus,_ := rand.Prime(rand.Reader,256)
+	for count := 1; count <= 16; count *= 2{
+		coefficients := make([]*big.Int, count)
+		points := make([]*big.Int, count)
+		for i := 0; i < count; i++{
+			coefficients[i],_ = rand.Int(rand.Reader,modulus)
+			points[i],_ = rand.Int(rand.Reader,modulus)
+		}
+		t.Run(fmt.Sprintf("TestLagrangeInterpolationBigInt_Interpolate%d", count),
+			testLagrangeInterpolationBigInt_InterpolateFunc(coefficients, modulus, points))
+	}
+}
+
+func testLagrangeInterpolationBigInt_InterpolateFunc(coefficients []*big.Int, modulus *big.Int, points []*big.Int) func(t *testing.T) {
+	return func(t *testing.T) {
+		newPoly, err := NewPolynomialBigInt(len(coefficients) - 1, coefficients, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a PolynomialBigInt: %s", err))}
+		values := make([]interface{}, len(points))
+		for i := 0; i < len(points); i++{
+			values[i], err = newPoly.Calculate(points[i])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the result: %s", err))}
+		}
+		interpolation, err := NewLagrangeInterpolationBigInt(points, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a LagrangeInterpolationBigInt: %s", err))}
+
+		for _, x := range []*big.Int{big.NewInt(0), big.NewInt(10), points[0]}{
+			expected, _ := newPoly.Calculate(x)
+			feedback, err := interpolation.Interpolate(x, values)
+			if err != nil {
+				t.Error(fmt.Sprintf("Error happens when interpolating: %s", err))
+			} else if feedback.(*big.Int).Cmp(expected.(*big.Int)) != 0 {
+				t.Error(fmt.Sprintf("Interpolated Result is False, Result:%s ,Expected: %s",feedback,expected))
+			}
+		}
+
+		feedbackPoly, err := interpolation.InterpolatePolynomial(values)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when interpolating the polynomial: %s", err))}
+		if (fmt.Sprint(feedbackPoly.GetCoefficients()) != fmt.Sprint(newPoly.GetCoefficients())){
+			t.Error(fmt.Sprintf("Interpolated Polynomial is False, Result:%v ,Expected: %v",
+				feedbackPoly.GetCoefficients(),newPoly.GetCoefficients()))
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package poly
+
+import (
+	"errors"
+)
+
+/**
+ * This class implements the Integer Lagrange interpolation over <i>Zp</i>, i.e. the Lagrange interpolation over <code>IntField</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type LagrangeInterpolationInt = FieldLagrangeInterpolationCalculator[int]
+
+/**
+ * Construct the Lagrange interpolation on the evaluation points, and precompute the barycentric weights.
+ *
+ * @param points The evaluation points, should be distinct modulo <i>p</i>.
+ * @param modulus Modulus <i>p</i>, should be prime.
+ * @return liFeedback The constructed LagrangeInterpolationInt
+ * @return error If the points or the modulus is invalid.
+ */
+func NewLagrangeInterpolationInt(points []int, modulus int) (*LagrangeInterpolationInt, error){
+	if (points == nil || len(points) == 0){
+		return nil, errors.New("At least one evaluation point should be provided.")
+	}
+	if (modulus <= 2){
+		return nil, errors.New("Modulus should be greater than 2.")
+	}
+	// primality is not tested here, a composite modulus fails when some weight is not invertible
+	return NewFieldLagrangeInterpolationCalculator[int](&IntField{modulus: modulus}, points)
+}
@@ -0,0 +1,64 @@
+package poly
+
+import (
+	"testing"
+	"fmt"
+)
+
+func TestNewLagrangeInterpolationInt(t *testing.T) {
+	t.Run("TestNewLagrangeInterpolationInt1", testNewLagrangeInterpolationIntFunc([]int{1,2,3,4}, 7, false))
+	t.Run("TestNewLagrangeInterpolationInt2", testNewLagrangeInterpolationIntFunc([]int{1,2,9,4}, 7, true))
+	t.Run("TestNewLagrangeInterpolationInt3", testNewLagrangeInterpolationIntFunc([]int{}, 7, true))
+	t.Run("TestNewLagrangeInterpolationInt4", testNewLagrangeInterpolationIntFunc([]int{1,2,3,4}, 2, true))
+}
+
+func testNewLagrangeInterpolationIntFunc(points []int, modulus int, errorExpected bool) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, err := NewLagrangeInterpolationInt(points, modulus)
+		if errorExpected == false && err != nil {
+			t.Error(fmt.Sprintf("Error happens when constructing a LagrangeInterpolationInt: %s", err))
+		} else if errorExpected == true && err == nil {
+			t.Error("Invalid LagrangeInterpolationInt should not be constructed.")
+		}
+	}
+}
+
+func TestLagrangeInterpolationInt_Interpolate(t *testing.T) {
+	t.Run("TestLagrangeInterpolationInt_Interpolate1", testLagrangeInterpolationInt_InterpolateFunc(
+		[]int{-78,4,71,7002}, 1000000007, []int{1,2,3,4}))
+	t.Run("TestLagrangeInterpolationInt_Interpolate2", testLagrangeInterpolationInt_InterpolateFunc(
+		[]int{5,0,0,6,2}, 7, []int{6,-3,1,0,2}))
+	t.Run("TestLagrangeInterpolationInt_Interpolate3", testLagrangeInterpolationInt_InterpolateFunc(
+		[]int{218932}, 1000003, []int{77}))
+}
+
+func testLagrangeInterpolationInt_InterpolateFunc(coefficients []int, modulus int, points []int) func(t *testing.T) {
+	return func(t *testing.T) {
+		newPoly, err := NewPolynomialInt(len(coefficients) - 1, coefficients, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a PolynomialInt: %s", err))}
+		values := make([]interface{}, len(points))
+		for i := 0; i < len(points); i++{
+			values[i], err = newPoly.Calculate((points[i] % modulus + modulus) % modulus)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the result: %s", err))}
+		}
+		interpolation, err := NewLagrangeInterpolationInt(points, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a LagrangeInterpolationInt: %s", err))}
+
+		for _, x := range []int{0, 10, points[0]}{
+			expected, _ := newPoly.Calculate(x)
+			feedback, err := interpolation.Interpolate(x, values)
+			if err != nil {
+				t.Error(fmt.Sprintf("Error happens when interpolating: %s", err))
+			} else if feedback != expected {
+				t.Error(fmt.Sprintf("Interpolated Result is False, Result:%d ,Expected: %d",feedback,expected))
+			}
+		}
+
+		feedbackPoly, err := interpolation.InterpolatePolynomial(values)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when interpolating the polynomial: %s", err))}
+		if (fmt.Sprint(feedbackPoly.GetCoefficients()) != fmt.Sprint(newPoly.GetCoefficients())){
+			t.Error(fmt.Sprintf("Interpolated Polynomial is False, Result:%v ,Expected: %v",
+				feedbackPoly.GetCoefficients(),newPoly.GetCoefficients()))
+		}
+	}
+}
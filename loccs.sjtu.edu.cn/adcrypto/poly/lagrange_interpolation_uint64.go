@@ -0,0 +1,33 @@
+package poly
+
+import (
+	"errors"
+)
+
+/**
+ * This class implements the uint64 Lagrange interpolation over <i>Zp</i>, for any modulus <i>p</i> < 2<sup>64</sup>,
+ * i.e. the Lagrange interpolation over <code>Uint64Field</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type LagrangeInterpolationUint64 = FieldLagrangeInterpolationCalculator[uint64]
+
+/**
+ * Construct the Lagrange interpolation on the evaluation points, and precompute the barycentric weights.
+ *
+ * @param points The evaluation points, should be distinct modulo <i>p</i>.
+ * @param modulus Modulus <i>p</i>, should be prime.
+ * @return liFeedback The constructed LagrangeInterpolationUint64
+ * @return error If the points or the modulus is invalid.
+ */
+func NewLagrangeInterpolationUint64(points []uint64, modulus uint64) (*LagrangeInterpolationUint64, error){
+	if (points == nil || len(points) == 0){
+		return nil, errors.New("At least one evaluation point should be provided.")
+	}
+	if (modulus <= 2){
+		return nil, errors.New("Modulus should be greater than 2.")
+	}
+	// primality is not tested here, a composite modulus fails when some weight is not invertible
+	return NewFieldLagrangeInterpolationCalculator[uint64](&Uint64Field{modulus: modulus}, points)
+}
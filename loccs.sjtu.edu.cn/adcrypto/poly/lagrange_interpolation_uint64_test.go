@@ -0,0 +1,60 @@
+package poly
+
+import (
+	"testing"
+	"fmt"
+)
+
+func TestNewLagrangeInterpolationUint64(t *testing.T) {
+	t.Run("TestNewLagrangeInterpolationUint641", testNewLagrangeInterpolationUint64Func([]uint64{1,2,3,4}, 7, false))
+	t.Run("TestNewLagrangeInterpolationUint642", testNewLagrangeInterpolationUint64Func([]uint64{1,2,9,4}, 7, true))
+	t.Run("TestNewLagrangeInterpolationUint643", testNewLagrangeInterpolationUint64Func([]uint64{}, 7, true))
+	t.Run("TestNewLagrangeInterpolationUint644", testNewLagrangeInterpolationUint64Func([]uint64{1,2,3,4}, 2, true))
+}
+
+func testNewLagrangeInterpolationUint64Func(points []uint64, modulus uint64, errorExpected bool) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, err := NewLagrangeInterpolationUint64(points, modulus)
+		if errorExpected == false && err != nil {
+			t.Error(fmt.Sprintf("Error happens when constructing a LagrangeInterpolationUint64: %s", err))
+		} else if errorExpected == true && err == nil {
+			t.Error("Invalid LagrangeInterpolationUint64 should not be constructed.")
+		}
+	}
+}
+
+func TestLagrangeInterpolationUint64_Interpolate(t *testing.T) {
+	for i, modulus := range primesUint64{
+		t.Run(fmt.Sprintf("TestLagrangeInterpolationUint64_Interpolate%d", i + 1), testLagrangeInterpolationUint64_InterpolateFunc(
+			randomPolynomialUint64(4, modulus), []uint64{1, 2, modulus - 1, 1 << 62, 1<<64 - 1}))
+	}
+}
+
+func testLagrangeInterpolationUint64_InterpolateFunc(newPoly *PolynomialUint64, points []uint64) func(t *testing.T) {
+	return func(t *testing.T) {
+		modulus := newPoly.GetModulus().(uint64)
+		values := make([]interface{}, len(points))
+		for i := 0; i < len(points); i++{
+			values[i], _ = newPoly.Calculate(points[i])
+		}
+		interpolation, err := NewLagrangeInterpolationUint64(points, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a LagrangeInterpolationUint64: %s", err))}
+
+		for _, x := range []uint64{0, modulus - 2, points[0]}{
+			expected, _ := newPoly.Calculate(x)
+			feedback, err := interpolation.Interpolate(x, values)
+			if err != nil {
+				t.Error(fmt.Sprintf("Error happens when interpolating: %s", err))
+			} else if feedback != expected {
+				t.Error(fmt.Sprintf("Interpolated Result is False, Result:%d ,Expected: %d",feedback,expected))
+			}
+		}
+
+		feedbackPoly, err := interpolation.InterpolatePolynomial(values)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when interpolating the polynomial: %s", err))}
+		if (fmt.Sprint(feedbackPoly.GetCoefficients()) != fmt.Sprint(newPoly.GetCoefficients())){
+			t.Error(fmt.Sprintf("Interpolated Polynomial is False, Result:%v ,Expected: %v",
+				feedbackPoly.GetCoefficients(),newPoly.GetCoefficients()))
+		}
+	}
+}
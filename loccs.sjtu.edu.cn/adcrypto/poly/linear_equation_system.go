@@ -1,7 +1,7 @@
 package poly
 
 import (
-	"container/list"
+	"errors"
 )
 
 /**
@@ -29,7 +29,9 @@ import (
  * </ol>
  * The abstract class <code>LinearEquationSystem</code> provides default abstract method that
  * calculate the solution of the linear equation set when there is single solution exists.
- * Subclasses of <code>LinearEquationSystem</code> should implement this method.
+ * It is implemented once by <code>FieldLinearEquationSystem</code>, and <code>FieldLinearEquationSystemCalculator</code> is the
+ * subclass delegating to it, of which <code>LinearEquationSystemInt</code>, <code>LinearEquationSystemBigInt</code> and
+ * <code>LinearEquationSystemUint64</code> are instances.
  *
  * @author 		LoCCS
  * @version		1.0
@@ -46,11 +48,6 @@ type LinearEquationSystem struct {
 	 */
 	modulus interface{}
 
-	/**
-	 * List that store all equations in the system.
-	 */
-	equations *list.List
-
 	/**
     * Abstract Interfaces of LinearEquationSystem
 	*/
@@ -89,6 +86,89 @@ type LinearEquationSystemCalculator interface {
     AddEquation (coefficients []interface{}, constant interface{}) error
 }
 
-func (lqs *LinearEquationSystem) Clear(){
-    lqs.equations.Init()
-}
\ No newline at end of file
+/**
+ * This class implements a system of linear equations over the field <i>Zp</i> with elements of type <i>E</i> as a
+ * <code>LinearEquationSystemCalculator</code>.
+ * <p>
+ * The system is delegated to a <code>FieldLinearEquationSystem</code>, and the elements passed as interface{} are checked
+ * to have type <i>E</i>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FieldLinearEquationSystemCalculator[E any] struct {
+	LinearEquationSystem
+
+	/**
+	 * The system of linear equations over the field.
+	 */
+	system *FieldLinearEquationSystem[E]
+}
+
+/**
+ * Construct a system of linear equations with number of variable count over a field.
+ *
+ * @param field The field <i>Zp</i>.
+ * @param variableCount Number of variables.
+ * @return linearESFeedback The constructed FieldLinearEquationSystemCalculator
+ * @return error If the field or number of variables is invalid.
+ */
+func NewFieldLinearEquationSystemCalculator[E any](field Field[E], variableCount int) (*FieldLinearEquationSystemCalculator[E], error){
+	system, err := NewFieldLinearEquationSystem(field, variableCount)
+	if (err != nil) {return nil, err}
+	linearESFeedback := new(FieldLinearEquationSystemCalculator[E])
+	linearESFeedback.variableCount = variableCount
+	linearESFeedback.modulus = field.GetModulus()
+	linearESFeedback.system = system
+	linearESFeedback.LinearEquationSystemITF = linearESFeedback
+	return linearESFeedback, nil
+}
+
+/**
+ * Add a linear equation to the system.
+ *
+ * @param coefficients Coefficients of the equation, should have type <i>E</i>.
+ * @param constant Constant term of the equation, should have type <i>E</i>.
+ * @return error If coefficients or constant is invalid.
+ */
+func (les *FieldLinearEquationSystemCalculator[E]) AddEquation(coefficients []interface{}, constant interface{}) error{
+	if ((coefficients == nil) || (len(coefficients) != les.variableCount)){
+		return errors.New("Number of coefficients should be equals to Number of variables.")
+	}
+	coefficientsE, ok := fromInterfaces[E](coefficients)
+	if (!ok) {return errors.New("Invalid type of coefficients.")}
+	if ((constant == nil) || !les.checkElement(constant)){
+		return errors.New("Invalid type of constant.")
+	}
+	return les.system.AddEquation(coefficientsE, constant.(E))
+}
+
+/**
+ * Clear all equations in the system.
+ */
+func (les *FieldLinearEquationSystemCalculator[E]) Clear(){
+	les.system.Clear()
+}
+
+/**
+ * Solve system of linear equation, see <code>FieldLinearEquationSystem.Solve</code>.
+ *
+ * @return The solution if there is single solution exists.
+ * @return error If the equations are not enough, or no solution or infinite solutions exist.
+ */
+func (les *FieldLinearEquationSystemCalculator[E]) Solve() ([]interface{}, error){
+	feedback, err := les.system.Solve()
+	if (err != nil) {return nil, err}
+	return toInterfaces(feedback), nil
+}
+
+/**
+ * Check if the type of input element is valid.
+ *
+ * @param e Element to be checked.
+ * @return True if the type of input element is <i>E</i>, otherwise return false.
+ */
+func (les *FieldLinearEquationSystemCalculator[E]) checkElement(e interface{}) bool{
+	_, ok := e.(E)
+	return ok
+}
@@ -0,0 +1,33 @@
+package poly
+
+import (
+	"errors"
+)
+
+/**
+ * This class implements an uint64 system of linear equations over <i>Zp</i>, for any modulus <i>p</i> < 2<sup>64</sup>,
+ * i.e. a system over <code>Uint64Field</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type LinearEquationSystemUint64 = FieldLinearEquationSystemCalculator[uint64]
+
+/**
+ * Construct a system of uint64 linear equation with number of variable count and modulus.
+ *
+ * @param variableCount Number of variables.
+ * @param modulus Modules <i>p</i>
+ * @return linearESFeedback The constructed LinearEquationSystemUint64
+ * @return error Whether number of variables or modulus is invalid.
+ */
+func NewLinearEquationSystemUint64(variableCount int, modulus uint64) (*LinearEquationSystemUint64, error){
+	if (variableCount < 1){
+		return nil, errors.New("Number of variables should be greater than 0.")
+	}
+	if (modulus <= 2){
+		return nil, errors.New("Modulus should be greater than 2.")
+	}
+	// primality is not tested here, a composite modulus fails when some pivot is not invertible
+	return NewFieldLinearEquationSystemCalculator[uint64](&Uint64Field{modulus: modulus}, variableCount)
+}
@@ -0,0 +1,91 @@
+package poly
+
+import (
+	"testing"
+	"crypto/rand"
+	"math/big"
+	"fmt"
+)
+
+func TestNewLinearEquationSystemUint64(t *testing.T) {
+	varCount := 6
+	leq, err := NewLinearEquationSystemUint64(varCount,13)
+	if err != nil {
+		t.Fatal(fmt.Sprintf("Error happens when constructing the LinearEquationSystem: %s", err))
+	}
+	coeTest := [][]uint64{  {2, 3, 5, 7, 9, 5},
+		{3, 4, 3, 6, 7, 6},
+		{7, 8, 3, 6, 2, 3},
+		{5, 6, 4, 7, 1, 1},
+		{6, 5, 4, 3, 9, 4},
+		{4, 2, 9, 5, 7, 9},
+	}
+	solTest := []uint64{6, 7, 9, 10, 11, 12}
+	for i:=0;i < varCount; i++{
+		oneEquation := make([]interface{},varCount)
+		for j:=0; j < varCount ; j++ {oneEquation[j] = coeTest[i][j]}
+		err := leq.AddEquation(oneEquation,solTest[i])
+		if err != nil {t.Error(fmt.Sprintf("Error happens when adding a LinearEquation: %s", err))}
+	}
+	result, err := leq.Solve()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when solving LinearEquationSystem: %s", err))}
+
+	// true solution
+	trueSolution := []uint64{1,10,2,12,1,8}
+	for i:=0;i < varCount;i++ {
+		if result[i].(uint64) != (trueSolution[i]) {
+			t.Error(fmt.Sprintf("Error happens when solving LinearEquationSystem: Solution is wrong"))
+			break
+		}
+	}
+}
+
+func TestLinearEquationSystemUint64_LargeModulus(t *testing.T) {
+	for _, modulus := range primesUint64{
+		t.Run(fmt.Sprintf("TestLinearEquationSystemUint64_LargeModulus%d", modulus),
+			testLinearEquationSystemUint64_LargeModulusFunc(8, modulus))
+	}
+}
+
+func testLinearEquationSystemUint64_LargeModulusFunc(varCount int, modulus uint64) func(t *testing.T) {
+	return func(t *testing.T) {
+		leq, err := NewLinearEquationSystemUint64(varCount, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing the LinearEquationSystem: %s", err))}
+		p := new(big.Int).SetUint64(modulus)
+		solution := make([]uint64, varCount)
+		for i := 0; i < varCount; i++{
+			random, _ := rand.Int(rand.Reader, p)
+			solution[i] = random.Uint64()
+		}
+		for i := 0; i < varCount; i++{
+			oneEquation := make([]interface{}, varCount)
+			constant := uint64(0)
+			for j := 0; j < varCount; j++{
+				random, _ := rand.Int(rand.Reader, p)
+				oneEquation[j] = random.Uint64()
+				constant = AddModUint64(constant, MultiplyModUint64(random.Uint64(), solution[j], modulus), modulus)
+			}
+			err := leq.AddEquation(oneEquation, constant)
+			if err != nil {t.Error(fmt.Sprintf("Error happens when adding a LinearEquation: %s", err))}
+		}
+		result, err := leq.Solve()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when solving LinearEquationSystem: %s", err))}
+		for i := 0; i < varCount; i++{
+			if (result[i].(uint64) != solution[i]){
+				t.Error(fmt.Sprintf("Solution is False, Result:%v ,Expected: %v", result, solution))
+				break
+			}
+		}
+	}
+}
+
+func TestNewLinearEquationSystemUint64NoSolutions(t *testing.T) {
+	leq, err := NewLinearEquationSystemUint64(2, primesUint64[2])
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing the LinearEquationSystem: %s", err))}
+	_ = leq.AddEquation([]interface{}{uint64(1), uint64(2)}, uint64(3))
+	_ = leq.AddEquation([]interface{}{uint64(2), uint64(4)}, uint64(5))
+	result, err := leq.Solve()
+	if err == nil {t.Error(fmt.Sprintf("Solution of the LinearEquationSystem: %d",result))}
+	err = leq.AddEquation([]interface{}{1, 2}, 3)
+	if err == nil {t.Error("Invalid type of coefficients should not be accepted.")}
+}
@@ -0,0 +1,95 @@
+package poly
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+)
+
+/**
+ * Modular arithmetic on uint64 elements of <i>Zp</i> for any modulus <i>p</i> < 2<sup>64</sup>.
+ * <p>
+ * The sums and the differences use the carry and the borrow of math/bits, and the products are calculated as 128-bit
+ * integers by <code>bits.Mul64</code> and reduced by <code>bits.Div64</code>, so nothing overflows even if <i>p</i> > 2<sup>63</sup>.
+ */
+
+/**
+ * Calculate <i>a</i> + <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @param modulus Modulus <i>p</i>, should be greater than 0.
+ * @return The sum in [0, <i>p</i>).
+ */
+func AddModUint64(a uint64, b uint64, modulus uint64) uint64{
+	a %= modulus
+	b %= modulus
+	sum, carry := bits.Add64(a, b, 0)
+	if (carry != 0 || sum >= modulus) {sum -= modulus}
+	return sum
+}
+
+/**
+ * Calculate <i>a</i> - <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @param modulus Modulus <i>p</i>, should be greater than 0.
+ * @return The difference in [0, <i>p</i>).
+ */
+func SubtractModUint64(a uint64, b uint64, modulus uint64) uint64{
+	a %= modulus
+	b %= modulus
+	difference, borrow := bits.Sub64(a, b, 0)
+	if (borrow != 0) {difference += modulus}
+	return difference
+}
+
+/**
+ * Calculate <i>a</i> * <i>b</i> mod <i>p</i> with a 128-bit product.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @param modulus Modulus <i>p</i>, should be greater than 0.
+ * @return The product in [0, <i>p</i>).
+ */
+func MultiplyModUint64(a uint64, b uint64, modulus uint64) uint64{
+	// the high word is less than p since a, b < p, so Div64 never panics
+	high, low := bits.Mul64(a % modulus, b % modulus)
+	_, feedback := bits.Div64(high, low, modulus)
+	return feedback
+}
+
+/**
+ * Calculate <i>a</i><sup><i>e</i></sup> mod <i>p</i> by square-and-multiply.
+ *
+ * @param a The base.
+ * @param exponent The exponent <i>e</i>.
+ * @param modulus Modulus <i>p</i>, should be greater than 0.
+ * @return The power in [0, <i>p</i>).
+ */
+func ExponentModUint64(a uint64, exponent uint64, modulus uint64) uint64{
+	feedback := uint64(1) % modulus
+	base := a % modulus
+	for ; exponent > 0; exponent >>= 1{
+		if (exponent & 1 == 1) {feedback = MultiplyModUint64(feedback, base, modulus)}
+		base = MultiplyModUint64(base, base, modulus)
+	}
+	return feedback
+}
+
+/**
+ * Calculate <i>a</i><sup>-1</sup> mod <i>p</i>.
+ *
+ * @param a The element.
+ * @param modulus Modulus <i>p</i>, should be greater than 1.
+ * @return The inverse in [0, <i>p</i>).
+ * @return error If <i>a</i> is not invertible.
+ */
+func InverseModUint64(a uint64, modulus uint64) (uint64, error){
+	inverse := big.NewInt(0)
+	if (inverse.ModInverse(new(big.Int).SetUint64(a % modulus), new(big.Int).SetUint64(modulus)) == nil){
+		return 0, errors.New("Error happens when calculating an inverse.")
+	}
+	return inverse.Uint64(), nil
+}
@@ -1,13 +1,20 @@
 package poly
 
+import (
+	"errors"
+)
+
 /**
  * Abstract class for polynomial over <i>Zp</i> with single variable.
  * <p>
  * The polynomial can be written in the form <i>f</i>(<i>x</i>) = <i>a</i><sub>0</sub> + <i>a</i><sub>1</sub><i>x</i> + ... + <i>a<sub>k</sub></i><i>x<sup>k</sup></i> mod <i>p</i>.
  * While <i>x</i> is the variable, <i>p</i> is the modulus, <i>a</i><sub>0</sub>, <i>a</i><sub>1</sub>, ..., <i>a<sub>k</sub></i> are coefficients in <i>Zp</i>, and <i>k</i> is the degree of the polynomial.
  * <p>
- * The abstract class <code>Polynomial</code> provides default abstract method that calculates result of the polynomial when
- * variable <i>x</i> is given. Subclasses of <code>Polynomial</code> should implement this method.
+ * The abstract class <code>Polynomial</code> keeps the degree, the coefficients and the modulus as interface{}. The calculation
+ * and the polynomial arithmetic over <i>Zp</i> (addition, subtraction, multiplication, division with remainder, GCD, derivative
+ * and composition) are implemented once by <code>FieldPolynomial</code>, and <code>FieldPolynomialCalculator</code> is the
+ * subclass delegating to it, of which <code>PolynomialInt</code>, <code>PolynomialBigInt</code> and <code>PolynomialUint64</code>
+ * are instances.
  *
  * @author 		LoCCS
  * @version		1.0
@@ -50,6 +57,27 @@ type PolynomialCalculator interface{
     */
 	Calculate(x interface{}) (interface{}, error)
 
+	Add(other PolynomialCalculator) (PolynomialCalculator, error)
+
+	Subtract(other PolynomialCalculator) (PolynomialCalculator, error)
+
+	Multiply(other PolynomialCalculator) (PolynomialCalculator, error)
+
+	ScalarMultiply(scalar interface{}) (PolynomialCalculator, error)
+
+	Divide(divisor PolynomialCalculator) (PolynomialCalculator, PolynomialCalculator, error)
+
+	GCD(other PolynomialCalculator) (PolynomialCalculator, error)
+
+	Derivative() PolynomialCalculator
+
+	Compose(inner PolynomialCalculator) (PolynomialCalculator, error)
+
+	IsZero() bool
+
+	MultiplyNTT(other PolynomialCalculator) (PolynomialCalculator, error)
+
+	CalculateMultipoint(points []interface{}) ([]interface{}, error)
 }
 
 /**
@@ -79,8 +107,311 @@ func (poly *Polynomial) GetModulus() interface{}{
 	return poly.modulus
 }
 
+/**
+ * This class implements the polynomial over the field <i>Zp</i> with elements of type <i>E</i> as a <code>PolynomialCalculator</code>.
+ * <p>
+ * The arithmetic is delegated to a <code>FieldPolynomial</code>, and the elements passed as interface{} are checked to have
+ * type <i>E</i>. The degree and the coefficients given at construction are kept as they are, including the leading zeros,
+ * while the results of the arithmetic have no leading zeros.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FieldPolynomialCalculator[E any] struct {
+	Polynomial
+
+	/**
+	 * The polynomial over the field, without leading zeros.
+	 */
+	polynomial *FieldPolynomial[E]
+}
+
+/**
+ * Construct a polynomial over a field from its coefficients.
+ * <p>
+ * The degree of the polynomial is the number of coefficients - 1, and the coefficients are reduced modulo <i>p</i>.
+ *
+ * @param field The field <i>Zp</i>.
+ * @param coefficients Coefficients of the polynomial, coefficients[i] contains <i>a<sub>i</sub></i>.
+ * @return polyFeedback The constructed FieldPolynomialCalculator
+ * @return error If the field is nil or no coefficient is given.
+ */
+func NewFieldPolynomialCalculator[E any](field Field[E], coefficients []E) (*FieldPolynomialCalculator[E], error){
+	polynomial, err := NewFieldPolynomial(field, coefficients)
+	if (err != nil) {return nil, err}
+	reduced := polynomial.zeros(len(coefficients))
+	copy(reduced, polynomial.coefficients)
+	polyFeedback := newFieldPolynomialCalculator(polynomial, reduced)
+	polyFeedback.modulus = field.GetModulus()
+	return polyFeedback, nil
+}
+
+/**
+ * Construct a polynomial from the polynomial over the field and the coefficients to be kept.
+ *
+ * @param polynomial The polynomial over the field.
+ * @param coefficients The coefficients of the polynomial, possibly with leading zeros.
+ * @return The polynomial object, whose modulus is not set.
+ */
+func newFieldPolynomialCalculator[E any](polynomial *FieldPolynomial[E], coefficients []E) *FieldPolynomialCalculator[E]{
+	polyFeedback := new(FieldPolynomialCalculator[E])
+	polyFeedback.degree = len(coefficients) - 1
+	polyFeedback.coefficients = toInterfaces(coefficients)
+	polyFeedback.polynomial = polynomial
+	polyFeedback.Polynomialcal = polyFeedback
+	return polyFeedback
+}
+
+/**
+ * Get the field of the coefficients.
+ *
+ * @return The field <i>Zp</i>.
+ */
+func (poly *FieldPolynomialCalculator[E]) GetField() Field[E]{
+	return poly.polynomial.field
+}
+
+/**
+ * Get the polynomial over the field, which does the arithmetic.
+ *
+ * @return The polynomial without leading zeros.
+ */
+func (poly *FieldPolynomialCalculator[E]) GetFieldPolynomial() *FieldPolynomial[E]{
+	return poly.polynomial
+}
+
+/**
+ * Calculate the results of the polynomial by given value of variable <i>x</i>.
+ *
+ * @param x The value of variable <i>x</i>.
+ * @return The results of the polynomial.
+ * @return error If the type of <i>x</i> is invalid.
+ */
+func (poly *FieldPolynomialCalculator[E]) Calculate(x interface{}) (interface{}, error){
+	xValue, ok := x.(E)
+	if (!ok){
+		return nil, errors.New("Invalid type of input.")
+	}
+	return poly.polynomial.Calculate(xValue), nil
+}
+
+/**
+ * Determine if the polynomial is the zero polynomial.
+ *
+ * @return True if all coefficients are 0, otherwise return false.
+ */
+func (poly *FieldPolynomialCalculator[E]) IsZero() bool{
+	return poly.polynomial.IsZero()
+}
+
+/**
+ * Calculate the sum of this polynomial and another polynomial over the same <i>Zp</i>.
+ *
+ * @param other The other polynomial.
+ * @return The sum.
+ * @return error If the other polynomial is invalid.
+ */
+func (poly *FieldPolynomialCalculator[E]) Add(other PolynomialCalculator) (PolynomialCalculator, error){
+	otherPolynomial, err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	return poly.wrapResult(poly.polynomial.Add(otherPolynomial))
+}
+
+/**
+ * Calculate the difference of this polynomial and another polynomial over the same <i>Zp</i>.
+ *
+ * @param other The other polynomial, the subtrahend.
+ * @return The difference.
+ * @return error If the other polynomial is invalid.
+ */
+func (poly *FieldPolynomialCalculator[E]) Subtract(other PolynomialCalculator) (PolynomialCalculator, error){
+	otherPolynomial, err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	return poly.wrapResult(poly.polynomial.Subtract(otherPolynomial))
+}
+
+/**
+ * Calculate the product of this polynomial and another polynomial over the same <i>Zp</i>, see <code>FieldPolynomial.Multiply</code>.
+ *
+ * @param other The other polynomial.
+ * @return The product.
+ * @return error If the other polynomial is invalid.
+ */
+func (poly *FieldPolynomialCalculator[E]) Multiply(other PolynomialCalculator) (PolynomialCalculator, error){
+	otherPolynomial, err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	return poly.wrapResult(poly.polynomial.Multiply(otherPolynomial))
+}
 
+/**
+ * Calculate the product of this polynomial and another polynomial over the same <i>Zp</i> by the number-theoretic transform,
+ * see <code>FieldPolynomial.MultiplyNTT</code>.
+ *
+ * @param other The other polynomial.
+ * @return The product.
+ * @return error If the other polynomial is invalid, or <i>p</i> is not NTT-friendly.
+ */
+func (poly *FieldPolynomialCalculator[E]) MultiplyNTT(other PolynomialCalculator) (PolynomialCalculator, error){
+	otherPolynomial, err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	return poly.wrapResult(poly.polynomial.MultiplyNTT(otherPolynomial))
+}
 
+/**
+ * Calculate the product of this polynomial and a scalar.
+ *
+ * @param scalar The scalar.
+ * @return The product.
+ * @return error If the type of scalar is invalid.
+ */
+func (poly *FieldPolynomialCalculator[E]) ScalarMultiply(scalar interface{}) (PolynomialCalculator, error){
+	scalarValue, ok := scalar.(E)
+	if (!ok){
+		return nil, errors.New("Invalid type of scalar.")
+	}
+	return poly.wrap(poly.polynomial.ScalarMultiply(scalarValue)), nil
+}
 
+/**
+ * Divide this polynomial by another polynomial over the same <i>Zp</i>, see <code>FieldPolynomial.Divide</code>.
+ *
+ * @param divisor The divisor.
+ * @return The quotient.
+ * @return The remainder.
+ * @return error If the divisor is invalid or zero, or its leading coefficient is not invertible.
+ */
+func (poly *FieldPolynomialCalculator[E]) Divide(divisor PolynomialCalculator) (PolynomialCalculator, PolynomialCalculator, error){
+	divisorPolynomial, err := poly.checkPolynomial(divisor)
+	if (err != nil) {return nil, nil, err}
+	quotient, remainder, err := poly.polynomial.Divide(divisorPolynomial)
+	if (err != nil) {return nil, nil, err}
+	return poly.wrap(quotient), poly.wrap(remainder), nil
+}
 
+/**
+ * Calculate the greatest common divisor of this polynomial and another polynomial over the same <i>Zp</i>.
+ *
+ * @param other The other polynomial.
+ * @return The monic GCD, or the zero polynomial if both polynomials are zero.
+ * @return error If the other polynomial is invalid, or some leading coefficient is not invertible.
+ */
+func (poly *FieldPolynomialCalculator[E]) GCD(other PolynomialCalculator) (PolynomialCalculator, error){
+	otherPolynomial, err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	return poly.wrapResult(poly.polynomial.GCD(otherPolynomial))
+}
 
+/**
+ * Calculate the formal derivative of this polynomial.
+ *
+ * @return The derivative.
+ */
+func (poly *FieldPolynomialCalculator[E]) Derivative() PolynomialCalculator{
+	return poly.wrap(poly.polynomial.Derivative())
+}
+
+/**
+ * Calculate the composition <i>f</i>(<i>g</i>(<i>x</i>)) of this polynomial <i>f</i> and another polynomial <i>g</i> over the same <i>Zp</i>.
+ *
+ * @param inner The inner polynomial <i>g</i>.
+ * @return The composition.
+ * @return error If the inner polynomial is invalid.
+ */
+func (poly *FieldPolynomialCalculator[E]) Compose(inner PolynomialCalculator) (PolynomialCalculator, error){
+	innerPolynomial, err := poly.checkPolynomial(inner)
+	if (err != nil) {return nil, err}
+	return poly.wrapResult(poly.polynomial.Compose(innerPolynomial))
+}
+
+/**
+ * Calculate the results of the polynomial on many points at once with a subproduct tree.
+ *
+ * @param points The values of variable <i>x</i>.
+ * @return The results of the polynomial, one for each point.
+ * @return error If no point is given, or the type of any point is invalid.
+ */
+func (poly *FieldPolynomialCalculator[E]) CalculateMultipoint(points []interface{}) ([]interface{}, error){
+	pointsE, ok := fromInterfaces[E](points)
+	if (!ok){
+		return nil, errors.New("Invalid type of evaluation point.")
+	}
+	feedback, err := poly.polynomial.CalculateMultipoint(pointsE)
+	if (err != nil) {return nil, err}
+	return toInterfaces(feedback), nil
+}
+
+/**
+ * Check if another polynomial can be used in arithmetic with this polynomial.
+ *
+ * @param other The other polynomial.
+ * @return The polynomial over the field of the other polynomial.
+ * @return error If the other polynomial is nil or over a different <i>Zp</i>.
+ */
+func (poly *FieldPolynomialCalculator[E]) checkPolynomial(other PolynomialCalculator) (*FieldPolynomial[E], error){
+	if (other == nil){
+		return nil, errors.New("Polynomial should not be nil.")
+	}
+	otherCalculator, ok := other.(*FieldPolynomialCalculator[E])
+	if (!ok || otherCalculator == nil){
+		return nil, errors.New("Polynomials should be over the same Zp.")
+	}
+	err := poly.polynomial.checkPolynomial(otherCalculator.polynomial)
+	if (err != nil) {return nil, err}
+	return otherCalculator.polynomial, nil
+}
+
+/**
+ * Construct a polynomial with the same modulus from a polynomial over the field.
+ *
+ * @param polynomial The polynomial over the field.
+ * @return The polynomial object.
+ */
+func (poly *FieldPolynomialCalculator[E]) wrap(polynomial *FieldPolynomial[E]) *FieldPolynomialCalculator[E]{
+	feedback := newFieldPolynomialCalculator(polynomial, polynomial.coefficients)
+	feedback.modulus = poly.modulus
+	return feedback
+}
+
+/**
+ * Construct a polynomial with the same modulus from the result of an arithmetic operation.
+ *
+ * @param polynomial The polynomial over the field.
+ * @param err The error of the operation.
+ * @return The polynomial object.
+ * @return error The error of the operation.
+ */
+func (poly *FieldPolynomialCalculator[E]) wrapResult(polynomial *FieldPolynomial[E], err error) (PolynomialCalculator, error){
+	if (err != nil) {return nil, err}
+	return poly.wrap(polynomial), nil
+}
+
+/**
+ * Copy elements to an interface{} array.
+ *
+ * @param elements The elements.
+ * @return The interface{} array.
+ */
+func toInterfaces[E any](elements []E) []interface{}{
+	feedback := make([]interface{}, len(elements))
+	for i := 0; i < len(elements); i++{
+		feedback[i] = elements[i]
+	}
+	return feedback
+}
+
+/**
+ * Copy an interface{} array to elements, checking their types.
+ *
+ * @param elements The interface{} array.
+ * @return The elements.
+ * @return True if all elements have type <i>E</i>, otherwise return false.
+ */
+func fromInterfaces[E any](elements []interface{}) ([]E, bool){
+	feedback := make([]E, len(elements))
+	for i := 0; i < len(elements); i++{
+		element, ok := elements[i].(E)
+		if (!ok) {return nil, false}
+		feedback[i] = element
+	}
+	return feedback, true
+}
@@ -2,6 +2,7 @@ package poly
 
 import (
 	"testing"
+	"crypto/rand"
 	"math/big"
 	"fmt"
 )
@@ -84,4 +85,70 @@ func testPolynomialBigInt_CalculateFunc(degree int,coefficients []*big.Int,
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+func TestPolynomialBigInt_Arithmetic(t *testing.T) {
+	modulus,_ := rand.Prime(rand.Reader,128)
+	randomPolynomial := func(degree int) PolynomialCalculator {
+		coefficients := make([]*big.Int, degree + 1)
+		for i := 0; i <= degree; i++{
+			coefficients[i],_ = rand.Int(rand.Reader,modulus)
+		}
+		coefficients[degree].Add(coefficients[degree], big.NewInt(1)).Mod(coefficients[degree], modulus)
+		if (coefficients[degree].Sign() == 0) {coefficients[degree].SetInt64(1)}
+		feedback,_ := NewPolynomialBigInt(degree, coefficients, modulus)
+		return feedback
+	}
+	f := randomPolynomial(5)
+	g := randomPolynomial(3)
+	h := randomPolynomial(2)
+	x,_ := rand.Int(rand.Reader,modulus)
+	fx,_ := f.Calculate(x)
+	gx,_ := g.Calculate(x)
+	calculate := func(name string, p PolynomialCalculator, err error) *big.Int {
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating %s: %s", name, err))}
+		feedback,_ := p.Calculate(x)
+		return feedback.(*big.Int)
+	}
+	expected := big.NewInt(0)
+
+	sum, err := f.Add(g)
+	expected.Add(fx.(*big.Int), gx.(*big.Int)).Mod(expected, modulus)
+	if (calculate("sum", sum, err).Cmp(expected) != 0) {t.Error("Calculated sum is False.")}
+	difference, err := f.Subtract(g)
+	expected.Sub(fx.(*big.Int), gx.(*big.Int)).Mod(expected, modulus)
+	if (calculate("difference", difference, err).Cmp(expected) != 0) {t.Error("Calculated difference is False.")}
+	product, err := f.Multiply(g)
+	expected.Mul(fx.(*big.Int), gx.(*big.Int)).Mod(expected, modulus)
+	if (calculate("product", product, err).Cmp(expected) != 0 || product.GetDegree() != 8) {t.Error("Calculated product is False.")}
+	composition, err := f.Compose(g)
+	fgx,_ := f.Calculate(gx)
+	if (calculate("composition", composition, err).Cmp(fgx.(*big.Int)) != 0 || composition.GetDegree() != 15) {
+		t.Error("Calculated composition is False.")
+	}
+
+	// f = qg + r
+	quotient, remainder, err := f.Divide(g)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when dividing: %s", err))}
+	if (quotient.GetDegree() != 2 || remainder.GetDegree() >= 3) {t.Error("Degrees of quotient and remainder are False.")}
+	restored, _ := quotient.Multiply(g)
+	restored, _ = restored.Add(remainder)
+	if (fmt.Sprint(restored.GetCoefficients()) != fmt.Sprint(f.GetCoefficients())) {t.Error("Calculated division is False.")}
+
+	// gcd(fh, gh) = monic h with overwhelming probability
+	fh, _ := f.Multiply(h)
+	gh, _ := g.Multiply(h)
+	gcd, err := fh.GCD(gh)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating GCD: %s", err))}
+	leading := h.GetCoefficients()[2].(*big.Int)
+	monic, _ := h.ScalarMultiply(big.NewInt(0).ModInverse(leading, modulus))
+	if (fmt.Sprint(gcd.GetCoefficients()) != fmt.Sprint(monic.GetCoefficients())) {
+		t.Error(fmt.Sprintf("Calculated GCD is False, Result:%v ,Expected: %v",gcd.GetCoefficients(),monic.GetCoefficients()))
+	}
+
+	// (fg)' = f'g + fg'
+	left := product.Derivative()
+	right1, _ := f.Derivative().Multiply(g)
+	right2, _ := f.Multiply(g.Derivative())
+	right, _ := right1.Add(right2)
+	if (fmt.Sprint(left.GetCoefficients()) != fmt.Sprint(right.GetCoefficients())) {t.Error("Calculated derivative is False.")}
+}
@@ -63,4 +63,46 @@ func testPolynomialInt_CalculateFunc(degree int,coefficients []int, modulus int,
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+func TestPolynomialInt_Arithmetic(t *testing.T) {
+	modulus := 7
+	// f = (x + 1)(x + 2) = x^2 + 3x + 2, g = (x + 1)(x + 3) = x^2 + 4x + 3
+	f, _ := NewPolynomialInt(2, []int{2,3,1}, modulus)
+	g, _ := NewPolynomialInt(2, []int{3,4,1}, modulus)
+	checkPolynomialInt := func(name string, feedback PolynomialCalculator, err error, expected []int) {
+		if err != nil {
+			t.Error(fmt.Sprintf("Error happens when calculating %s: %s", name, err))
+		} else if fmt.Sprint(feedback.GetCoefficients()) != fmt.Sprint(expected) || feedback.GetDegree() != len(expected) - 1 {
+			t.Error(fmt.Sprintf("Calculated %s is False, Result:%v ,Expected: %v",name,feedback.GetCoefficients(),expected))
+		}
+	}
+
+	feedback, err := f.Add(g)
+	checkPolynomialInt("sum", feedback, err, []int{5,0,2})
+	feedback, err = f.Subtract(g)
+	checkPolynomialInt("difference", feedback, err, []int{6,6})
+	feedback, err = f.Multiply(g)
+	checkPolynomialInt("product", feedback, err, []int{6,3,3,0,1})
+	feedback, err = f.ScalarMultiply(-1)
+	checkPolynomialInt("scalar product", feedback, err, []int{5,4,6})
+	feedback = f.Derivative()
+	checkPolynomialInt("derivative", feedback, nil, []int{3,2})
+	feedback, err = f.Compose(g)
+	// (x^2 + 4x + 3)^2 + 3(x^2 + 4x + 3) + 2
+	checkPolynomialInt("composition", feedback, err, []int{6,1,4,1,1})
+	feedback, err = f.GCD(g)
+	checkPolynomialInt("GCD", feedback, err, []int{1,1})
+
+	product, _ := f.Multiply(g)
+	remainder, _ := NewPolynomialInt(1, []int{4,5}, modulus)
+	dividend, _ := product.Add(remainder)
+	quotient, rest, err := dividend.Divide(g)
+	checkPolynomialInt("quotient", quotient, err, []int{2,3,1})
+	checkPolynomialInt("remainder", rest, err, []int{4,5})
+	zero, _ := NewPolynomialInt(0, []int{0}, modulus)
+	_, _, err = f.Divide(zero)
+	if err == nil {t.Error("Division by zero polynomial should not be accepted.")}
+	other, _ := NewPolynomialInt(2, []int{2,3,1}, 11)
+	_, err = f.Add(other)
+	if err == nil {t.Error("Polynomials over different Zp should not be added.")}
+}
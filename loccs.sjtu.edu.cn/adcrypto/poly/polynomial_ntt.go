@@ -0,0 +1,281 @@
+package poly
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+/**
+ * Minimum number of coefficients of both operands for which <code>Multiply</code> and <code>Divide</code> switch
+ * to the number-theoretic transform, below it the schoolbook methods are faster.
+ */
+const nttThreshold = 32
+
+/**
+ * Calculate the product of this polynomial and another polynomial over the same field by the number-theoretic transform.
+ * <p>
+ * The coefficients are transformed to the values on the powers of a primitive <i>N</i>-th root of unity, where <i>N</i> is the
+ * smallest power of 2 not less than the number of coefficients of the product, multiplied pointwise and transformed back,
+ * in O(<i>N</i> log <i>N</i>). It requires an NTT-friendly prime, i.e. <i>N</i> divides <i>p</i>-1 (see <code>GenerateNTTFriendlyPrime</code>).
+ *
+ * @param other The other polynomial.
+ * @return The product.
+ * @return error If the other polynomial is invalid, or <i>p</i>-1 is not divisible by <i>N</i>.
+ */
+func (poly *FieldPolynomial[E]) MultiplyNTT(other *FieldPolynomial[E]) (*FieldPolynomial[E], error){
+	err := poly.checkPolynomial(other)
+	if (err != nil) {return nil, err}
+	count := len(poly.coefficients) + len(other.coefficients) - 1
+	size := 1
+	for (size < count) {size <<= 1}
+	root, err := getRootOfUnity(poly.field, size)
+	if (err != nil) {return nil, err}
+
+	left := poly.zeros(size)
+	copy(left, poly.coefficients)
+	right := poly.zeros(size)
+	copy(right, other.coefficients)
+	poly.transform(left, root)
+	poly.transform(right, root)
+	for i := 0; i < size; i++{
+		left[i] = poly.field.Multiply(left[i], right[i])
+	}
+
+	// inverse transform with root^-1, then divide by N
+	rootInverse, err := poly.field.Inverse(root)
+	if (err != nil) {return nil, err}
+	sizeInverse, err := poly.field.Inverse(poly.field.FromInt(size))
+	if (err != nil) {return nil, err}
+	poly.transform(left, rootInverse)
+	for i := 0; i < count; i++{
+		left[i] = poly.field.Multiply(left[i], sizeInverse)
+	}
+	return newFieldPolynomial(poly.field, left[:count]), nil
+}
+
+/**
+ * Transform the values in place by the iterative Cooley-Tukey algorithm.
+ *
+ * @param values The values, whose number is a power of 2.
+ * @param root A primitive root of unity whose order is the number of values.
+ */
+func (poly *FieldPolynomial[E]) transform(values []E, root E){
+	count := len(values)
+	// bit-reversal permutation
+	for i, j := 1, 0; i < count; i++{
+		bit := count >> 1
+		for ; j & bit != 0; bit >>= 1{
+			j ^= bit
+		}
+		j ^= bit
+		if (i < j) {values[i], values[j] = values[j], values[i]}
+	}
+	for length := 2; length <= count; length <<= 1{
+		// root of unity of order length
+		step := root
+		for k := length; k < count; k <<= 1{
+			step = poly.field.Multiply(step, step)
+		}
+		half := length >> 1
+		for start := 0; start < count; start += length{
+			w := poly.field.One()
+			for j := 0; j < half; j++{
+				u := values[start + j]
+				v := poly.field.Multiply(values[start + j + half], w)
+				values[start + j] = poly.field.Add(u, v)
+				values[start + j + half] = poly.field.Subtract(u, v)
+				w = poly.field.Multiply(w, step)
+			}
+		}
+	}
+}
+
+/**
+ * Determine if the number-theoretic transform of the given length is supported over the field.
+ *
+ * @param count The number of coefficients of the product.
+ * @return True if the transform is supported, otherwise return false.
+ */
+func (poly *FieldPolynomial[E]) isNTTSupported(count int) bool{
+	size := 1
+	for (size < count) {size <<= 1}
+	_, err := getRootOfUnity(poly.field, size)
+	return err == nil
+}
+
+/**
+ * Calculate the power series inverse <i>g</i> of <i>f</i> with <i>fg</i> = 1 mod <i>x<sup>count</sup></i> by Newton's iteration
+ * <i>g</i> &larr; <i>g</i>(2 - <i>fg</i>), which doubles the precision each time.
+ *
+ * @param f Coefficients of <i>f</i>, <i>f</i>(0) should be invertible.
+ * @param count The precision.
+ * @return Coefficients of <i>g</i>, exactly count of them.
+ * @return error If <i>f</i>(0) is not invertible.
+ */
+func (poly *FieldPolynomial[E]) inverseSeries(f []E, count int) ([]E, error){
+	g0, err := poly.field.Inverse(f[0])
+	if (err != nil) {return nil, err}
+	g := newFieldPolynomial(poly.field, []E{g0})
+	two := poly.field.FromInt(2)
+	for precision := 1; precision < count; {
+		precision <<= 1
+		fTruncated := newFieldPolynomial(poly.field, poly.truncate(f, minInt(precision, len(f))))
+		product, err := fTruncated.Multiply(g)
+		if (err != nil) {return nil, err}
+		correction := poly.truncate(product.coefficients, precision)
+		for i := 0; i < len(correction); i++{
+			correction[i] = poly.field.Negate(correction[i])
+		}
+		correction[0] = poly.field.Add(correction[0], two)
+		g, err = g.Multiply(newFieldPolynomial(poly.field, correction))
+		if (err != nil) {return nil, err}
+		g = newFieldPolynomial(poly.field, poly.truncate(g.coefficients, precision))
+	}
+	return poly.truncate(g.coefficients, count), nil
+}
+
+/**
+ * Divide by Newton's iteration in O(M(<i>n</i>)), where M(<i>n</i>) is the cost of multiplication.
+ * <p>
+ * With rev(<i>f</i>) = <i>x</i><sup>deg <i>f</i></sup><i>f</i>(1/<i>x</i>), the quotient satisfies
+ * rev(<i>q</i>) = rev(<i>f</i>) rev(<i>g</i>)<sup>-1</sup> mod <i>x</i><sup>deg <i>f</i> - deg <i>g</i> + 1</sup>.
+ *
+ * @param dividend Trimmed coefficients of the dividend <i>f</i>.
+ * @param divisor Trimmed coefficients of the divisor <i>g</i>, with deg <i>g</i> &le; deg <i>f</i>.
+ * @return The quotient.
+ * @return The remainder.
+ * @return error If the leading coefficient of the divisor is not invertible.
+ */
+func (poly *FieldPolynomial[E]) divideNewton(dividend []E, divisor []E) (*FieldPolynomial[E], *FieldPolynomial[E], error){
+	count := len(dividend) - len(divisor) + 1
+	inverse, err := poly.inverseSeries(reverse(divisor), count)
+	if (err != nil) {return nil, nil, err}
+	reversedDividend := newFieldPolynomial(poly.field, reverse(dividend)[:count])
+	reversedQuotient, err := reversedDividend.Multiply(newFieldPolynomial(poly.field, inverse))
+	if (err != nil) {return nil, nil, err}
+	quotient := newFieldPolynomial(poly.field, reverse(poly.truncate(reversedQuotient.coefficients, count)))
+	product, err := quotient.Multiply(newFieldPolynomial(poly.field, divisor))
+	if (err != nil) {return nil, nil, err}
+	remainder, err := newFieldPolynomial(poly.field, dividend).Subtract(product)
+	if (err != nil) {return nil, nil, err}
+	return quotient, remainder, nil
+}
+
+/**
+ * Copy the first count coefficients, padded with zeros if there are fewer.
+ *
+ * @param coefficients The coefficient array.
+ * @param count Number of coefficients.
+ * @return The truncated copy.
+ */
+func (poly *FieldPolynomial[E]) truncate(coefficients []E, count int) []E{
+	feedback := poly.zeros(count)
+	copy(feedback, coefficients[:minInt(count, len(coefficients))])
+	return feedback
+}
+
+/**
+ * Copy a coefficient array in reversed order.
+ *
+ * @param coefficients The coefficient array.
+ * @return The reversed copy.
+ */
+func reverse[E any](coefficients []E) []E{
+	feedback := make([]E, len(coefficients))
+	for i := 0; i < len(coefficients); i++{
+		feedback[i] = coefficients[len(coefficients) - 1 - i]
+	}
+	return feedback
+}
+
+/**
+ * Get the minimum of two ints.
+ *
+ * @param a The first int.
+ * @param b The second int.
+ * @return The minimum.
+ */
+func minInt(a int, b int) int{
+	if (a < b) {return a}
+	return b
+}
+
+/**
+ * Get a primitive root of unity of the given order in the field.
+ * <p>
+ * If <i>z</i> is a quadratic non-residue, <i>w</i> = <i>z</i><sup>(<i>p</i>-1)/<i>order</i></sup> satisfies <i>w<sup>order</sup></i> = 1 and
+ * <i>w</i><sup><i>order</i>/2</sup> = <i>z</i><sup>(<i>p</i>-1)/2</sup> = -1, so its order is exactly <i>order</i>.
+ *
+ * @param field The field <i>Zp</i>, <i>p</i> should be an odd prime.
+ * @param order The order, should be a power of 2 dividing <i>p</i>-1.
+ * @return The root of unity.
+ * @return error If the order is invalid or <i>Zp</i> has no such root.
+ */
+func getRootOfUnity[E any](field Field[E], order int) (E, error){
+	if (order < 1 || order & (order - 1) != 0){
+		return field.Zero(), errors.New("Order of root of unity should be a power of 2.")
+	}
+	pMinusOne := field.ToBigInt(field.GetModulus())
+	pMinusOne.Sub(pMinusOne, big.NewInt(1))
+	exponent := big.NewInt(0)
+	remainder := big.NewInt(0)
+	exponent.DivMod(pMinusOne, big.NewInt(int64(order)), remainder)
+	if (remainder.Sign() != 0){
+		return field.Zero(), errors.New("Modulus is not NTT-friendly, order of root of unity should divide modulus - 1.")
+	}
+	if (order == 1) {return field.One(), nil}
+	half := big.NewInt(0)
+	half.Rsh(pMinusOne, 1)
+	minusOne := field.FromInt(-1)
+	for z := 2; z < 1000; z++{
+		if (field.Equal(power(field, field.FromInt(z), half), minusOne)){
+			return power(field, field.FromInt(z), exponent), nil
+		}
+	}
+	return field.Zero(), errors.New("Quadratic non-residue not found, modulus should be prime.")
+}
+
+/**
+ * Calculate <i>a<sup>e</sup></i> mod <i>p</i> by square-and-multiply.
+ *
+ * @param field The field <i>Zp</i>.
+ * @param a The base.
+ * @param exponent The non-negative exponent <i>e</i>.
+ * @return The power.
+ */
+func power[E any](field Field[E], a E, exponent *big.Int) E{
+	feedback := field.One()
+	for i := exponent.BitLen() - 1; i >= 0; i--{
+		feedback = field.Multiply(feedback, feedback)
+		if (exponent.Bit(i) == 1) {feedback = field.Multiply(feedback, a)}
+	}
+	return feedback
+}
+
+/**
+ * Generate an NTT-friendly prime <i>p</i> = <i>c</i>2<sup><i>s</i></sup> + 1 with random <i>c</i>, so that the number-theoretic
+ * transform of any length up to 2<sup><i>s</i></sup> is supported over <i>Zp</i>.
+ *
+ * @param bits Bit length of <i>p</i>, should be greater than <i>s</i> + 1.
+ * @param logOrder <i>s</i>, the binary logarithm of the maximum transform length.
+ * @return The prime.
+ * @return error If the parameters are invalid or random numbers cannot be generated.
+ */
+func GenerateNTTFriendlyPrime(bits int, logOrder int) (*big.Int, error){
+	if (logOrder < 1 || bits <= logOrder + 1){
+		return nil, errors.New("Bit length should be greater than binary logarithm of order + 1.")
+	}
+	one := big.NewInt(1)
+	max := big.NewInt(0)
+	max.Lsh(one, uint(bits - logOrder - 1))
+	p := big.NewInt(0)
+	for {
+		c, err := rand.Int(rand.Reader, max)
+		if (err != nil) {return nil, err}
+		// top bit set, so that p has exactly the given bit length
+		c.Add(c, max)
+		p.Lsh(c, uint(logOrder)).Add(p, one)
+		if (p.ProbablyPrime(20)) {return p, nil}
+	}
+}
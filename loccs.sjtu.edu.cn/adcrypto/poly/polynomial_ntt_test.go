@@ -0,0 +1,88 @@
+package poly
+
+import (
+	"testing"
+	"crypto/rand"
+	"math/big"
+	"fmt"
+)
+
+func TestGenerateNTTFriendlyPrime(t *testing.T) {
+	prime, err := GenerateNTTFriendlyPrime(256, 20)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating NTT-friendly prime: %s", err))}
+	if (prime.BitLen() != 256 || !prime.ProbablyPrime(20)){
+		t.Error(fmt.Sprintf("Invalid NTT-friendly prime: %s", prime))
+	}
+	root, err := getRootOfUnity[*big.Int](&BigIntField{modulus: prime}, 1 << 20)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating root of unity: %s", err))}
+	if (big.NewInt(0).Exp(root, big.NewInt(1 << 20), prime).Cmp(big.NewInt(1)) != 0 ||
+		big.NewInt(0).Exp(root, big.NewInt(1 << 19), prime).Cmp(big.NewInt(1)) == 0){
+		t.Error("Root of unity is not primitive.")
+	}
+	_, err = GenerateNTTFriendlyPrime(20, 20)
+	if err == nil {t.Error("Invalid parameters should not be accepted.")}
+}
+
+func TestPolynomialInt_MultiplyNTT(t *testing.T) {
+	// 998244353 = 119 * 2^23 + 1
+	t.Run("TestPolynomialInt_MultiplyNTT1", testPolynomialInt_MultiplyNTTFunc(998244353, 3, 5, false))
+	t.Run("TestPolynomialInt_MultiplyNTT2", testPolynomialInt_MultiplyNTTFunc(998244353, 100, 70, false))
+	t.Run("TestPolynomialInt_MultiplyNTT3", testPolynomialInt_MultiplyNTTFunc(1000000007, 100, 70, true))
+}
+
+func testPolynomialInt_MultiplyNTTFunc(modulus int, degreeF int, degreeG int, errorExpected bool) func(t *testing.T) {
+	return func(t *testing.T) {
+		f := randomPolynomialInt(degreeF, modulus)
+		g := randomPolynomialInt(degreeG, modulus)
+		product, err := f.MultiplyNTT(g)
+		if (errorExpected){
+			if err == nil {t.Error("Modulus which is not NTT-friendly should not be accepted.")}
+			return
+		}
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when multiplying: %s", err))}
+		if (product.GetDegree() != degreeF + degreeG) {t.Error("Degree of the product is False.")}
+		for x := 0; x < 10; x++{
+			fx, _ := f.Calculate(x)
+			gx, _ := g.Calculate(x)
+			px, _ := product.Calculate(x)
+			if (int64(px.(int)) != int64(fx.(int)) * int64(gx.(int)) % int64(modulus)){
+				t.Error(fmt.Sprintf("Calculated product is False on %d.", x))
+			}
+		}
+	}
+}
+
+func TestPolynomialBigInt_DivideNewton(t *testing.T) {
+	modulus, err := GenerateNTTFriendlyPrime(128, 12)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating NTT-friendly prime: %s", err))}
+	f := randomPolynomialBigInt(300, modulus)
+	g := randomPolynomialBigInt(100, modulus)
+	quotient, remainder, err := f.Divide(g)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when dividing: %s", err))}
+	if (quotient.GetDegree() != 200 || remainder.GetDegree() >= 100){
+		t.Error("Degrees of quotient and remainder are False.")
+	}
+	restored, _ := quotient.MultiplyNTT(g)
+	restored, _ = restored.Add(remainder)
+	if (fmt.Sprint(restored.GetCoefficients()) != fmt.Sprint(f.GetCoefficients())) {t.Error("Calculated division is False.")}
+}
+
+func randomPolynomialInt(degree int, modulus int) PolynomialCalculator {
+	coefficients := make([]int, degree + 1)
+	for i := 0; i <= degree; i++{
+		tmp, _ := rand.Int(rand.Reader, big.NewInt(int64(modulus - 1)))
+		coefficients[i] = int(tmp.Int64()) + 1
+	}
+	feedback, _ := NewPolynomialInt(degree, coefficients, modulus)
+	return feedback
+}
+
+func randomPolynomialBigInt(degree int, modulus *big.Int) PolynomialCalculator {
+	coefficients := make([]*big.Int, degree + 1)
+	for i := 0; i <= degree; i++{
+		coefficients[i], _ = rand.Int(rand.Reader, big.NewInt(0).Sub(modulus, big.NewInt(1)))
+		coefficients[i].Add(coefficients[i], big.NewInt(1))
+	}
+	feedback, _ := NewPolynomialBigInt(degree, coefficients, modulus)
+	return feedback
+}
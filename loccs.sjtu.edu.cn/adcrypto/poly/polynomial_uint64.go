@@ -0,0 +1,39 @@
+package poly
+
+import (
+	"errors"
+)
+
+/**
+ * This class implements an uint64 polynomial over <i>Zp</i> with single variable, for any modulus <i>p</i> < 2<sup>64</sup>,
+ * i.e. a polynomial over <code>Uint64Field</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type PolynomialUint64 = FieldPolynomialCalculator[uint64]
+
+/**
+ * Construct polynomialUint64 with degree, coefficients and modulus.
+ * <p>
+ * Number of coefficients must be degree + 1, coefficients[i] contains <i>a<sub>i</sub></i> (0 ≤ <i>i</i> ≤ <i>k</i>).
+ *
+ * @param degree Degree of the polynomial.
+ * @param coefficients	Coefficients of the polynomial.
+ * @param modulus Modulus p of the polynomial.
+ * @return polyFeedback The constructed PolynomialUint64
+ * @return error If any of the degree, coefficients and modulus is invalid.
+ */
+func NewPolynomialUint64(degree int, coefficients []uint64, modulus uint64) (*PolynomialUint64, error){
+	if (degree < 0) {
+		return nil, errors.New("Invalid polynomial degree, should not be less than 0.")
+	}
+	if ((coefficients == nil) || len(coefficients) != (degree + 1)){
+		return nil, errors.New("Number of polynomial coefficients should be degree + 1.")
+	}
+	if (modulus < 2){
+		return nil, errors.New("Invalid polynomial modulus, should be greater than 1.")
+	}
+	// the modulus of a polynomial may be composite, so the field is not checked
+	return NewFieldPolynomialCalculator[uint64](&Uint64Field{modulus: modulus}, coefficients)
+}
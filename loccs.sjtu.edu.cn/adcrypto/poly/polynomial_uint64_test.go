@@ -0,0 +1,177 @@
+package poly
+
+import (
+	"testing"
+	"crypto/rand"
+	"math/big"
+	"fmt"
+)
+
+// primes just above 2^63, just below 2^63 and just below 2^64, and the NTT-friendly 2^64 - 2^32 + 1
+var primesUint64 = []uint64{9223372036854775837, 9223372036854775783, 18446744073709551557, 18446744069414584321}
+
+func TestModularUint64(t *testing.T) {
+	for _, modulus := range primesUint64{
+		p := new(big.Int).SetUint64(modulus)
+		edges := []uint64{0, 1, 2, modulus - 2, modulus - 1, modulus >> 1, (modulus >> 1) + 1}
+		for i := 0; i < 20; i++{
+			random, _ := rand.Int(rand.Reader, p)
+			edges = append(edges, random.Uint64())
+		}
+		for _, a := range edges{
+			for _, b := range edges{
+				bigA := new(big.Int).SetUint64(a)
+				bigB := new(big.Int).SetUint64(b)
+				expected := big.NewInt(0)
+				if (AddModUint64(a, b, modulus) != expected.Add(bigA, bigB).Mod(expected, p).Uint64()){
+					t.Fatal(fmt.Sprintf("Sum is False, %d + %d mod %d", a, b, modulus))
+				}
+				if (SubtractModUint64(a, b, modulus) != expected.Sub(bigA, bigB).Mod(expected, p).Uint64()){
+					t.Fatal(fmt.Sprintf("Difference is False, %d - %d mod %d", a, b, modulus))
+				}
+				if (MultiplyModUint64(a, b, modulus) != expected.Mul(bigA, bigB).Mod(expected, p).Uint64()){
+					t.Fatal(fmt.Sprintf("Product is False, %d * %d mod %d", a, b, modulus))
+				}
+				if (ExponentModUint64(a, b, modulus) != expected.Exp(bigA, bigB, p).Uint64()){
+					t.Fatal(fmt.Sprintf("Power is False, %d ^ %d mod %d", a, b, modulus))
+				}
+			}
+			inverse, err := InverseModUint64(a, modulus)
+			if (a == 0){
+				if err == nil {t.Error("0 should not be invertible.")}
+				continue
+			}
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating an inverse: %s", err))}
+			if (MultiplyModUint64(a, inverse, modulus) != 1){
+				t.Error(fmt.Sprintf("Inverse is False, %d ^ -1 mod %d", a, modulus))
+			}
+		}
+	}
+}
+
+func TestNewPolynomialUint64(t *testing.T) {
+	t.Run("TestNewPolynomialUint641", testNewPolynomialUint64Func(3, []uint64{78,4,71,7002}, 7, false))
+	t.Run("TestNewPolynomialUint642", testNewPolynomialUint64Func(3, []uint64{78,4,71}, 7, true))
+	t.Run("TestNewPolynomialUint643", testNewPolynomialUint64Func(3, []uint64{78,4,71,7002}, 1, true))
+	t.Run("TestNewPolynomialUint644", testNewPolynomialUint64Func(1, []uint64{1 << 63, 1<<64 - 1}, primesUint64[2], false))
+}
+
+func testNewPolynomialUint64Func(degree int, coefficients []uint64, modulus uint64, errorExpected bool) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, err := NewPolynomialUint64(degree, coefficients, modulus)
+		if errorExpected == false && err != nil {
+			t.Error(fmt.Sprintf("Error happens when constructing a PolynomialUint64: %s", err))
+		} else if errorExpected == true && err == nil {
+			t.Error("Invalid PolynomialUint64 should not be constructed.")
+		}
+	}
+}
+
+func TestPolynomialUint64_Calculate(t *testing.T) {
+	for i, modulus := range primesUint64{
+		t.Run(fmt.Sprintf("TestPolynomialUint64_Calculate%d", i + 1), testPolynomialUint64_CalculateFunc(10, modulus))
+	}
+}
+
+func testPolynomialUint64_CalculateFunc(degree int, modulus uint64) func(t *testing.T) {
+	return func(t *testing.T) {
+		newPoly := randomPolynomialUint64(degree, modulus)
+		coefficients := make([]*big.Int, degree + 1)
+		for i := 0; i <= degree; i++{
+			coefficients[i] = new(big.Int).SetUint64(newPoly.GetCoefficients()[i].(uint64))
+		}
+		p := new(big.Int).SetUint64(modulus)
+		bigPoly, err := NewPolynomialBigInt(degree, coefficients, p)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a PolynomialBigInt: %s", err))}
+		for _, x := range []uint64{0, 1, modulus - 1, modulus >> 1, 1<<64 - 1}{
+			feedback, err := newPoly.Calculate(x)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating the result: %s", err))}
+			expected, _ := bigPoly.Calculate(new(big.Int).SetUint64(x))
+			if (feedback.(uint64) != expected.(*big.Int).Uint64()){
+				t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: %s", feedback, expected))
+			}
+		}
+		_, err = newPoly.Calculate(1)
+		if err == nil {t.Error("Invalid type of input should not be accepted.")}
+	}
+}
+
+func TestPolynomialUint64_Arithmetic(t *testing.T) {
+	for _, modulus := range primesUint64{
+		f := randomPolynomialUint64(40, modulus)
+		g := randomPolynomialUint64(35, modulus)
+		product, err := f.Multiply(g)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when multiplying: %s", err))}
+		quotient, remainder, err := product.Divide(g)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when dividing: %s", err))}
+		if (fmt.Sprint(quotient.GetCoefficients()) != fmt.Sprint(f.GetCoefficients()) || !remainder.IsZero()){
+			t.Error(fmt.Sprintf("Division is False over Z%d.", modulus))
+		}
+		sum, err := f.Add(g)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding: %s", err))}
+		difference, err := sum.Subtract(g)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when subtracting: %s", err))}
+		if (fmt.Sprint(difference.GetCoefficients()) != fmt.Sprint(f.GetCoefficients())){
+			t.Error(fmt.Sprintf("Addition and subtraction are False over Z%d.", modulus))
+		}
+		x := modulus - 3
+		fx, _ := f.Calculate(x)
+		gx, _ := g.Calculate(x)
+		px, _ := product.Calculate(x)
+		if (px.(uint64) != MultiplyModUint64(fx.(uint64), gx.(uint64), modulus)){
+			t.Error(fmt.Sprintf("Calculated product is False over Z%d.", modulus))
+		}
+	}
+}
+
+func TestPolynomialUint64_MultiplyNTT(t *testing.T) {
+	// 2^64 - 2^32 + 1 supports transforms of length up to 2^32
+	modulus := primesUint64[3]
+	f := randomPolynomialUint64(100, modulus)
+	g := randomPolynomialUint64(70, modulus)
+	product, err := f.MultiplyNTT(g)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when multiplying: %s", err))}
+	for _, x := range []uint64{0, 1, 2, modulus - 1, modulus >> 1}{
+		fx, _ := f.Calculate(x)
+		gx, _ := g.Calculate(x)
+		px, _ := product.Calculate(x)
+		if (px.(uint64) != MultiplyModUint64(fx.(uint64), gx.(uint64), modulus)){
+			t.Error(fmt.Sprintf("Calculated product is False on %d.", x))
+		}
+	}
+	_, err = randomPolynomialUint64(100, primesUint64[2]).MultiplyNTT(randomPolynomialUint64(70, primesUint64[2]))
+	if err == nil {t.Error("Modulus which is not NTT-friendly should not be accepted.")}
+}
+
+func TestSubproductTreeUint64(t *testing.T) {
+	modulus := primesUint64[0]
+	points := []uint64{1, 2, modulus - 1, 1 << 63, 12345678901234567890}
+	tree, err := NewSubproductTreeUint64(points, modulus)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a SubproductTree: %s", err))}
+	f := randomPolynomialUint64(len(points) - 1, modulus)
+	values, err := tree.Evaluate(f)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when evaluating: %s", err))}
+	for i := 0; i < len(points); i++{
+		expected, _ := f.Calculate(points[i])
+		if (values[i] != expected){
+			t.Error(fmt.Sprintf("Evaluated Result is False, Result:%d ,Expected: %d", values[i], expected))
+		}
+	}
+	interpolated, err := tree.Interpolate(values)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when interpolating: %s", err))}
+	if (fmt.Sprint(interpolated.GetCoefficients()) != fmt.Sprint(f.GetCoefficients())){
+		t.Error("Interpolated Polynomial is False.")
+	}
+}
+
+func randomPolynomialUint64(degree int, modulus uint64) *PolynomialUint64{
+	coefficients := make([]uint64, degree + 1)
+	p := new(big.Int).SetUint64(modulus)
+	for i := 0; i <= degree; i++{
+		random, _ := rand.Int(rand.Reader, p)
+		coefficients[i] = random.Uint64()
+	}
+	if (coefficients[degree] == 0) {coefficients[degree] = 1}
+	feedback, _ := NewPolynomialUint64(degree, coefficients, modulus)
+	return feedback
+}
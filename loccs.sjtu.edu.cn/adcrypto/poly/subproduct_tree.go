@@ -0,0 +1,360 @@
+package poly
+
+import (
+	"errors"
+	"math/big"
+)
+
+/**
+ * This class implements the subproduct tree of a set of evaluation points over <i>Zp</i>, for fast multipoint evaluation
+ * and interpolation, as in "von zur Gathen J, Gerhard J. Modern computer algebra. Cambridge University Press; 2013. Chapter 10."
+ * <p>
+ * The leaves are <i>x</i> - <i>x<sub>i</sub></i>, and every inner node is the product of its children, so the root is
+ * <i>M</i>(<i>x</i>) = &prod;(<i>x</i> - <i>x<sub>i</sub></i>). A polynomial is evaluated on all points by reducing it modulo the
+ * nodes from the root down to the leaves, and interpolation combines the values from the leaves up to the root.
+ * <p>
+ * With NTT-friendly primes, multiplication and division take O(<i>n</i> log <i>n</i>), so both multipoint evaluation and
+ * interpolation take O(<i>n</i> log<sup>2</sup> <i>n</i>). Otherwise they fall back to the schoolbook methods.
+ * <p>
+ * <code>SubproductTree</code> is the view of it taking elements as interface{}, constructed by <code>NewSubproductTreeInt</code>,
+ * <code>NewSubproductTreeBigInt</code> or <code>NewSubproductTreeUint64</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FieldSubproductTree[E any] struct {
+	/**
+	 * The field <i>Zp</i>.
+	 */
+	field Field[E]
+
+	/**
+	 * The evaluation points.
+	 */
+	points []E
+
+	/**
+	 * levels[0] are the leaves, levels[i+1][j] is the product of levels[i][2j] and levels[i][2j+1],
+	 * or levels[i][2j] itself if it has no sibling. The last level contains only the root.
+	 */
+	levels [][]*FieldPolynomial[E]
+}
+
+/**
+ * Construct the subproduct tree of evaluation points over a field.
+ *
+ * @param field The field <i>Zp</i>.
+ * @param points The evaluation points.
+ * @return The constructed FieldSubproductTree
+ * @return error If the field is nil or no point is given.
+ */
+func NewFieldSubproductTree[E any](field Field[E], points []E) (*FieldSubproductTree[E], error){
+	if (field == nil){
+		return nil, errors.New("Field should not be nil.")
+	}
+	if (len(points) == 0){
+		return nil, errors.New("At least one evaluation point should be provided.")
+	}
+	leaves := make([]*FieldPolynomial[E], len(points))
+	for i := 0; i < len(points); i++{
+		leaves[i] = newFieldPolynomial(field, []E{field.Negate(points[i]), field.One()})
+	}
+	feedback := new(FieldSubproductTree[E])
+	feedback.field = field
+	feedback.points = append([]E{}, points...)
+	feedback.levels = [][]*FieldPolynomial[E]{leaves}
+	for current := leaves; len(current) > 1; {
+		next := make([]*FieldPolynomial[E], (len(current) + 1) / 2)
+		for j := 0; j < len(next); j++{
+			if (2 * j + 1 == len(current)){
+				next[j] = current[2 * j]
+				continue
+			}
+			product, err := current[2 * j].Multiply(current[2 * j + 1])
+			if (err != nil) {return nil, err}
+			next[j] = product
+		}
+		feedback.levels = append(feedback.levels, next)
+		current = next
+	}
+	return feedback, nil
+}
+
+/**
+ * Get the evaluation points.
+ *
+ * @return A copy of the evaluation points.
+ */
+func (tree *FieldSubproductTree[E]) GetPoints() []E{
+	return append([]E{}, tree.points...)
+}
+
+/**
+ * Get the root <i>M</i>(<i>x</i>) = &prod;(<i>x</i> - <i>x<sub>i</sub></i>) of the tree.
+ *
+ * @return The root polynomial.
+ */
+func (tree *FieldSubproductTree[E]) GetRoot() *FieldPolynomial[E]{
+	return tree.levels[len(tree.levels) - 1][0]
+}
+
+/**
+ * Evaluate a polynomial on all evaluation points, i.e. <i>f</i>(<i>x<sub>i</sub></i>) = <i>f</i> mod (<i>x</i> - <i>x<sub>i</sub></i>).
+ *
+ * @param f The polynomial, over the same field.
+ * @return The values <i>f</i>(<i>x</i><sub>1</sub>), <i>f</i>(<i>x</i><sub>2</sub>), ..., <i>f</i>(<i>x<sub>n</sub></i>).
+ * @return error If the polynomial is invalid.
+ */
+func (tree *FieldSubproductTree[E]) Evaluate(f *FieldPolynomial[E]) ([]E, error){
+	if (f == nil){
+		return nil, errors.New("Polynomial should not be nil.")
+	}
+	_, remainder, err := f.Divide(tree.GetRoot())
+	if (err != nil) {return nil, err}
+	remainders := []*FieldPolynomial[E]{remainder}
+	for level := len(tree.levels) - 2; level >= 0; level--{
+		nodes := tree.levels[level]
+		next := make([]*FieldPolynomial[E], len(nodes))
+		for j := 0; j < len(nodes); j++{
+			_, next[j], err = remainders[j / 2].Divide(nodes[j])
+			if (err != nil) {return nil, err}
+		}
+		remainders = next
+	}
+	feedback := make([]E, len(remainders))
+	for i := 0; i < len(remainders); i++{
+		feedback[i] = remainders[i].coefficients[0]
+	}
+	return feedback, nil
+}
+
+/**
+ * Calculate the polynomial <i>f</i> of degree less than <i>n</i> with <i>f</i>(<i>x<sub>i</sub></i>) = <i>y<sub>i</sub></i>.
+ * <p>
+ * <i>f</i> = &sum; <i>c<sub>i</sub></i><i>M</i>(<i>x</i>)/(<i>x</i> - <i>x<sub>i</sub></i>) with <i>c<sub>i</sub></i> = <i>y<sub>i</sub></i>/<i>M</i>'(<i>x<sub>i</sub></i>),
+ * where <i>M</i>'(<i>x<sub>i</sub></i>) are evaluated on the tree, and the sum is combined from the leaves up to the root.
+ *
+ * @param values The values <i>y</i><sub>1</sub>, <i>y</i><sub>2</sub>, ..., <i>y<sub>n</sub></i>.
+ * @return The polynomial.
+ * @return error If the number of values is wrong, or the evaluation points are not distinct.
+ */
+func (tree *FieldSubproductTree[E]) Interpolate(values []E) (*FieldPolynomial[E], error){
+	leaves := tree.levels[0]
+	if (len(values) != len(leaves)){
+		return nil, errors.New("Number of values should be equal to number of evaluation points.")
+	}
+	weights, err := tree.Evaluate(tree.GetRoot().Derivative())
+	if (err != nil) {return nil, err}
+	current := make([]*FieldPolynomial[E], len(leaves))
+	for i := 0; i < len(leaves); i++{
+		inverse, err := tree.field.Inverse(weights[i])
+		if (err != nil) {return nil, errors.New("Evaluation points should be distinct.")}
+		current[i] = newFieldPolynomial(tree.field, []E{tree.field.Multiply(values[i], inverse)})
+	}
+	for level := 0; level < len(tree.levels) - 1; level++{
+		nodes := tree.levels[level]
+		next := make([]*FieldPolynomial[E], len(tree.levels[level + 1]))
+		for j := 0; j < len(next); j++{
+			if (2 * j + 1 == len(nodes)){
+				next[j] = current[2 * j]
+				continue
+			}
+			left, err := current[2 * j].Multiply(nodes[2 * j + 1])
+			if (err != nil) {return nil, err}
+			right, err := current[2 * j + 1].Multiply(nodes[2 * j])
+			if (err != nil) {return nil, err}
+			next[j], err = left.Add(right)
+			if (err != nil) {return nil, err}
+		}
+		current = next
+	}
+	return current[0], nil
+}
+
+/**
+ * Calculate the results of the polynomial on many points at once with a subproduct tree.
+ *
+ * @param points The values of variable <i>x</i>.
+ * @return The results of the polynomial, one for each point.
+ * @return error If no point is given.
+ */
+func (poly *FieldPolynomial[E]) CalculateMultipoint(points []E) ([]E, error){
+	tree, err := NewFieldSubproductTree(poly.field, points)
+	if (err != nil) {return nil, err}
+	return tree.Evaluate(poly)
+}
+
+/**
+ * This class is the view of <code>FieldSubproductTree</code> taking elements and polynomials as interface{}.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type SubproductTree struct {
+	/**
+	 * The evaluation points.
+	 */
+	points []interface{}
+
+	/**
+	 * The tree over the field of the points.
+	 */
+	tree subproductTreeCalculator
+}
+
+/**
+ * Interface of the subproduct trees over the fields of different element types.
+ */
+type subproductTreeCalculator interface {
+	GetRoot() PolynomialCalculator
+	Evaluate(f PolynomialCalculator) ([]interface{}, error)
+	Interpolate(values []interface{}) (PolynomialCalculator, error)
+}
+
+/**
+ * This class adapts a <code>FieldSubproductTree</code> to <code>subproductTreeCalculator</code>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type fieldSubproductTreeCalculator[E any] struct {
+	/**
+	 * The tree over the field.
+	 */
+	tree *FieldSubproductTree[E]
+
+	/**
+	 * Any polynomial over the field, whose modulus is kept by the polynomials in the results.
+	 */
+	sample *FieldPolynomialCalculator[E]
+}
+
+/**
+ * Construct the subproduct tree of int evaluation points.
+ *
+ * @param points The evaluation points.
+ * @param modulus Modulus <i>p</i>, should be prime.
+ * @return The constructed SubproductTree
+ * @return error If the points or the modulus is invalid.
+ */
+func NewSubproductTreeInt(points []int, modulus int) (*SubproductTree, error){
+	sample, err := NewPolynomialInt(0, []int{0}, modulus)
+	if (err != nil) {return nil, err}
+	return newSubproductTree(points, sample)
+}
+
+/**
+ * Construct the subproduct tree of BigInt evaluation points.
+ *
+ * @param points The evaluation points.
+ * @param modulus Modulus <i>p</i>, should be prime.
+ * @return The constructed SubproductTree
+ * @return error If the points or the modulus is invalid.
+ */
+func NewSubproductTreeBigInt(points []*big.Int, modulus *big.Int) (*SubproductTree, error){
+	sample, err := NewPolynomialBigInt(0, []*big.Int{big.NewInt(0)}, modulus)
+	if (err != nil) {return nil, err}
+	for i := 0; i < len(points); i++{
+		if (points[i] == nil) {return nil, errors.New("Evaluation point should not be nil.")}
+	}
+	return newSubproductTree(points, sample)
+}
+
+/**
+ * Construct the subproduct tree of uint64 evaluation points.
+ *
+ * @param points The evaluation points.
+ * @param modulus Modulus <i>p</i>, should be prime.
+ * @return The constructed SubproductTree
+ * @return error If the points or the modulus is invalid.
+ */
+func NewSubproductTreeUint64(points []uint64, modulus uint64) (*SubproductTree, error){
+	sample, err := NewPolynomialUint64(0, []uint64{0}, modulus)
+	if (err != nil) {return nil, err}
+	return newSubproductTree(points, sample)
+}
+
+/**
+ * Construct the subproduct tree over the field of the sample.
+ *
+ * @param points The evaluation points.
+ * @param sample A polynomial over the same field.
+ * @return The constructed SubproductTree
+ * @return error If the points are invalid.
+ */
+func newSubproductTree[E any](points []E, sample *FieldPolynomialCalculator[E]) (*SubproductTree, error){
+	tree, err := NewFieldSubproductTree(sample.GetField(), points)
+	if (err != nil) {return nil, err}
+	feedback := new(SubproductTree)
+	feedback.points = toInterfaces(points)
+	feedback.tree = &fieldSubproductTreeCalculator[E]{tree: tree, sample: sample}
+	return feedback, nil
+}
+
+/**
+ * Get the evaluation points.
+ *
+ * @return The evaluation points.
+ */
+func (tree *SubproductTree) GetPoints() []interface{}{
+	return tree.points
+}
+
+/**
+ * Get the root <i>M</i>(<i>x</i>) = &prod;(<i>x</i> - <i>x<sub>i</sub></i>) of the tree.
+ *
+ * @return The root polynomial.
+ */
+func (tree *SubproductTree) GetRoot() PolynomialCalculator{
+	return tree.tree.GetRoot()
+}
+
+/**
+ * Evaluate a polynomial on all evaluation points, i.e. <i>f</i>(<i>x<sub>i</sub></i>) = <i>f</i> mod (<i>x</i> - <i>x<sub>i</sub></i>).
+ *
+ * @param f The polynomial, over the same <i>Zp</i>.
+ * @return The values <i>f</i>(<i>x</i><sub>1</sub>), <i>f</i>(<i>x</i><sub>2</sub>), ..., <i>f</i>(<i>x<sub>n</sub></i>).
+ * @return error If the polynomial is invalid.
+ */
+func (tree *SubproductTree) Evaluate(f PolynomialCalculator) ([]interface{}, error){
+	return tree.tree.Evaluate(f)
+}
+
+/**
+ * Calculate the polynomial <i>f</i> of degree less than <i>n</i> with <i>f</i>(<i>x<sub>i</sub></i>) = <i>y<sub>i</sub></i>.
+ *
+ * @param values The values <i>y</i><sub>1</sub>, <i>y</i><sub>2</sub>, ..., <i>y<sub>n</sub></i>.
+ * @return The polynomial.
+ * @return error If the values are invalid, or the evaluation points are not distinct.
+ */
+func (tree *SubproductTree) Interpolate(values []interface{}) (PolynomialCalculator, error){
+	return tree.tree.Interpolate(values)
+}
+
+func (calculator *fieldSubproductTreeCalculator[E]) GetRoot() PolynomialCalculator{
+	return calculator.sample.wrap(calculator.tree.GetRoot())
+}
+
+func (calculator *fieldSubproductTreeCalculator[E]) Evaluate(f PolynomialCalculator) ([]interface{}, error){
+	if (f == nil){
+		return nil, errors.New("Polynomial should not be nil.")
+	}
+	polynomial, err := calculator.sample.checkPolynomial(f)
+	if (err != nil) {return nil, err}
+	feedback, err := calculator.tree.Evaluate(polynomial)
+	if (err != nil) {return nil, err}
+	return toInterfaces(feedback), nil
+}
+
+func (calculator *fieldSubproductTreeCalculator[E]) Interpolate(values []interface{}) (PolynomialCalculator, error){
+	if (len(values) != len(calculator.tree.points)){
+		return nil, errors.New("Number of values should be equal to number of evaluation points.")
+	}
+	valuesE, ok := fromInterfaces[E](values)
+	if (!ok){
+		return nil, errors.New("Invalid type of values.")
+	}
+	feedback, err := calculator.tree.Interpolate(valuesE)
+	if (err != nil) {return nil, err}
+	return calculator.sample.wrap(feedback), nil
+}
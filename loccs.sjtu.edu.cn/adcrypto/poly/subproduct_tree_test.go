@@ -0,0 +1,71 @@
+package poly
+
+import (
+	"testing"
+	"crypto/rand"
+	"math/big"
+	"fmt"
+)
+
+func TestSubproductTreeInt(t *testing.T) {
+	t.Run("TestSubproductTreeInt1", testSubproductTreeIntFunc(998244353, 1, 0))
+	t.Run("TestSubproductTreeInt2", testSubproductTreeIntFunc(998244353, 77, 76))
+	t.Run("TestSubproductTreeInt3", testSubproductTreeIntFunc(998244353, 100, 250))
+	t.Run("TestSubproductTreeInt4", testSubproductTreeIntFunc(1000000007, 33, 10))
+}
+
+func testSubproductTreeIntFunc(modulus int, count int, degree int) func(t *testing.T) {
+	return func(t *testing.T) {
+		points := make([]int, count)
+		for i := 0; i < count; i++{
+			points[i] = 3 * i + 1
+		}
+		tree, err := NewSubproductTreeInt(points, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a SubproductTree: %s", err))}
+		if (tree.GetRoot().GetDegree() != count) {t.Error("Degree of the root is False.")}
+		f := randomPolynomialInt(degree, modulus)
+		values, err := tree.Evaluate(f)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when evaluating: %s", err))}
+		for i := 0; i < count; i++{
+			expected, _ := f.Calculate(points[i])
+			if (values[i] != expected){
+				t.Error(fmt.Sprintf("Evaluated Result is False, Result:%d ,Expected: %d",values[i],expected))
+			}
+		}
+		if (degree >= count) {return}
+		interpolated, err := tree.Interpolate(values)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when interpolating: %s", err))}
+		if (fmt.Sprint(interpolated.GetCoefficients()) != fmt.Sprint(f.GetCoefficients())){
+			t.Error(fmt.Sprintf("Interpolated Polynomial is False, Result:%v ,Expected: %v",
+				interpolated.GetCoefficients(),f.GetCoefficients()))
+		}
+	}
+}
+
+func TestSubproductTreeBigInt(t *testing.T) {
+	modulus, _ := rand.Prime(rand.Reader, 256)
+	nttModulus, err := GenerateNTTFriendlyPrime(256, 12)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating NTT-friendly prime: %s", err))}
+	for _, p := range []*big.Int{modulus, nttModulus}{
+		count := 70
+		points := make([]*big.Int, count)
+		values := make([]interface{}, count)
+		for i := 0; i < count; i++{
+			points[i], _ = rand.Int(rand.Reader, p)
+			values[i], _ = rand.Int(rand.Reader, p)
+		}
+		tree, err := NewSubproductTreeBigInt(points, p)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a SubproductTree: %s", err))}
+		interpolated, err := tree.Interpolate(values)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when interpolating: %s", err))}
+		evaluated, err := interpolated.CalculateMultipoint(tree.GetPoints())
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when evaluating: %s", err))}
+		if (fmt.Sprint(evaluated) != fmt.Sprint(values)) {t.Error("Interpolated polynomial does not pass through the values.")}
+	}
+
+	points := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(1)}
+	tree, err := NewSubproductTreeBigInt(points, modulus)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing a SubproductTree: %s", err))}
+	_, err = tree.Interpolate([]interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	if err == nil {t.Error("Evaluation points should be distinct for interpolation.")}
+}
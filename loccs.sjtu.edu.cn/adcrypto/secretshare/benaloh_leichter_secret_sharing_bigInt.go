@@ -0,0 +1,239 @@
+package secretshare
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+/**
+ * The class implements Benaloh-Leichter secret sharing over <i>Zp</i> for general monotone access structures.
+ * <p>
+ * The secret is shared along the formula of a <code>FormulaAccessStructure</code> from the root: a gate with threshold
+ * <i>k</i> over <i>m</i> children shares its value with Shamir's scheme, i.e. chooses a random <i>k</i>-1 degree polynomial
+ * <i>f</i> with <i>f</i>(0) = value and gives <i>f</i>(<i>j</i>) to its <i>j</i>-th child (<i>j</i> from 1 to <i>m</i>), and a leaf gives
+ * its value to its participant. AND and OR gates are the cases <i>k</i> = <i>m</i> and <i>k</i> = 1.
+ * <p>
+ * The shared value of a participant is a []interface{} of BigInt, one for each leaf of the participant in the order of
+ * the leaves in the formula (depth first, children from left to right). It is empty if the participant is not in the formula.
+ * The secret is recovered in the reverse way, by Lagrange interpolation at 0 on the first <i>k</i> recovered children of each gate.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type BenalohLeichterSecretSharingBigInt struct {
+	/**
+	 * The prime modulus <i>p</i>.
+	 */
+	modulus *big.Int
+
+	SecretSharingScheme
+}
+
+/**
+ * Construct Benaloh-Leichter secret sharing scheme over <i>Zp</i> with the number of participants and the modulus.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @param modulus The modulus <i>p</i>, should be a prime greater than 2.
+ * @return feedback the newly constructed BenalohLeichterSecretSharingBigInt
+ * @return error If the number of participants or the modulus is invalid.
+ */
+func NewBenalohLeichterSecretSharingBigInt(participantCount int, modulus *big.Int) (*BenalohLeichterSecretSharingBigInt, error){
+	if (participantCount < 2){
+		return nil, errors.New("Invalid participant count. Should be larger than 1.")
+	}
+	if (modulus == nil || modulus.Cmp(big.NewInt(2)) <= 0 || !modulus.ProbablyPrime(20)){
+		return nil, errors.New("Modulus should be a prime greater than 2.")
+	}
+	feedback := new(BenalohLeichterSecretSharingBigInt)
+	feedback.participantCount = participantCount
+	feedback.modulus = new(big.Int).Set(modulus)
+	feedback.SecretSharingSchemeITF = feedback
+	return feedback, nil
+}
+
+/**
+ * Get the modulus.
+ *
+ * @return The modulus <i>p</i>.
+ */
+func (blssb *BenalohLeichterSecretSharingBigInt) GetModulus() *big.Int{
+	return new(big.Int).Set(blssb.modulus)
+}
+
+/**
+ * Set access structure.
+ * <p>
+ * The participant count in AccessStructure and that in this scheme must be equal, and every gate should have fewer
+ * children than the modulus, so that the evaluation points 1, 2, ..., <i>m</i> are distinct and non-zero.
+ *
+ * @param access Access structure, should be FormulaAccessStructure.
+ * @return error If the access structure is invalid.
+ */
+func (blssb *BenalohLeichterSecretSharingBigInt) SetAccessStructure(access AccessStructureInterface) error{
+	accessValue, ok := access.(*FormulaAccessStructure)
+	if (!ok) {return errors.New("Invalid AccessStructure type. Should be 'FormulaAccessStructure'.")}
+	if (accessValue.participantCount != blssb.participantCount) {
+		return errors.New("The participant count in AccessStructure and Scheme should be equal.")
+	}
+	if (!blssb.checkGateSize(accessValue.formula)){
+		return errors.New("A gate has too many children for the modulus.")
+	}
+	blssb.access = accessValue
+	return nil
+}
+
+/**
+ * Determine if the scheme object is initialized properly for generating shares and calculating secret.
+ *
+ * @return True if the access structure is set, otherwise return false.
+ */
+func (blssb *BenalohLeichterSecretSharingBigInt) IsInitialized() bool{
+	return blssb.access != nil
+}
+
+/**
+ * Generate shares from input secret.
+ *
+ * @param secret The secret from which shares are generated, should be BigInt in [0, <i>p</i>).
+ * @param auxiliary Not used, should be nil.
+ * @return N shares, the value of each is a []interface{} of BigInt, one for each leaf of the participant.
+ * @return error If the secret or the auxiliary data is invalid.
+ */
+func (blssb *BenalohLeichterSecretSharingBigInt) generateSharesImpl(secret interface{}, auxiliary []interface{}) ([]*SecretShare, error){
+	secretValue, ok := secret.(*big.Int)
+	if (!ok || secretValue == nil) {return nil, errors.New("Invalid type of secret, should be BigInt.")}
+	if (secretValue.Sign() < 0 || secretValue.Cmp(blssb.modulus) >= 0){
+		return nil, errors.New("Secret should be in [0, p).")
+	}
+	if (auxiliary != nil) {return nil, errors.New("Auxiliary data is not used, should be nil.")}
+	values := make([][]interface{}, blssb.participantCount)
+	for i := 0; i < blssb.participantCount; i++{
+		values[i] = make([]interface{}, 0)
+	}
+	err := blssb.share(blssb.access.(*FormulaAccessStructure).formula, secretValue, values)
+	if (err != nil) {return nil, err}
+	shares := make([]*SecretShare, blssb.participantCount)
+	for i := 0; i < blssb.participantCount; i++{
+		shares[i] = NewSecretShare(i, values[i])
+	}
+	return shares, nil
+}
+
+/**
+ * Calculating secret from input shares by recovering the formula from the leaves to the root.
+ *
+ * @param shares The shares from which secret is calculated.
+ * @return The secret (BigInt) calculated from the input shares.
+ * @return error If any of the input shares is invalid.
+ */
+func (blssb *BenalohLeichterSecretSharingBigInt) calculateSecretImpl(shares []*SecretShare) (interface{}, error){
+	formula := blssb.access.(*FormulaAccessStructure).formula
+	leafCounts := make([]int, blssb.participantCount)
+	formula.countLeaves(leafCounts)
+	values := map[int][]interface{}{}
+	for i := 0; i < len(shares); i++{
+		value, ok := shares[i].GetValue().([]interface{})
+		if (!ok) {return nil, errors.New("Invalid type of share value, should be []interface{}.")}
+		if (len(value) != leafCounts[shares[i].GetParticipant()]){
+			return nil, errors.New("Number of values in a share does not match the formula.")
+		}
+		for j := 0; j < len(value); j++{
+			element, ok := value[j].(*big.Int)
+			if (!ok || element == nil || element.Sign() < 0 || element.Cmp(blssb.modulus) >= 0){
+				return nil, errors.New("Invalid value in a share, should be BigInt in [0, p).")
+			}
+		}
+		values[shares[i].GetParticipant()] = value
+	}
+	used := make([]int, blssb.participantCount)
+	feedback, err := blssb.recover(formula, values, used)
+	if (err != nil) {return nil, err}
+	if (feedback == nil) {return nil, errors.New("Access Test not passed.")}
+	return feedback, nil
+}
+
+/**
+ * Share a value along a sub-formula, and append the values of the leaves to the participants.
+ *
+ * @param formula The sub-formula.
+ * @param value The value of the sub-formula.
+ * @param values values[i] collects the values of the leaves of participant i.
+ * @return error If random numbers cannot be generated.
+ */
+func (blssb *BenalohLeichterSecretSharingBigInt) share(formula *AccessFormula, value *big.Int, values [][]interface{}) error{
+	if (formula.IsLeaf()){
+		values[formula.participant] = append(values[formula.participant], value)
+		return nil
+	}
+	coefficients := make([]*big.Int, formula.threshold)
+	coefficients[0] = value
+	for i := 1; i < formula.threshold; i++{
+		random, err := rand.Int(rand.Reader, blssb.modulus)
+		if (err != nil) {return err}
+		coefficients[i] = random
+	}
+	polynomial, err := poly.NewPolynomialBigInt(formula.threshold - 1, coefficients, blssb.modulus)
+	if (err != nil) {return err}
+	for j := 0; j < len(formula.children); j++{
+		childValue, err := polynomial.Calculate(big.NewInt(int64(j + 1)))
+		if (err != nil) {return err}
+		err = blssb.share(formula.children[j], childValue.(*big.Int), values)
+		if (err != nil) {return err}
+	}
+	return nil
+}
+
+/**
+ * Recover the value of a sub-formula.
+ * <p>
+ * Leaves are visited in the same order as in <code>share</code>, so used[i] is the index of the next value of participant i,
+ * even for the sub-formulas that are skipped.
+ *
+ * @param formula The sub-formula.
+ * @param values values[i] is the share value of participant i, missing if participant i is absent.
+ * @param used used[i] is the number of leaves of participant i visited so far.
+ * @return The value of the sub-formula, or nil if it cannot be recovered.
+ * @return error If the interpolation fails.
+ */
+func (blssb *BenalohLeichterSecretSharingBigInt) recover(formula *AccessFormula, values map[int][]interface{}, used []int) (*big.Int, error){
+	if (formula.IsLeaf()){
+		index := used[formula.participant]
+		used[formula.participant]++
+		value, ok := values[formula.participant]
+		if (!ok) {return nil, nil}
+		return value[index].(*big.Int), nil
+	}
+	points := make([]*big.Int, 0, formula.threshold)
+	childValues := make([]interface{}, 0, formula.threshold)
+	for j := 0; j < len(formula.children); j++{
+		childValue, err := blssb.recover(formula.children[j], values, used)
+		if (err != nil) {return nil, err}
+		if (childValue != nil && len(points) < formula.threshold){
+			points = append(points, big.NewInt(int64(j + 1)))
+			childValues = append(childValues, childValue)
+		}
+	}
+	if (len(points) < formula.threshold) {return nil, nil}
+	interpolation, err := poly.NewLagrangeInterpolationBigInt(points, blssb.modulus)
+	if (err != nil) {return nil, err}
+	feedback, err := interpolation.Interpolate(big.NewInt(0), childValues)
+	if (err != nil) {return nil, err}
+	return feedback.(*big.Int), nil
+}
+
+/**
+ * Check that every gate of a sub-formula has fewer children than the modulus.
+ *
+ * @param formula The sub-formula.
+ * @return True if all gates are small enough.
+ */
+func (blssb *BenalohLeichterSecretSharingBigInt) checkGateSize(formula *AccessFormula) bool{
+	if (formula.IsLeaf()) {return true}
+	if (big.NewInt(int64(len(formula.children))).Cmp(blssb.modulus) >= 0) {return false}
+	for j := 0; j < len(formula.children); j++{
+		if (!blssb.checkGateSize(formula.children[j])) {return false}
+	}
+	return true
+}
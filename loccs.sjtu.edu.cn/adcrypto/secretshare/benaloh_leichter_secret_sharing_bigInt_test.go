@@ -0,0 +1,155 @@
+package secretshare
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"crypto/rand"
+)
+
+func formulaLeaf(participant int) *AccessFormula {
+	feedback, _ := NewParticipantFormula(participant)
+	return feedback
+}
+
+func TestFormulaAccessStructure(t *testing.T) {
+	// 0 and (1 or 2), or any 2 of 3, 4, 5
+	and := mustFormula(NewAndFormula(formulaLeaf(0), mustFormula(NewOrFormula(formulaLeaf(1), formulaLeaf(2)))))
+	formula := mustFormula(NewOrFormula(and, mustFormula(NewThresholdFormula(2, formulaLeaf(3), formulaLeaf(4), formulaLeaf(5)))))
+	access, err := NewFormulaAccessStructure(6, formula)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing FormulaAccessStructure: %s", err))}
+	cases := []struct {
+		participants []int
+		expected bool
+	}{
+		{[]int{0, 1}, true},
+		{[]int{2, 0}, true},
+		{[]int{0}, false},
+		{[]int{1, 2}, false},
+		{[]int{3, 5}, true},
+		{[]int{4, 4}, false},
+		{[]int{0, 3}, false},
+		{[]int{}, false},
+	}
+	for i := 0; i < len(cases); i++{
+		ok, err := access.TestAccessable(cases[i].participants)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when testing access: %s", err))}
+		if (ok != cases[i].expected) {t.Error(fmt.Sprintf("Access test of %v is %v, Expected: %v", cases[i].participants, ok, cases[i].expected))}
+	}
+	_, err = access.TestAccessable([]int{6})
+	if err == nil {t.Error("Invalid participant ID should not be accepted.")}
+
+	_, err = NewFormulaAccessStructure(5, formula)
+	if err == nil {t.Error("Participant out of range should not be accepted.")}
+	_, err = NewThresholdFormula(3, formulaLeaf(0), formulaLeaf(1))
+	if err == nil {t.Error("Threshold larger than number of children should not be accepted.")}
+	_, err = NewAndFormula()
+	if err == nil {t.Error("Gate without children should not be accepted.")}
+	_, err = NewParticipantFormula(-1)
+	if err == nil {t.Error("Negative participant ID should not be accepted.")}
+}
+
+func TestBenalohLeichterSecretSharingBigIntProcedure(t *testing.T) {
+	modulus, _ := rand.Prime(rand.Reader, 64)
+	// participant 1 appears twice, participant 6 never
+	formula1 := mustFormula(NewOrFormula(
+		mustFormula(NewAndFormula(formulaLeaf(0), mustFormula(NewOrFormula(formulaLeaf(1), formulaLeaf(2))))),
+		mustFormula(NewThresholdFormula(2, formulaLeaf(3), formulaLeaf(4), formulaLeaf(5), formulaLeaf(1)))))
+	t.Run("TestBenalohLeichterSecretSharingBigIntProcedure1", testBenalohLeichterSecretSharingBigIntProcedure(7, formula1, modulus))
+	// a threshold structure written as a formula
+	formula2 := mustFormula(NewThresholdFormula(3, formulaLeaf(0), formulaLeaf(1), formulaLeaf(2), formulaLeaf(3), formulaLeaf(4)))
+	t.Run("TestBenalohLeichterSecretSharingBigIntProcedure2", testBenalohLeichterSecretSharingBigIntProcedure(5, formula2, modulus))
+	// nested AND of ORs
+	formula3 := mustFormula(NewAndFormula(
+		mustFormula(NewOrFormula(formulaLeaf(0), formulaLeaf(1))),
+		mustFormula(NewOrFormula(formulaLeaf(2), mustFormula(NewAndFormula(formulaLeaf(3), formulaLeaf(4))))),
+		formulaLeaf(5)))
+	t.Run("TestBenalohLeichterSecretSharingBigIntProcedure3", testBenalohLeichterSecretSharingBigIntProcedure(6, formula3, big.NewInt(5)))
+}
+
+func testBenalohLeichterSecretSharingBigIntProcedure(participantCount int, formula *AccessFormula, modulus *big.Int) func(t *testing.T) {
+	return func(t *testing.T) {
+		scheme, err := NewBenalohLeichterSecretSharingBigInt(participantCount, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing BenalohLeichterSecretSharingBigInt: %s", err))}
+		access, err := NewFormulaAccessStructure(participantCount, formula)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing FormulaAccessStructure: %s", err))}
+		err = scheme.SetAccessStructure(access)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding FormulaAccessStructure: %s", err))}
+		secret, _ := rand.Int(rand.Reader, modulus)
+		shares, err := scheme.GenerateShares(secret, nil)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+
+		// every subset is either authorized and recovers the secret, or rejected
+		for mask := 0; mask < (1 << uint(participantCount)); mask++{
+			subset := make([]*SecretShare, 0)
+			participants := make([]int, 0)
+			for i := 0; i < participantCount; i++{
+				if (mask & (1 << uint(i)) != 0){
+					subset = append(subset, shares[i])
+					participants = append(participants, i)
+				}
+			}
+			authorized, _ := access.TestAccessable(participants)
+			secretNew, err := scheme.CalculateSecret(subset)
+			if (authorized){
+				if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret from %v: %s", participants, err))}
+				if (secretNew.(*big.Int).Cmp(secret) != 0){
+					t.Error(fmt.Sprintf("Calculate Result of %v is False, Result:%s ,Expected: %s", participants, secretNew, secret))
+				}
+			} else if err == nil {
+				t.Error(fmt.Sprintf("Secret should not be calculated from %v.", participants))
+			}
+			// recovery itself fails on unauthorized sets, not only the access test
+			_, err = scheme.calculateSecretImpl(subset)
+			if ((err == nil) != authorized) {t.Error(fmt.Sprintf("Recovery of %v does not match the formula.", participants))}
+		}
+
+		// shares survive the binary encoding
+		decodedShares := make([]*SecretShare, participantCount)
+		for i := 0; i < participantCount; i++{
+			data, err := shares[i].MarshalBinary()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share: %s", err))}
+			decodedShares[i] = new(SecretShare)
+			err = decodedShares[i].UnmarshalBinary(data)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share: %s", err))}
+		}
+		secretNew, err := scheme.CalculateSecret(decodedShares)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (secretNew.(*big.Int).Cmp(secret) != 0) {t.Error("Calculate Result from decoded shares is False.")}
+	}
+}
+
+func TestBenalohLeichterSecretSharingBigIntInvalid(t *testing.T) {
+	_, err := NewBenalohLeichterSecretSharingBigInt(5, big.NewInt(15))
+	if err == nil {t.Error("Composite modulus should not be accepted.")}
+	scheme, _ := NewBenalohLeichterSecretSharingBigInt(5, big.NewInt(3))
+	_, err = scheme.GenerateShares(big.NewInt(1), nil)
+	if err == nil {t.Error("Shares should not be generated before the access structure is set.")}
+	threshold, _ := NewThresholdAccessStructure(5, 3)
+	err = scheme.SetAccessStructure(threshold)
+	if err == nil {t.Error("ThresholdAccessStructure should not be accepted.")}
+	// 3 children need the evaluation points 1, 2, 3, which are not distinct modulo 3
+	wide, _ := NewFormulaAccessStructure(5, mustFormula(NewOrFormula(formulaLeaf(0), formulaLeaf(1), formulaLeaf(2))))
+	err = scheme.SetAccessStructure(wide)
+	if err == nil {t.Error("Gate with too many children for the modulus should not be accepted.")}
+
+	scheme, _ = NewBenalohLeichterSecretSharingBigInt(5, big.NewInt(101))
+	access, _ := NewFormulaAccessStructure(5, mustFormula(NewAndFormula(formulaLeaf(0), formulaLeaf(1))))
+	err = scheme.SetAccessStructure(access)
+	if err != nil {t.Fatal(err)}
+	_, err = scheme.GenerateShares(big.NewInt(101), nil)
+	if err == nil {t.Error("Secret out of range should not be accepted.")}
+	_, err = scheme.GenerateShares(big.NewInt(1), []interface{}{big.NewInt(1)})
+	if err == nil {t.Error("Auxiliary data should not be accepted.")}
+	shares, err := scheme.GenerateShares(big.NewInt(42), nil)
+	if err != nil {t.Fatal(err)}
+	if (len(shares[4].GetValue().([]interface{})) != 0) {t.Error("Participant out of the formula should get an empty share.")}
+	shares[1] = NewSecretShare(1, []interface{}{big.NewInt(1), big.NewInt(2)})
+	_, err = scheme.CalculateSecret(shares[:2])
+	if err == nil {t.Error("Share with wrong number of values should not be accepted.")}
+}
+
+func mustFormula(formula *AccessFormula, err error) *AccessFormula {
+	if (err != nil) {panic(err)}
+	return formula
+}
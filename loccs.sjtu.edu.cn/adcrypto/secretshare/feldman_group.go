@@ -0,0 +1,154 @@
+package secretshare
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+/**
+ * This class represents a subgroup of prime order <i>q</i> in <i>Zp</i><sup>*</sup>, used for the commitments of Feldman's VSS.
+ * <p>
+ * The primes satisfy <i>p</i> = <i>kq</i> + 1, and the generator <i>g</i> has order <i>q</i>, so the exponents live in <i>Zq</i>,
+ * the same field as the Shamir's polynomial.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FeldmanGroup struct {
+	/**
+	 * Modulus <i>p</i> of the group, must be prime.
+	 */
+	p *big.Int
+
+	/**
+	 * Order <i>q</i> of the subgroup, must be prime and divide <i>p</i>-1.
+	 */
+	q *big.Int
+
+	/**
+	 * Generator <i>g</i> of the subgroup.
+	 */
+	g *big.Int
+}
+
+/**
+ * Construct a group with given parameters.
+ *
+ * @param p Modulus <i>p</i> of the group.
+ * @param q Order <i>q</i> of the subgroup.
+ * @param g Generator <i>g</i> of the subgroup.
+ * @return feedback the constructed FeldmanGroup
+ * @return error If <i>p</i> or <i>q</i> is not prime, <i>q</i> does not divide <i>p</i>-1, or <i>g</i> is not of order <i>q</i>.
+ */
+func NewFeldmanGroup(p *big.Int, q *big.Int, g *big.Int) (*FeldmanGroup, error){
+	if (p == nil || q == nil || g == nil){
+		return nil, errors.New("Group parameters should not be nil.")
+	}
+	if (q.Cmp(big.NewInt(2)) <= 0 || !q.ProbablyPrime(20)){
+		return nil, errors.New("Invalid order of subgroup. Should be an odd prime.")
+	}
+	if (!p.ProbablyPrime(20)){
+		return nil, errors.New("Invalid modulus of group. Should be prime.")
+	}
+	pMinusOne := big.NewInt(0)
+	pMinusOne.Sub(p, big.NewInt(1))
+	if (big.NewInt(0).Mod(pMinusOne, q).Sign() != 0){
+		return nil, errors.New("Order of subgroup should divide modulus - 1.")
+	}
+	if (g.Cmp(big.NewInt(1)) <= 0 || g.Cmp(p) >= 0 || big.NewInt(0).Exp(g, q, p).Cmp(big.NewInt(1)) != 0){
+		return nil, errors.New("Invalid generator of subgroup.")
+	}
+	feedback := new(FeldmanGroup)
+	feedback.p = p
+	feedback.q = q
+	feedback.g = g
+	return feedback, nil
+}
+
+/**
+ * Generate a group whose subgroup has the given prime order.
+ * <p>
+ * <i>p</i> = <i>kq</i> + 1 is found by trying random even <i>k</i>, so that <i>p</i> has about the given number of bits.
+ * If the number of bits is not greater than the bit length of <i>q</i>, <i>k</i> is tried from 2 instead.
+ *
+ * @param q Order <i>q</i> of the subgroup, must be an odd prime.
+ * @param bits Expected bit length of <i>p</i>.
+ * @return feedback the generated FeldmanGroup
+ * @return error If <i>q</i> is invalid or random numbers cannot be generated.
+ */
+func GenerateFeldmanGroup(q *big.Int, bits int) (*FeldmanGroup, error){
+	if (q == nil || q.Cmp(big.NewInt(2)) <= 0 || !q.ProbablyPrime(20)){
+		return nil, errors.New("Invalid order of subgroup. Should be an odd prime.")
+	}
+	one := big.NewInt(1)
+	k := big.NewInt(2)
+	random := bits > q.BitLen() + 1
+	p := big.NewInt(0)
+	for {
+		if (random){
+			max := big.NewInt(0)
+			max.Lsh(one, uint(bits - q.BitLen()))
+			tmp, err := rand.Int(rand.Reader, max)
+			if (err != nil) {return nil, err}
+			k.SetBit(tmp, 0, 0)
+			if (k.Sign() == 0) {continue}
+		}
+		p.Mul(k, q).Add(p, one)
+		if (p.ProbablyPrime(20)) {break}
+		if (!random) {k.Add(k, big.NewInt(2))}
+	}
+
+	// g = h^k is of order q if it is not 1
+	g := big.NewInt(0)
+	for h := int64(2); ; h++{
+		g.Exp(big.NewInt(h), k, p)
+		if (g.Cmp(one) != 0) {break}
+	}
+	return NewFeldmanGroup(p, q, g)
+}
+
+/**
+ * Get modulus <i>p</i> of the group.
+ *
+ * @return Modulus <i>p</i>.
+ */
+func (group *FeldmanGroup) GetP() *big.Int{
+	return group.p
+}
+
+/**
+ * Get order <i>q</i> of the subgroup.
+ *
+ * @return Order <i>q</i>.
+ */
+func (group *FeldmanGroup) GetQ() *big.Int{
+	return group.q
+}
+
+/**
+ * Get generator <i>g</i> of the subgroup.
+ *
+ * @return Generator <i>g</i>.
+ */
+func (group *FeldmanGroup) GetG() *big.Int{
+	return group.g
+}
+
+/**
+ * Calculate the commitments <i>g</i><sup><i>a<sub>i</sub></i></sup> mod <i>p</i> of the coefficients of a polynomial.
+ *
+ * @param coefficients Coefficients of the polynomial over <i>Zq</i>.
+ * @return The commitments.
+ * @return error If any coefficient is not a BigInt.
+ */
+func (group *FeldmanGroup) Commit(coefficients []interface{}) ([]*big.Int, error){
+	feedback := make([]*big.Int, len(coefficients))
+	for i := 0; i < len(coefficients); i++{
+		coefficient, ok := coefficients[i].(*big.Int)
+		if (!ok) {return nil, errors.New("Invalid type of coefficient, should be big.Int.")}
+		feedback[i] = big.NewInt(0)
+		feedback[i].Exp(group.g, coefficient, group.p)
+	}
+	return feedback, nil
+}
@@ -0,0 +1,131 @@
+package secretshare
+
+import (
+	"errors"
+	"math/big"
+)
+
+/**
+ * The class implements Feldman's verifiable secret sharing scheme on BigInt field.
+ * <p>
+ * The scheme is Shamir's secret sharing scheme over <i>Zq</i>, where the dealer also publishes the commitments
+ * <i>C<sub>j</sub></i> = <i>g</i><sup><i>a<sub>j</sub></i></sup> mod <i>p</i> to the coefficients of the polynomial,
+ * as in "Feldman P. A practical scheme for non-interactive verifiable secret sharing. In 28th Annual Symposium on
+ * Foundations of Computer Science 1987 Oct 12 (pp. 427-438). IEEE."
+ * <p>
+ * A participant holding share (<i>r</i>, <i>q</i>(<i>r</i>)) accepts it only if
+ * <i>g</i><sup><i>q</i>(<i>r</i>)</sup> = <i>C</i><sub>0</sub> <i>C</i><sub>1</sub><sup><i>r</i></sup> ... <i>C</i><sub><i>k</i>-1</sub><sup><i>r</i><sup><i>k</i>-1</sup></sup> mod <i>p</i>.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FeldmanSecretSharingBigInt struct {
+	ShamirSecretSharingBigInt
+
+	/**
+	 * The group in which the commitments are calculated, its subgroup order is the modulus of the scheme.
+	 */
+	group *FeldmanGroup
+}
+
+/**
+ * Construct Feldman's secret sharing scheme with the number of participants and the group of commitments.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @param group The group of commitments, whose subgroup order <i>q</i> is used as the modulus of the scheme.
+ * @return feedback the newly constructed FeldmanSecretSharingBigInt
+ * @return error If the number of participants or the group is invalid.
+ */
+func NewFeldmanSecretSharingBigInt(participantCount int, group *FeldmanGroup) (*FeldmanSecretSharingBigInt, error){
+	if (group == nil){
+		return nil, errors.New("Group of commitments should not be nil.")
+	}
+	shamir, err := NewShamirSecretSharingBigInt(participantCount, group.GetQ())
+	if (err != nil) {return nil, err}
+	feedback := new(FeldmanSecretSharingBigInt)
+	feedback.ShamirSecretSharingBigInt = *shamir
+	feedback.group = group
+	feedback.ShamirSecretSharingITF = feedback
+	feedback.SecretSharingSchemeITF = &feedback.ShamirSecretSharing
+	return feedback, nil
+}
+
+/**
+ * Get the group of commitments.
+ *
+ * @return The group of commitments.
+ */
+func (fssb *FeldmanSecretSharingBigInt) GetGroup() *FeldmanGroup{
+	return fssb.group
+}
+
+/**
+ * Generate shares from input secret, together with the commitments to the polynomial.
+ *
+ * @param secret The secret from which shares are generated.
+ * @param auxiliary Auxiliary data for generating shares. Can be nil(use default auxiliary).
+ * @return N shares that generated from the input secret, one for each participant.
+ * @return The commitments <i>C</i><sub>0</sub>, <i>C</i><sub>1</sub>, ..., <i>C</i><sub><i>k</i>-1</sub>.
+ * @return error If the scheme is not initialized or the input secret is invalid.
+ */
+func (fssb *FeldmanSecretSharingBigInt) GenerateSharesWithCommitments(secret interface{}, auxiliary []interface{}) ([]*SecretShare, []*big.Int, error){
+	if (!fssb.IsInitialized()) {
+		return nil, nil, errors.New("Not ready for generate shares.")
+	}
+	auxiliary, err := fssb.checkSecretAndAuxiliary(secret, auxiliary)
+	if (err != nil) {return nil, nil, err}
+	secretValue := big.NewInt(0)
+	secretValue.Mod(secret.(*big.Int), fssb.group.GetQ())
+	poly := fssb.GetRandomPolynomial(secretValue)
+	shares, err := fssb.generateSharesFromPolynomial(poly, auxiliary)
+	if (err != nil) {return nil, nil, err}
+	commitments, err := fssb.group.Commit(poly.GetCoefficients())
+	if (err != nil) {return nil, nil, err}
+	return shares, commitments, nil
+}
+
+/**
+ * Verify a share against the commitments published by the dealer.
+ *
+ * @param share The share to be verified.
+ * @param commitments The commitments to the polynomial.
+ * @return True if the share lies on the committed polynomial, otherwise return false.
+ * @return error If the share or the commitments are invalid.
+ */
+func (fssb *FeldmanSecretSharingBigInt) VerifyShare(share *SecretShare, commitments []*big.Int) (bool, error){
+	if (share == nil){
+		return false, errors.New("Share should not be nil.")
+	}
+	value, ok := share.GetValue().(*ShamirSecretShareValue)
+	if (!ok) {return false, errors.New("Invalid type of share value, should be ShamirSecretShareValue.")}
+	r, okR := value.GetR().(*big.Int)
+	qr, okQr := value.GetQr().(*big.Int)
+	if (!okR || !okQr) {return false, errors.New("Invalid type of elements in ShamirSecretShareValue.")}
+	if (commitments == nil || len(commitments) == 0){
+		return false, errors.New("At least one commitment should be provided.")
+	}
+	if (fssb.access != nil && len(commitments) != fssb.access.GetThreshold()){
+		return false, errors.New("Number of commitments should be equal to threshold.")
+	}
+	p := fssb.group.GetP()
+	q := fssb.group.GetQ()
+	for i := 0; i < len(commitments); i++{
+		if (commitments[i] == nil || commitments[i].Sign() <= 0 || commitments[i].Cmp(p) >= 0){
+			return false, errors.New("Invalid commitment.")
+		}
+	}
+
+	left := big.NewInt(0)
+	left.Mod(qr, q).Exp(fssb.group.GetG(), left, p)
+	right := big.NewInt(1)
+	power := big.NewInt(1)
+	x := big.NewInt(0)
+	x.Mod(r, q)
+	for i := 0; i < len(commitments); i++{
+		tmp := big.NewInt(0)
+		tmp.Exp(commitments[i], power, p)
+		right.Mul(right, tmp).Mod(right, p)
+		power.Mul(power, x).Mod(power, q)
+	}
+	return left.Cmp(right) == 0, nil
+}
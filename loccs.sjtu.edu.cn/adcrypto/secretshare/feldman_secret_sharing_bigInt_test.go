@@ -0,0 +1,63 @@
+package secretshare
+
+import (
+	"testing"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+func TestFeldmanSecretSharingBigIntProcedure(t *testing.T) {
+	participantCount := 10
+	threshold := 4
+	q,_ := rand.Prime(rand.Reader,160)
+	group, err := GenerateFeldmanGroup(q, 512)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating FeldmanGroup: %s", err))}
+	if (group.GetP().BitLen() < 500){
+		t.Error(fmt.Sprintf("Modulus of group is too short: %d bits", group.GetP().BitLen()))
+	}
+	_, err = NewFeldmanGroup(group.GetP(), group.GetQ(), big.NewInt(1))
+	if err == nil {t.Error("Generator 1 should be rejected.")}
+
+	feldman, err := NewFeldmanSecretSharingBigInt(participantCount, group)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing FeldmanSecretSharingBigInt: %s", err))}
+	threAccessStruct, err := NewThresholdAccessStructure(participantCount,threshold)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+	err = feldman.SetAccessStructure(threAccessStruct)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+
+	secret,_ := rand.Int(rand.Reader,q)
+	shares, commitments, err := feldman.GenerateSharesWithCommitments(secret, feldman.GenerateRandomAuxiliary())
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+	if (len(commitments) != threshold){
+		t.Error(fmt.Sprintf("Number of commitments is %d, expected %d", len(commitments), threshold))
+	}
+	for i := 0; i < participantCount; i++{
+		valid, err := feldman.VerifyShare(shares[i], commitments)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when verifying share: %s", err))}
+		if (!valid) {t.Error(fmt.Sprintf("Valid share of participant %d is rejected.", i))}
+	}
+
+	// the scheme still works as a Shamir's scheme
+	secretNew, err := feldman.CalculateSecret(shares)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+	if (secretNew.(*big.Int).Cmp(secret) != 0){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s",secretNew,secret))
+	}
+
+	// a share not on the committed polynomial is rejected
+	value := shares[2].GetValue().(*ShamirSecretShareValue)
+	wrongQr := big.NewInt(1)
+	wrongQr.Add(wrongQr, value.GetQr().(*big.Int)).Mod(wrongQr, q)
+	wrongShare := NewSecretShare(2, NewShamirSecretShareValue(value.GetR(), wrongQr))
+	valid, err := feldman.VerifyShare(wrongShare, commitments)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when verifying share: %s", err))}
+	if (valid) {t.Error("Wrong share is accepted.")}
+
+	// a share from another polynomial is rejected
+	otherShares, _, err := feldman.GenerateSharesWithCommitments(secret, feldman.GenerateRandomAuxiliary())
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+	valid, err = feldman.VerifyShare(otherShares[0], commitments)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when verifying share: %s", err))}
+	if (valid) {t.Error("Share of another polynomial is accepted.")}
+}
@@ -0,0 +1,491 @@
+package secretshare
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"errors"
+	"math/big"
+)
+
+/**
+ * The class implements the abstract methods of <code>ShamirSecretSharing</code> over the field <i>Zp</i> with elements of type <i>E</i>.
+ * <p>
+ * <code>ShamirSecretSharingInt</code>, <code>ShamirSecretSharingUint64</code> and <code>ShamirSecretSharingBigInt</code> are
+ * this class over <code>poly.IntField</code>, <code>poly.Uint64Field</code> and <code>poly.BigIntField</code>, and
+ * <code>FieldShamirSecretSharing</code> is a typed view of it over any field. The elements passed as interface{} are checked
+ * to have type <i>E</i>, and the polynomials, interpolations and equation systems are the <code>poly.Field*Calculator</code> classes.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type shamirSecretSharingField[E any] struct {
+	ShamirSecretSharing
+
+	/**
+	 * The field <i>Zp</i>, <i>p</i> must be prime.
+	 */
+	field poly.Field[E]
+}
+
+/**
+ * Set the number of participants and the field of the scheme.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @param field The field <i>Zp</i> used by the polynomial.
+ */
+func (sss *shamirSecretSharingField[E]) init(participantCount int, field poly.Field[E]){
+	sss.participantCount = participantCount
+	sss.field = field
+	sss.modolus = field.GetModulus()
+}
+
+/**
+ * Get the field.
+ *
+ * @return The field <i>Zp</i>.
+ */
+func (sss *shamirSecretSharingField[E]) GetField() poly.Field[E]{
+	return sss.field
+}
+
+/**
+ * Generate <i>n</i> random auxiliary data from <i>Zp</i><sup>*</sup>, one for each participant.
+ * <p>
+ * The auxiliary data are distinct, unless <i>Zp</i><sup>*</sup> has less than <i>n</i> elements.
+ *
+ * @return Random auxiliary
+ */
+func (sss *shamirSecretSharingField[E]) GenerateRandomAuxiliary() []interface{}{
+	feedback, _ := sss.generateRandomAuxiliary()
+	return toElementInterfaces(feedback)
+}
+
+/**
+ * Generate <i>n</i> random auxiliary data from <i>Zp</i><sup>*</sup>, one for each participant.
+ *
+ * @return Random auxiliary, distinct unless <i>Zp</i><sup>*</sup> has less than <i>n</i> elements.
+ * @return error If random numbers cannot be generated.
+ */
+func (sss *shamirSecretSharingField[E]) generateRandomAuxiliary() ([]E, error){
+	distinct := sss.field.ToBigInt(sss.field.GetModulus()).Cmp(big.NewInt(int64(sss.participantCount))) > 0
+	feedback := make([]E, 0, sss.participantCount)
+	for (len(feedback) < sss.participantCount){
+		random, err := sss.randomNonZeroElement()
+		if (err != nil) {return nil, err}
+		found := false
+		for i := 0; i < len(feedback) && distinct && !found; i++{
+			found = sss.field.Equal(feedback[i], random)
+		}
+		if (!found) {feedback = append(feedback, random)}
+	}
+	return feedback, nil
+}
+
+/**
+ * Get a random <i>k</i>-1 degree polynomial over <i>Zp</i>.
+ * <p>
+ * <i>a</i><sub>0</sub> is the secret specified by input parameter,
+ * and <i>a</i><sub>1</sub>, <i>a</i><sub>2</sub>, ..., <i>a</i><sub><i>k</i>-1</sub>
+ * are chosen randomly in <i>Zp</i><sup>*</sup>.
+ *
+ * @param a0 Constant term of the polynomial, should have type <i>E</i>.
+ * @return The polynomial object(FieldPolynomialCalculator).
+ */
+func (sss *shamirSecretSharingField[E]) GetRandomPolynomial(a0 interface{}) poly.PolynomialCalculator{
+	coefficients := make([]E, sss.access.(*ThresholdAccessStructure).GetThreshold())
+	coefficients[0] = a0.(E)
+	for i := 1; i < len(coefficients); i++{
+		coefficients[i], _ = sss.randomNonZeroElement()
+	}
+	feedback, _ := poly.NewFieldPolynomialCalculator(sss.field, coefficients)
+	return feedback
+}
+
+/**
+ * Create default auxiliary data from generate shares.
+ * <p>
+ * i.e. 1, 2, ..., <i>n</i>
+ *
+ * @return Default auxiliary data
+ */
+func (sss *shamirSecretSharingField[E]) CreateDefaultAuxiliary() []interface{}{
+	feedback := make([]interface{}, sss.participantCount)
+	for i := 0; i < sss.participantCount; i++{
+		feedback[i] = sss.field.FromInt(i + 1)
+	}
+	return feedback
+}
+
+/**
+ * Get a system linear equation with <i>k</i> variables over <i>Zp</i> for calculating secret.
+ *
+ * @return Linear equation system object(FieldLinearEquationSystemCalculator).
+ */
+func (sss *shamirSecretSharingField[E]) GetEquationSystem() poly.LinearEquationSystemCalculator{
+	return sss.GetEquationSystemWithVariableCount(sss.access.(*ThresholdAccessStructure).GetThreshold())
+}
+
+/**
+ * Restore the coefficients of the equation by the first element of the share.
+ * <p>
+ * i.e. 1, <i>x</i>, <i>x</i><sup>2</sup>, ... , <i>x</i><sup><i>k</i>-1</sup> mod <i>p</i>
+ *
+ * @param x The first element of the share.
+ * @return The coefficient array.
+ */
+func (sss *shamirSecretSharingField[E]) GetEquationCoefficients(x interface{}) []interface{}{
+	return sss.GetPowers(x, sss.access.(*ThresholdAccessStructure).GetThreshold())
+}
+
+/**
+ * Get a system linear equation with given number of variables over <i>Zp</i>.
+ *
+ * @param variableCount Number of variables.
+ * @return Linear equation system object(FieldLinearEquationSystemCalculator).
+ */
+func (sss *shamirSecretSharingField[E]) GetEquationSystemWithVariableCount(variableCount int) poly.LinearEquationSystemCalculator{
+	feedback, _ := poly.NewFieldLinearEquationSystemCalculator(sss.field, variableCount)
+	return feedback
+}
+
+/**
+ * Get the Lagrange interpolation over <i>Zp</i> on the given evaluation points.
+ *
+ * @param points The distinct evaluation points, should have type <i>E</i>.
+ * @return Lagrange interpolation object(FieldLagrangeInterpolationCalculator).
+ * @return error If any evaluation point is invalid or the points are not distinct.
+ */
+func (sss *shamirSecretSharingField[E]) GetLagrangeInterpolation(points []interface{}) (poly.LagrangeInterpolationCalculator, error){
+	pointsE, ok := toElements[E](points)
+	if (!ok) {return nil, errors.New("Invalid type of evaluation point.")}
+	return poly.NewFieldLagrangeInterpolationCalculator(sss.field, pointsE)
+}
+
+/**
+ * Calculate the powers of an element.
+ * <p>
+ * i.e. 1, <i>x</i>, <i>x</i><sup>2</sup>, ... , <i>x</i><sup><i>count</i>-1</sup> mod <i>p</i>
+ *
+ * @param x The element.
+ * @param count Number of powers.
+ * @return The power array.
+ */
+func (sss *shamirSecretSharingField[E]) GetPowers(x interface{}, count int) []interface{}{
+	feedback := make([]interface{}, count)
+	pile := sss.field.One()
+	for i := 0; i < count; i++{
+		feedback[i] = pile
+		pile = sss.field.Multiply(pile, x.(E))
+	}
+	return feedback
+}
+
+/**
+ * Get the element of value 0.
+ *
+ * @return Element of value 0.
+ */
+func (sss *shamirSecretSharingField[E]) getElementZero() interface{}{
+	return sss.field.Zero()
+}
+
+/**
+ * Get the element of value 1.
+ *
+ * @return Element of value 1.
+ */
+func (sss *shamirSecretSharingField[E]) getElementOne() interface{}{
+	return sss.field.One()
+}
+
+/**
+ * Construct a polynomial over <i>Zp</i> from its coefficients.
+ *
+ * @param coefficients Coefficients of the polynomial, coefficients[i] is <i>a<sub>i</sub></i>.
+ * @return The polynomial object(FieldPolynomialCalculator).
+ */
+func (sss *shamirSecretSharingField[E]) getPolynomial(coefficients []interface{}) poly.PolynomialCalculator{
+	coefficientsE, _ := toElements[E](coefficients)
+	feedback, _ := poly.NewFieldPolynomialCalculator(sss.field, coefficientsE)
+	return feedback
+}
+
+/**
+ * Calculate <i>a</i> + <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The sum in [0, <i>p</i>).
+ */
+func (sss *shamirSecretSharingField[E]) addElements(a interface{}, b interface{}) interface{}{
+	return sss.field.Add(a.(E), b.(E))
+}
+
+/**
+ * Calculate <i>a</i> * <i>b</i> mod <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return The product in [0, <i>p</i>).
+ */
+func (sss *shamirSecretSharingField[E]) multiplyElements(a interface{}, b interface{}) interface{}{
+	return sss.field.Multiply(a.(E), b.(E))
+}
+
+/**
+ * Calculate -<i>a</i> mod <i>p</i>.
+ *
+ * @param a The element.
+ * @return The negation in [0, <i>p</i>).
+ */
+func (sss *shamirSecretSharingField[E]) negateElement(a interface{}) interface{}{
+	return sss.field.Negate(a.(E))
+}
+
+/**
+ * Test if two elements are equal modulo <i>p</i>.
+ *
+ * @param a The first element.
+ * @param b The second element.
+ * @return True if <i>a</i> = <i>b</i> mod <i>p</i>, otherwise return false.
+ */
+func (sss *shamirSecretSharingField[E]) isElementEqual(a interface{}, b interface{}) bool{
+	return sss.field.Equal(a.(E), b.(E))
+}
+
+/**
+ * Check if the type of input element is valid.
+ *
+ * @param e Element to be checked.
+ * @return True if the type of input element is <i>E</i>, otherwise return false.
+ */
+func (sss *shamirSecretSharingField[E]) checkElement(e interface{}) bool{
+	_, ok := e.(E)
+	return ok
+}
+
+/**
+ * Generate a random element of <i>Zp</i><sup>*</sup>.
+ *
+ * @return The random element.
+ * @return error If random numbers cannot be generated.
+ */
+func (sss *shamirSecretSharingField[E]) randomNonZeroElement() (E, error){
+	for {
+		feedback, err := sss.field.Random()
+		if (err != nil) {return feedback, err}
+		if (!sss.field.IsZero(feedback)) {return feedback, nil}
+	}
+}
+
+/**
+ * Convert elements to interface{}.
+ *
+ * @param elements The elements.
+ * @return The elements as interface{}.
+ */
+func toElementInterfaces[E any](elements []E) []interface{}{
+	feedback := make([]interface{}, len(elements))
+	for i := 0; i < len(elements); i++{
+		feedback[i] = elements[i]
+	}
+	return feedback
+}
+
+/**
+ * Convert interface{} to elements of type <i>E</i>.
+ *
+ * @param elements The elements as interface{}.
+ * @return The elements.
+ * @return False if any element does not have type <i>E</i>.
+ */
+func toElements[E any](elements []interface{}) ([]E, bool){
+	feedback := make([]E, len(elements))
+	for i := 0; i < len(elements); i++{
+		element, ok := elements[i].(E)
+		if (!ok) {return nil, false}
+		feedback[i] = element
+	}
+	return feedback, true
+}
+
+/**
+ * The class implements Shamir's secret sharing scheme over the field <i>Zp</i> with elements of type <i>E</i>.
+ * <p>
+ * It is a typed view of the same scheme as <code>ShamirSecretSharingInt</code>, <code>ShamirSecretSharingUint64</code> and
+ * <code>ShamirSecretSharingBigInt</code>: the secret, the auxiliary data and the recovered secret have the element type of the
+ * field, so they are checked by the compiler instead of <code>checkElement</code>. The shares are still <code>SecretShare</code>
+ * objects with <code>ShamirSecretShareValue</code>, so they can be encoded by the wire package and exchanged with the
+ * interface{} schemes over the same <i>Zp</i>.
+ * <p>
+ * <code>FieldShamirSecretSharing</code> use <code>ThresholdAccessStructure</code> as its internal access structure.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FieldShamirSecretSharing[E any] struct {
+	/**
+	 * The scheme doing the work, with elements passed as interface{}.
+	 */
+	scheme *shamirSecretSharingField[E]
+}
+
+/**
+ * Construct secret sharing scheme with the number of participants over a field.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @param field The field <i>Zp</i> used by the polynomial.
+ * @return The newly constructed FieldShamirSecretSharing
+ * @return error If the number of participants or the field is invalid.
+ */
+func NewFieldShamirSecretSharing[E any](participantCount int, field poly.Field[E]) (*FieldShamirSecretSharing[E], error){
+	if (participantCount < 2){
+		return nil, errors.New("Invalid participant count. Should be larger than 1.")
+	}
+	if (field == nil){
+		return nil, errors.New("Field should not be nil.")
+	}
+	scheme := new(shamirSecretSharingField[E])
+	scheme.init(participantCount, field)
+	scheme.ShamirSecretSharingITF = scheme
+	scheme.SecretSharingSchemeITF = &scheme.ShamirSecretSharing
+	return &FieldShamirSecretSharing[E]{scheme: scheme}, nil
+}
+
+/**
+ * Get the scheme with elements passed as interface{}, which shares the state with this one.
+ * <p>
+ * It provides the methods not typed here, e.g. <code>CalculateSecretRobust</code> and <code>RefreshShare</code>.
+ *
+ * @return The scheme.
+ */
+func (sss *FieldShamirSecretSharing[E]) GetScheme() ShamirSecretSharingInterface{
+	return sss.scheme
+}
+
+/**
+ * Get participant count.
+ *
+ * @return participantCount, type int.
+ */
+func (sss *FieldShamirSecretSharing[E]) GetParticipantCount() int{
+	return sss.scheme.GetParticipantCount()
+}
+
+/**
+ * Get the field.
+ *
+ * @return The field <i>Zp</i>.
+ */
+func (sss *FieldShamirSecretSharing[E]) GetField() poly.Field[E]{
+	return sss.scheme.GetField()
+}
+
+/**
+ * Get access structure.
+ *
+ * @return AccessStructureInterface, nil if not set.
+ */
+func (sss *FieldShamirSecretSharing[E]) GetAccessStructure() AccessStructureInterface{
+	return sss.scheme.GetAccessStructure()
+}
+
+/**
+ * Set access structure.
+ * <p>
+ * The participant count in AccessStructure and that in this scheme must be equal.
+ *
+ * @param access Access structure, should be ThresholdAccessStructure.
+ * @return error If the access structure is invalid.
+ */
+func (sss *FieldShamirSecretSharing[E]) SetAccessStructure(access AccessStructureInterface) error{
+	return sss.scheme.SetAccessStructure(access)
+}
+
+/**
+ * Determine if the scheme object is initialized properly for generating shares and calculating secret.
+ *
+ * @return True if the access structure is set, otherwise return false.
+ */
+func (sss *FieldShamirSecretSharing[E]) IsInitialized() bool{
+	return sss.scheme.access != nil
+}
+
+/**
+ * Create default auxiliary data from generate shares.
+ * <p>
+ * i.e. 1, 2, ..., <i>n</i>
+ *
+ * @return Default auxiliary data
+ */
+func (sss *FieldShamirSecretSharing[E]) CreateDefaultAuxiliary() []E{
+	feedback, _ := toElements[E](sss.scheme.CreateDefaultAuxiliary())
+	return feedback
+}
+
+/**
+ * Generate <i>n</i> distinct random auxiliary data from <i>Zp</i><sup>*</sup>, one for each participant.
+ *
+ * @return Random auxiliary
+ * @return error If random numbers cannot be generated.
+ */
+func (sss *FieldShamirSecretSharing[E]) GenerateRandomAuxiliary() ([]E, error){
+	return sss.scheme.generateRandomAuxiliary()
+}
+
+/**
+ * Get a random <i>k</i>-1 degree polynomial over <i>Zp</i>.
+ * <p>
+ * <i>a</i><sub>0</sub> is the secret specified by input parameter,
+ * and <i>a</i><sub>1</sub>, <i>a</i><sub>2</sub>, ..., <i>a</i><sub><i>k</i>-1</sub>
+ * are chosen randomly in <i>Zp</i><sup>*</sup>.
+ *
+ * @param a0 Constant term of the polynomial.
+ * @return The polynomial object.
+ * @return error If the scheme is not initialized.
+ */
+func (sss *FieldShamirSecretSharing[E]) GetRandomPolynomial(a0 E) (*poly.FieldPolynomial[E], error){
+	if (!sss.IsInitialized()){
+		return nil, errors.New("Not ready for generate shares.")
+	}
+	return sss.scheme.GetRandomPolynomial(a0).(*poly.FieldPolynomialCalculator[E]).GetFieldPolynomial(), nil
+}
+
+/**
+ * Generate shares from input secret under Shamir's secret sharing scheme.
+ * <p>
+ * The auxiliary data are <i>n</i> different numbers from <i>Zp</i><sup>*</sup>, each for one participant to calculate its share.
+ * If parameter auxiliary is nil, then number 1, 2, ..., <i>n</i> are used.
+ *
+ * @param secret The secret from which shares are generated.
+ * @param auxiliary Auxiliary data for generating shares. Can be nil(use default auxiliary).
+ * @return N shares that generated from the input secret, one for each participant.
+ * @return error If the scheme is not initialized or the auxiliary data is invalid.
+ */
+func (sss *FieldShamirSecretSharing[E]) GenerateShares(secret E, auxiliary []E) ([]*SecretShare, error){
+	if (auxiliary == nil){
+		return sss.scheme.GenerateShares(secret, nil)
+	}
+	for i := 0; i < len(auxiliary); i++{
+		if (sss.scheme.field.IsZero(auxiliary[i])) {return nil, errors.New("Auxiliary data should not be 0.")}
+	}
+	return sss.scheme.GenerateShares(secret, toElementInterfaces(auxiliary))
+}
+
+/**
+ * Calculating secret from input shares.
+ * <p>
+ * The secret <i>q</i>(0) is calculated by Lagrange interpolation on the first <i>k</i> shares.
+ *
+ * @param shares The shares from which secret is calculated.
+ * @return The secret calculated from the input shares.
+ * @return error If the scheme is not initialized, the shares do not pass the access test, or any of them is invalid.
+ */
+func (sss *FieldShamirSecretSharing[E]) CalculateSecret(shares []*SecretShare) (E, error){
+	zero := sss.scheme.field.Zero()
+	for i := 0; i < len(shares); i++{
+		if (shares[i] == nil) {return zero, errors.New("Share should not be nil.")}
+	}
+	feedback, err := sss.scheme.CalculateSecret(shares)
+	if (err != nil) {return zero, err}
+	return feedback.(E), nil
+}
@@ -0,0 +1,88 @@
+package secretshare
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"testing"
+	"math/big"
+	"fmt"
+)
+
+func TestFieldShamirSecretSharingProcedure(t *testing.T) {
+	intField, _ := poly.NewIntField(2147483647)
+	uint64Field, _ := poly.NewUint64Field(18446744073709551557)
+	bigIntModulus, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+	bigIntField, _ := poly.NewBigIntField(bigIntModulus)
+	t.Run("TestFieldShamirSecretSharingInt", testFieldShamirSecretSharingProcedure[int](intField, 16, 11, 218932111))
+	t.Run("TestFieldShamirSecretSharingUint64", testFieldShamirSecretSharingProcedure[uint64](uint64Field, 16, 11, 18446744073709551556))
+	t.Run("TestFieldShamirSecretSharingBigInt", testFieldShamirSecretSharingProcedure[*big.Int](bigIntField, 16, 11, new(big.Int).Rsh(bigIntModulus, 1)))
+}
+
+func testFieldShamirSecretSharingProcedure[E any](field poly.Field[E], participantCount int, threshold int, secret E) func(t *testing.T) {
+	return func(t *testing.T) {
+		shamirSecretSharing, err := NewFieldShamirSecretSharing(participantCount, field)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing FieldShamirSecretSharing: %s", err))}
+		_, err = shamirSecretSharing.GenerateShares(secret, nil)
+		if err == nil {t.Error("Shares should not be generated before the access structure is set.")}
+		threAccessStruct, err := NewThresholdAccessStructure(participantCount, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+		err = shamirSecretSharing.SetAccessStructure(threAccessStruct)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+		auxi, err := shamirSecretSharing.GenerateRandomAuxiliary()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+		shares, err := shamirSecretSharing.GenerateShares(secret, auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+
+		// shares survive the binary encoding
+		decodedShares := make([]*SecretShare, threshold)
+		for i := 0; i < threshold; i++{
+			data, err := shares[participantCount - 1 - i].MarshalBinary()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share: %s", err))}
+			decodedShares[i] = new(SecretShare)
+			err = decodedShares[i].UnmarshalBinary(data)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share: %s", err))}
+		}
+		secretNew, err := shamirSecretSharing.CalculateSecret(decodedShares)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (!field.Equal(secretNew, secret)){
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%v ,Expected: %v", secretNew, secret))
+		}
+
+		_, err = shamirSecretSharing.CalculateSecret(decodedShares[:threshold - 1])
+		if err == nil {t.Error("Secret should not be calculated with too few shares.")}
+	}
+}
+
+func TestFieldShamirSecretSharingCompatibility(t *testing.T) {
+	// shares of the generic scheme are recovered by the interface{} scheme over the same Zp, and vice versa
+	participantCount, threshold, secret := 7, 4, big.NewInt(9876543210)
+	modulus := big.NewInt(2305843009213693951)
+	field, _ := poly.NewBigIntField(modulus)
+	access, _ := NewThresholdAccessStructure(participantCount, threshold)
+	generic, _ := NewFieldShamirSecretSharing[*big.Int](participantCount, field)
+	legacy, _ := NewShamirSecretSharingBigInt(participantCount, modulus)
+	_ = generic.SetAccessStructure(access)
+	_ = legacy.SetAccessStructure(access)
+
+	shares, err := generic.GenerateShares(secret, nil)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+	secretNew, err := legacy.CalculateSecret(shares[2:])
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+	if (secretNew.(*big.Int).Cmp(secret) != 0){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s", secretNew, secret))
+	}
+
+	shares, err = legacy.GenerateShares(secret, nil)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+	secretGeneric, err := generic.CalculateSecret(shares[:threshold])
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+	if (secretGeneric.Cmp(secret) != 0){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s", secretGeneric, secret))
+	}
+
+	// shares of another element type are rejected
+	intField, _ := poly.NewIntField(2147483647)
+	intScheme, _ := NewFieldShamirSecretSharing[int](participantCount, intField)
+	_ = intScheme.SetAccessStructure(access)
+	_, err = intScheme.CalculateSecret(shares)
+	if err == nil {t.Error("Shares of invalid element type should not be accepted.")}
+}
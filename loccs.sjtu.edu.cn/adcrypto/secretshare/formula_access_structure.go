@@ -0,0 +1,246 @@
+package secretshare
+
+import "errors"
+
+/**
+ * A monotone boolean formula over participant IDs, i.e. a tree whose leaves are participants and whose inner
+ * nodes are threshold gates.
+ * <p>
+ * A gate with threshold <i>k</i> over <i>m</i> children is satisfied if at least <i>k</i> of its children are satisfied,
+ * so AND is the gate with <i>k</i> = <i>m</i> and OR is the gate with <i>k</i> = 1. A leaf is satisfied if its participant
+ * is present. A participant may appear in several leaves.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type AccessFormula struct {
+	/**
+	 * ID of the participant of a leaf, -1 for a gate.
+	 */
+	participant int
+
+	/**
+	 * Threshold <i>k</i> of a gate, 0 for a leaf.
+	 */
+	threshold int
+
+	/**
+	 * Children of a gate, nil for a leaf.
+	 */
+	children []*AccessFormula
+}
+
+/**
+ * Construct a leaf satisfied by a participant.
+ *
+ * @param participant ID of the participant.
+ * @return feedback the constructed AccessFormula
+ * @return error If the ID is negative.
+ */
+func NewParticipantFormula(participant int) (*AccessFormula, error){
+	if (participant < 0) {return nil, errors.New("Invalid participant ID.")}
+	feedback := new(AccessFormula)
+	feedback.participant = participant
+	return feedback, nil
+}
+
+/**
+ * Construct a threshold gate satisfied if at least <i>k</i> of its children are satisfied.
+ *
+ * @param threshold The threshold <i>k</i>, from 1 to the number of children.
+ * @param children The sub-formulas.
+ * @return feedback the constructed AccessFormula
+ * @return error If the threshold is invalid or any child is nil.
+ */
+func NewThresholdFormula(threshold int, children ...*AccessFormula) (*AccessFormula, error){
+	if (len(children) == 0) {return nil, errors.New("A gate should have at least one child.")}
+	if (threshold < 1 || threshold > len(children)){
+		return nil, errors.New("Invalid threshold of gate. Should be larger than 0 and no more than number of children.")
+	}
+	for i := 0; i < len(children); i++{
+		if (children[i] == nil) {return nil, errors.New("Child of gate should not be nil.")}
+	}
+	feedback := new(AccessFormula)
+	feedback.participant = -1
+	feedback.threshold = threshold
+	feedback.children = append([]*AccessFormula{}, children...)
+	return feedback, nil
+}
+
+/**
+ * Construct an AND gate, satisfied if all its children are satisfied.
+ *
+ * @param children The sub-formulas.
+ * @return The constructed AccessFormula.
+ * @return error If there is no child or any child is nil.
+ */
+func NewAndFormula(children ...*AccessFormula) (*AccessFormula, error){
+	return NewThresholdFormula(len(children), children...)
+}
+
+/**
+ * Construct an OR gate, satisfied if any of its children is satisfied.
+ *
+ * @param children The sub-formulas.
+ * @return The constructed AccessFormula.
+ * @return error If there is no child or any child is nil.
+ */
+func NewOrFormula(children ...*AccessFormula) (*AccessFormula, error){
+	return NewThresholdFormula(1, children...)
+}
+
+/**
+ * Determine if the formula is a leaf.
+ *
+ * @return True for a leaf, false for a gate.
+ */
+func (formula *AccessFormula) IsLeaf() bool{
+	return formula.children == nil
+}
+
+/**
+ * Get ID of the participant of a leaf.
+ *
+ * @return ID of the participant, -1 for a gate.
+ */
+func (formula *AccessFormula) GetParticipant() int{
+	return formula.participant
+}
+
+/**
+ * Get threshold of a gate.
+ *
+ * @return The threshold <i>k</i>, 0 for a leaf.
+ */
+func (formula *AccessFormula) GetThreshold() int{
+	return formula.threshold
+}
+
+/**
+ * Get children of a gate.
+ *
+ * @return The sub-formulas, nil for a leaf.
+ */
+func (formula *AccessFormula) GetChildren() []*AccessFormula{
+	if (formula.children == nil) {return nil}
+	return append([]*AccessFormula{}, formula.children...)
+}
+
+/**
+ * Evaluate the formula on a set of participants.
+ *
+ * @param present present[i] is true if participant i is present.
+ * @return If the formula is satisfied.
+ */
+func (formula *AccessFormula) evaluate(present map[int]bool) bool{
+	if (formula.IsLeaf()) {return present[formula.participant]}
+	count := 0
+	for i := 0; i < len(formula.children) && count < formula.threshold; i++{
+		if (formula.children[i].evaluate(present)) {count++}
+	}
+	return count >= formula.threshold
+}
+
+/**
+ * Count the leaves of each participant.
+ *
+ * @param counts counts[i] is increased by the number of leaves of participant i.
+ */
+func (formula *AccessFormula) countLeaves(counts []int){
+	if (formula.IsLeaf()){
+		counts[formula.participant]++
+		return
+	}
+	for i := 0; i < len(formula.children); i++{
+		formula.children[i].countLeaves(counts)
+	}
+}
+
+/**
+ * Get the largest participant ID in the leaves.
+ *
+ * @return The largest ID.
+ */
+func (formula *AccessFormula) maxParticipant() int{
+	if (formula.IsLeaf()) {return formula.participant}
+	feedback := -1
+	for i := 0; i < len(formula.children); i++{
+		max := formula.children[i].maxParticipant()
+		if (max > feedback) {feedback = max}
+	}
+	return feedback
+}
+
+/**
+ * The class implements an access structure defined by a monotone boolean formula of AND, OR and threshold gates.
+ * <p>
+ * A set of participants matches the access structure if it satisfies the formula. Every monotone access structure
+ * can be written in this form, e.g. "participant 0 and any 2 of participants 1, 2, 3" is AND(0, 2-of(1, 2, 3)).
+ * Participants not in the formula never help to match it.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type FormulaAccessStructure struct {
+	/**
+	 * The formula.
+	 */
+	formula *AccessFormula
+
+	AccessStructure
+}
+
+/**
+ * Construct FormulaAccessStructure with the number of participants and the formula.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @param formula The monotone boolean formula.
+ * @return newFormulaAccessStructure the new constructed FormulaAccessStructure
+ * @return error If the number of participants is invalid, or a leaf of the formula is not a participant.
+ */
+func NewFormulaAccessStructure(participantCount int, formula *AccessFormula) (*FormulaAccessStructure, error){
+	if (participantCount < 2){
+		return nil, errors.New("Invalid participant count. Should be greater than 1.")
+	}
+	if (formula == nil){
+		return nil, errors.New("Formula should not be nil.")
+	}
+	if (formula.maxParticipant() >= participantCount){
+		return nil, errors.New("Invalid participant ID in formula. Should be less than participant count.")
+	}
+	newFormulaAccessStructure := new(FormulaAccessStructure)
+	newFormulaAccessStructure.participantCount = participantCount
+	newFormulaAccessStructure.formula = formula
+	newFormulaAccessStructure.AccessStructureITF = newFormulaAccessStructure
+	return newFormulaAccessStructure, nil
+}
+
+/**
+ * Get the formula.
+ *
+ * @return The monotone boolean formula.
+ */
+func (faccs *FormulaAccessStructure) GetFormula() *AccessFormula{
+	return faccs.formula
+}
+
+/**
+ * Get threshold of the root gate.
+ *
+ * @return Threshold of the root gate, or 1 if the formula is a single participant.
+ */
+func (faccs *FormulaAccessStructure) GetThreshold() int{
+	if (faccs.formula.IsLeaf()) {return 1}
+	return faccs.formula.threshold
+}
+
+/**
+ * Test if the participants trying to calculate secret satisfy the formula. Repeated IDs count once.
+ */
+func (faccs *FormulaAccessStructure) testAccessableImpl(participants []int) (bool){
+	present := map[int]bool{}
+	for i := 0; i < len(participants); i++{
+		present[participants[i]] = true
+	}
+	return faccs.formula.evaluate(present)
+}
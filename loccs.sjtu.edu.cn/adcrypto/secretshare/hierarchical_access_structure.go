@@ -0,0 +1,139 @@
+package secretshare
+
+import "errors"
+
+/**
+ * The class implements a hierarchical (conjunctive) threshold access structure for secret sharing.
+ *
+ * <p>
+ * The participants are divided into levels 0, 1, ..., <i>m</i>, level 0 being the most senior one, and every level
+ * <i>i</i> has a cumulative threshold <i>k<sub>i</sub></i> with 0 &lt; <i>k</i><sub>0</sub> &lt; <i>k</i><sub>1</sub> &lt; ... &lt; <i>k<sub>m</sub></i>.
+ * A set of participants matches the access structure if, for every <i>i</i>, at least <i>k<sub>i</sub></i> of its
+ * participants are in levels 0 to <i>i</i>. For example, "at least 2 of the 3 executives plus any 3 staff" puts the
+ * executives in level 0 and the staff in level 1 with thresholds (2, 5); an executive can stand in for a staff member.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type HierarchicalAccessStructure struct {
+
+	/**
+	 * Levels of the participants, indexed by ID.
+	 */
+	levels []int
+
+	/**
+	 * Cumulative thresholds of the levels.
+	 */
+	thresholds []int
+
+	AccessStructure
+}
+
+/**
+ * Construct HierarchicalAccessStructure with the levels of the participants and the cumulative thresholds.
+ *
+ * @param levels Levels of the participants, indexed by ID, the number of participants is len(levels).
+ * @param thresholds Cumulative thresholds <i>k</i><sub>0</sub> &lt; <i>k</i><sub>1</sub> &lt; ... &lt; <i>k<sub>m</sub></i>, one for each level.
+ * @return newHierarchicalAccessStructure the new constructed HierarchicalAccessStructure
+ * @return error If the number of participants, any level or any threshold is invalid, or no set of participants matches.
+ */
+func NewHierarchicalAccessStructure(levels []int, thresholds []int) (*HierarchicalAccessStructure, error){
+	if (len(levels) < 2){
+		return nil, errors.New("Invalid participant count. Should be greater than 1.")
+	}
+	if (len(thresholds) == 0){
+		return nil, errors.New("At least one level should be given.")
+	}
+	counts := make([]int, len(thresholds))
+	for i := 0; i < len(levels); i++{
+		if (levels[i] < 0 || levels[i] >= len(thresholds)){
+			return nil, errors.New("Invalid level. Should be between 0 and number of thresholds - 1.")
+		}
+		counts[levels[i]]++
+	}
+	cumulative := 0
+	for i := 0; i < len(thresholds); i++{
+		if (thresholds[i] < 1 || (i > 0 && thresholds[i] <= thresholds[i - 1])){
+			return nil, errors.New("Invalid thresholds. Should be positive and strictly increasing.")
+		}
+		cumulative += counts[i]
+		if (cumulative < thresholds[i]){
+			return nil, errors.New("Invalid thresholds. Levels 0 to i should have at least k_i participants.")
+		}
+	}
+	newHierarchicalAccessStructure := new(HierarchicalAccessStructure)
+	newHierarchicalAccessStructure.participantCount = len(levels)
+	newHierarchicalAccessStructure.levels = append([]int{}, levels...)
+	newHierarchicalAccessStructure.thresholds = append([]int{}, thresholds...)
+	newHierarchicalAccessStructure.AccessStructureITF = newHierarchicalAccessStructure
+	return newHierarchicalAccessStructure, nil
+}
+
+/**
+ * Get threshold of the last level, i.e. the number of shares needed to calculate the secret.
+ *
+ * @return threshold <i>k<sub>m</sub></i>.
+ */
+func (haccs *HierarchicalAccessStructure) GetThreshold() int{
+	return haccs.thresholds[len(haccs.thresholds) - 1]
+}
+
+/**
+ * Get the cumulative thresholds.
+ *
+ * @return Thresholds <i>k</i><sub>0</sub>, <i>k</i><sub>1</sub>, ..., <i>k<sub>m</sub></i>.
+ */
+func (haccs *HierarchicalAccessStructure) GetThresholds() []int{
+	return append([]int{}, haccs.thresholds...)
+}
+
+/**
+ * Get the number of levels.
+ *
+ * @return Number of levels <i>m</i>+1.
+ */
+func (haccs *HierarchicalAccessStructure) GetLevelCount() int{
+	return len(haccs.thresholds)
+}
+
+/**
+ * Get level of a participant.
+ *
+ * @param participant ID of the participant.
+ * @return The level, -1 if the ID is invalid.
+ */
+func (haccs *HierarchicalAccessStructure) GetLevel(participant int) int{
+	if (participant < 0 || participant >= haccs.participantCount) {return -1}
+	return haccs.levels[participant]
+}
+
+/**
+ * Get the order of the derivative given to the participants of a level, i.e. the threshold of the level above.
+ *
+ * @param level The level.
+ * @return <i>k</i><sub><i>level</i>-1</sub>, or 0 for level 0.
+ */
+func (haccs *HierarchicalAccessStructure) getDerivativeOrder(level int) int{
+	if (level == 0) {return 0}
+	return haccs.thresholds[level - 1]
+}
+
+/**
+ * Test if every level and the levels above it have enough participants trying to calculate secret. Repeated IDs count once.
+ */
+func (haccs *HierarchicalAccessStructure) testAccessableImpl(participants []int) (bool){
+	present := map[int]bool{}
+	counts := make([]int, len(haccs.thresholds))
+	for i := 0; i < len(participants); i++{
+		if (present[participants[i]]) {continue}
+		present[participants[i]] = true
+		counts[haccs.levels[participants[i]]]++
+	}
+	cumulative := 0
+	for i := 0; i < len(haccs.thresholds); i++{
+		cumulative += counts[i]
+		if (cumulative < haccs.thresholds[i]) {return false}
+	}
+	return true
+}
@@ -0,0 +1,276 @@
+package secretshare
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+/**
+ * The class implements packed secret sharing over <i>Zp</i> (Franklin-Yung), which shares <i>l</i> secrets with a single polynomial.
+ * <p>
+ * With threshold <i>k</i> &ge; <i>l</i>, the secrets <i>s</i><sub>0</sub>, ..., <i>s</i><sub><i>l</i>-1</sub> are embedded at the fixed
+ * points 0, -1, ..., -(<i>l</i>-1) of a random <i>k</i>-1 degree polynomial <i>f</i>, i.e. <i>f</i>(-<i>j</i>) = <i>s<sub>j</sub></i>, and every
+ * participant gets one evaluation <i>f</i>(<i>x</i>) as in Shamir's scheme. Any <i>k</i> shares recover all <i>l</i> secrets by Lagrange
+ * interpolation, while any <i>k</i>-<i>l</i> shares reveal nothing, so <i>l</i> secrets cost the traffic of one. With <i>l</i> = 1 it is
+ * Shamir's scheme.
+ * <p>
+ * The polynomial is fixed by its values on 0, -1, ..., -(<i>k</i>-1): the secrets, then <i>k</i>-<i>l</i> random values. The secret is a
+ * []interface{} of <i>l</i> BigInt, and the share value is a <code>ShamirSecretShareValue</code> (<i>x</i>, <i>f</i>(<i>x</i>)). Since the
+ * shares are linear in the secrets, the shares of a linear combination of packed vectors are the same linear combination of the shares.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type PackedSecretSharingBigInt struct {
+	/**
+	 * The prime modulus <i>p</i>.
+	 */
+	modulus *big.Int
+
+	/**
+	 * Number of secrets <i>l</i> packed in one polynomial.
+	 */
+	packingCount int
+
+	SecretSharingScheme
+}
+
+/**
+ * Construct packed secret sharing scheme over <i>Zp</i> with the number of participants, the number of packed secrets and the modulus.
+ *
+ * @param participantCount The number of participants that share the secrets.
+ * @param packingCount The number of secrets <i>l</i> packed in one polynomial.
+ * @param modulus The modulus <i>p</i>, should be a prime larger than <i>n</i>+<i>l</i>-1, so that the default evaluation points
+ *        1, 2, ..., <i>n</i> are not secret points.
+ * @return feedback the newly constructed PackedSecretSharingBigInt
+ * @return error If the number of participants, the number of packed secrets or the modulus is invalid.
+ */
+func NewPackedSecretSharingBigInt(participantCount int, packingCount int, modulus *big.Int) (*PackedSecretSharingBigInt, error){
+	if (participantCount < 2){
+		return nil, errors.New("Invalid participant count. Should be larger than 1.")
+	}
+	if (packingCount < 1 || packingCount > participantCount){
+		return nil, errors.New("Invalid packing count. Should be larger than 0 and no more than participant count.")
+	}
+	if (modulus == nil || modulus.Cmp(big.NewInt(int64(participantCount + packingCount - 1))) <= 0 || !modulus.ProbablyPrime(20)){
+		return nil, errors.New("Modulus should be a prime larger than participant count + packing count - 1.")
+	}
+	feedback := new(PackedSecretSharingBigInt)
+	feedback.participantCount = participantCount
+	feedback.packingCount = packingCount
+	feedback.modulus = new(big.Int).Set(modulus)
+	feedback.SecretSharingSchemeITF = feedback
+	return feedback, nil
+}
+
+/**
+ * Get the modulus.
+ *
+ * @return The modulus <i>p</i>.
+ */
+func (pssb *PackedSecretSharingBigInt) GetModulus() *big.Int{
+	return new(big.Int).Set(pssb.modulus)
+}
+
+/**
+ * Get the number of secrets packed in one polynomial.
+ *
+ * @return The packing count <i>l</i>.
+ */
+func (pssb *PackedSecretSharingBigInt) GetPackingCount() int{
+	return pssb.packingCount
+}
+
+/**
+ * Get the points where the secrets are embedded.
+ *
+ * @return 0, -1, ..., -(<i>l</i>-1) mod <i>p</i>.
+ */
+func (pssb *PackedSecretSharingBigInt) GetSecretPoints() []*big.Int{
+	return pssb.getFixedPoints(pssb.packingCount)
+}
+
+/**
+ * Set access structure.
+ * <p>
+ * The participant count in AccessStructure and that in this scheme must be equal, and the threshold <i>k</i> should be at least
+ * the packing count <i>l</i>. Up to <i>k</i>-<i>l</i> participants learn nothing of the secrets.
+ *
+ * @param access Access structure, should be ThresholdAccessStructure.
+ * @return error If the access structure is invalid.
+ */
+func (pssb *PackedSecretSharingBigInt) SetAccessStructure(access AccessStructureInterface) error{
+	accessValue, ok := access.(*ThresholdAccessStructure)
+	if (!ok) {return errors.New("Invalid AccessStructure type. Should be 'ThresholdAccessStructure'.")}
+	if (accessValue.participantCount != pssb.participantCount) {
+		return errors.New("The participant count in AccessStructure and Scheme should be equal.")
+	}
+	if (accessValue.GetThreshold() < pssb.packingCount){
+		return errors.New("The threshold should be no less than the packing count.")
+	}
+	pssb.access = accessValue
+	return nil
+}
+
+/**
+ * Determine if the scheme object is initialized properly for generating shares and calculating secret.
+ *
+ * @return True if the access structure is set, otherwise return false.
+ */
+func (pssb *PackedSecretSharingBigInt) IsInitialized() bool{
+	return pssb.access != nil
+}
+
+/**
+ * Create default auxiliary data (BigInt array) for generating shares.
+ * <p>
+ * i.e. 1, 2, ..., <i>n</i>
+ *
+ * @return Default auxiliary data
+ */
+func (pssb *PackedSecretSharingBigInt) CreateDefaultAuxiliary() []interface{}{
+	feedback := make([]interface{}, pssb.participantCount)
+	for i := 0; i < pssb.participantCount; i++{
+		feedback[i] = big.NewInt(int64(i + 1))
+	}
+	return feedback
+}
+
+/**
+ * Generate <i>n</i> random auxiliary data, i.e. distinct random evaluation points which are not secret points.
+ *
+ * @return Random auxiliary (BigInt array).
+ */
+func (pssb *PackedSecretSharingBigInt) GenerateRandomAuxiliary() []interface{}{
+	feedback := make([]interface{}, pssb.participantCount)
+	points := make([]*big.Int, 0, pssb.participantCount)
+	for (len(points) < pssb.participantCount){
+		point, err := rand.Int(rand.Reader, pssb.modulus)
+		if (err != nil) {continue}
+		if (pssb.checkPoint(point, points) != nil) {continue}
+		feedback[len(points)] = point
+		points = append(points, point)
+	}
+	return feedback
+}
+
+/**
+ * Generate shares from input secrets.
+ *
+ * @param secret The <i>l</i> secrets from which shares are generated, should be a []interface{} of BigInt.
+ * @param auxiliary Distinct evaluation points (BigInt) which are not secret points, one for each participant. Can be nil(use default auxiliary).
+ * @return N shares, the value of each is a ShamirSecretShareValue (<i>x</i>, <i>f</i>(<i>x</i>)).
+ * @return error If the secrets or the auxiliary data is invalid.
+ */
+func (pssb *PackedSecretSharingBigInt) generateSharesImpl(secret interface{}, auxiliary []interface{}) ([]*SecretShare, error){
+	secrets, ok := secret.([]interface{})
+	if (!ok || len(secrets) != pssb.packingCount){
+		return nil, errors.New("Invalid secret, should be a []interface{} of as many BigInt as the packing count.")
+	}
+	if (auxiliary == nil){
+		auxiliary = pssb.CreateDefaultAuxiliary()
+	} else if (len(auxiliary) != pssb.participantCount){
+		return nil, errors.New("Invalid number of auxiliary data, should be equal to number of participants.")
+	}
+	points := make([]*big.Int, 0, pssb.participantCount)
+	for i := 0; i < pssb.participantCount; i++{
+		point, ok := auxiliary[i].(*big.Int)
+		if (!ok || point == nil) {return nil, errors.New("Invalid type of auxiliary data, should be BigInt.")}
+		point = new(big.Int).Mod(point, pssb.modulus)
+		err := pssb.checkPoint(point, points)
+		if (err != nil) {return nil, err}
+		points = append(points, point)
+	}
+
+	// f is fixed by the secrets on 0, -1, ..., -(l-1) and random values on -l, ..., -(k-1)
+	threshold := pssb.access.GetThreshold()
+	interpolation, err := poly.NewLagrangeInterpolationBigInt(pssb.getFixedPoints(threshold), pssb.modulus)
+	if (err != nil) {return nil, err}
+	values := make([]interface{}, threshold)
+	for j := 0; j < threshold; j++{
+		if (j < pssb.packingCount){
+			value, ok := secrets[j].(*big.Int)
+			if (!ok || value == nil) {return nil, errors.New("Invalid type of secret, should be BigInt.")}
+			values[j] = new(big.Int).Mod(value, pssb.modulus)
+			continue
+		}
+		random, err := rand.Int(rand.Reader, pssb.modulus)
+		if (err != nil) {return nil, err}
+		values[j] = random
+	}
+	shares := make([]*SecretShare, pssb.participantCount)
+	for i := 0; i < pssb.participantCount; i++{
+		value, err := interpolation.Interpolate(points[i], values)
+		if (err != nil) {return nil, err}
+		shares[i] = NewSecretShare(i, NewShamirSecretShareValue(points[i], value))
+	}
+	return shares, nil
+}
+
+/**
+ * Calculating secrets from input shares.
+ * <p>
+ * The secrets <i>f</i>(0), <i>f</i>(-1), ..., <i>f</i>(-(<i>l</i>-1)) are calculated by Lagrange interpolation on the first <i>k</i> shares.
+ *
+ * @param shares The shares from which secrets are calculated.
+ * @return The <i>l</i> secrets ([]interface{} of BigInt) calculated from the input shares.
+ * @return error If any of the input shares is invalid.
+ */
+func (pssb *PackedSecretSharingBigInt) calculateSecretImpl(shares []*SecretShare) (interface{}, error){
+	threshold := pssb.access.GetThreshold()
+	points := make([]*big.Int, threshold)
+	values := make([]interface{}, threshold)
+	for i := 0; i < threshold; i++{
+		value, ok := shares[i].GetValue().(*ShamirSecretShareValue)
+		if (!ok) {return nil, errors.New("Invalid type of share value, should be ShamirSecretShareValue.")}
+		x, okX := value.GetR().(*big.Int)
+		y, okY := value.GetQr().(*big.Int)
+		if (!okX || !okY || x == nil || y == nil) {return nil, errors.New("Invalid type of elements in ShamirSecretShareValue.")}
+		points[i] = x
+		values[i] = y
+	}
+	interpolation, err := poly.NewLagrangeInterpolationBigInt(points, pssb.modulus)
+	if (err != nil) {return nil, errors.New("At least one invalid share value.")}
+	secretPoints := pssb.GetSecretPoints()
+	feedback := make([]interface{}, pssb.packingCount)
+	for j := 0; j < pssb.packingCount; j++{
+		feedback[j], err = interpolation.Interpolate(secretPoints[j], values)
+		if (err != nil) {return nil, err}
+	}
+	return feedback, nil
+}
+
+/**
+ * Get the fixed points 0, -1, ..., -(<i>count</i>-1) mod <i>p</i>.
+ *
+ * @param count Number of points.
+ * @return The points.
+ */
+func (pssb *PackedSecretSharingBigInt) getFixedPoints(count int) []*big.Int{
+	feedback := make([]*big.Int, count)
+	for j := 0; j < count; j++{
+		feedback[j] = big.NewInt(int64(-j))
+		feedback[j].Mod(feedback[j], pssb.modulus)
+	}
+	return feedback
+}
+
+/**
+ * Check if an evaluation point is not a secret point and differs from the previous ones.
+ *
+ * @param point The evaluation point in [0, <i>p</i>).
+ * @param previous The previous evaluation points.
+ * @return error If the point is a secret point or repeated.
+ */
+func (pssb *PackedSecretSharingBigInt) checkPoint(point *big.Int, previous []*big.Int) error{
+	secretPoints := pssb.GetSecretPoints()
+	for j := 0; j < len(secretPoints); j++{
+		if (secretPoints[j].Cmp(point) == 0) {return errors.New("Auxiliary data should not be a secret point.")}
+	}
+	for j := 0; j < len(previous); j++{
+		if (previous[j].Cmp(point) == 0) {return errors.New("Auxiliary data should be distinct modulo p.")}
+	}
+	return nil
+}
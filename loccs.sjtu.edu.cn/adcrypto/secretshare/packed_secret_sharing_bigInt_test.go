@@ -0,0 +1,107 @@
+package secretshare
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestPackedSecretSharingBigIntProcedure(t *testing.T) {
+	modulus, _ := rand.Prime(rand.Reader, 64)
+	t.Run("TestPackedSecretSharingBigIntProcedure1", testPackedSecretSharingBigIntProcedure(10, 4, 7, modulus, false))
+	t.Run("TestPackedSecretSharingBigIntProcedure2", testPackedSecretSharingBigIntProcedure(10, 4, 7, modulus, true))
+	// one secret is Shamir's scheme
+	t.Run("TestPackedSecretSharingBigIntProcedure3", testPackedSecretSharingBigIntProcedure(5, 1, 3, modulus, false))
+	// the smallest modulus for 6 participants and 3 secrets
+	t.Run("TestPackedSecretSharingBigIntProcedure4", testPackedSecretSharingBigIntProcedure(6, 3, 6, big.NewInt(11), false))
+}
+
+func testPackedSecretSharingBigIntProcedure(participantCount int, packingCount int, threshold int, modulus *big.Int, randomAuxiliary bool) func(t *testing.T) {
+	return func(t *testing.T) {
+		scheme, err := NewPackedSecretSharingBigInt(participantCount, packingCount, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing PackedSecretSharingBigInt: %s", err))}
+		access, err := NewThresholdAccessStructure(participantCount, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+		err = scheme.SetAccessStructure(access)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+		var auxi []interface{}
+		if (randomAuxiliary) {auxi = scheme.GenerateRandomAuxiliary()}
+		secrets := make([]interface{}, packingCount)
+		for j := 0; j < packingCount; j++{
+			secrets[j], _ = rand.Int(rand.Reader, modulus)
+		}
+		shares, err := scheme.GenerateShares(secrets, auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+
+		// any k shares recover all secrets
+		for start := 0; start < participantCount; start++{
+			subset := make([]*SecretShare, threshold)
+			for i := 0; i < threshold; i++{
+				subset[i] = shares[(start + i) % participantCount]
+			}
+			secretsNew, err := scheme.CalculateSecret(subset)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secrets: %s", err))}
+			for j := 0; j < packingCount; j++{
+				if (secretsNew.([]interface{})[j].(*big.Int).Cmp(secrets[j].(*big.Int)) != 0){
+					t.Error(fmt.Sprintf("Calculate Result %d is False, Result:%s ,Expected: %s", j, secretsNew.([]interface{})[j], secrets[j]))
+				}
+			}
+		}
+		_, err = scheme.CalculateSecret(shares[:threshold - 1])
+		if err == nil {t.Error("Secrets should not be calculated from k-1 shares.")}
+	}
+}
+
+func TestPackedSecretSharingBigIntLinearity(t *testing.T) {
+	// 3 a + b is shared by 3 share(a) + share(b)
+	modulus := big.NewInt(1000003)
+	scheme, _ := NewPackedSecretSharingBigInt(7, 3, modulus)
+	access, _ := NewThresholdAccessStructure(7, 5)
+	scheme.SetAccessStructure(access)
+	a := []interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	b := []interface{}{big.NewInt(10), big.NewInt(20), big.NewInt(1000002)}
+	sharesA, _ := scheme.GenerateShares(a, nil)
+	sharesB, _ := scheme.GenerateShares(b, nil)
+	combined := make([]*SecretShare, 7)
+	for i := 0; i < 7; i++{
+		valueA := sharesA[i].GetValue().(*ShamirSecretShareValue)
+		valueB := sharesB[i].GetValue().(*ShamirSecretShareValue)
+		y := new(big.Int).Mul(valueA.GetQr().(*big.Int), big.NewInt(3))
+		y.Add(y, valueB.GetQr().(*big.Int)).Mod(y, modulus)
+		combined[i] = NewSecretShare(i, NewShamirSecretShareValue(valueA.GetR(), y))
+	}
+	result, err := scheme.CalculateSecret(combined[2:])
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secrets: %s", err))}
+	expected := []int64{13, 26, 8}
+	for j := 0; j < 3; j++{
+		if (result.([]interface{})[j].(*big.Int).Int64() != expected[j]){
+			t.Error(fmt.Sprintf("Calculate Result %d is False, Result:%s ,Expected: %d", j, result.([]interface{})[j], expected[j]))
+		}
+	}
+}
+
+func TestPackedSecretSharingBigIntInvalid(t *testing.T) {
+	_, err := NewPackedSecretSharingBigInt(6, 3, big.NewInt(7))
+	if err == nil {t.Error("Modulus not larger than n+l-1 should not be accepted.")}
+	_, err = NewPackedSecretSharingBigInt(4, 5, big.NewInt(101))
+	if err == nil {t.Error("Packing count larger than participant count should not be accepted.")}
+	scheme, _ := NewPackedSecretSharingBigInt(5, 3, big.NewInt(101))
+	_, err = scheme.GenerateShares([]interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3)}, nil)
+	if err == nil {t.Error("Shares should not be generated before the access structure is set.")}
+	low, _ := NewThresholdAccessStructure(5, 2)
+	err = scheme.SetAccessStructure(low)
+	if err == nil {t.Error("Threshold less than the packing count should not be accepted.")}
+	access, _ := NewThresholdAccessStructure(5, 4)
+	err = scheme.SetAccessStructure(access)
+	if err != nil {t.Fatal(err)}
+	_, err = scheme.GenerateShares([]interface{}{big.NewInt(1), big.NewInt(2)}, nil)
+	if err == nil {t.Error("Wrong number of secrets should not be accepted.")}
+	_, err = scheme.GenerateShares(big.NewInt(1), nil)
+	if err == nil {t.Error("Single BigInt secret should not be accepted.")}
+	secrets := []interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	_, err = scheme.GenerateShares(secrets, []interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(100)})
+	if err == nil {t.Error("Secret point -1 as auxiliary data should not be accepted.")}
+	_, err = scheme.GenerateShares(secrets, []interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(102)})
+	if err == nil {t.Error("Auxiliary data repeated modulo p should not be accepted.")}
+}
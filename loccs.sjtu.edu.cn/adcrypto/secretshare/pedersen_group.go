@@ -0,0 +1,123 @@
+package secretshare
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+/**
+ * This class represents a subgroup of prime order <i>q</i> in <i>Zp</i><sup>*</sup> with two generators <i>g</i> and <i>h</i>,
+ * used for the commitments of Pedersen's VSS.
+ * <p>
+ * Nobody should know log<sub><i>g</i></sub><i>h</i>, otherwise the commitments are not binding.
+ * <code>GeneratePedersenGroup</code> derives <i>h</i> from a hash of <i>p</i> and <i>g</i> for this reason.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type PedersenGroup struct {
+	FeldmanGroup
+
+	/**
+	 * The second generator <i>h</i> of the subgroup.
+	 */
+	h *big.Int
+}
+
+/**
+ * Construct a group with given parameters.
+ *
+ * @param p Modulus <i>p</i> of the group.
+ * @param q Order <i>q</i> of the subgroup.
+ * @param g Generator <i>g</i> of the subgroup.
+ * @param h The second generator <i>h</i> of the subgroup.
+ * @return feedback the constructed PedersenGroup
+ * @return error If the parameters are invalid, or <i>g</i> = <i>h</i>.
+ */
+func NewPedersenGroup(p *big.Int, q *big.Int, g *big.Int, h *big.Int) (*PedersenGroup, error){
+	group, err := NewFeldmanGroup(p, q, g)
+	if (err != nil) {return nil, err}
+	if (h == nil || h.Cmp(big.NewInt(1)) <= 0 || h.Cmp(p) >= 0 || big.NewInt(0).Exp(h, q, p).Cmp(big.NewInt(1)) != 0){
+		return nil, errors.New("Invalid second generator of subgroup.")
+	}
+	if (h.Cmp(g) == 0){
+		return nil, errors.New("The two generators should be different.")
+	}
+	feedback := new(PedersenGroup)
+	feedback.FeldmanGroup = *group
+	feedback.h = h
+	return feedback, nil
+}
+
+/**
+ * Generate a group whose subgroup has the given prime order.
+ * <p>
+ * <i>p</i> and <i>g</i> are generated as in <code>GenerateFeldmanGroup</code>, and <i>h</i> = <i>x</i><sup>(<i>p</i>-1)/<i>q</i></sup> mod <i>p</i>,
+ * where <i>x</i> is derived from SHA-256 of <i>p</i>, <i>g</i> and a counter.
+ *
+ * @param q Order <i>q</i> of the subgroup, must be an odd prime.
+ * @param bits Expected bit length of <i>p</i>.
+ * @return feedback the generated PedersenGroup
+ * @return error If <i>q</i> is invalid or random numbers cannot be generated.
+ */
+func GeneratePedersenGroup(q *big.Int, bits int) (*PedersenGroup, error){
+	group, err := GenerateFeldmanGroup(q, bits)
+	if (err != nil) {return nil, err}
+	p := group.GetP()
+	cofactor := big.NewInt(0)
+	cofactor.Sub(p, big.NewInt(1)).Div(cofactor, q)
+	h := big.NewInt(0)
+	for counter := 0; ; counter++{
+		x := big.NewInt(0)
+		// expand the hash until it is as long as p
+		seed := make([]byte, 0)
+		for block := 0; len(seed) * 8 < p.BitLen() + 64; block++{
+			digest := sha256.New()
+			digest.Write([]byte("PedersenGroup"))
+			digest.Write(p.Bytes())
+			digest.Write(group.GetG().Bytes())
+			digest.Write([]byte{byte(counter >> 8), byte(counter), byte(block)})
+			seed = digest.Sum(seed)
+		}
+		x.SetBytes(seed).Mod(x, p)
+		h.Exp(x, cofactor, p)
+		if (h.Cmp(big.NewInt(1)) > 0 && h.Cmp(group.GetG()) != 0) {break}
+	}
+	return NewPedersenGroup(p, q, group.GetG(), h)
+}
+
+/**
+ * Get the second generator <i>h</i> of the subgroup.
+ *
+ * @return Generator <i>h</i>.
+ */
+func (group *PedersenGroup) GetH() *big.Int{
+	return group.h
+}
+
+/**
+ * Calculate the commitments <i>g</i><sup><i>a<sub>i</sub></i></sup><i>h</i><sup><i>b<sub>i</sub></i></sup> mod <i>p</i>
+ * of the coefficients of two polynomials.
+ *
+ * @param a Coefficients of the polynomial hiding the secret.
+ * @param b Coefficients of the blinding polynomial.
+ * @return The commitments.
+ * @return error If the numbers of coefficients are different, or any coefficient is not a BigInt.
+ */
+func (group *PedersenGroup) Commit(a []interface{}, b []interface{}) ([]*big.Int, error){
+	if (len(a) != len(b)){
+		return nil, errors.New("Numbers of coefficients of the two polynomials should be equal.")
+	}
+	feedback := make([]*big.Int, len(a))
+	for i := 0; i < len(a); i++{
+		ai, okA := a[i].(*big.Int)
+		bi, okB := b[i].(*big.Int)
+		if (!okA || !okB) {return nil, errors.New("Invalid type of coefficient, should be big.Int.")}
+		tmp := big.NewInt(0)
+		tmp.Exp(group.h, bi, group.p)
+		feedback[i] = big.NewInt(0)
+		feedback[i].Exp(group.g, ai, group.p).Mul(feedback[i], tmp).Mod(feedback[i], group.p)
+	}
+	return feedback, nil
+}
@@ -0,0 +1,80 @@
+package secretshare
+
+/**
+ * The class stores a secret share value in Pedersen's verifiable secret sharing scheme.
+ * <p>
+ * A share in Pedersen's scheme is a 3-tuple (<i>r</i>, <i>q</i>(<i>r</i>), <i>b</i>(<i>r</i>)),
+ * where <i>r</i> is the input of the polynomials, <i>q</i>(<i>r</i>) is the result of the polynomial
+ * hiding the secret, and <i>b</i>(<i>r</i>) is the result of the blinding polynomial.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type PedersenSecretShareValue struct{
+	/**
+	 * <i>r</i>, the input of the polynomials.
+	 */
+	r interface{}
+
+	/**
+	 * <i>q</i>(<i>r</i>), the result of the polynomial hiding the secret.
+	 */
+	qr interface{}
+
+	/**
+	 * <i>b</i>(<i>r</i>), the result of the blinding polynomial.
+	 */
+	br interface{}
+}
+
+/**
+ * Construct a share value by <i>r</i>, <i>q</i>(<i>r</i>) and <i>b</i>(<i>r</i>).
+ *
+ * @param r <i>r</i>, the input of the polynomials.
+ * @param qr <i>q</i>(<i>r</i>), the result of the polynomial hiding the secret.
+ * @param br <i>b</i>(<i>r</i>), the result of the blinding polynomial.
+ * @return newPedersenSecretShareValue the new constructed PedersenSecretShareValue
+ */
+func NewPedersenSecretShareValue(r interface{}, qr interface{}, br interface{}) (*PedersenSecretShareValue){
+	newPedersenSecretShareValue := new(PedersenSecretShareValue)
+	newPedersenSecretShareValue.r = r
+	newPedersenSecretShareValue.qr = qr
+	newPedersenSecretShareValue.br = br
+	return newPedersenSecretShareValue
+}
+
+/**
+ * Get <i>r</i>, the input of the polynomials.
+ *
+ * @return <i>r</i>, the input of the polynomials.
+ */
+func (pssv *PedersenSecretShareValue) GetR() interface{}{
+	return pssv.r
+}
+
+/**
+ * Get <i>q</i>(<i>r</i>), the result of the polynomial hiding the secret.
+ *
+ * @return <i>q</i>(<i>r</i>), the result of the polynomial hiding the secret.
+ */
+func (pssv *PedersenSecretShareValue) GetQr() interface{}{
+	return pssv.qr
+}
+
+/**
+ * Get <i>b</i>(<i>r</i>), the result of the blinding polynomial.
+ *
+ * @return <i>b</i>(<i>r</i>), the result of the blinding polynomial.
+ */
+func (pssv *PedersenSecretShareValue) GetBr() interface{}{
+	return pssv.br
+}
+
+/**
+ * Get the Shamir's share value (<i>r</i>, <i>q</i>(<i>r</i>)), without the blinding part.
+ *
+ * @return The Shamir's share value.
+ */
+func (pssv *PedersenSecretShareValue) GetShamirSecretShareValue() *ShamirSecretShareValue{
+	return NewShamirSecretShareValue(pssv.r, pssv.qr)
+}
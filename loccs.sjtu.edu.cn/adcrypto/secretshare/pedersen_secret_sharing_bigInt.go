@@ -0,0 +1,204 @@
+package secretshare
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+/**
+ * The class implements Pedersen's verifiable secret sharing scheme on BigInt field.
+ * <p>
+ * The scheme follows "Pedersen TP. Non-interactive and information-theoretic secure verifiable secret sharing.
+ * In Annual International Cryptology Conference 1991 Aug 11 (pp. 129-140). Springer.", where the dealer shares
+ * the secret with a polynomial <i>q</i> over <i>Zq</i> as in Shamir's scheme, together with a random blinding
+ * polynomial <i>b</i> of the same degree, and publishes the commitments
+ * <i>C<sub>j</sub></i> = <i>g</i><sup><i>a<sub>j</sub></i></sup><i>h</i><sup><i>b<sub>j</sub></i></sup> mod <i>p</i>.
+ * <p>
+ * Shares are <code>PedersenSecretShareValue</code> (<i>r</i>, <i>q</i>(<i>r</i>), <i>b</i>(<i>r</i>)), and a participant accepts
+ * its share only if <i>g</i><sup><i>q</i>(<i>r</i>)</sup><i>h</i><sup><i>b</i>(<i>r</i>)</sup> =
+ * <i>C</i><sub>0</sub> <i>C</i><sub>1</sub><sup><i>r</i></sup> ... <i>C</i><sub><i>k</i>-1</sub><sup><i>r</i><sup><i>k</i>-1</sup></sup> mod <i>p</i>.
+ * Unlike Feldman's scheme, the commitments reveal nothing about the secret even to an unbounded adversary.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type PedersenSecretSharingBigInt struct {
+	ShamirSecretSharingBigInt
+
+	/**
+	 * The group in which the commitments are calculated, its subgroup order is the modulus of the scheme.
+	 */
+	group *PedersenGroup
+}
+
+/**
+ * Construct Pedersen's secret sharing scheme with the number of participants and the group of commitments.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @param group The group of commitments, whose subgroup order <i>q</i> is used as the modulus of the scheme.
+ * @return feedback the newly constructed PedersenSecretSharingBigInt
+ * @return error If the number of participants or the group is invalid.
+ */
+func NewPedersenSecretSharingBigInt(participantCount int, group *PedersenGroup) (*PedersenSecretSharingBigInt, error){
+	if (group == nil){
+		return nil, errors.New("Group of commitments should not be nil.")
+	}
+	shamir, err := NewShamirSecretSharingBigInt(participantCount, group.GetQ())
+	if (err != nil) {return nil, err}
+	feedback := new(PedersenSecretSharingBigInt)
+	feedback.ShamirSecretSharingBigInt = *shamir
+	feedback.group = group
+	feedback.ShamirSecretSharingITF = feedback
+	feedback.SecretSharingSchemeITF = feedback
+	return feedback, nil
+}
+
+/**
+ * Get the group of commitments.
+ *
+ * @return The group of commitments.
+ */
+func (pssb *PedersenSecretSharingBigInt) GetGroup() *PedersenGroup{
+	return pssb.group
+}
+
+/**
+ * Generate shares from input secret, together with the commitments to the two polynomials.
+ *
+ * @param secret The secret from which shares are generated.
+ * @param auxiliary Auxiliary data for generating shares. Can be nil(use default auxiliary).
+ * @return N shares(with PedersenSecretShareValue) that generated from the input secret, one for each participant.
+ * @return The commitments <i>C</i><sub>0</sub>, <i>C</i><sub>1</sub>, ..., <i>C</i><sub><i>k</i>-1</sub>.
+ * @return error If the scheme is not initialized or the input secret is invalid.
+ */
+func (pssb *PedersenSecretSharingBigInt) GenerateSharesWithCommitments(secret interface{}, auxiliary []interface{}) ([]*SecretShare, []*big.Int, error){
+	if (!pssb.IsInitialized()) {
+		return nil, nil, errors.New("Not ready for generate shares.")
+	}
+	auxiliary, err := pssb.checkSecretAndAuxiliary(secret, auxiliary)
+	if (err != nil) {return nil, nil, err}
+
+	secretValue := big.NewInt(0)
+	secretValue.Mod(secret.(*big.Int), pssb.group.GetQ())
+	blinding, err := rand.Int(rand.Reader, pssb.group.GetQ())
+	if (err != nil) {return nil, nil, err}
+	secretPoly := pssb.GetRandomPolynomial(secretValue)
+	blindingPoly := pssb.GetRandomPolynomial(blinding)
+
+	shares := make([]*SecretShare, pssb.participantCount)
+	for i := 0; i < pssb.participantCount; i++{
+		qr, err := secretPoly.Calculate(auxiliary[i])
+		if (err != nil) {return nil, nil, err}
+		br, err := blindingPoly.Calculate(auxiliary[i])
+		if (err != nil) {return nil, nil, err}
+		shares[i] = NewSecretShare(i, NewPedersenSecretShareValue(auxiliary[i], qr, br))
+	}
+	commitments, err := pssb.group.Commit(secretPoly.GetCoefficients(), blindingPoly.GetCoefficients())
+	if (err != nil) {return nil, nil, err}
+	return shares, commitments, nil
+}
+
+/**
+ * Generate shares from input secret, the commitments are dropped.
+ *
+ * @param secret The secret from which shares are generated.
+ * @param auxiliary Auxiliary data for generating shares. Can be nil(use default auxiliary).
+ * @return N shares(with PedersenSecretShareValue) that generated from the input secret, one for each participant.
+ * @return error If the input secret is invalid.
+ */
+func (pssb *PedersenSecretSharingBigInt) generateSharesImpl(secret interface{}, auxiliary []interface{}) ([]*SecretShare, error){
+	shares, _, err := pssb.GenerateSharesWithCommitments(secret, auxiliary)
+	return shares, err
+}
+
+/**
+ * Calculating secret from input shares(with PedersenSecretShareValue).
+ *
+ * @param shares The shares from which secret is calculated.
+ * @return The secret calculated from the input shares.
+ * @error If any of the input shares is invalid.
+ */
+func (pssb *PedersenSecretSharingBigInt) calculateSecretImpl(shares []*SecretShare) (interface{}, error){
+	shamirShares, err := pssb.toShamirShares(shares)
+	if (err != nil) {return nil, err}
+	return pssb.ShamirSecretSharing.calculateSecretImpl(shamirShares)
+}
+
+/**
+ * Calculating secret from input shares(with PedersenSecretShareValue), some of which may be corrupted.
+ *
+ * @param shares The shares from which secret is calculated, usually from all participants.
+ * @return The secret calculated from the input shares.
+ * @return IDs of the participants whose shares are inconsistent with the secret.
+ * @return error If the scheme is not initialized, the input shares are invalid, or too many shares are corrupted.
+ */
+func (pssb *PedersenSecretSharingBigInt) CalculateSecretRobust(shares []*SecretShare) (interface{}, []int, error){
+	shamirShares, err := pssb.toShamirShares(shares)
+	if (err != nil) {return nil, nil, err}
+	return pssb.ShamirSecretSharing.CalculateSecretRobust(shamirShares)
+}
+
+/**
+ * Verify a share against the commitments published by the dealer.
+ *
+ * @param share The share(with PedersenSecretShareValue) to be verified.
+ * @param commitments The commitments to the polynomials.
+ * @return True if the share lies on the committed polynomials, otherwise return false.
+ * @return error If the share or the commitments are invalid.
+ */
+func (pssb *PedersenSecretSharingBigInt) VerifyShare(share *SecretShare, commitments []*big.Int) (bool, error){
+	if (share == nil){
+		return false, errors.New("Share should not be nil.")
+	}
+	value, ok := share.GetValue().(*PedersenSecretShareValue)
+	if (!ok) {return false, errors.New("Invalid type of share value, should be PedersenSecretShareValue.")}
+	r, okR := value.GetR().(*big.Int)
+	qr, okQr := value.GetQr().(*big.Int)
+	br, okBr := value.GetBr().(*big.Int)
+	if (!okR || !okQr || !okBr) {return false, errors.New("Invalid type of elements in PedersenSecretShareValue.")}
+	if (commitments == nil || len(commitments) == 0){
+		return false, errors.New("At least one commitment should be provided.")
+	}
+	if (pssb.access != nil && len(commitments) != pssb.access.GetThreshold()){
+		return false, errors.New("Number of commitments should be equal to threshold.")
+	}
+	p := pssb.group.GetP()
+	q := pssb.group.GetQ()
+	for i := 0; i < len(commitments); i++{
+		if (commitments[i] == nil || commitments[i].Sign() <= 0 || commitments[i].Cmp(p) >= 0){
+			return false, errors.New("Invalid commitment.")
+		}
+	}
+
+	left, err := pssb.group.Commit([]interface{}{big.NewInt(0).Mod(qr, q)}, []interface{}{big.NewInt(0).Mod(br, q)})
+	if (err != nil) {return false, err}
+	right := big.NewInt(1)
+	power := big.NewInt(1)
+	x := big.NewInt(0)
+	x.Mod(r, q)
+	for i := 0; i < len(commitments); i++{
+		tmp := big.NewInt(0)
+		tmp.Exp(commitments[i], power, p)
+		right.Mul(right, tmp).Mod(right, p)
+		power.Mul(power, x).Mod(power, q)
+	}
+	return left[0].Cmp(right) == 0, nil
+}
+
+/**
+ * Drop the blinding part of the shares.
+ *
+ * @param shares The shares with PedersenSecretShareValue.
+ * @return The shares with ShamirSecretShareValue.
+ * @return error If any share value is not a PedersenSecretShareValue.
+ */
+func (pssb *PedersenSecretSharingBigInt) toShamirShares(shares []*SecretShare) ([]*SecretShare, error){
+	feedback := make([]*SecretShare, len(shares))
+	for i := 0; i < len(shares); i++{
+		value, ok := shares[i].GetValue().(*PedersenSecretShareValue)
+		if (!ok) {return nil, errors.New("Invalid type of share value, should be PedersenSecretShareValue.")}
+		feedback[i] = NewSecretShare(shares[i].GetParticipant(), value.GetShamirSecretShareValue())
+	}
+	return feedback, nil
+}
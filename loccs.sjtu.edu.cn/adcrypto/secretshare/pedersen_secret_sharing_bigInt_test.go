@@ -0,0 +1,82 @@
+package secretshare
+
+import (
+	"testing"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+func TestPedersenSecretSharingBigIntProcedure(t *testing.T) {
+	participantCount := 10
+	threshold := 4
+	q,_ := rand.Prime(rand.Reader,160)
+	group, err := GeneratePedersenGroup(q, 512)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating PedersenGroup: %s", err))}
+	pedersen, err := NewPedersenSecretSharingBigInt(participantCount, group)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing PedersenSecretSharingBigInt: %s", err))}
+	threAccessStruct, err := NewThresholdAccessStructure(participantCount,threshold)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+	// used through the common interface
+	var scheme ShamirSecretSharingInterface = pedersen
+	err = scheme.SetAccessStructure(threAccessStruct)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+
+	secret,_ := rand.Int(rand.Reader,q)
+	shares, commitments, err := pedersen.GenerateSharesWithCommitments(secret, scheme.GenerateRandomAuxiliary())
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+	for i := 0; i < participantCount; i++{
+		valid, err := pedersen.VerifyShare(shares[i], commitments)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when verifying share: %s", err))}
+		if (!valid) {t.Error(fmt.Sprintf("Valid share of participant %d is rejected.", i))}
+	}
+
+	secretNew, err := scheme.CalculateSecret(shares[3:3+threshold])
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+	if (secretNew.(*big.Int).Cmp(secret) != 0){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s",secretNew,secret))
+	}
+
+	// plain GenerateShares also produces Pedersen shares
+	plainShares, err := scheme.GenerateShares(secret, nil)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+	if _, ok := plainShares[0].GetValue().(*PedersenSecretShareValue); !ok {
+		t.Error("Share value should be PedersenSecretShareValue.")
+	}
+	secretNew, _, err = scheme.CalculateSecretRobust(plainShares)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+	if (secretNew.(*big.Int).Cmp(secret) != 0){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%s ,Expected: %s",secretNew,secret))
+	}
+
+	// tampering with either the secret part or the blinding part is detected
+	value := shares[5].GetValue().(*PedersenSecretShareValue)
+	wrong := big.NewInt(1)
+	wrong.Add(wrong, value.GetBr().(*big.Int)).Mod(wrong, q)
+	valid, err := pedersen.VerifyShare(NewSecretShare(5, NewPedersenSecretShareValue(value.GetR(), value.GetQr(), wrong)), commitments)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when verifying share: %s", err))}
+	if (valid) {t.Error("Share with wrong blinding is accepted.")}
+	wrong.Add(big.NewInt(1), value.GetQr().(*big.Int)).Mod(wrong, q)
+	valid, err = pedersen.VerifyShare(NewSecretShare(5, NewPedersenSecretShareValue(value.GetR(), wrong, value.GetBr())), commitments)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when verifying share: %s", err))}
+	if (valid) {t.Error("Share with wrong secret part is accepted.")}
+
+	// shares survive the wire format
+	for _, share := range shares[:2]{
+		data, err := share.MarshalBinary()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share: %s", err))}
+		decoded := new(SecretShare)
+		err = decoded.UnmarshalBinary(data)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share: %s", err))}
+		valid, err = pedersen.VerifyShare(decoded, commitments)
+		if (err != nil || !valid) {t.Error("Decoded share is not valid.")}
+		data, err = json.Marshal(share)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share as JSON: %s", err))}
+		decoded = new(SecretShare)
+		err = json.Unmarshal(data, decoded)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share from JSON: %s", err))}
+		valid, err = pedersen.VerifyShare(decoded, commitments)
+		if (err != nil || !valid) {t.Error("Decoded share is not valid.")}
+	}
+}
@@ -0,0 +1,392 @@
+package secretshare
+
+import (
+	"encoding/json"
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/wire"
+)
+
+/**
+ * The JSON form of a secret share, for debugging.
+ */
+type secretShareJSON struct {
+	Participant int `json:"participant"`
+	Shamir *shamirSecretShareValueJSON `json:"shamir,omitempty"`
+	Pedersen *pedersenSecretShareValueJSON `json:"pedersen,omitempty"`
+	Element *wire.JSONElement `json:"element,omitempty"`
+}
+
+/**
+ * The JSON form of a Shamir's share value, for debugging.
+ */
+type shamirSecretShareValueJSON struct {
+	R *wire.JSONElement `json:"r"`
+	Qr *wire.JSONElement `json:"qr"`
+	Epoch int `json:"epoch,omitempty"`
+}
+
+/**
+ * The JSON form of a Pedersen's share value, for debugging.
+ */
+type pedersenSecretShareValueJSON struct {
+	R *wire.JSONElement `json:"r"`
+	Qr *wire.JSONElement `json:"qr"`
+	Br *wire.JSONElement `json:"br"`
+}
+
+/**
+ * Encode the secret share in the binary wire format.
+ * <p>
+ * The body contains the participant ID, followed by the frame of the shared value, which is
+ * a <code>ShamirSecretShareValue</code>, a <code>PedersenSecretShareValue</code> or a single element.
+ *
+ * @return The encoded frame.
+ * @return error If the participant ID or the shared value cannot be encoded.
+ */
+func (ss *SecretShare) MarshalBinary() ([]byte, error){
+	body := wire.NewWriter()
+	err := body.WriteIndex(ss.participant)
+	if (err != nil) {return nil, err}
+	value, err := marshalShareValue(ss.value)
+	if (err != nil) {return nil, err}
+	body.WriteBytes(value)
+	writer := wire.NewWriter()
+	writer.WriteFrame(wire.KindSecretShare, body.Bytes())
+	return writer.Bytes(), nil
+}
+
+/**
+ * Decode the secret share from the binary wire format.
+ *
+ * @param data The encoded frame.
+ * @return error If the data is invalid.
+ */
+func (ss *SecretShare) UnmarshalBinary(data []byte) error{
+	reader := wire.NewReader(data)
+	body, err := reader.ReadFrame(wire.KindSecretShare)
+	if (err != nil) {return err}
+	if (reader.Remaining() != 0) {return errors.New("Unexpected data after frame.")}
+	bodyReader := wire.NewReader(body)
+	participant, err := bodyReader.ReadIndex()
+	if (err != nil) {return err}
+	valueData, err := bodyReader.ReadBytes()
+	if (err != nil) {return err}
+	if (bodyReader.Remaining() != 0) {return errors.New("Unexpected data after secret share.")}
+	value, err := unmarshalShareValue(valueData)
+	if (err != nil) {return err}
+	ss.participant = participant
+	ss.value = value
+	return nil
+}
+
+/**
+ * Encode the secret share in the JSON form.
+ *
+ * @return The JSON form.
+ * @return error If the shared value cannot be encoded.
+ */
+func (ss *SecretShare) MarshalJSON() ([]byte, error){
+	feedback := secretShareJSON{Participant: ss.participant}
+	switch value := ss.value.(type) {
+	case *ShamirSecretShareValue:
+		shamir, err := value.toJSON()
+		if (err != nil) {return nil, err}
+		feedback.Shamir = shamir
+	case *PedersenSecretShareValue:
+		pedersen, err := value.toJSON()
+		if (err != nil) {return nil, err}
+		feedback.Pedersen = pedersen
+	default:
+		element, err := wire.ToJSONElement(value)
+		if (err != nil) {return nil, err}
+		feedback.Element = element
+	}
+	return json.Marshal(&feedback)
+}
+
+/**
+ * Decode the secret share from the JSON form.
+ *
+ * @param data The JSON form.
+ * @return error If the data is invalid.
+ */
+func (ss *SecretShare) UnmarshalJSON(data []byte) error{
+	var j secretShareJSON
+	err := json.Unmarshal(data, &j)
+	if (err != nil) {return err}
+	if (j.Participant < 0) {return errors.New("Invalid participant ID.")}
+	var value interface{}
+	if (j.Shamir != nil){
+		shamir := new(ShamirSecretShareValue)
+		err = shamir.fromJSON(j.Shamir)
+		value = shamir
+	} else if (j.Pedersen != nil){
+		pedersen := new(PedersenSecretShareValue)
+		err = pedersen.fromJSON(j.Pedersen)
+		value = pedersen
+	} else {
+		value, err = wire.FromJSONElement(j.Element)
+	}
+	if (err != nil) {return err}
+	ss.participant = j.Participant
+	ss.value = value
+	return nil
+}
+
+/**
+ * Encode the share value in the binary wire format.
+ * <p>
+ * A share of epoch 0 is a frame of kind <code>KindShamirSecretShareValue</code> containing <i>r</i> and <i>q</i>(<i>r</i>),
+ * the layout of version 1, and a refreshed share is a frame of kind <code>KindShamirSecretShareValueEpoch</code>
+ * containing <i>r</i>, <i>q</i>(<i>r</i>) and the epoch (4 bytes).
+ *
+ * @return The encoded frame.
+ * @return error If any element cannot be encoded.
+ */
+func (sssv *ShamirSecretShareValue) MarshalBinary() ([]byte, error){
+	body := wire.NewWriter()
+	err := body.WriteElement(sssv.r)
+	if (err != nil) {return nil, err}
+	err = body.WriteElement(sssv.qr)
+	if (err != nil) {return nil, err}
+	kind := wire.KindShamirSecretShareValue
+	if (sssv.epoch != 0){
+		err = body.WriteIndex(sssv.epoch)
+		if (err != nil) {return nil, err}
+		kind = wire.KindShamirSecretShareValueEpoch
+	}
+	writer := wire.NewWriter()
+	writer.WriteFrame(kind, body.Bytes())
+	return writer.Bytes(), nil
+}
+
+/**
+ * Decode the share value from the binary wire format, with or without the epoch.
+ *
+ * @param data The encoded frame.
+ * @return error If the data is invalid.
+ */
+func (sssv *ShamirSecretShareValue) UnmarshalBinary(data []byte) error{
+	reader := wire.NewReader(data)
+	kind, err := reader.PeekKind()
+	if (err != nil) {return err}
+	if (kind != wire.KindShamirSecretShareValue && kind != wire.KindShamirSecretShareValueEpoch){
+		return errors.New("Unexpected kind of frame, should be a Shamir's share value.")
+	}
+	body, err := reader.ReadFrame(kind)
+	if (err != nil) {return err}
+	if (reader.Remaining() != 0) {return errors.New("Unexpected data after frame.")}
+	bodyReader := wire.NewReader(body)
+	r, err := bodyReader.ReadElement()
+	if (err != nil) {return errors.New("Invalid r of the share value: " + err.Error())}
+	qr, err := bodyReader.ReadElement()
+	if (err != nil) {return errors.New("Invalid q(r) of the share value: " + err.Error())}
+	epoch := 0
+	if (kind == wire.KindShamirSecretShareValueEpoch){
+		epoch, err = bodyReader.ReadIndex()
+		if (err != nil) {return errors.New("Invalid epoch of the share value: " + err.Error())}
+	}
+	if (bodyReader.Remaining() != 0) {return errors.New("Unexpected data after share value.")}
+	sssv.r = r
+	sssv.qr = qr
+	sssv.epoch = epoch
+	return nil
+}
+
+/**
+ * Encode the share value in the JSON form.
+ *
+ * @return The JSON form.
+ * @return error If any element cannot be encoded.
+ */
+func (sssv *ShamirSecretShareValue) MarshalJSON() ([]byte, error){
+	feedback, err := sssv.toJSON()
+	if (err != nil) {return nil, err}
+	return json.Marshal(feedback)
+}
+
+/**
+ * Decode the share value from the JSON form.
+ *
+ * @param data The JSON form.
+ * @return error If the data is invalid.
+ */
+func (sssv *ShamirSecretShareValue) UnmarshalJSON(data []byte) error{
+	var j shamirSecretShareValueJSON
+	err := json.Unmarshal(data, &j)
+	if (err != nil) {return err}
+	return sssv.fromJSON(&j)
+}
+
+/**
+ * Convert the share value to its JSON form.
+ *
+ * @return The JSON form.
+ * @return error If any element cannot be encoded.
+ */
+func (sssv *ShamirSecretShareValue) toJSON() (*shamirSecretShareValueJSON, error){
+	r, err := wire.ToJSONElement(sssv.r)
+	if (err != nil) {return nil, err}
+	qr, err := wire.ToJSONElement(sssv.qr)
+	if (err != nil) {return nil, err}
+	return &shamirSecretShareValueJSON{R: r, Qr: qr, Epoch: sssv.epoch}, nil
+}
+
+/**
+ * Restore the share value from its JSON form.
+ *
+ * @param j The JSON form.
+ * @return error If the JSON form is invalid.
+ */
+func (sssv *ShamirSecretShareValue) fromJSON(j *shamirSecretShareValueJSON) error{
+	r, err := wire.FromJSONElement(j.R)
+	if (err != nil) {return err}
+	qr, err := wire.FromJSONElement(j.Qr)
+	if (err != nil) {return err}
+	if (j.Epoch < 0) {return errors.New("Invalid epoch.")}
+	sssv.r = r
+	sssv.qr = qr
+	sssv.epoch = j.Epoch
+	return nil
+}
+
+/**
+ * Encode the share value in the binary wire format, i.e. a frame containing <i>r</i>, <i>q</i>(<i>r</i>) and <i>b</i>(<i>r</i>).
+ *
+ * @return The encoded frame.
+ * @return error If any element cannot be encoded.
+ */
+func (pssv *PedersenSecretShareValue) MarshalBinary() ([]byte, error){
+	body := wire.NewWriter()
+	elements := []interface{}{pssv.r, pssv.qr, pssv.br}
+	for i := 0; i < len(elements); i++{
+		err := body.WriteElement(elements[i])
+		if (err != nil) {return nil, err}
+	}
+	writer := wire.NewWriter()
+	writer.WriteFrame(wire.KindPedersenSecretShareValue, body.Bytes())
+	return writer.Bytes(), nil
+}
+
+/**
+ * Decode the share value from the binary wire format.
+ *
+ * @param data The encoded frame.
+ * @return error If the data is invalid.
+ */
+func (pssv *PedersenSecretShareValue) UnmarshalBinary(data []byte) error{
+	reader := wire.NewReader(data)
+	body, err := reader.ReadFrame(wire.KindPedersenSecretShareValue)
+	if (err != nil) {return err}
+	if (reader.Remaining() != 0) {return errors.New("Unexpected data after frame.")}
+	bodyReader := wire.NewReader(body)
+	elements := make([]interface{}, 3)
+	for i := 0; i < len(elements); i++{
+		elements[i], err = bodyReader.ReadElement()
+		if (err != nil) {return err}
+	}
+	if (bodyReader.Remaining() != 0) {return errors.New("Unexpected data after share value.")}
+	pssv.r = elements[0]
+	pssv.qr = elements[1]
+	pssv.br = elements[2]
+	return nil
+}
+
+/**
+ * Encode the share value in the JSON form.
+ *
+ * @return The JSON form.
+ * @return error If any element cannot be encoded.
+ */
+func (pssv *PedersenSecretShareValue) MarshalJSON() ([]byte, error){
+	feedback, err := pssv.toJSON()
+	if (err != nil) {return nil, err}
+	return json.Marshal(feedback)
+}
+
+/**
+ * Decode the share value from the JSON form.
+ *
+ * @param data The JSON form.
+ * @return error If the data is invalid.
+ */
+func (pssv *PedersenSecretShareValue) UnmarshalJSON(data []byte) error{
+	var j pedersenSecretShareValueJSON
+	err := json.Unmarshal(data, &j)
+	if (err != nil) {return err}
+	return pssv.fromJSON(&j)
+}
+
+/**
+ * Convert the share value to its JSON form.
+ *
+ * @return The JSON form.
+ * @return error If any element cannot be encoded.
+ */
+func (pssv *PedersenSecretShareValue) toJSON() (*pedersenSecretShareValueJSON, error){
+	elements, err := wire.ToJSONElements([]interface{}{pssv.r, pssv.qr, pssv.br})
+	if (err != nil) {return nil, err}
+	return &pedersenSecretShareValueJSON{R: elements[0], Qr: elements[1], Br: elements[2]}, nil
+}
+
+/**
+ * Restore the share value from its JSON form.
+ *
+ * @param j The JSON form.
+ * @return error If the JSON form is invalid.
+ */
+func (pssv *PedersenSecretShareValue) fromJSON(j *pedersenSecretShareValueJSON) error{
+	elements, err := wire.FromJSONElements([]*wire.JSONElement{j.R, j.Qr, j.Br})
+	if (err != nil) {return err}
+	pssv.r = elements[0]
+	pssv.qr = elements[1]
+	pssv.br = elements[2]
+	return nil
+}
+
+/**
+ * Encode a shared value, i.e. a <code>ShamirSecretShareValue</code>, a <code>PedersenSecretShareValue</code>
+ * or a single element, as a frame.
+ *
+ * @param value The shared value.
+ * @return The encoded frame.
+ * @return error If the shared value cannot be encoded.
+ */
+func marshalShareValue(value interface{}) ([]byte, error){
+	switch v := value.(type) {
+	case *ShamirSecretShareValue:
+		return v.MarshalBinary()
+	case *PedersenSecretShareValue:
+		return v.MarshalBinary()
+	default:
+		return wire.MarshalElement(v)
+	}
+}
+
+/**
+ * Decode a shared value from a frame, according to the kind of the frame.
+ *
+ * @param data The encoded frame.
+ * @return The shared value.
+ * @return error If the data is invalid.
+ */
+func unmarshalShareValue(data []byte) (interface{}, error){
+	kind, err := wire.NewReader(data).PeekKind()
+	if (err != nil) {return nil, err}
+	switch kind {
+	case wire.KindShamirSecretShareValue, wire.KindShamirSecretShareValueEpoch:
+		feedback := new(ShamirSecretShareValue)
+		err = feedback.UnmarshalBinary(data)
+		if (err != nil) {return nil, err}
+		return feedback, nil
+	case wire.KindPedersenSecretShareValue:
+		feedback := new(PedersenSecretShareValue)
+		err = feedback.UnmarshalBinary(data)
+		if (err != nil) {return nil, err}
+		return feedback, nil
+	case wire.KindElement:
+		return wire.UnmarshalElement(data)
+	default:
+		return nil, errors.New("Unexpected kind of share value.")
+	}
+}
@@ -0,0 +1,120 @@
+package secretshare
+
+import (
+	"testing"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+func TestSecretShareEncoding(t *testing.T) {
+	participantCount := 8
+	threshold := 5
+	modulusBigInt,_ := rand.Prime(rand.Reader,30)
+	shamirInt, err := NewShamirSecretSharingInt(participantCount,int(modulusBigInt.Int64()))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingInt: %s", err))}
+	t.Run("testSecretShareEncodingInt",
+		testSecretShareEncoding(shamirInt, participantCount, threshold, 218932))
+
+	modulusBigInt,_ = rand.Prime(rand.Reader,256)
+	shamirBigInt, err := NewShamirSecretSharingBigInt(participantCount,modulusBigInt)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingBigInt: %s", err))}
+	secret,_ := new(big.Int).SetString("218932111218932111218932111", 10)
+	t.Run("testSecretShareEncodingBigInt",
+		testSecretShareEncoding(shamirBigInt, participantCount, threshold, secret))
+}
+
+func testSecretShareEncoding(scheme ShamirSecretSharingInterface, participantCount int, threshold int, secret interface{}) func(t *testing.T) {
+	return func(t *testing.T) {
+		threAccessStruct, err := NewThresholdAccessStructure(participantCount,threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+		err = scheme.SetAccessStructure(threAccessStruct)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+		shares, err := scheme.GenerateShares(secret, scheme.GenerateRandomAuxiliary())
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+
+		binaryShares := make([]*SecretShare, len(shares))
+		jsonShares := make([]*SecretShare, len(shares))
+		for i := 0; i < len(shares); i++{
+			data, err := shares[i].MarshalBinary()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share: %s", err))}
+			binaryShares[i] = new(SecretShare)
+			err = binaryShares[i].UnmarshalBinary(data)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share: %s", err))}
+			checkSecretShareEqual(t, shares[i], binaryShares[i])
+
+			data, err = json.Marshal(shares[i])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share as JSON: %s", err))}
+			jsonShares[i] = new(SecretShare)
+			err = json.Unmarshal(data, jsonShares[i])
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share from JSON: %s", err))}
+			checkSecretShareEqual(t, shares[i], jsonShares[i])
+		}
+
+		secretBinary, err := scheme.CalculateSecret(binaryShares)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		secretJSON, err := scheme.CalculateSecret(jsonShares)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (fmt.Sprint(secretBinary) != fmt.Sprint(secret) || fmt.Sprint(secretJSON) != fmt.Sprint(secret)){
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%v %v ,Expected: %v",secretBinary,secretJSON,secret))
+		}
+
+		// truncated or corrupted data should be rejected
+		data, _ := shares[0].MarshalBinary()
+		err = new(SecretShare).UnmarshalBinary(data[:len(data)-1])
+		if err == nil {t.Error("Truncated share should not be decoded.")}
+		data[0] = 0xFF
+		err = new(SecretShare).UnmarshalBinary(data)
+		if err == nil {t.Error("Share with unknown version should not be decoded.")}
+	}
+}
+
+func TestSecretShareEncodingEpoch(t *testing.T) {
+	// frames written by version 1, before shares carried an epoch
+	data, _ := hex.DecodeString("0103000000100200000002000302000000040012d687")
+	value := new(ShamirSecretShareValue)
+	err := value.UnmarshalBinary(data)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding a version 1 share value: %s", err))}
+	if (fmt.Sprint(value.GetR(), value.GetQr(), value.GetEpoch()) != "3 1234567 0"){
+		t.Error(fmt.Sprintf("Version 1 share value is decoded wrongly: %v %v %d", value.GetR(), value.GetQr(), value.GetEpoch()))
+	}
+	data, _ = hex.DecodeString("010200000028000000020000002001030000001a010000000800000000000000050100000008000000000000002a")
+	share := new(SecretShare)
+	err = share.UnmarshalBinary(data)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding a version 1 share: %s", err))}
+	checkSecretShareEqual(t, NewSecretShare(2, NewShamirSecretShareValue(5, 42)), share)
+
+	// shares of epoch 0 keep the version 1 layout
+	encoded, _ := share.MarshalBinary()
+	if (hex.EncodeToString(encoded) != hex.EncodeToString(data)) {t.Error("Share of epoch 0 should be encoded as in version 1.")}
+
+	// refreshed shares carry the epoch in their own kind of frame
+	refreshed := NewSecretShare(2, NewShamirSecretShareValueWithEpoch(big.NewInt(5), big.NewInt(42), 3))
+	encoded, err = refreshed.MarshalBinary()
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share: %s", err))}
+	share = new(SecretShare)
+	err = share.UnmarshalBinary(encoded)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share: %s", err))}
+	checkSecretShareEqual(t, refreshed, share)
+}
+
+func checkSecretShareEqual(t *testing.T, expected *SecretShare, actual *SecretShare) {
+	if (expected.GetParticipant() != actual.GetParticipant()){
+		t.Error(fmt.Sprintf("Participant mismatch, Result:%d ,Expected: %d",actual.GetParticipant(),expected.GetParticipant()))
+	}
+	expectedValue := expected.GetValue().(*ShamirSecretShareValue)
+	actualValue, ok := actual.GetValue().(*ShamirSecretShareValue)
+	if (!ok){
+		t.Error("Share value should be ShamirSecretShareValue.")
+		return
+	}
+	if (fmt.Sprintf("%T %v %T %v", expectedValue.GetR(), expectedValue.GetR(), expectedValue.GetQr(), expectedValue.GetQr()) !=
+		fmt.Sprintf("%T %v %T %v", actualValue.GetR(), actualValue.GetR(), actualValue.GetQr(), actualValue.GetQr())){
+		t.Error("Share value mismatch.")
+	}
+	if (expectedValue.GetEpoch() != actualValue.GetEpoch()){
+		t.Error(fmt.Sprintf("Epoch mismatch, Result:%d ,Expected: %d",actualValue.GetEpoch(),expectedValue.GetEpoch()))
+	}
+}
@@ -0,0 +1,117 @@
+package secretshare
+
+import (
+	"errors"
+)
+
+/**
+ * Generate the sub-shares for redistributing a share to a new committee.
+ * <p>
+ * The redistribution follows "Desmedt Y, Jajodia S. Redistributing secret shares to new access structures and its
+ * applications. Technical Report ISSE-TR-97-01, George Mason University, 1997." Every old holder in a qualified set
+ * shares its <i>q</i>(<i>r</i>) with the scheme of the new committee, and sends the sub-shares to the new holders.
+ * The secret is never reconstructed.
+ *
+ * @param share The share of the old holder, generated by this scheme.
+ * @param newScheme The scheme of the new committee, whose access structure is set. The modulus should be the same.
+ * @param newAuxiliary Auxiliary data of the new committee. Can be nil(use default auxiliary).
+ * @return <i>n</i>' sub-shares, sub-shares[j] should be sent to new holder j.
+ * @return error If the share or the new scheme is invalid.
+ */
+func (sss *ShamirSecretSharing) GenerateRedistributionShares(share *SecretShare, newScheme ShamirSecretSharingInterface, newAuxiliary []interface{}) ([]*SecretShare, error){
+	err := sss.checkRedistributionScheme(newScheme)
+	if (err != nil) {return nil, err}
+	if (share == nil){
+		return nil, errors.New("Share should not be nil.")
+	}
+	value, ok := share.GetValue().(*ShamirSecretShareValue)
+	if (!ok) {return nil, errors.New("Invalid type of share value, should be ShamirSecretShareValue.")}
+	if (!sss.ShamirSecretSharingITF.checkElement(value.GetR()) || !sss.ShamirSecretSharingITF.checkElement(value.GetQr())){
+		return nil, errors.New("Invalid type of elements in ShamirSecretShareValue.")
+	}
+	qr := sss.ShamirSecretSharingITF.addElements(value.GetQr(), sss.ShamirSecretSharingITF.getElementZero())
+	return newScheme.GenerateShares(qr, newAuxiliary)
+}
+
+/**
+ * Combine the sub-shares received by a new holder into its share of the new committee.
+ * <p>
+ * The new share is <i>q</i>'(<i>r</i>') = <i>&lambda;</i><sub>1</sub><i>s</i><sub>1</sub> + ... + <i>&lambda;<sub>m</sub></i><i>s<sub>m</sub></i>,
+ * where <i>s<sub>i</sub></i> is the sub-share from the old holder with evaluation point <i>x<sub>i</sub></i>, and
+ * (<i>&lambda;</i><sub>1</sub>, ..., <i>&lambda;<sub>m</sub></i>) is the recombination vector (Lagrange coefficients at 0) of
+ * <i>x</i><sub>1</sub>, ..., <i>x<sub>m</sub></i>. The new shares form a Shamir's sharing of the same secret under the
+ * access structure of the new scheme.
+ *
+ * @param oldPoints The evaluation points of the old holders, at least as many as the threshold of this scheme.
+ * @param subShares The sub-shares received, subShares[i] is from the old holder with evaluation point oldPoints[i].
+ * @param newScheme The scheme of the new committee, same as the one used by <code>GenerateRedistributionShares</code>.
+ * @return The share of the new holder.
+ * @return error If the evaluation points, the sub-shares or the new scheme are invalid.
+ */
+func (sss *ShamirSecretSharing) CombineRedistributionShares(oldPoints []interface{}, subShares []*SecretShare, newScheme ShamirSecretSharingInterface) (*SecretShare, error){
+	err := sss.checkRedistributionScheme(newScheme)
+	if (err != nil) {return nil, err}
+	if (oldPoints == nil || subShares == nil || len(oldPoints) != len(subShares)){
+		return nil, errors.New("Each sub-share should come with the evaluation point of its sender.")
+	}
+	if (len(subShares) < sss.access.GetThreshold()){
+		return nil, errors.New("Number of sub-shares should not be less than threshold.")
+	}
+	for i := 0; i < len(oldPoints); i++{
+		for j := 0; j < i; j++{
+			if (sss.ShamirSecretSharingITF.isElementEqual(oldPoints[i], oldPoints[j])){
+				return nil, errors.New("Sub-shares should come from old holders with distinct evaluation points.")
+			}
+		}
+	}
+	lambda, err := sss.ShamirSecretSharingITF.GetRecombinationVector(oldPoints)
+	if (err != nil) {return nil, err}
+
+	participant := -1
+	var r interface{}
+	qr := sss.ShamirSecretSharingITF.getElementZero()
+	for i := 0; i < len(subShares); i++{
+		if (subShares[i] == nil){
+			return nil, errors.New("Sub-share should not be nil.")
+		}
+		value, ok := subShares[i].GetValue().(*ShamirSecretShareValue)
+		if (!ok) {return nil, errors.New("Invalid type of sub-share value, should be ShamirSecretShareValue.")}
+		if (!sss.ShamirSecretSharingITF.checkElement(value.GetR()) || !sss.ShamirSecretSharingITF.checkElement(value.GetQr())){
+			return nil, errors.New("Invalid type of elements in ShamirSecretShareValue.")
+		}
+		if (i == 0){
+			participant = subShares[i].GetParticipant()
+			r = value.GetR()
+		} else if (subShares[i].GetParticipant() != participant || !sss.ShamirSecretSharingITF.isElementEqual(value.GetR(), r)){
+			return nil, errors.New("Sub-shares should be generated for the same new holder.")
+		}
+		qr = sss.ShamirSecretSharingITF.addElements(qr, sss.ShamirSecretSharingITF.multiplyElements(lambda[i], value.GetQr()))
+	}
+	if (participant < 0 || participant >= newScheme.GetParticipantCount()){
+		return nil, errors.New("Invalid participant of sub-shares.")
+	}
+	return NewSecretShare(participant, NewShamirSecretShareValue(r, qr)), nil
+}
+
+/**
+ * Check the scheme of the new committee, which should be initialized with the same modulus and type of elements.
+ *
+ * @param newScheme The scheme of the new committee.
+ * @return error If the new scheme is invalid.
+ */
+func (sss *ShamirSecretSharing) checkRedistributionScheme(newScheme ShamirSecretSharingInterface) error{
+	if (!sss.ShamirSecretSharingITF.IsInitialized()){
+		return errors.New("Not ready for redistributing shares.")
+	}
+	if (newScheme == nil || !newScheme.IsInitialized()){
+		return errors.New("Scheme of the new committee should be initialized.")
+	}
+	// p' = 0 mod p and p = 0 mod p' iff p = p'
+	newModulus := newScheme.GetModulus()
+	if (!sss.ShamirSecretSharingITF.checkElement(newModulus) || !newScheme.checkElement(sss.modolus) ||
+		!sss.ShamirSecretSharingITF.isElementEqual(newModulus, sss.ShamirSecretSharingITF.getElementZero()) ||
+		!newScheme.isElementEqual(sss.modolus, newScheme.getElementZero())){
+		return errors.New("Modulus of the new scheme should be the same.")
+	}
+	return nil
+}
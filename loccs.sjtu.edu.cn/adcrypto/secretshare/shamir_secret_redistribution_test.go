@@ -0,0 +1,80 @@
+package secretshare
+
+import (
+	"testing"
+	"crypto/rand"
+	"fmt"
+)
+
+func TestShamirSecretRedistribution(t *testing.T) {
+	modulusBigInt,_ := rand.Prime(rand.Reader,30)
+	modulus := int(modulusBigInt.Int64())
+	oldInt, err := NewShamirSecretSharingInt(7,modulus)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingInt: %s", err))}
+	newInt, err := NewShamirSecretSharingInt(10,modulus)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingInt: %s", err))}
+	t.Run("testShamirSecretRedistributionInt",
+		testShamirSecretRedistribution(oldInt, 7, 4, newInt, 10, 6, 218932 % modulus))
+
+	modulusBigInt,_ = rand.Prime(rand.Reader,256)
+	oldBigInt, err := NewShamirSecretSharingBigInt(9,modulusBigInt)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingBigInt: %s", err))}
+	newBigInt, err := NewShamirSecretSharingBigInt(5,modulusBigInt)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingBigInt: %s", err))}
+	secret,_ := rand.Int(rand.Reader,modulusBigInt)
+	t.Run("testShamirSecretRedistributionBigInt",
+		testShamirSecretRedistribution(oldBigInt, 9, 5, newBigInt, 5, 3, secret))
+}
+
+func testShamirSecretRedistribution(oldScheme ShamirSecretSharingInterface, oldCount int, oldThreshold int,
+	newScheme ShamirSecretSharingInterface, newCount int, newThreshold int, secret interface{}) func(t *testing.T) {
+	return func(t *testing.T) {
+		oldAccess, err := NewThresholdAccessStructure(oldCount,oldThreshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+		err = oldScheme.SetAccessStructure(oldAccess)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+		newAccess, err := NewThresholdAccessStructure(newCount,newThreshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+		err = newScheme.SetAccessStructure(newAccess)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+
+		shares, err := oldScheme.GenerateShares(secret, oldScheme.GenerateRandomAuxiliary())
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+		newAuxiliary := newScheme.GenerateRandomAuxiliary()
+
+		// the last threshold old holders redistribute their shares
+		holders := shares[oldCount-oldThreshold:]
+		oldPoints := make([]interface{}, len(holders))
+		subShares := make([][]*SecretShare, len(holders))
+		for i := 0; i < len(holders); i++{
+			oldPoints[i] = holders[i].GetValue().(*ShamirSecretShareValue).GetR()
+			subShares[i], err = oldScheme.GenerateRedistributionShares(holders[i], newScheme, newAuxiliary)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating redistribution shares: %s", err))}
+		}
+		newShares := make([]*SecretShare, newCount)
+		for j := 0; j < newCount; j++{
+			received := make([]*SecretShare, len(holders))
+			for i := 0; i < len(holders); i++{
+				received[i] = subShares[i][j]
+			}
+			newShares[j], err = oldScheme.CombineRedistributionShares(oldPoints, received, newScheme)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when combining redistribution shares: %s", err))}
+		}
+
+		calculatedSecret, err := newScheme.CalculateSecret(newShares[newCount-newThreshold:])
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (fmt.Sprint(calculatedSecret) != fmt.Sprint(secret)){
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%v ,Expected: %v",calculatedSecret,secret))
+		}
+		_, err = newScheme.CalculateSecret(newShares[:newThreshold-1])
+		if err == nil {t.Error("Secret should not be calculated from less than threshold shares.")}
+
+		// less than threshold old holders cannot redistribute
+		received := make([]*SecretShare, len(holders)-1)
+		for i := 1; i < len(holders); i++{
+			received[i-1] = subShares[i][0]
+		}
+		_, err = oldScheme.CombineRedistributionShares(oldPoints[1:], received, newScheme)
+		if err == nil {t.Error("Sub-shares from less than threshold old holders should not be combined.")}
+	}
+}
@@ -6,6 +6,9 @@ package secretshare
  * A share in Shamir's scheme is a 2-tuple (<i>r</i>, <i>q</i>(<i>r</i>)),
  * where <i>r</i> is the input of the polynomial, <i>q</i>(<i>r</i>) is the result
  * of the polynomial.
+ * <p>
+ * The epoch counts how many times the share has been refreshed, shares from different epochs
+ * lie on different polynomials and cannot be combined.
  *
  * @author 		LoCCS
  * @version		1.0
@@ -21,6 +24,11 @@ type ShamirSecretShareValue struct{
 	 * <i>q</i>(<i>r</i>), the result of the polynomial.
 	 */
 	qr interface{}
+
+	/**
+	 * Epoch of the share, 0 for freshly generated shares.
+	 */
+	epoch int
 }
 
 /**
@@ -53,4 +61,26 @@ func (sssv *ShamirSecretShareValue) GetR() interface{}{
  */
 func (sssv *ShamirSecretShareValue) GetQr() interface{}{
 	return sssv.qr
-}
\ No newline at end of file
+}
+/**
+ * Construct a share value by <i>r</i>, <i>q</i>(<i>r</i>) and the epoch.
+ *
+ * @param r <i>r</i>, the input of the polynomial.
+ * @param qr <i>q</i>(<i>r</i>), the result of the polynomial.
+ * @param epoch Epoch of the share.
+ * @return newShamirSecretShareValue the new constructed ShamirSecretShareValue
+ */
+func NewShamirSecretShareValueWithEpoch(r interface{}, qr interface{}, epoch int) (*ShamirSecretShareValue){
+	newShamirSecretShareValue := NewShamirSecretShareValue(r, qr)
+	newShamirSecretShareValue.epoch = epoch
+	return newShamirSecretShareValue
+}
+
+/**
+ * Get epoch of the share, i.e. how many times it has been refreshed.
+ *
+ * @return Epoch of the share.
+ */
+func (sssv *ShamirSecretShareValue) GetEpoch() int{
+	return sssv.epoch
+}
@@ -63,6 +63,16 @@ type ShamirSecretSharingInterface interface {
 
 	CalculateSecret(shares []*SecretShare) (interface{}, error)
 
+	CalculateSecretRobust(shares []*SecretShare) (interface{}, []int, error)
+
+	GenerateZeroShares(auxiliary []interface{}) ([]*SecretShare, error)
+
+	RefreshShare(share *SecretShare, subShares []*SecretShare) (*SecretShare, error)
+
+	GenerateRedistributionShares(share *SecretShare, newScheme ShamirSecretSharingInterface, newAuxiliary []interface{}) ([]*SecretShare, error)
+
+	CombineRedistributionShares(oldPoints []interface{}, subShares []*SecretShare, newScheme ShamirSecretSharingInterface) (*SecretShare, error)
+
 	/**
     * Abstract method of generating <i>n</i> random auxiliary data from each participant.
     *
@@ -108,6 +118,100 @@ type ShamirSecretSharingInterface interface {
  	*/
 	GetEquationCoefficients(x interface{}) []interface{}
 
+	/**
+	* Abstract method of getting the Lagrange interpolation over <i>Zp</i> on the given evaluation points.
+	*
+	* @param points The distinct evaluation points.
+	* @return Lagrange interpolation object.
+	* @return error If any evaluation point is invalid or the points are not distinct.
+	*/
+	GetLagrangeInterpolation(points []interface{}) (poly.LagrangeInterpolationCalculator, error)
+
+	/**
+	* Get the recombination vector of the given evaluation points.
+	*
+	* @param points The distinct evaluation points.
+	* @return The recombination vector.
+	* @return error If any evaluation point is invalid.
+	*/
+	GetRecombinationVector(points []interface{}) ([]interface{}, error)
+
+	/**
+	* Abstract method of getting a system linear equation with given number of variables over <i>Zp</i>.
+	*
+	* @param variableCount Number of variables.
+	* @return Linear equation system object.
+	*/
+	GetEquationSystemWithVariableCount(variableCount int) poly.LinearEquationSystemCalculator
+
+	/**
+	* Abstract method of calculating the powers of an element.
+	* <p>
+	* i.e. 1, <i>x</i>, <i>x</i><sup>2</sup>, ... , <i>x</i><sup><i>count</i>-1</sup> mod <i>p</i>
+	*
+	* @param x The element.
+	* @param count Number of powers.
+	* @return The power array.
+	*/
+	GetPowers(x interface{}, count int) []interface{}
+
+	/**
+	* Abstract method of getting an proper object for value 0.
+	*
+	* @return Element for value 0.
+	*/
+	getElementZero() interface{}
+
+	/**
+	* Abstract method of getting an proper object for value 1.
+	*
+	* @return Element for value 1.
+	*/
+	getElementOne() interface{}
+
+	/**
+	* Abstract method of constructing a polynomial over <i>Zp</i> from its coefficients.
+	*
+	* @param coefficients Coefficients of the polynomial, coefficients[i] is <i>a<sub>i</sub></i>.
+	* @return The polynomial object.
+	*/
+	getPolynomial(coefficients []interface{}) poly.PolynomialCalculator
+
+	/**
+	* Abstract method of calculating <i>a</i> + <i>b</i> mod <i>p</i>.
+	*
+	* @param a The first element.
+	* @param b The second element.
+	* @return The sum.
+	*/
+	addElements(a interface{}, b interface{}) interface{}
+
+	/**
+	* Abstract method of calculating <i>a</i> * <i>b</i> mod <i>p</i>.
+	*
+	* @param a The first element.
+	* @param b The second element.
+	* @return The product.
+	*/
+	multiplyElements(a interface{}, b interface{}) interface{}
+
+	/**
+	* Abstract method of calculating -<i>a</i> mod <i>p</i>.
+	*
+	* @param a The element.
+	* @return The negation.
+	*/
+	negateElement(a interface{}) interface{}
+
+	/**
+	* Abstract method of testing if two elements are equal modulo <i>p</i>.
+	*
+	* @param a The first element.
+	* @param b The second element.
+	* @return True if the elements are equal modulo <i>p</i>, otherwise return false.
+	*/
+	isElementEqual(a interface{}, b interface{}) bool
+
    /**
    * Abstract method of checking if the type of input element is valid.
    *
@@ -157,6 +261,23 @@ func (sss *ShamirSecretSharing) IsInitialized() bool{
  * @return error If the input secret is invalid.
  */
 func (sss *ShamirSecretSharing) generateSharesImpl(secret interface{}, auxiliary []interface{}) ([]*SecretShare, error){
+	auxiliary, err := sss.checkSecretAndAuxiliary(secret, auxiliary)
+	if (err != nil) {return nil, err}
+
+	// Use random k-1 degree polynomial to generate shares.
+	poly := sss.ShamirSecretSharingITF.GetRandomPolynomial(secret)
+	return sss.generateSharesFromPolynomial(poly, auxiliary)
+}
+
+/**
+ * Check the secret and the auxiliary data for generating shares.
+ *
+ * @param secret The secret from which shares are generated.
+ * @param auxiliary Auxiliary data for generating shares. Can be nil(use default auxiliary).
+ * @return The auxiliary data to be used.
+ * @return error If the secret or the auxiliary data is invalid.
+ */
+func (sss *ShamirSecretSharing) checkSecretAndAuxiliary(secret interface{}, auxiliary []interface{}) ([]interface{}, error){
     if (!sss.ShamirSecretSharingITF.checkElement(secret)){
     	return nil, errors.New("Invalid type of secret.")
 	}
@@ -174,22 +295,96 @@ func (sss *ShamirSecretSharing) generateSharesImpl(secret interface{}, auxiliary
 			}
 		}
 	}
+	return auxiliary, nil
+}
 
-	// Use random k-1 degree polynomial to generate shares.
+/**
+ * Generate shares by evaluating the polynomial on the auxiliary data.
+ * <p>
+ * The polynomial is evaluated on all auxiliary data at once with a subproduct tree, see <code>poly.SubproductTree</code>.
+ *
+ * @param poly The polynomial whose constant term is the secret.
+ * @param auxiliary Auxiliary data for generating shares, one for each participant.
+ * @return N shares, one for each participant.
+ * @return error If the polynomial cannot be evaluated.
+ */
+func (sss *ShamirSecretSharing) generateSharesFromPolynomial(poly poly.PolynomialCalculator, auxiliary []interface{}) ([]*SecretShare, error){
+	results, err := poly.CalculateMultipoint(auxiliary[:sss.participantCount])
+	if (err != nil) {return nil, err}
 	shares := make([]*SecretShare, sss.participantCount)
-	poly := sss.ShamirSecretSharingITF.GetRandomPolynomial(secret)
 	for i:=0;i<sss.participantCount;i++{
-		qr, err := poly.Calculate(auxiliary[i])
-		if (err != nil) {return nil, err}
-		value := NewShamirSecretShareValue(auxiliary[i],qr)
+		value := NewShamirSecretShareValue(auxiliary[i],results[i])
 		shares[i] = NewSecretShare(i,value)
 	}
 
 	return shares, nil
 }
 
+/**
+ * Generate shares of zero, used by the participants to refresh their shares proactively.
+ * <p>
+ * The shares lie on a random <i>k</i>-1 degree polynomial with <i>a</i><sub>0</sub> = 0, so adding them to the shares of
+ * a secret gives new shares of the same secret, which are independent of the old ones.
+ *
+ * @param auxiliary Auxiliary data of the shares to be refreshed. Can be nil(use default auxiliary).
+ * @return N sub-shares of zero, one for each participant.
+ * @return error If the scheme is not initialized or the auxiliary data is invalid.
+ */
+func (sss *ShamirSecretSharing) GenerateZeroShares(auxiliary []interface{}) ([]*SecretShare, error){
+	if (!sss.ShamirSecretSharingITF.IsInitialized()){
+		return nil, errors.New("Not ready for generate shares.")
+	}
+	zero := sss.ShamirSecretSharingITF.getElementZero()
+	auxiliary, err := sss.checkSecretAndAuxiliary(zero, auxiliary)
+	if (err != nil) {return nil, err}
+	return sss.generateSharesFromPolynomial(sss.ShamirSecretSharingITF.GetRandomPolynomial(zero), auxiliary)
+}
+
+/**
+ * Refresh a share by adding the sub-shares of zero received from the participants.
+ * <p>
+ * All sub-shares should be generated for the holder of the share, i.e. on the same participant and evaluation point.
+ * The epoch of the refreshed share is the epoch of the input share plus 1.
+ *
+ * @param share The share to be refreshed.
+ * @param subShares The sub-shares of zero generated by <code>GenerateZeroShares</code>, usually one from each participant.
+ * @return The refreshed share.
+ * @return error If the share or any sub-share is invalid.
+ */
+func (sss *ShamirSecretSharing) RefreshShare(share *SecretShare, subShares []*SecretShare) (*SecretShare, error){
+	if (share == nil){
+		return nil, errors.New("Share should not be nil.")
+	}
+	value, ok := share.GetValue().(*ShamirSecretShareValue)
+	if (!ok) {return nil, errors.New("Invalid type of share value, should be ShamirSecretShareValue.")}
+	if (!sss.ShamirSecretSharingITF.checkElement(value.GetR()) || !sss.ShamirSecretSharingITF.checkElement(value.GetQr())){
+		return nil, errors.New("Invalid type of elements in ShamirSecretShareValue.")
+	}
+	if (subShares == nil || len(subShares) == 0){
+		return nil, errors.New("At least one sub-share should be provided.")
+	}
+	qr := sss.ShamirSecretSharingITF.addElements(value.GetQr(), sss.ShamirSecretSharingITF.getElementZero())
+	for i := 0; i < len(subShares); i++{
+		if (subShares[i] == nil || subShares[i].GetParticipant() != share.GetParticipant()){
+			return nil, errors.New("Sub-shares should be generated for the holder of the share.")
+		}
+		subValue, ok := subShares[i].GetValue().(*ShamirSecretShareValue)
+		if (!ok) {return nil, errors.New("Invalid type of sub-share value, should be ShamirSecretShareValue.")}
+		if (!sss.ShamirSecretSharingITF.checkElement(subValue.GetR()) || !sss.ShamirSecretSharingITF.checkElement(subValue.GetQr())){
+			return nil, errors.New("Invalid type of elements in ShamirSecretShareValue.")
+		}
+		if (!sss.ShamirSecretSharingITF.isElementEqual(subValue.GetR(), value.GetR())){
+			return nil, errors.New("Sub-shares should be evaluated on the same point as the share.")
+		}
+		qr = sss.ShamirSecretSharingITF.addElements(qr, subValue.GetQr())
+	}
+	return NewSecretShare(share.GetParticipant(), NewShamirSecretShareValueWithEpoch(value.GetR(), qr, value.GetEpoch() + 1)), nil
+}
+
 /**
  * Calculating secret from input shares.
+ * <p>
+ * The secret <i>q</i>(0) is calculated by Lagrange interpolation on the first <i>k</i> shares, in O(<i>k</i><sup>2</sup>).
  *
  * @param shares The shares from which secret is calculated.
  * @return The secret calculated from the input shares.
@@ -203,20 +398,172 @@ func (sss *ShamirSecretSharing) calculateSecretImpl(shares []*SecretShare) (inte
     	if (!sss.ShamirSecretSharingITF.checkElement(valueReal.GetR()) || !sss.ShamirSecretSharingITF.checkElement(valueReal.GetQr())){
     		return nil, errors.New("Invalid type of elements in ShamirSecretShareValue.")
 		}
+		if (valueReal.GetEpoch() != shares[0].GetValue().(*ShamirSecretShareValue).GetEpoch()){
+			return nil, errors.New("Shares should come from the same epoch.")
+		}
 	}
-	// Since it matches threshold access structure, at least k equations are provided.
-	// Use the first k equations to solve the solution.
+	// Since it matches threshold access structure, at least k shares are provided.
+	// Use the first k shares to interpolate q(0).
 	threshold := sss.access.GetThreshold()
-	linearEquationSystem := sss.ShamirSecretSharingITF.GetEquationSystem()
+	points := make([]interface{}, threshold)
+	values := make([]interface{}, threshold)
 	for i := 0; i < threshold ; i++{
 		value := shares[i].GetValue().(*ShamirSecretShareValue)
-		err := linearEquationSystem.
-			AddEquation(sss.ShamirSecretSharingITF.GetEquationCoefficients(value.GetR()) , value.GetQr())
-		if (err != nil) {return nil, err}
+		points[i] = value.GetR()
+		values[i] = value.GetQr()
 	}
-	solution, err := linearEquationSystem.Solve()
+	interpolation, err := sss.ShamirSecretSharingITF.GetLagrangeInterpolation(points)
 	if (err != nil) {return nil, errors.New("At least one invalid share value.")}
-	return solution[0],nil
+	return interpolation.Interpolate(sss.ShamirSecretSharingITF.getElementZero(), values)
+}
+
+/**
+ * Calculating secret from input shares, some of which may be corrupted.
+ * <p>
+ * The shares of all participants form a codeword of a Reed-Solomon code, so the secret can be recovered
+ * by the decoder of Berlekamp and Welch, as long as at most (<i>m</i>-<i>k</i>)/2 of the <i>m</i> input shares are
+ * corrupted. With all <i>n</i> shares and threshold <i>t</i> = <i>k</i>-1, it is (<i>n</i>-<i>t</i>-1)/2.
+ * <p>
+ * For <i>e</i> = 0, 1, ..., (<i>m</i>-<i>k</i>)/2, the first <i>k</i>+2<i>e</i> shares are used to solve the key equation
+ * <i>Q</i>(<i>x<sub>i</sub></i>) = <i>y<sub>i</sub></i><i>E</i>(<i>x<sub>i</sub></i>), where the error locator <i>E</i> is monic
+ * with degree <i>e</i> and <i>Q</i> has degree less than <i>k</i>+<i>e</i>. The polynomial is then interpolated from the shares
+ * not located by <i>E</i>, and accepted if at most (<i>m</i>-<i>k</i>)/2 shares disagree with it, which makes it unique.
+ *
+ * @param shares The shares from which secret is calculated, usually from all participants.
+ * @return The secret calculated from the input shares.
+ * @return IDs of the participants whose shares are inconsistent with the secret.
+ * @return error If the scheme is not initialized, the input shares are invalid, or too many shares are corrupted.
+ */
+func (sss *ShamirSecretSharing) CalculateSecretRobust(shares []*SecretShare) (interface{}, []int, error){
+	if (!sss.ShamirSecretSharingITF.IsInitialized()){
+		return nil, nil, errors.New("Not ready for calculating secret.")
+	}
+	threshold := sss.access.GetThreshold()
+	if (shares == nil || len(shares) < threshold){
+		return nil, nil, errors.New("Number of shares should not be less than threshold.")
+	}
+	points := make([]interface{}, len(shares))
+	values := make([]interface{}, len(shares))
+	for i := 0; i < len(shares); i++{
+		value, ok := shares[i].GetValue().(*ShamirSecretShareValue)
+		if (!ok) {return nil, nil, errors.New("Invalid type of share value, should be ShamirSecretShareValue.")}
+		if (!sss.ShamirSecretSharingITF.checkElement(value.GetR()) || !sss.ShamirSecretSharingITF.checkElement(value.GetQr())){
+			return nil, nil, errors.New("Invalid type of elements in ShamirSecretShareValue.")
+		}
+		if (value.GetEpoch() != shares[0].GetValue().(*ShamirSecretShareValue).GetEpoch()){
+			return nil, nil, errors.New("Shares should come from the same epoch.")
+		}
+		for j := 0; j < i; j++{
+			if (shares[j].GetParticipant() == shares[i].GetParticipant() || sss.ShamirSecretSharingITF.isElementEqual(points[j], value.GetR())){
+				return nil, nil, errors.New("Shares should come from distinct participants with distinct evaluation points.")
+			}
+		}
+		points[i] = value.GetR()
+		values[i] = value.GetQr()
+	}
+
+	maxErrorCount := (len(shares) - threshold) / 2
+	for errorCount := 0; errorCount <= maxErrorCount; errorCount++{
+		polynomial := sss.decodeWithErrorCount(points, values, threshold, errorCount)
+		if (polynomial == nil) {continue}
+		corrupted := make([]int, 0)
+		for i := 0; i < len(shares); i++{
+			qr, err := polynomial.Calculate(points[i])
+			if (err != nil) {return nil, nil, err}
+			if (!sss.ShamirSecretSharingITF.isElementEqual(qr, values[i])){
+				corrupted = append(corrupted, shares[i].GetParticipant())
+			}
+		}
+		if (len(corrupted) <= maxErrorCount){
+			return polynomial.GetCoefficients()[0], corrupted, nil
+		}
+	}
+	return nil, nil, errors.New("Too many corrupted shares.")
+}
+
+/**
+ * Try to find the <i>k</i>-1 degree polynomial from the first <i>k</i>+2<i>e</i> points, assuming <i>e</i> of them are corrupted.
+ * <p>
+ * The result may be wrong if the assumption does not hold, so it should be checked against all points.
+ *
+ * @param points The evaluation points <i>x<sub>i</sub></i>.
+ * @param values The share values <i>y<sub>i</sub></i>.
+ * @param threshold Threshold <i>k</i>.
+ * @param errorCount Number of corrupted points <i>e</i>.
+ * @return The candidate polynomial, nil if not found.
+ */
+func (sss *ShamirSecretSharing) decodeWithErrorCount(points []interface{}, values []interface{}, threshold int, errorCount int) poly.PolynomialCalculator{
+	// unknowns: coefficients of Q (k+e), followed by the coefficients of E except the leading one (e)
+	qCount := threshold + errorCount
+	linearEquationSystem := sss.ShamirSecretSharingITF.GetEquationSystemWithVariableCount(qCount + errorCount)
+	for i := 0; i < qCount + errorCount; i++{
+		powers := sss.ShamirSecretSharingITF.GetPowers(points[i], qCount)
+		coefficients := make([]interface{}, qCount + errorCount)
+		for j := 0; j < qCount; j++{
+			coefficients[j] = powers[j]
+		}
+		for j := 0; j < errorCount; j++{
+			coefficients[qCount + j] = sss.ShamirSecretSharingITF.negateElement(
+				sss.ShamirSecretSharingITF.multiplyElements(values[i], powers[j]))
+		}
+		constant := sss.ShamirSecretSharingITF.multiplyElements(values[i], powers[errorCount])
+		err := linearEquationSystem.AddEquation(coefficients, constant)
+		if (err != nil) {return nil}
+	}
+	solution, err := linearEquationSystem.Solve()
+	if (err != nil) {return nil}
+
+	// points which are not roots of E are considered correct
+	locatorCoefficients := make([]interface{}, errorCount + 1)
+	copy(locatorCoefficients, solution[qCount:])
+	locatorCoefficients[errorCount] = sss.ShamirSecretSharingITF.getElementOne()
+	locator := sss.ShamirSecretSharingITF.getPolynomial(locatorCoefficients)
+	correctPoints := make([]interface{}, 0, threshold)
+	correctValues := make([]interface{}, 0, threshold)
+	for i := 0; i < qCount + errorCount && len(correctPoints) < threshold; i++{
+		located, err := locator.Calculate(points[i])
+		if (err != nil) {return nil}
+		if (sss.ShamirSecretSharingITF.isElementEqual(located, sss.ShamirSecretSharingITF.getElementZero())) {continue}
+		correctPoints = append(correctPoints, points[i])
+		correctValues = append(correctValues, values[i])
+	}
+	if (len(correctPoints) < threshold) {return nil}
+	interpolation, err := sss.ShamirSecretSharingITF.GetLagrangeInterpolation(correctPoints)
+	if (err != nil) {return nil}
+	feedback, err := interpolation.InterpolatePolynomial(correctValues)
+	if (err != nil) {return nil}
+	return feedback
+}
+
+/**
+ * Get the recombination vector of the given evaluation points.
+ * <p>
+ * The recombination vector (<i>r</i><sub>1</sub>, <i>r</i><sub>2</sub>, ..., <i>r<sub>m</sub></i>) is the first row of the
+ * inverse of the Vandermonde matrix of <i>x</i><sub>1</sub>, <i>x</i><sub>2</sub>, ..., <i>x<sub>m</sub></i>, so that
+ * <i>q</i>(0) = <i>r</i><sub>1</sub><i>q</i>(<i>x</i><sub>1</sub>) + ... + <i>r<sub>m</sub></i><i>q</i>(<i>x<sub>m</sub></i>) mod <i>p</i>
+ * holds for every polynomial <i>q</i> whose degree is less than <i>m</i>.
+ * <p>
+ * i.e. the Lagrange coefficients at 0, <i>r<sub>i</sub></i> = &prod;<sub><i>j</i>&ne;<i>i</i></sub> <i>x<sub>j</sub></i>/(<i>x<sub>j</sub></i> - <i>x<sub>i</sub></i>).
+ *
+ * @param points The distinct evaluation points.
+ * @return The recombination vector.
+ * @return error If any evaluation point is invalid.
+ */
+func (sss *ShamirSecretSharing) GetRecombinationVector(points []interface{}) ([]interface{}, error){
+	if (sss.modolus == nil){
+		return nil, errors.New("Modulus not set.")
+	}
+	if (points == nil || len(points) == 0){
+		return nil, errors.New("At least one evaluation point should be provided.")
+	}
+	for i := 0; i < len(points); i++{
+		if (!sss.ShamirSecretSharingITF.checkElement(points[i])){
+			return nil, errors.New("Invalid type of evaluation point.")
+		}
+	}
+	interpolation, err := sss.ShamirSecretSharingITF.GetLagrangeInterpolation(points)
+	if (err != nil) {return nil, errors.New("Evaluation points should be distinct.")}
+	return interpolation.GetLagrangeCoefficients(sss.ShamirSecretSharingITF.getElementZero())
 }
 
 /**
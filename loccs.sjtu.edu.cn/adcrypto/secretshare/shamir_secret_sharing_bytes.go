@@ -0,0 +1,286 @@
+package secretshare
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"crypto/rand"
+	"errors"
+)
+
+/**
+ * The class implements Shamir's secret sharing scheme on GF(2<sup>8</sup>) for arbitrary binary secrets.
+ * <p>
+ * A secret of <i>m</i> bytes is shared byte by byte: for each byte, a random <i>k</i>-1 degree polynomial over GF(2<sup>8</sup>)
+ * with the byte as constant term is evaluated on the evaluation point <i>x</i> of each participant. The shared value of a
+ * participant is a []byte of <i>m</i>+1 bytes, i.e. the <i>m</i> evaluations followed by <i>x</i>.
+ * <p>
+ * <code>ShamirSecretSharingBytes</code> use <code>ThresholdAccessStructure</code> as its internal access structure. The
+ * evaluation points are distinct non-zero bytes, so there are at most 255 participants.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type ShamirSecretSharingBytes struct {
+	SecretSharingScheme
+}
+
+/**
+ * Construct secret sharing scheme on GF(2<sup>8</sup>) with the number of participants.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @return feedback the newly constructed ShamirSecretSharingBytes
+ * @return error If the number of participants is invalid.
+ */
+func NewShamirSecretSharingBytes(participantCount int) (*ShamirSecretSharingBytes, error){
+	if (participantCount < 2 || participantCount > 255){
+		return nil, errors.New("Invalid participant count. Should be larger than 1 and no more than 255.")
+	}
+	feedback := new(ShamirSecretSharingBytes)
+	feedback.participantCount = participantCount
+	feedback.SecretSharingSchemeITF = feedback
+	return feedback, nil
+}
+
+/**
+ * Set access structure.
+ * <p>
+ * The participant count in AccessStructure and that in this scheme must be equal.
+ *
+ * @param access Access structure, should be ThresholdAccessStructure.
+ * @return error If the access structure is invalid.
+ */
+func (sssb *ShamirSecretSharingBytes) SetAccessStructure(access AccessStructureInterface) error{
+	accessValue, ok := access.(*ThresholdAccessStructure)
+	if (!ok) {return errors.New("Invalid AccessStructure type. Should be 'ThresholdAccessStructure'.")}
+	if (accessValue.participantCount != sssb.participantCount) {
+		return errors.New("The participant count in AccessStructure and Scheme should be equal.")
+	}
+	sssb.access = accessValue
+	return nil
+}
+
+/**
+ * Determine if the scheme object is initialized properly for generating shares and calculating secret.
+ *
+ * @return True if the access structure is set, otherwise return false.
+ */
+func (sssb *ShamirSecretSharingBytes) IsInitialized() bool{
+	return sssb.access != nil
+}
+
+/**
+ * Create default auxiliary data, i.e. the evaluation points 1, 2, ..., <i>n</i>.
+ *
+ * @return Default auxiliary data (byte array).
+ */
+func (sssb *ShamirSecretSharingBytes) CreateDefaultAuxiliary() []interface{}{
+	feedback := make([]interface{}, sssb.participantCount)
+	for i := 0; i < sssb.participantCount; i++{
+		feedback[i] = byte(i + 1)
+	}
+	return feedback
+}
+
+/**
+ * Generate <i>n</i> random auxiliary data, i.e. distinct non-zero evaluation points chosen randomly.
+ *
+ * @return Random auxiliary (byte array).
+ * @return error If random numbers cannot be generated.
+ */
+func (sssb *ShamirSecretSharingBytes) GenerateRandomAuxiliary() ([]interface{}, error){
+	points, err := randomGF256Points(sssb.participantCount)
+	if (err != nil) {return nil, err}
+	feedback := make([]interface{}, sssb.participantCount)
+	for i := 0; i < sssb.participantCount; i++{
+		feedback[i] = points[i]
+	}
+	return feedback, nil
+}
+
+/**
+ * Generate shares from input secret.
+ *
+ * @param secret The secret from which shares are generated, should be a non-empty []byte.
+ * @param auxiliary Evaluation points (distinct non-zero bytes) for generating shares. Can be nil(use default auxiliary).
+ * @return N shares, the value of each is a []byte of the evaluations followed by the evaluation point.
+ * @return error If the secret or the auxiliary data is invalid.
+ */
+func (sssb *ShamirSecretSharingBytes) generateSharesImpl(secret interface{}, auxiliary []interface{}) ([]*SecretShare, error){
+	secretBytes, ok := secret.([]byte)
+	if (!ok || len(secretBytes) == 0){
+		return nil, errors.New("Invalid secret, should be a non-empty []byte.")
+	}
+	if (auxiliary == nil){
+		auxiliary = sssb.CreateDefaultAuxiliary()
+	} else if (len(auxiliary) != sssb.participantCount){
+		return nil, errors.New("Invalid number of auxiliary data, should be equal to number of participants.")
+	}
+	points := make([]byte, sssb.participantCount)
+	for i := 0; i < sssb.participantCount; i++{
+		point, ok := auxiliary[i].(byte)
+		if (!ok) {return nil, errors.New("Invalid type of auxiliary data, should be byte.")}
+		if (point == 0) {return nil, errors.New("Auxiliary data should not be 0.")}
+		for j := 0; j < i; j++{
+			if (points[j] == point) {return nil, errors.New("Auxiliary data should be distinct.")}
+		}
+		points[i] = point
+	}
+	values, err := splitGF256(secretBytes, points, sssb.access.GetThreshold())
+	if (err != nil) {return nil, err}
+	shares := make([]*SecretShare, sssb.participantCount)
+	for i := 0; i < sssb.participantCount; i++{
+		shares[i] = NewSecretShare(i, values[i])
+	}
+	return shares, nil
+}
+
+/**
+ * Calculating secret from input shares by Lagrange interpolation on the first <i>k</i> shares, byte by byte.
+ *
+ * @param shares The shares from which secret is calculated.
+ * @return The secret ([]byte) calculated from the input shares.
+ * @return error If any of the input shares is invalid.
+ */
+func (sssb *ShamirSecretSharingBytes) calculateSecretImpl(shares []*SecretShare) (interface{}, error){
+	threshold := sssb.access.GetThreshold()
+	values := make([][]byte, threshold)
+	for i := 0; i < threshold; i++{
+		value, ok := shares[i].GetValue().([]byte)
+		if (!ok) {return nil, errors.New("Invalid type of share value, should be []byte.")}
+		values[i] = value
+	}
+	return combineGF256(values)
+}
+
+/**
+ * Split a secret into byte-wise shares without a scheme object.
+ * <p>
+ * The evaluation points are distinct non-zero bytes chosen randomly, and each share is the evaluations followed by its
+ * evaluation point, i.e. <i>m</i>+1 bytes for a secret of <i>m</i> bytes.
+ *
+ * @param secret The secret, should not be empty.
+ * @param participantCount The number of shares <i>n</i>, from 2 to 255.
+ * @param threshold The threshold <i>k</i>, from 2 to <i>n</i>.
+ * @return N shares.
+ * @return error If the parameters are invalid or random numbers cannot be generated.
+ */
+func SplitBytes(secret []byte, participantCount int, threshold int) ([][]byte, error){
+	if (len(secret) == 0){
+		return nil, errors.New("Invalid secret, should be a non-empty []byte.")
+	}
+	if (participantCount < 2 || participantCount > 255){
+		return nil, errors.New("Invalid participant count. Should be larger than 1 and no more than 255.")
+	}
+	if (threshold < 2 || threshold > participantCount){
+		return nil, errors.New("Invalid threshold. Should be larger than 1 and no more than participant count.")
+	}
+	points, err := randomGF256Points(participantCount)
+	if (err != nil) {return nil, err}
+	return splitGF256(secret, points, threshold)
+}
+
+/**
+ * Combine byte-wise shares generated by <code>SplitBytes</code> into the secret.
+ * <p>
+ * All shares are used, so they should come from the same secret, and at least <i>k</i> of them are needed.
+ *
+ * @param shares The shares, each of which is the evaluations followed by its evaluation point.
+ * @return The secret.
+ * @return error If the shares are invalid.
+ */
+func CombineBytes(shares [][]byte) ([]byte, error){
+	if (len(shares) < 2){
+		return nil, errors.New("At least two shares should be provided.")
+	}
+	return combineGF256(shares)
+}
+
+/**
+ * Choose distinct non-zero evaluation points in GF(2<sup>8</sup>) randomly.
+ *
+ * @param count Number of points, no more than 255.
+ * @return The evaluation points.
+ * @return error If random numbers cannot be generated.
+ */
+func randomGF256Points(count int) ([]byte, error){
+	// shuffle 1, 2, ..., 255 by Fisher-Yates, and take the first points (the points are public, so the modulo bias is harmless)
+	points := make([]byte, 255)
+	for i := 0; i < 255; i++{
+		points[i] = byte(i + 1)
+	}
+	random := make([]byte, 255)
+	_, err := rand.Read(random)
+	if (err != nil) {return nil, err}
+	for i := 254; i > 0; i--{
+		j := int(random[i]) % (i + 1)
+		points[i], points[j] = points[j], points[i]
+	}
+	return points[:count], nil
+}
+
+/**
+ * Split a secret byte by byte on the given evaluation points.
+ *
+ * @param secret The secret.
+ * @param points Distinct non-zero evaluation points, one for each share.
+ * @param threshold The threshold <i>k</i>.
+ * @return The shares, each of which is the evaluations followed by its evaluation point.
+ * @return error If random numbers cannot be generated.
+ */
+func splitGF256(secret []byte, points []byte, threshold int) ([][]byte, error){
+	shares := make([][]byte, len(points))
+	for i := 0; i < len(points); i++{
+		shares[i] = make([]byte, len(secret) + 1)
+		shares[i][len(secret)] = points[i]
+	}
+	coefficients := make([]byte, threshold)
+	for k := 0; k < len(secret); k++{
+		coefficients[0] = secret[k]
+		_, err := rand.Read(coefficients[1:])
+		if (err != nil) {return nil, err}
+		for i := 0; i < len(points); i++{
+			shares[i][k] = poly.CalculateGF256(coefficients, points[i])
+		}
+	}
+	return shares, nil
+}
+
+/**
+ * Recover the secret from shares by Lagrange interpolation at 0, byte by byte.
+ * <p>
+ * The Lagrange coefficient of share <i>i</i> at 0 is &prod;<sub><i>j</i>&ne;<i>i</i></sub> <i>x<sub>j</sub></i> / (<i>x<sub>j</sub></i> - <i>x<sub>i</sub></i>),
+ * where subtraction is XOR in GF(2<sup>8</sup>).
+ *
+ * @param shares The shares, each of which is the evaluations followed by its evaluation point.
+ * @return The secret.
+ * @return error If the shares have different lengths, or the evaluation points are zero or not distinct.
+ */
+func combineGF256(shares [][]byte) ([]byte, error){
+	length := len(shares[0])
+	if (length < 2) {return nil, errors.New("Share should contain at least two bytes.")}
+	points := make([]byte, len(shares))
+	for i := 0; i < len(shares); i++{
+		if (len(shares[i]) != length) {return nil, errors.New("Shares should have the same length.")}
+		points[i] = shares[i][length - 1]
+		if (points[i] == 0) {return nil, errors.New("Evaluation point of a share should not be 0.")}
+		for j := 0; j < i; j++{
+			if (points[j] == points[i]) {return nil, errors.New("Evaluation points of shares should be distinct.")}
+		}
+	}
+	lagrange := make([]byte, len(shares))
+	for i := 0; i < len(shares); i++{
+		lagrange[i] = 1
+		for j := 0; j < len(shares); j++{
+			if (j == i) {continue}
+			factor, err := poly.DivideGF256(points[j], points[j] ^ points[i])
+			if (err != nil) {return nil, err}
+			lagrange[i] = poly.MultiplyGF256(lagrange[i], factor)
+		}
+	}
+	feedback := make([]byte, length - 1)
+	for k := 0; k < length - 1; k++{
+		for i := 0; i < len(shares); i++{
+			feedback[k] ^= poly.MultiplyGF256(lagrange[i], shares[i][k])
+		}
+	}
+	return feedback, nil
+}
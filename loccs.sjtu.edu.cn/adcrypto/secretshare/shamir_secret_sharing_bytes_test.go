@@ -0,0 +1,163 @@
+package secretshare
+
+import (
+	"testing"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+)
+
+func TestShamirSecretSharingBytesProcedure(t *testing.T) {
+	t.Run("TestShamirSecretSharingBytesProcedure1", testShamirSecretSharingBytesProcedure(16, 11, []byte("a secret key of arbitrary bytes \x00\xff")))
+	t.Run("TestShamirSecretSharingBytesProcedure2", testShamirSecretSharingBytesProcedure(255, 128, []byte{0}))
+	t.Run("TestShamirSecretSharingBytesProcedure3", testShamirSecretSharingBytesProcedure(3, 1, []byte("threshold 1")))
+}
+
+func testShamirSecretSharingBytesProcedure(participantCount int, threshold int, secret []byte) func(t *testing.T) {
+	return func(t *testing.T) {
+		shamirSecretSharingBytes, err := NewShamirSecretSharingBytes(participantCount)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingBytes: %s", err))}
+		threAccessStruct, err := NewThresholdAccessStructure(participantCount, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+		err = shamirSecretSharingBytes.SetAccessStructure(threAccessStruct)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+		auxi, err := shamirSecretSharingBytes.GenerateRandomAuxiliary()
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing Auxiliary: %s", err))}
+		shares, err := shamirSecretSharingBytes.GenerateShares(secret, auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+		for i := 0; i < participantCount; i++{
+			value := shares[i].GetValue().([]byte)
+			if (len(value) != len(secret) + 1 || value[len(secret)] != auxi[i].(byte)){
+				t.Fatal("Share layout is False.")
+			}
+		}
+
+		// shares survive the binary encoding
+		decodedShares := make([]*SecretShare, threshold)
+		for i := 0; i < threshold; i++{
+			data, err := shares[participantCount - 1 - i].MarshalBinary()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share: %s", err))}
+			decodedShares[i] = new(SecretShare)
+			err = decodedShares[i].UnmarshalBinary(data)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share: %s", err))}
+		}
+		secretNew, err := shamirSecretSharingBytes.CalculateSecret(decodedShares)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (!bytes.Equal(secretNew.([]byte), secret)){
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%x ,Expected: %x", secretNew, secret))
+		}
+		_, err = shamirSecretSharingBytes.CalculateSecret(decodedShares[:threshold - 1])
+		if err == nil {t.Error("Secret should not be calculated with too few shares.")}
+	}
+}
+
+func TestShamirSecretSharingBytesInvalid(t *testing.T) {
+	_, err := NewShamirSecretSharingBytes(256)
+	if err == nil {t.Error("More than 255 participants should not be accepted.")}
+	scheme, _ := NewShamirSecretSharingBytes(5)
+	_, err = scheme.GenerateShares([]byte("secret"), nil)
+	if err == nil {t.Error("Shares should not be generated before the access structure is set.")}
+	access, _ := NewThresholdAccessStructure(5, 3)
+	_ = scheme.SetAccessStructure(access)
+	_, err = scheme.GenerateShares([]byte{}, nil)
+	if err == nil {t.Error("Empty secret should not be accepted.")}
+	_, err = scheme.GenerateShares(12, nil)
+	if err == nil {t.Error("Invalid type of secret should not be accepted.")}
+	_, err = scheme.GenerateShares([]byte("secret"), []interface{}{byte(1), byte(2), byte(3), byte(4), byte(1)})
+	if err == nil {t.Error("Auxiliary data which is not distinct should not be accepted.")}
+	_, err = scheme.GenerateShares([]byte("secret"), []interface{}{byte(1), byte(2), byte(3), byte(4), byte(0)})
+	if err == nil {t.Error("Auxiliary data 0 should not be accepted.")}
+
+	shares, _ := scheme.GenerateShares([]byte("secret"), nil)
+	shares[1] = NewSecretShare(1, []byte("short"))
+	_, err = scheme.CalculateSecret(shares[:3])
+	if err == nil {t.Error("Shares of different lengths should not be accepted.")}
+}
+
+func TestSplitBytes(t *testing.T) {
+	secret := []byte("correct horse battery staple")
+	shares, err := SplitBytes(secret, 10, 4)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when splitting: %s", err))}
+	for _, subset := range [][]int{{0, 1, 2, 3}, {9, 5, 7, 2}, {0, 1, 2, 3, 4, 5, 6, 7, 8, 9}}{
+		parts := make([][]byte, len(subset))
+		for i, index := range subset{
+			parts[i] = shares[index]
+		}
+		secretNew, err := CombineBytes(parts)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when combining: %s", err))}
+		if (!bytes.Equal(secretNew, secret)){
+			t.Error(fmt.Sprintf("Combined Result is False, Result:%x ,Expected: %x", secretNew, secret))
+		}
+	}
+	secretNew, _ := CombineBytes(shares[:3])
+	if (bytes.Equal(secretNew, secret)){
+		t.Error("Secret should not be recovered with too few shares.")
+	}
+	_, err = SplitBytes(secret, 10, 1)
+	if err == nil {t.Error("Threshold 1 should not be accepted.")}
+	_, err = SplitBytes(nil, 10, 3)
+	if err == nil {t.Error("Empty secret should not be accepted.")}
+}
+
+func TestCombineBytesFixedShares(t *testing.T) {
+	// shares with threshold 3, i.e. the evaluations of the fixed polynomials secret[k] + a1[k]x + a2[k]x^2 below
+	// followed by the evaluation point, so the layout of the shares is pinned independently of SplitBytes
+	secret := []byte("hello, vault")
+	a1, _ := hex.DecodeString("0b30557a9fc4e90e33587da2")
+	a2, _ := hex.DecodeString("c8237ed9348fea45a0fb56b1")
+	vectors := []string{
+		"2fcb9250d97291a70e0dc2e52a",
+		"55bd6bd558060e1aced2732b7f",
+		"2574370afd153bc3372f0770c3",
+		"ab7647cfc467233df2d6476701",
+		"7fae9cd88bce6a4346863918fe",
+	}
+	parts := make([][]byte, len(vectors))
+	for i := 0; i < len(vectors); i++{
+		parts[i], _ = hex.DecodeString(vectors[i])
+		if (len(parts[i]) != len(secret) + 1){
+			t.Fatal(fmt.Sprintf("Share %d should have %d bytes.", i, len(secret) + 1))
+		}
+		x := parts[i][len(secret)]
+		for k := 0; k < len(secret); k++{
+			if (parts[i][k] != poly.CalculateGF256([]byte{secret[k], a1[k], a2[k]}, x)){
+				t.Error(fmt.Sprintf("Byte %d of share %d is not the evaluation at its last byte.", k, i))
+			}
+		}
+	}
+	for _, subset := range [][]int{{0, 1, 2}, {4, 3, 2}, {0, 2, 4, 1}, {0, 1, 2, 3, 4}}{
+		selected := make([][]byte, len(subset))
+		for i, index := range subset{
+			selected[i] = parts[index]
+		}
+		secretNew, err := CombineBytes(selected)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when combining: %s", err))}
+		if (!bytes.Equal(secretNew, secret)){
+			t.Error(fmt.Sprintf("Combined Result is False, Result:%q", secretNew))
+		}
+	}
+
+	// the same shares are accepted by the scheme object, whichever participants hold them
+	scheme, _ := NewShamirSecretSharingBytes(5)
+	access, _ := NewThresholdAccessStructure(5, 3)
+	_ = scheme.SetAccessStructure(access)
+	shares := []*SecretShare{NewSecretShare(4, parts[4]), NewSecretShare(0, parts[0]), NewSecretShare(2, parts[2])}
+	secretNew, err := scheme.CalculateSecret(shares)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+	if (!bytes.Equal(secretNew.([]byte), secret)){
+		t.Error(fmt.Sprintf("Calculate Result is False, Result:%q", secretNew))
+	}
+
+	// shares of SplitBytes have the same layout, with distinct non-zero evaluation points
+	split, err := SplitBytes(secret, 255, 3)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when splitting: %s", err))}
+	used := make(map[byte]bool)
+	for i := 0; i < len(split); i++{
+		x := split[i][len(split[i]) - 1]
+		if (len(split[i]) != len(secret) + 1 || x == 0 || used[x]){
+			t.Fatal("Shares should be the evaluations followed by distinct non-zero evaluation points.")
+		}
+		used[x] = true
+	}
+}
@@ -0,0 +1,83 @@
+package secretshare
+
+import (
+	"testing"
+	"crypto/rand"
+	"fmt"
+)
+
+func TestShamirSecretSharingRefresh(t *testing.T) {
+	participantCount := 8
+	threshold := 5
+	modulusBigInt,_ := rand.Prime(rand.Reader,30)
+	shamirInt, err := NewShamirSecretSharingInt(participantCount,int(modulusBigInt.Int64()))
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingInt: %s", err))}
+	t.Run("testShamirSecretSharingRefreshInt",
+		testShamirSecretSharingRefresh(shamirInt, participantCount, threshold, 218932 % int(modulusBigInt.Int64())))
+
+	modulusBigInt,_ = rand.Prime(rand.Reader,256)
+	shamirBigInt, err := NewShamirSecretSharingBigInt(participantCount,modulusBigInt)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingBigInt: %s", err))}
+	secret,_ := rand.Int(rand.Reader,modulusBigInt)
+	t.Run("testShamirSecretSharingRefreshBigInt",
+		testShamirSecretSharingRefresh(shamirBigInt, participantCount, threshold, secret))
+}
+
+func testShamirSecretSharingRefresh(scheme ShamirSecretSharingInterface, participantCount int, threshold int, secret interface{}) func(t *testing.T) {
+	return func(t *testing.T) {
+		threAccessStruct, err := NewThresholdAccessStructure(participantCount,threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+		err = scheme.SetAccessStructure(threAccessStruct)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+		auxiliary := scheme.GenerateRandomAuxiliary()
+		shares, err := scheme.GenerateShares(secret, auxiliary)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+
+		// subShares[i][j] is the sub-share of zero from participant i to participant j
+		subShares := make([][]*SecretShare, participantCount)
+		for i := 0; i < participantCount; i++{
+			subShares[i], err = scheme.GenerateZeroShares(auxiliary)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares of zero: %s", err))}
+		}
+		refreshed := make([]*SecretShare, participantCount)
+		for j := 0; j < participantCount; j++{
+			received := make([]*SecretShare, participantCount)
+			for i := 0; i < participantCount; i++{
+				received[i] = subShares[i][j]
+			}
+			refreshed[j], err = scheme.RefreshShare(shares[j], received)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when refreshing share: %s", err))}
+			if (refreshed[j].GetValue().(*ShamirSecretShareValue).GetEpoch() != 1){
+				t.Error("Epoch of refreshed share should be 1.")
+			}
+		}
+		_, err = scheme.RefreshShare(shares[0], subShares[0])
+		if err == nil {t.Error("Sub-shares of other participants should not be accepted.")}
+
+		calculatedSecret, err := scheme.CalculateSecret(refreshed[participantCount-threshold:])
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (fmt.Sprint(calculatedSecret) != fmt.Sprint(secret)){
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%v ,Expected: %v",calculatedSecret,secret))
+		}
+		calculatedSecret, _, err = scheme.CalculateSecretRobust(refreshed)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret robustly: %s", err))}
+		if (fmt.Sprint(calculatedSecret) != fmt.Sprint(secret)){
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%v ,Expected: %v",calculatedSecret,secret))
+		}
+		if (fmt.Sprint(refreshed[0].GetValue().(*ShamirSecretShareValue).GetQr()) ==
+			fmt.Sprint(shares[0].GetValue().(*ShamirSecretShareValue).GetQr()) &&
+			fmt.Sprint(refreshed[1].GetValue().(*ShamirSecretShareValue).GetQr()) ==
+			fmt.Sprint(shares[1].GetValue().(*ShamirSecretShareValue).GetQr())){
+			t.Error("Shares are not changed by refreshing.")
+		}
+
+		// mixing shares from different epochs
+		mixed := make([]*SecretShare, participantCount)
+		copy(mixed, refreshed)
+		mixed[0] = shares[0]
+		_, err = scheme.CalculateSecret(mixed)
+		if err == nil {t.Error("Shares from different epochs should not be combined.")}
+		_, _, err = scheme.CalculateSecretRobust(mixed)
+		if err == nil {t.Error("Shares from different epochs should not be combined.")}
+	}
+}
@@ -0,0 +1,78 @@
+package secretshare
+
+import (
+	"testing"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+func TestShamirSecretSharingRobust(t *testing.T) {
+	participantCount := 16
+	threshold := 6
+	modulusBigInt,_ := rand.Prime(rand.Reader,30)
+	modulus := int(modulusBigInt.Int64())
+	shamirInt, err := NewShamirSecretSharingInt(participantCount,modulus)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingInt: %s", err))}
+	t.Run("testShamirSecretSharingRobustInt",
+		testShamirSecretSharingRobust(shamirInt, participantCount, threshold, 218932111 % modulus,
+			func(e interface{}) interface{} {return (e.(int) + 1) % modulus}))
+
+	modulusBigInt,_ = rand.Prime(rand.Reader,256)
+	shamirBigInt, err := NewShamirSecretSharingBigInt(participantCount,modulusBigInt)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingBigInt: %s", err))}
+	secret,_ := rand.Int(rand.Reader,modulusBigInt)
+	t.Run("testShamirSecretSharingRobustBigInt",
+		testShamirSecretSharingRobust(shamirBigInt, participantCount, threshold, secret,
+			func(e interface{}) interface{} {
+				r,_ := rand.Int(rand.Reader,modulusBigInt)
+				return r.Add(r, e.(*big.Int)).Add(r, big.NewInt(1)).Mod(r, modulusBigInt)
+			}))
+
+	// the largest prime below 2^64
+	modulusUint64 := uint64(18446744073709551557)
+	shamirUint64, err := NewShamirSecretSharingUint64(participantCount,modulusUint64)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingUint64: %s", err))}
+	t.Run("testShamirSecretSharingRobustUint64",
+		testShamirSecretSharingRobust(shamirUint64, participantCount, threshold, modulusUint64 - 1,
+			func(e interface{}) interface{} {return (e.(uint64) + 1) % modulusUint64}))
+}
+
+func testShamirSecretSharingRobust(scheme ShamirSecretSharingInterface, participantCount int, threshold int, secret interface{}, corrupt func(interface{}) interface{}) func(t *testing.T) {
+	return func(t *testing.T) {
+		threAccessStruct, err := NewThresholdAccessStructure(participantCount,threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+		err = scheme.SetAccessStructure(threAccessStruct)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+		shares, err := scheme.GenerateShares(secret, scheme.GenerateRandomAuxiliary())
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+
+		// (n-k)/2 = 5 corrupted shares can be corrected
+		maxErrorCount := (participantCount - threshold) / 2
+		corruptedIDs := []int{0, 3, 7, 12, 15}
+		for errorCount := 0; errorCount <= maxErrorCount; errorCount++{
+			corruptedShares := make([]*SecretShare, participantCount)
+			copy(corruptedShares, shares)
+			for _, id := range corruptedIDs[:errorCount]{
+				value := shares[id].GetValue().(*ShamirSecretShareValue)
+				corruptedShares[id] = NewSecretShare(id, NewShamirSecretShareValue(value.GetR(), corrupt(value.GetQr())))
+			}
+			secretNew, corrupted, err := scheme.CalculateSecretRobust(corruptedShares)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret with %d corrupted shares: %s", errorCount, err))}
+			if (fmt.Sprint(secretNew) != fmt.Sprint(secret)){
+				t.Error(fmt.Sprintf("Calculate Result is False, Result:%v ,Expected: %v",secretNew,secret))
+			}
+			if (fmt.Sprint(corrupted) != fmt.Sprint(corruptedIDs[:errorCount])){
+				t.Error(fmt.Sprintf("Corrupted participants mismatch, Result:%v ,Expected: %v",corrupted,corruptedIDs[:errorCount]))
+			}
+		}
+
+		// one more corrupted share cannot be corrected
+		for _, id := range append(corruptedIDs, 9){
+			value := shares[id].GetValue().(*ShamirSecretShareValue)
+			shares[id] = NewSecretShare(id, NewShamirSecretShareValue(value.GetR(), corrupt(value.GetQr())))
+		}
+		_, _, err = scheme.CalculateSecretRobust(shares)
+		if err == nil {t.Error("Too many corrupted shares should be detected.")}
+	}
+}
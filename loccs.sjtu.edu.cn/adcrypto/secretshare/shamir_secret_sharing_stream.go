@@ -0,0 +1,361 @@
+package secretshare
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/wire"
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"hash/crc32"
+	"io"
+	"strconv"
+)
+
+/**
+ * Streaming Shamir's secret sharing on GF(2<sup>8</sup>) for large files.
+ * <p>
+ * <code>SplitStream</code> reads the secret chunk by chunk and writes one share stream for each participant, and
+ * <code>CombineStreams</code> reads any <i>k</i> or more share streams chunk by chunk and writes the secret, so the
+ * whole file is never kept in memory. Each chunk is shared by <code>ShamirSecretSharingBytes</code> with the evaluation point
+ * of the participant, and a share stream is a sequence of wire frames:
+ * <ol>
+ * 		<li> A header (<code>wire.KindShareStreamHeader</code>): stream ID (16 random bytes, the same for all shares of a secret),
+ * 		number of participants, threshold, participant ID, evaluation point, chunk size, and the CRC-32 of the header;
+ * 		<li> Chunks (<code>wire.KindShareStreamChunk</code>): chunk index, the shared bytes, and the CRC-32 of the chunk;
+ * 		<li> A trailer (<code>wire.KindShareStreamTrailer</code>): number of chunks and number of bytes of the secret.
+ * </ol>
+ * Streams of different secrets, corrupted chunks and truncated streams are reported by <code>ShareStreamError</code>.
+ */
+
+/**
+ * Default size of a chunk of the secret in bytes.
+ */
+const DefaultShareStreamChunkSize = 1 << 16
+
+/**
+ * Length of the stream ID in bytes.
+ */
+const shareStreamIDLength = 16
+
+/**
+ * The header of a share stream.
+ */
+type shareStreamHeader struct {
+	/**
+	 * Random ID shared by all streams of the same secret.
+	 */
+	streamID []byte
+
+	/**
+	 * The number of participants <i>n</i>.
+	 */
+	participantCount int
+
+	/**
+	 * The threshold <i>k</i>.
+	 */
+	threshold int
+
+	/**
+	 * ID of the participant holding the stream.
+	 */
+	participant int
+
+	/**
+	 * The evaluation point of the participant.
+	 */
+	point byte
+
+	/**
+	 * Size of a chunk of the secret in bytes.
+	 */
+	chunkSize int
+}
+
+/**
+ * The error of a share stream, reporting which stream and participant it comes from.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type ShareStreamError struct {
+	/**
+	 * Index of the stream in the input of <code>CombineStreams</code>.
+	 */
+	Stream int
+
+	/**
+	 * ID of the participant holding the stream, -1 if the header has not been read.
+	 */
+	Participant int
+
+	/**
+	 * What is wrong with the stream.
+	 */
+	Reason string
+}
+
+/**
+ * Describe the error, e.g. "Share stream 2 (participant 4): Checksum mismatch in chunk."
+ *
+ * @return The stream, the participant if the header has been read, and the reason.
+ */
+func (sse *ShareStreamError) Error() string{
+	feedback := "Share stream " + strconv.Itoa(sse.Stream)
+	if (sse.Participant >= 0) {feedback += " (participant " + strconv.Itoa(sse.Participant) + ")"}
+	return feedback + ": " + sse.Reason
+}
+
+/**
+ * Split a secret into share streams chunk by chunk.
+ * <p>
+ * The evaluation points are distinct non-zero bytes chosen randomly, and the participant ID of the <i>i</i>-th stream is <i>i</i>.
+ *
+ * @param secret The secret to be read until EOF.
+ * @param shares One share stream for each participant, from 2 to 255 streams.
+ * @param threshold The threshold <i>k</i>, from 2 to the number of streams.
+ * @param chunkSize Size of a chunk of the secret in bytes, DefaultShareStreamChunkSize is used if it is not positive.
+ * @return error If the parameters are invalid, or error happens when reading or writing.
+ */
+func SplitStream(secret io.Reader, shares []io.Writer, threshold int, chunkSize int) error{
+	participantCount := len(shares)
+	if (participantCount < 2 || participantCount > 255){
+		return errors.New("Invalid participant count. Should be larger than 1 and no more than 255.")
+	}
+	if (threshold < 2 || threshold > participantCount){
+		return errors.New("Invalid threshold. Should be larger than 1 and no more than participant count.")
+	}
+	if (chunkSize <= 0) {chunkSize = DefaultShareStreamChunkSize}
+	if (chunkSize + 64 > wire.MaxBodyLength){
+		return errors.New("Chunk size is too large.")
+	}
+	points, err := randomGF256Points(participantCount)
+	if (err != nil) {return err}
+	streamID := make([]byte, shareStreamIDLength)
+	_, err = rand.Read(streamID)
+	if (err != nil) {return err}
+	for i := 0; i < participantCount; i++{
+		header := &shareStreamHeader{streamID: streamID, participantCount: participantCount, threshold: threshold,
+			participant: i, point: points[i], chunkSize: chunkSize}
+		err = wire.WriteFrame(shares[i], wire.KindShareStreamHeader, header.marshal())
+		if (err != nil) {return err}
+	}
+
+	buffer := make([]byte, chunkSize)
+	chunkCount, length := uint64(0), uint64(0)
+	for {
+		count, readErr := io.ReadFull(secret, buffer)
+		if (count > 0){
+			values, err := splitGF256(buffer[:count], points, threshold)
+			if (err != nil) {return err}
+			for i := 0; i < participantCount; i++{
+				err = wire.WriteFrame(shares[i], wire.KindShareStreamChunk, marshalShareStreamChunk(chunkCount, values[i][:count]))
+				if (err != nil) {return err}
+			}
+			chunkCount++
+			length += uint64(count)
+		}
+		if (readErr == io.EOF || readErr == io.ErrUnexpectedEOF) {break}
+		if (readErr != nil) {return readErr}
+	}
+
+	trailer := wire.NewWriter()
+	trailer.WriteUint64(chunkCount)
+	trailer.WriteUint64(length)
+	for i := 0; i < participantCount; i++{
+		err = wire.WriteFrame(shares[i], wire.KindShareStreamTrailer, trailer.Bytes())
+		if (err != nil) {return err}
+	}
+	return nil
+}
+
+/**
+ * Combine share streams into the secret chunk by chunk.
+ * <p>
+ * All streams are used, so they should be <i>k</i> or more streams of the same secret from distinct participants.
+ * The chunks before a corrupted chunk may have been written when the error is reported.
+ *
+ * @param shares The share streams.
+ * @param secret The stream to write the secret.
+ * @return error ShareStreamError if a stream is invalid, corrupted, truncated or does not match the others,
+ *         or other error if the streams are not enough or error happens when writing.
+ */
+func CombineStreams(shares []io.Reader, secret io.Writer) error{
+	if (len(shares) < 2){
+		return errors.New("At least two share streams should be provided.")
+	}
+	headers := make([]*shareStreamHeader, len(shares))
+	for i := 0; i < len(shares); i++{
+		kind, frame, err := wire.ReadFrame(shares[i])
+		if (err != nil) {return &ShareStreamError{i, -1, "Cannot read header: " + err.Error()}}
+		if (kind != wire.KindShareStreamHeader) {return &ShareStreamError{i, -1, "Header expected."}}
+		headers[i], err = unmarshalShareStreamHeader(frame)
+		if (err != nil) {return &ShareStreamError{i, -1, err.Error()}}
+		if (!bytes.Equal(headers[i].streamID, headers[0].streamID) || headers[i].participantCount != headers[0].participantCount ||
+			headers[i].threshold != headers[0].threshold || headers[i].chunkSize != headers[0].chunkSize){
+			return &ShareStreamError{i, headers[i].participant, "Does not belong to the same secret as stream 0."}
+		}
+		for j := 0; j < i; j++{
+			if (headers[j].participant == headers[i].participant || headers[j].point == headers[i].point){
+				return &ShareStreamError{i, headers[i].participant, "Duplicates stream " + strconv.Itoa(j) + "."}
+			}
+		}
+	}
+	if (len(shares) < headers[0].threshold){
+		return errors.New("Number of share streams should not be less than threshold " + strconv.Itoa(headers[0].threshold) + ".")
+	}
+
+	values := make([][]byte, len(shares))
+	chunkCount, length := uint64(0), uint64(0)
+	for {
+		trailers, ended := 0, 0
+		var trailer []byte
+		for i := 0; i < len(shares); i++{
+			kind, frame, err := wire.ReadFrame(shares[i])
+			if (err != nil) {return &ShareStreamError{i, headers[i].participant, "Truncated: " + err.Error()}}
+			switch kind {
+			case wire.KindShareStreamChunk:
+				index, data, err := unmarshalShareStreamChunk(frame)
+				if (err != nil) {return &ShareStreamError{i, headers[i].participant, err.Error()}}
+				if (index != chunkCount){
+					return &ShareStreamError{i, headers[i].participant, "Chunk " + strconv.FormatUint(chunkCount, 10) + " expected."}
+				}
+				if (len(data) == 0 || len(data) > headers[i].chunkSize){
+					return &ShareStreamError{i, headers[i].participant, "Invalid length of chunk."}
+				}
+				values[i] = append(data, headers[i].point)
+			case wire.KindShareStreamTrailer:
+				body, err := wire.NewReader(frame).ReadFrame(wire.KindShareStreamTrailer)
+				if (err != nil) {return &ShareStreamError{i, headers[i].participant, err.Error()}}
+				if (trailer != nil && !bytes.Equal(body, trailer)){
+					return &ShareStreamError{i, headers[i].participant, "Trailer does not match the other streams."}
+				}
+				trailer = body
+				trailers++
+				ended = i
+			default:
+				return &ShareStreamError{i, headers[i].participant, "Chunk or trailer expected."}
+			}
+		}
+		if (trailers == len(shares)){
+			reader := wire.NewReader(trailer)
+			expectedChunks, err := reader.ReadUint64()
+			if (err != nil) {return &ShareStreamError{0, headers[0].participant, "Invalid trailer."}}
+			expectedLength, err := reader.ReadUint64()
+			if (err != nil || reader.Remaining() != 0) {return &ShareStreamError{0, headers[0].participant, "Invalid trailer."}}
+			if (expectedChunks != chunkCount || expectedLength != length){
+				return &ShareStreamError{0, headers[0].participant, "Chunks are missing."}
+			}
+			return nil
+		}
+		if (trailers != 0){
+			return &ShareStreamError{ended, headers[ended].participant, "Ends before the other streams."}
+		}
+		chunk, err := combineGF256(values)
+		if (err != nil) {return errors.New("Chunks " + strconv.FormatUint(chunkCount, 10) + " have different lengths.")}
+		_, err = secret.Write(chunk)
+		if (err != nil) {return err}
+		chunkCount++
+		length += uint64(len(chunk))
+	}
+}
+
+/**
+ * Encode the header, with the CRC-32 of the encoded fields at the end.
+ *
+ * @return The body of the header frame.
+ */
+func (header *shareStreamHeader) marshal() []byte{
+	body := wire.NewWriter()
+	body.WriteBytes(header.streamID)
+	body.WriteIndex(header.participantCount)
+	body.WriteIndex(header.threshold)
+	body.WriteIndex(header.participant)
+	body.WriteUint8(header.point)
+	body.WriteIndex(header.chunkSize)
+	body.WriteUint32(crc32.ChecksumIEEE(body.Bytes()))
+	return body.Bytes()
+}
+
+/**
+ * Decode and check the header.
+ *
+ * @param frame The header frame.
+ * @return The header.
+ * @return error If the checksum does not match or the parameters are invalid.
+ */
+func unmarshalShareStreamHeader(frame []byte) (*shareStreamHeader, error){
+	body, err := wire.NewReader(frame).ReadFrame(wire.KindShareStreamHeader)
+	if (err != nil) {return nil, err}
+	if (len(body) < 4 || crc32.ChecksumIEEE(body[:len(body) - 4]) != crc32Of(body[len(body) - 4:])){
+		return nil, errors.New("Checksum mismatch in header.")
+	}
+	reader := wire.NewReader(body[:len(body) - 4])
+	header := new(shareStreamHeader)
+	header.streamID, err = reader.ReadBytes()
+	if (err != nil) {return nil, err}
+	header.participantCount, err = reader.ReadIndex()
+	if (err != nil) {return nil, err}
+	header.threshold, err = reader.ReadIndex()
+	if (err != nil) {return nil, err}
+	header.participant, err = reader.ReadIndex()
+	if (err != nil) {return nil, err}
+	header.point, err = reader.ReadUint8()
+	if (err != nil) {return nil, err}
+	header.chunkSize, err = reader.ReadIndex()
+	if (err != nil) {return nil, err}
+	if (reader.Remaining() != 0) {return nil, errors.New("Unexpected data after header.")}
+	if (header.threshold < 2 || header.threshold > header.participantCount || header.participant >= header.participantCount ||
+		header.point == 0 || header.chunkSize <= 0){
+		return nil, errors.New("Invalid parameters in header.")
+	}
+	return header, nil
+}
+
+/**
+ * Encode a chunk, with the CRC-32 of the encoded fields at the end.
+ *
+ * @param index Index of the chunk, from 0.
+ * @param data The shared bytes, without the evaluation point.
+ * @return The body of the chunk frame.
+ */
+func marshalShareStreamChunk(index uint64, data []byte) []byte{
+	body := wire.NewWriter()
+	body.WriteUint64(index)
+	body.WriteBytes(data)
+	body.WriteUint32(crc32.ChecksumIEEE(body.Bytes()))
+	return body.Bytes()
+}
+
+/**
+ * Decode and check a chunk.
+ *
+ * @param frame The chunk frame.
+ * @return Index of the chunk.
+ * @return The shared bytes, without the evaluation point.
+ * @return error If the checksum does not match or the chunk is invalid.
+ */
+func unmarshalShareStreamChunk(frame []byte) (uint64, []byte, error){
+	body, err := wire.NewReader(frame).ReadFrame(wire.KindShareStreamChunk)
+	if (err != nil) {return 0, nil, err}
+	if (len(body) < 4 || crc32.ChecksumIEEE(body[:len(body) - 4]) != crc32Of(body[len(body) - 4:])){
+		return 0, nil, errors.New("Checksum mismatch in chunk.")
+	}
+	reader := wire.NewReader(body[:len(body) - 4])
+	index, err := reader.ReadUint64()
+	if (err != nil) {return 0, nil, err}
+	data, err := reader.ReadBytes()
+	if (err != nil) {return 0, nil, err}
+	if (reader.Remaining() != 0) {return 0, nil, errors.New("Unexpected data after chunk.")}
+	return index, data, nil
+}
+
+/**
+ * Read the CRC-32 stored at the end of a body.
+ *
+ * @param data The last 4 bytes of the body.
+ * @return The checksum.
+ */
+func crc32Of(data []byte) uint32{
+	checksum, _ := wire.NewReader(data).ReadUint32()
+	return checksum
+}
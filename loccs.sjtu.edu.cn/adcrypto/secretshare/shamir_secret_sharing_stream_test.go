@@ -0,0 +1,93 @@
+package secretshare
+
+import (
+	"testing"
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func TestShamirSecretSharingStream(t *testing.T) {
+	t.Run("TestShamirSecretSharingStream1", testShamirSecretSharingStream(5, 3, 300000, 4096, []int{4, 0, 2}))
+	t.Run("TestShamirSecretSharingStream2", testShamirSecretSharingStream(7, 4, 4096 * 3, 4096, []int{0, 1, 2, 3, 4, 5, 6}))
+	t.Run("TestShamirSecretSharingStream3", testShamirSecretSharingStream(3, 2, 0, 0, []int{1, 2}))
+	t.Run("TestShamirSecretSharingStream4", testShamirSecretSharingStream(3, 2, 100, 0, []int{2, 0}))
+}
+
+func testShamirSecretSharingStream(participantCount int, threshold int, length int, chunkSize int, combineFrom []int) func(t *testing.T) {
+	return func(t *testing.T) {
+		secret := make([]byte, length)
+		_, _ = rand.Read(secret)
+		streams, err := splitStreamToBuffers(secret, participantCount, threshold, chunkSize)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when splitting: %s", err))}
+		readers := make([]io.Reader, len(combineFrom))
+		for i, from := range combineFrom{
+			readers[i] = bytes.NewReader(streams[from])
+		}
+		var output bytes.Buffer
+		err = CombineStreams(readers, &output)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when combining: %s", err))}
+		if (!bytes.Equal(output.Bytes(), secret)){
+			t.Error(fmt.Sprintf("Combined Result is False, length %d, expected %d", output.Len(), len(secret)))
+		}
+	}
+}
+
+func TestShamirSecretSharingStreamMismatch(t *testing.T) {
+	secret := []byte(strings.Repeat("a large file, chunk by chunk. ", 1000))
+	streams, err := splitStreamToBuffers(secret, 4, 3, 1000)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when splitting: %s", err))}
+	others, err := splitStreamToBuffers(secret, 4, 3, 1000)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when splitting: %s", err))}
+
+	corrupted := append([]byte{}, streams[2]...)
+	corrupted[len(corrupted) / 2] ^= 1
+	headerCorrupted := append([]byte{}, streams[1]...)
+	headerCorrupted[20] ^= 1
+	cases := []struct {
+		name string
+		streams [][]byte
+		stream int
+		reason string
+	}{
+		{"Corrupted", [][]byte{streams[0], streams[1], corrupted}, 2, "Checksum mismatch in chunk"},
+		{"HeaderCorrupted", [][]byte{streams[0], headerCorrupted, streams[2]}, 1, "Checksum mismatch in header"},
+		{"OtherSecret", [][]byte{streams[0], streams[1], others[2]}, 2, "Does not belong to the same secret"},
+		{"Duplicated", [][]byte{streams[0], streams[1], streams[0]}, 2, "Duplicates stream 0"},
+		{"Truncated", [][]byte{streams[0], streams[1][:len(streams[1]) - 30], streams[2]}, 1, "Truncated"},
+	}
+	for _, c := range cases{
+		readers := make([]io.Reader, len(c.streams))
+		for i := 0; i < len(c.streams); i++{
+			readers[i] = bytes.NewReader(c.streams[i])
+		}
+		err = CombineStreams(readers, io.Discard)
+		streamErr, ok := err.(*ShareStreamError)
+		if (!ok || streamErr.Stream != c.stream || !strings.Contains(streamErr.Reason, c.reason)){
+			t.Error(fmt.Sprintf("Case %s is not reported properly: %v", c.name, err))
+		}
+	}
+
+	err = CombineStreams([]io.Reader{bytes.NewReader(streams[0]), bytes.NewReader(streams[3])}, io.Discard)
+	if err == nil {t.Error("Secret should not be combined with too few streams.")}
+	err = SplitStream(bytes.NewReader(secret), []io.Writer{io.Discard, io.Discard}, 3, 0)
+	if err == nil {t.Error("Invalid threshold should not be accepted.")}
+}
+
+func splitStreamToBuffers(secret []byte, participantCount int, threshold int, chunkSize int) ([][]byte, error){
+	buffers := make([]*bytes.Buffer, participantCount)
+	writers := make([]io.Writer, participantCount)
+	for i := 0; i < participantCount; i++{
+		buffers[i] = new(bytes.Buffer)
+		writers[i] = buffers[i]
+	}
+	err := SplitStream(bytes.NewReader(secret), writers, threshold, chunkSize)
+	if (err != nil) {return nil, err}
+	feedback := make([][]byte, participantCount)
+	for i := 0; i < participantCount; i++{
+		feedback[i] = buffers[i].Bytes()
+	}
+	return feedback, nil
+}
@@ -0,0 +1,42 @@
+package secretshare
+
+import (
+	"errors"
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+)
+
+/**
+ * The class implements Shamir's secret sharing scheme on uint64 field, for any prime modulus <i>p</i> < 2<sup>64</sup>.
+ * <p>
+ * It is <code>ShamirSecretSharing</code> over <code>poly.Uint64Field</code>, see <code>FieldShamirSecretSharing</code> for the typed view.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type ShamirSecretSharingUint64 struct {
+	shamirSecretSharingField[uint64]
+}
+
+/**
+ * Construct secret sharing scheme with the number of participants and uint64 modulus.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @param modulus The order the finite field used by the polynomial.
+ * @return feedback the newly constructed ShamirSecretSharingUint64
+ * @return error If the number of participants or modulus is invalid.
+ */
+func NewShamirSecretSharingUint64(participantCount int, modulus uint64) (*ShamirSecretSharingUint64, error){
+	if (participantCount < 2){
+		return nil, errors.New("Invalid participant count. Should be larger than 1.")
+	}
+	if (modulus <= 2){
+		return nil, errors.New("Invalid modulus. Should be larger than 2.")
+	}
+	field, err := poly.NewUint64Field(modulus)
+	if (err != nil) {return nil, errors.New("Invalid modulus. Should be prime")}
+	feedback := new(ShamirSecretSharingUint64)
+	feedback.init(participantCount, field)
+	feedback.ShamirSecretSharingITF = feedback
+	feedback.SecretSharingSchemeITF = &feedback.ShamirSecretSharing
+	return feedback, nil
+}
@@ -0,0 +1,58 @@
+package secretshare
+
+import (
+	"testing"
+	"fmt"
+)
+
+func TestShamirSecretSharingUint64Procedure(t *testing.T) {
+	participantCount := 16
+	threshold := 11
+	// primes just above 2^63, just below 2^63 and just below 2^64
+	for _, modulus := range []uint64{9223372036854775837, 9223372036854775783, 18446744073709551557}{
+		for _, secret := range []uint64{0, 218932111, modulus >> 1, modulus - 1}{
+			t.Run(fmt.Sprintf("testShamirSecretSharingUint64Procedure%d_%d", modulus, secret),
+				testShamirSecretSharingUint64Procedure(participantCount,threshold,secret,modulus))
+		}
+	}
+}
+
+func testShamirSecretSharingUint64Procedure(participantCount int, threshold int, secret uint64, modulus uint64) func(t *testing.T) {
+	return func(t *testing.T) {
+		shamirSecretSharingUint64, err := NewShamirSecretSharingUint64(participantCount,modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ShamirSecretSharingUint64: %s", err))}
+		threAccessStruct, err := NewThresholdAccessStructure(participantCount,threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing ThresholdAccessStructure: %s", err))}
+		err = shamirSecretSharingUint64.SetAccessStructure(threAccessStruct)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding ThresholdAccessStructure: %s", err))}
+		auxi := shamirSecretSharingUint64.GenerateRandomAuxiliary()
+		shares, err:= shamirSecretSharingUint64.GenerateShares(secret,auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+
+		// shares survive the binary encoding
+		decodedShares := make([]*SecretShare, threshold)
+		for i := 0; i < threshold; i++{
+			data, err := shares[participantCount - 1 - i].MarshalBinary()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share: %s", err))}
+			decodedShares[i] = new(SecretShare)
+			err = decodedShares[i].UnmarshalBinary(data)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share: %s", err))}
+		}
+
+		secretNew, err := shamirSecretSharingUint64.CalculateSecret(decodedShares)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if secretNew.(uint64) != secret {
+			t.Error(fmt.Sprintf("Calculate Result is False, Result:%d ,Expected: %d",secretNew,secret))
+		}
+
+		_, err = shamirSecretSharingUint64.CalculateSecret(decodedShares[:threshold - 1])
+		if err == nil {t.Error("Secret should not be calculated with too few shares.")}
+	}
+}
+
+func TestNewShamirSecretSharingUint64(t *testing.T) {
+	_, err := NewShamirSecretSharingUint64(16, 1 << 63 + 1)
+	if err == nil {t.Error("Modulus which is not prime should not be accepted.")}
+	_, err = NewShamirSecretSharingUint64(1, 18446744073709551557)
+	if err == nil {t.Error("Invalid participant count should not be accepted.")}
+}
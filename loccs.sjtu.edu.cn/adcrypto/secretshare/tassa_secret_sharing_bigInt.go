@@ -0,0 +1,255 @@
+package secretshare
+
+import (
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+/**
+ * The class implements Tassa's hierarchical threshold secret sharing over <i>Zp</i> for <code>HierarchicalAccessStructure</code>.
+ * <p>
+ * The secret is the constant term of a random <i>k<sub>m</sub></i>-1 degree polynomial <i>f</i>. A participant of level 0 gets
+ * <i>f</i>(<i>x</i>) as in Shamir's scheme, and a participant of level <i>i</i> &gt; 0 gets the derivative
+ * <i>f</i><sup>(<i>k</i><sub><i>i</i>-1</sub>)</sup>(<i>x</i>), which carries no information on the first <i>k</i><sub><i>i</i>-1</sub>
+ * coefficients, so junior participants cannot replace the senior ones. The secret is recovered by Birkhoff interpolation, i.e.
+ * every share gives a linear equation of the coefficients, solved with <code>LinearEquationSystemBigInt</code>.
+ * <p>
+ * The share value is a <code>ShamirSecretShareValue</code> (<i>x</i>, <i>f</i><sup>(<i>r</i>)</sup>(<i>x</i>)), the order <i>r</i> being
+ * given by the level of the participant. The default evaluation points 1, 2, ..., <i>n</i> are handed out level by level,
+ * senior levels first, for which Tassa proved that the equations of every authorized set are solvable when <i>p</i> is large
+ * enough (about <i>n</i><sup><i>k</i>(<i>k</i>-1)/2</sup>); with other points the equations are solvable with high probability.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type TassaSecretSharingBigInt struct {
+	/**
+	 * The prime modulus <i>p</i>.
+	 */
+	modulus *big.Int
+
+	SecretSharingScheme
+}
+
+/**
+ * Construct Tassa's hierarchical secret sharing scheme over <i>Zp</i> with the number of participants and the modulus.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @param modulus The modulus <i>p</i>, should be a prime larger than the number of participants.
+ * @return feedback the newly constructed TassaSecretSharingBigInt
+ * @return error If the number of participants or the modulus is invalid.
+ */
+func NewTassaSecretSharingBigInt(participantCount int, modulus *big.Int) (*TassaSecretSharingBigInt, error){
+	if (participantCount < 2){
+		return nil, errors.New("Invalid participant count. Should be larger than 1.")
+	}
+	if (modulus == nil || modulus.Cmp(big.NewInt(int64(participantCount))) <= 0 || !modulus.ProbablyPrime(20)){
+		return nil, errors.New("Modulus should be a prime larger than the participant count.")
+	}
+	feedback := new(TassaSecretSharingBigInt)
+	feedback.participantCount = participantCount
+	feedback.modulus = new(big.Int).Set(modulus)
+	feedback.SecretSharingSchemeITF = feedback
+	return feedback, nil
+}
+
+/**
+ * Get the modulus.
+ *
+ * @return The modulus <i>p</i>.
+ */
+func (tssb *TassaSecretSharingBigInt) GetModulus() *big.Int{
+	return new(big.Int).Set(tssb.modulus)
+}
+
+/**
+ * Set access structure.
+ * <p>
+ * The participant count in AccessStructure and that in this scheme must be equal.
+ *
+ * @param access Access structure, should be HierarchicalAccessStructure.
+ * @return error If the access structure is invalid.
+ */
+func (tssb *TassaSecretSharingBigInt) SetAccessStructure(access AccessStructureInterface) error{
+	accessValue, ok := access.(*HierarchicalAccessStructure)
+	if (!ok) {return errors.New("Invalid AccessStructure type. Should be 'HierarchicalAccessStructure'.")}
+	if (accessValue.participantCount != tssb.participantCount) {
+		return errors.New("The participant count in AccessStructure and Scheme should be equal.")
+	}
+	tssb.access = accessValue
+	return nil
+}
+
+/**
+ * Determine if the scheme object is initialized properly for generating shares and calculating secret.
+ *
+ * @return True if the access structure is set, otherwise return false.
+ */
+func (tssb *TassaSecretSharingBigInt) IsInitialized() bool{
+	return tssb.access != nil
+}
+
+/**
+ * Create default auxiliary data, i.e. the evaluation points 1, 2, ..., <i>n</i> handed out level by level.
+ * <p>
+ * The participants of level 0 get the smallest points, ordered by ID, then those of level 1, and so on.
+ *
+ * @return Default auxiliary data (BigInt array) indexed by ID, nil if the access structure is not set.
+ */
+func (tssb *TassaSecretSharingBigInt) CreateDefaultAuxiliary() []interface{}{
+	if (tssb.access == nil) {return nil}
+	access := tssb.access.(*HierarchicalAccessStructure)
+	feedback := make([]interface{}, tssb.participantCount)
+	point := int64(1)
+	for level := 0; level < access.GetLevelCount(); level++{
+		for i := 0; i < tssb.participantCount; i++{
+			if (access.levels[i] != level) {continue}
+			feedback[i] = big.NewInt(point)
+			point++
+		}
+	}
+	return feedback
+}
+
+/**
+ * Get a random <i>k<sub>m</sub></i>-1 degree polynomial over <i>Zp</i>.
+ * <p>
+ * <i>a</i><sub>0</sub> is the BigInt secret specified by input parameter, and the other coefficients are chosen randomly in <i>Zp</i>.
+ *
+ * @param a0 Constant term of the polynomial.
+ * @return The polynomial object.
+ * @return error If random numbers cannot be generated.
+ */
+func (tssb *TassaSecretSharingBigInt) GetRandomPolynomial(a0 *big.Int) (poly.PolynomialCalculator, error){
+	degree := tssb.access.GetThreshold() - 1
+	coefficients := make([]*big.Int, degree + 1)
+	coefficients[0] = a0
+	for i := 1; i <= degree; i++{
+		random, err := rand.Int(rand.Reader, tssb.modulus)
+		if (err != nil) {return nil, err}
+		coefficients[i] = random
+	}
+	return poly.NewPolynomialBigInt(degree, coefficients, tssb.modulus)
+}
+
+/**
+ * Generate shares from input secret.
+ *
+ * @param secret The secret from which shares are generated, should be BigInt.
+ * @param auxiliary Distinct non-zero evaluation points (BigInt), one for each participant. Can be nil(use default auxiliary).
+ * @return N shares, the value of each is a ShamirSecretShareValue (<i>x</i>, <i>f</i><sup>(<i>r</i>)</sup>(<i>x</i>)).
+ * @return error If the secret or the auxiliary data is invalid.
+ */
+func (tssb *TassaSecretSharingBigInt) generateSharesImpl(secret interface{}, auxiliary []interface{}) ([]*SecretShare, error){
+	secretValue, ok := secret.(*big.Int)
+	if (!ok || secretValue == nil) {return nil, errors.New("Invalid type of secret, should be BigInt.")}
+	if (auxiliary == nil){
+		auxiliary = tssb.CreateDefaultAuxiliary()
+	} else if (len(auxiliary) != tssb.participantCount){
+		return nil, errors.New("Invalid number of auxiliary data, should be equal to number of participants.")
+	}
+	points := make([]*big.Int, tssb.participantCount)
+	for i := 0; i < tssb.participantCount; i++{
+		point, ok := auxiliary[i].(*big.Int)
+		if (!ok || point == nil) {return nil, errors.New("Invalid type of auxiliary data, should be BigInt.")}
+		points[i] = new(big.Int).Mod(point, tssb.modulus)
+		if (points[i].Sign() == 0) {return nil, errors.New("Auxiliary data should not be 0 modulo p.")}
+		for j := 0; j < i; j++{
+			if (points[j].Cmp(points[i]) == 0) {return nil, errors.New("Auxiliary data should be distinct modulo p.")}
+		}
+	}
+
+	polynomial, err := tssb.GetRandomPolynomial(secretValue)
+	if (err != nil) {return nil, err}
+	access := tssb.access.(*HierarchicalAccessStructure)
+	// derivatives[i] is the derivative given to level i
+	derivatives := make([]poly.PolynomialCalculator, access.GetLevelCount())
+	derivative := polynomial
+	order := 0
+	for level := 0; level < access.GetLevelCount(); level++{
+		for (order < access.getDerivativeOrder(level)){
+			derivative = derivative.Derivative()
+			order++
+		}
+		derivatives[level] = derivative
+	}
+	shares := make([]*SecretShare, tssb.participantCount)
+	for i := 0; i < tssb.participantCount; i++{
+		value, err := derivatives[access.levels[i]].Calculate(points[i])
+		if (err != nil) {return nil, err}
+		shares[i] = NewSecretShare(i, NewShamirSecretShareValue(points[i], value))
+	}
+	return shares, nil
+}
+
+/**
+ * Calculating secret from input shares by Birkhoff interpolation.
+ * <p>
+ * The shares are taken level by level, senior levels first, and the first <i>k<sub>m</sub></i> of them, which form an authorized set,
+ * give the linear equations &sum;<sub><i>j</i>&ge;<i>r</i></sub> <i>j</i>!/(<i>j</i>-<i>r</i>)! <i>x</i><sup><i>j</i>-<i>r</i></sup> <i>a<sub>j</sub></i>
+ * = <i>f</i><sup>(<i>r</i>)</sup>(<i>x</i>) of the coefficients <i>a</i><sub>0</sub>, ..., <i>a</i><sub><i>k<sub>m</sub></i>-1</sub>.
+ *
+ * @param shares The shares from which secret is calculated.
+ * @return The secret (BigInt) calculated from the input shares.
+ * @return error If any of the input shares is invalid, or the equations are not solvable.
+ */
+func (tssb *TassaSecretSharingBigInt) calculateSecretImpl(shares []*SecretShare) (interface{}, error){
+	access := tssb.access.(*HierarchicalAccessStructure)
+	threshold := access.GetThreshold()
+	system, err := poly.NewLinearEquationSystemBigInt(threshold, tssb.modulus)
+	if (err != nil) {return nil, err}
+	present := map[int]bool{}
+	count := 0
+	for level := 0; level < access.GetLevelCount() && count < threshold; level++{
+		order := access.getDerivativeOrder(level)
+		for i := 0; i < len(shares) && count < threshold; i++{
+			participant := shares[i].GetParticipant()
+			if (access.levels[participant] != level || present[participant]) {continue}
+			present[participant] = true
+			value, ok := shares[i].GetValue().(*ShamirSecretShareValue)
+			if (!ok) {return nil, errors.New("Invalid type of share value, should be ShamirSecretShareValue.")}
+			x, okX := value.GetR().(*big.Int)
+			y, okY := value.GetQr().(*big.Int)
+			if (!okX || !okY || x == nil || y == nil) {return nil, errors.New("Invalid type of elements in ShamirSecretShareValue.")}
+			err = system.AddEquation(tssb.getBirkhoffCoefficients(x, order, threshold), y)
+			if (err != nil) {return nil, err}
+			count++
+		}
+	}
+	if (count < threshold) {return nil, errors.New("Shares are not enough for calculating secret.")}
+	solution, err := system.Solve()
+	if (err != nil) {return nil, errors.New("Birkhoff interpolation is not solvable for the shares.")}
+	return solution[0], nil
+}
+
+/**
+ * Get coefficients of the equation given by <i>f</i><sup>(<i>r</i>)</sup>(<i>x</i>), i.e. <i>j</i>!/(<i>j</i>-<i>r</i>)! <i>x</i><sup><i>j</i>-<i>r</i></sup>
+ * for <i>j</i> &ge; <i>r</i> and 0 for <i>j</i> &lt; <i>r</i>.
+ *
+ * @param x The evaluation point.
+ * @param order The order <i>r</i> of the derivative.
+ * @param count Number of coefficients <i>k<sub>m</sub></i>.
+ * @return The coefficients.
+ */
+func (tssb *TassaSecretSharingBigInt) getBirkhoffCoefficients(x *big.Int, order int, count int) []interface{}{
+	feedback := make([]interface{}, count)
+	power := big.NewInt(1)
+	for j := 0; j < count; j++{
+		if (j < order){
+			feedback[j] = big.NewInt(0)
+			continue
+		}
+		// j! / (j - r)! = j (j - 1) ... (j - r + 1)
+		factor := big.NewInt(1)
+		for l := j - order + 1; l <= j; l++{
+			factor.Mul(factor, big.NewInt(int64(l)))
+		}
+		coefficient := new(big.Int).Mul(factor, power)
+		feedback[j] = coefficient.Mod(coefficient, tssb.modulus)
+		power = new(big.Int).Mul(power, x)
+		power.Mod(power, tssb.modulus)
+	}
+	return feedback
+}
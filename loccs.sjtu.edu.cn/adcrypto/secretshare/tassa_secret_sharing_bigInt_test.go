@@ -0,0 +1,144 @@
+package secretshare
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"crypto/rand"
+	"loccs.sjtu.edu.cn/adcrypto/poly"
+)
+
+func TestHierarchicalAccessStructure(t *testing.T) {
+	// at least 2 of the 3 executives plus any 3 staff
+	access, err := NewHierarchicalAccessStructure([]int{0, 0, 0, 1, 1, 1, 1, 1}, []int{2, 5})
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing HierarchicalAccessStructure: %s", err))}
+	if (access.GetThreshold() != 5 || access.GetLevelCount() != 2 || access.GetLevel(4) != 1) {t.Error("Levels are stored wrongly.")}
+	cases := []struct {
+		participants []int
+		expected bool
+	}{
+		{[]int{0, 1, 3, 4, 5}, true},
+		{[]int{0, 1, 2, 3, 4}, true},
+		{[]int{0, 3, 4, 5, 6, 7}, false},
+		{[]int{0, 1, 3, 4}, false},
+		{[]int{0, 1, 3, 3, 4}, false},
+	}
+	for i := 0; i < len(cases); i++{
+		ok, err := access.TestAccessable(cases[i].participants)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when testing access: %s", err))}
+		if (ok != cases[i].expected) {t.Error(fmt.Sprintf("Access test of %v is %v, Expected: %v", cases[i].participants, ok, cases[i].expected))}
+	}
+
+	_, err = NewHierarchicalAccessStructure([]int{0, 0, 2}, []int{1, 2})
+	if err == nil {t.Error("Level out of range should not be accepted.")}
+	_, err = NewHierarchicalAccessStructure([]int{0, 1, 1}, []int{2, 2})
+	if err == nil {t.Error("Thresholds not increasing should not be accepted.")}
+	_, err = NewHierarchicalAccessStructure([]int{0, 1, 1}, []int{2, 3})
+	if err == nil {t.Error("Level with too few participants should not be accepted.")}
+}
+
+func TestTassaSecretSharingBigIntProcedure(t *testing.T) {
+	modulus, _ := rand.Prime(rand.Reader, 64)
+	t.Run("TestTassaSecretSharingBigIntProcedure1", testTassaSecretSharingBigIntProcedure([]int{0, 0, 0, 1, 1, 1, 1, 1}, []int{2, 5}, modulus, nil))
+	// levels are not sorted by ID, and the thresholds have three levels
+	t.Run("TestTassaSecretSharingBigIntProcedure2", testTassaSecretSharingBigIntProcedure([]int{2, 1, 0, 2, 1, 0, 2, 2}, []int{1, 3, 6}, modulus, nil))
+	// a single level is Shamir's scheme
+	t.Run("TestTassaSecretSharingBigIntProcedure3", testTassaSecretSharingBigIntProcedure([]int{0, 0, 0, 0, 0}, []int{3}, modulus, nil))
+	auxi := make([]interface{}, 7)
+	for i := 0; i < 7; i++{
+		auxi[i], _ = rand.Int(rand.Reader, modulus)
+	}
+	t.Run("TestTassaSecretSharingBigIntProcedure4", testTassaSecretSharingBigIntProcedure([]int{0, 1, 1, 0, 1, 1, 1}, []int{1, 4}, modulus, auxi))
+}
+
+func testTassaSecretSharingBigIntProcedure(levels []int, thresholds []int, modulus *big.Int, auxi []interface{}) func(t *testing.T) {
+	return func(t *testing.T) {
+		participantCount := len(levels)
+		scheme, err := NewTassaSecretSharingBigInt(participantCount, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing TassaSecretSharingBigInt: %s", err))}
+		access, err := NewHierarchicalAccessStructure(levels, thresholds)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing HierarchicalAccessStructure: %s", err))}
+		err = scheme.SetAccessStructure(access)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding HierarchicalAccessStructure: %s", err))}
+		secret, _ := rand.Int(rand.Reader, modulus)
+		shares, err := scheme.GenerateShares(secret, auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+
+		// every subset is either authorized and recovers the secret, or rejected
+		for mask := 0; mask < (1 << uint(participantCount)); mask++{
+			subset := make([]*SecretShare, 0)
+			participants := make([]int, 0)
+			for i := participantCount - 1; i >= 0; i--{
+				if (mask & (1 << uint(i)) != 0){
+					subset = append(subset, shares[i])
+					participants = append(participants, i)
+				}
+			}
+			authorized, _ := access.TestAccessable(participants)
+			secretNew, err := scheme.CalculateSecret(subset)
+			if (authorized){
+				if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret from %v: %s", participants, err))}
+				if (secretNew.(*big.Int).Cmp(secret) != 0){
+					t.Error(fmt.Sprintf("Calculate Result of %v is False, Result:%s ,Expected: %s", participants, secretNew, secret))
+				}
+			} else if err == nil {
+				t.Error(fmt.Sprintf("Secret should not be calculated from %v.", participants))
+			}
+		}
+
+		// shares survive the binary encoding
+		decodedShares := make([]*SecretShare, participantCount)
+		for i := 0; i < participantCount; i++{
+			data, err := shares[i].MarshalBinary()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share: %s", err))}
+			decodedShares[i] = new(SecretShare)
+			err = decodedShares[i].UnmarshalBinary(data)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share: %s", err))}
+		}
+		secretNew, err := scheme.CalculateSecret(decodedShares)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (secretNew.(*big.Int).Cmp(secret) != 0) {t.Error("Calculate Result from decoded shares is False.")}
+	}
+}
+
+func TestTassaSecretSharingBigIntJuniorShares(t *testing.T) {
+	// the staff shares are derivatives of order 2, so they do not depend on the secret
+	modulus := big.NewInt(1000003)
+	scheme, _ := NewTassaSecretSharingBigInt(8, modulus)
+	access, _ := NewHierarchicalAccessStructure([]int{0, 0, 0, 1, 1, 1, 1, 1}, []int{2, 5})
+	scheme.SetAccessStructure(access)
+	polynomial, err := scheme.GetRandomPolynomial(big.NewInt(42))
+	if err != nil {t.Fatal(err)}
+	if (polynomial.GetDegree() > 4) {t.Error("Degree of the polynomial should be k_m - 1.")}
+	shifted, _ := polynomial.Add(mustPolynomial(big.NewInt(7), modulus))
+	x := big.NewInt(5)
+	junior1, _ := polynomial.Derivative().Derivative().Calculate(x)
+	junior2, _ := shifted.Derivative().Derivative().Calculate(x)
+	if (junior1.(*big.Int).Cmp(junior2.(*big.Int)) != 0) {t.Error("Junior shares should not depend on the constant term.")}
+	point := scheme.CreateDefaultAuxiliary()[2].(*big.Int)
+	if (point.Int64() != 3) {t.Error("Senior participants should get the smallest default points.")}
+}
+
+func TestTassaSecretSharingBigIntInvalid(t *testing.T) {
+	_, err := NewTassaSecretSharingBigInt(5, big.NewInt(5))
+	if err == nil {t.Error("Modulus not larger than participant count should not be accepted.")}
+	scheme, _ := NewTassaSecretSharingBigInt(4, big.NewInt(101))
+	_, err = scheme.GenerateShares(big.NewInt(1), nil)
+	if err == nil {t.Error("Shares should not be generated before the access structure is set.")}
+	threshold, _ := NewThresholdAccessStructure(4, 2)
+	err = scheme.SetAccessStructure(threshold)
+	if err == nil {t.Error("ThresholdAccessStructure should not be accepted.")}
+	access, _ := NewHierarchicalAccessStructure([]int{0, 0, 1, 1}, []int{1, 3})
+	err = scheme.SetAccessStructure(access)
+	if err != nil {t.Fatal(err)}
+	_, err = scheme.GenerateShares(big.NewInt(1), []interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(102)})
+	if err == nil {t.Error("Auxiliary data repeated modulo p should not be accepted.")}
+	_, err = scheme.GenerateShares(big.NewInt(1), []interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(0)})
+	if err == nil {t.Error("Zero auxiliary data should not be accepted.")}
+}
+
+func mustPolynomial(constant *big.Int, modulus *big.Int) *poly.PolynomialBigInt {
+	feedback, err := poly.NewPolynomialBigInt(0, []*big.Int{constant}, modulus)
+	if (err != nil) {panic(err)}
+	return feedback
+}
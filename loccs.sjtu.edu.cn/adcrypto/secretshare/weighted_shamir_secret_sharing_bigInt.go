@@ -0,0 +1,178 @@
+package secretshare
+
+import (
+	"errors"
+	"math/big"
+)
+
+/**
+ * The class implements weighted Shamir's secret sharing over <i>Zp</i> for <code>WeightedThresholdAccessStructure</code>.
+ * <p>
+ * With total weight <i>W</i> and threshold <i>k</i>, the secret is shared by a <i>k</i>-1 degree polynomial on <i>W</i>
+ * evaluation points, and a participant of weight <i>w</i> gets <i>w</i> of them: participant 0 gets the first <i>w</i><sub>0</sub>
+ * points, participant 1 the next <i>w</i><sub>1</sub> ones, and so on. A set whose total weight reaches <i>k</i> holds at least
+ * <i>k</i> points, so the secret can be interpolated. The evaluation points are the auxiliary data, 1, 2, ..., <i>W</i> by default.
+ * <p>
+ * The shared value of a participant is a []interface{} of <i>w</i> pairs, each of which is a []interface{} of two BigInt
+ * (<i>x</i>, <i>q</i>(<i>x</i>)), so the shares can be encoded by the wire format.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type WeightedShamirSecretSharingBigInt struct {
+	/**
+	 * The prime modulus <i>p</i>.
+	 */
+	modulus *big.Int
+
+	/**
+	 * Shamir's scheme of the <i>W</i> evaluation points, set with the access structure.
+	 */
+	pointSharing *ShamirSecretSharingBigInt
+
+	SecretSharingScheme
+}
+
+/**
+ * Construct weighted Shamir's secret sharing scheme over <i>Zp</i> with the number of participants and the modulus.
+ *
+ * @param participantCount The number of participants that share the secret.
+ * @param modulus The modulus <i>p</i>, should be a prime greater than 2.
+ * @return feedback the newly constructed WeightedShamirSecretSharingBigInt
+ * @return error If the number of participants or the modulus is invalid.
+ */
+func NewWeightedShamirSecretSharingBigInt(participantCount int, modulus *big.Int) (*WeightedShamirSecretSharingBigInt, error){
+	if (participantCount < 2){
+		return nil, errors.New("Invalid participant count. Should be larger than 1.")
+	}
+	if (modulus == nil || modulus.Cmp(big.NewInt(2)) <= 0 || !modulus.ProbablyPrime(20)){
+		return nil, errors.New("Modulus should be a prime greater than 2.")
+	}
+	feedback := new(WeightedShamirSecretSharingBigInt)
+	feedback.participantCount = participantCount
+	feedback.modulus = new(big.Int).Set(modulus)
+	feedback.SecretSharingSchemeITF = feedback
+	return feedback, nil
+}
+
+/**
+ * Get the modulus.
+ *
+ * @return The modulus <i>p</i>.
+ */
+func (wsssb *WeightedShamirSecretSharingBigInt) GetModulus() *big.Int{
+	return new(big.Int).Set(wsssb.modulus)
+}
+
+/**
+ * Set access structure.
+ * <p>
+ * The participant count in AccessStructure and that in this scheme must be equal, and the total weight should be
+ * less than the modulus, so that the default evaluation points are distinct and non-zero.
+ *
+ * @param access Access structure, should be WeightedThresholdAccessStructure.
+ * @return error If the access structure is invalid.
+ */
+func (wsssb *WeightedShamirSecretSharingBigInt) SetAccessStructure(access AccessStructureInterface) error{
+	accessValue, ok := access.(*WeightedThresholdAccessStructure)
+	if (!ok) {return errors.New("Invalid AccessStructure type. Should be 'WeightedThresholdAccessStructure'.")}
+	if (accessValue.participantCount != wsssb.participantCount) {
+		return errors.New("The participant count in AccessStructure and Scheme should be equal.")
+	}
+	totalWeight := accessValue.GetTotalWeight()
+	if (big.NewInt(int64(totalWeight)).Cmp(wsssb.modulus) >= 0){
+		return errors.New("The total weight should be less than the modulus.")
+	}
+	pointSharing, err := NewShamirSecretSharingBigInt(totalWeight, wsssb.modulus)
+	if (err != nil) {return err}
+	pointAccess, err := NewThresholdAccessStructure(totalWeight, accessValue.threshold)
+	if (err != nil) {return err}
+	err = pointSharing.SetAccessStructure(pointAccess)
+	if (err != nil) {return err}
+	wsssb.pointSharing = pointSharing
+	wsssb.access = accessValue
+	return nil
+}
+
+/**
+ * Determine if the scheme object is initialized properly for generating shares and calculating secret.
+ *
+ * @return True if the access structure is set, otherwise return false.
+ */
+func (wsssb *WeightedShamirSecretSharingBigInt) IsInitialized() bool{
+	return wsssb.access != nil
+}
+
+/**
+ * Create default auxiliary data, i.e. the evaluation points 1, 2, ..., <i>W</i>.
+ *
+ * @return Default auxiliary data (BigInt array), nil if the access structure is not set.
+ */
+func (wsssb *WeightedShamirSecretSharingBigInt) CreateDefaultAuxiliary() []interface{}{
+	if (wsssb.pointSharing == nil) {return nil}
+	return wsssb.pointSharing.CreateDefaultAuxiliary()
+}
+
+/**
+ * Generate <i>W</i> random auxiliary data, i.e. random non-zero evaluation points.
+ *
+ * @return Random auxiliary (BigInt array), nil if the access structure is not set.
+ */
+func (wsssb *WeightedShamirSecretSharingBigInt) GenerateRandomAuxiliary() []interface{}{
+	if (wsssb.pointSharing == nil) {return nil}
+	return wsssb.pointSharing.GenerateRandomAuxiliary()
+}
+
+/**
+ * Generate shares from input secret.
+ *
+ * @param secret The secret from which shares are generated, should be BigInt.
+ * @param auxiliary <i>W</i> evaluation points for generating shares. Can be nil(use default auxiliary).
+ * @return N shares, the value of each is a []interface{} of (<i>x</i>, <i>q</i>(<i>x</i>)) pairs, one for each unit of weight.
+ * @return error If the secret or the auxiliary data is invalid.
+ */
+func (wsssb *WeightedShamirSecretSharingBigInt) generateSharesImpl(secret interface{}, auxiliary []interface{}) ([]*SecretShare, error){
+	pointShares, err := wsssb.pointSharing.GenerateShares(secret, auxiliary)
+	if (err != nil) {return nil, err}
+	access := wsssb.access.(*WeightedThresholdAccessStructure)
+	shares := make([]*SecretShare, wsssb.participantCount)
+	offset := 0
+	for i := 0; i < wsssb.participantCount; i++{
+		value := make([]interface{}, access.weights[i])
+		for j := 0; j < access.weights[i]; j++{
+			point := pointShares[offset + j].GetValue().(*ShamirSecretShareValue)
+			value[j] = []interface{}{point.GetR(), point.GetQr()}
+		}
+		shares[i] = NewSecretShare(i, value)
+		offset += access.weights[i]
+	}
+	return shares, nil
+}
+
+/**
+ * Calculating secret from input shares by Lagrange interpolation on the points of the distinct participants.
+ *
+ * @param shares The shares from which secret is calculated.
+ * @return The secret (BigInt) calculated from the input shares.
+ * @return error If any of the input shares is invalid.
+ */
+func (wsssb *WeightedShamirSecretSharingBigInt) calculateSecretImpl(shares []*SecretShare) (interface{}, error){
+	access := wsssb.access.(*WeightedThresholdAccessStructure)
+	present := map[int]bool{}
+	pointShares := make([]*SecretShare, 0, access.threshold)
+	for i := 0; i < len(shares); i++{
+		participant := shares[i].GetParticipant()
+		if (present[participant]) {continue}
+		present[participant] = true
+		value, ok := shares[i].GetValue().([]interface{})
+		if (!ok || len(value) != access.weights[participant]){
+			return nil, errors.New("Invalid share value, should be a []interface{} of as many pairs as the weight.")
+		}
+		for j := 0; j < len(value); j++{
+			pair, ok := value[j].([]interface{})
+			if (!ok || len(pair) != 2) {return nil, errors.New("Invalid pair in share value, should be (x, q(x)).")}
+			pointShares = append(pointShares, NewSecretShare(len(pointShares), NewShamirSecretShareValue(pair[0], pair[1])))
+		}
+	}
+	return wsssb.pointSharing.CalculateSecret(pointShares)
+}
@@ -0,0 +1,122 @@
+package secretshare
+
+import (
+	"testing"
+	"fmt"
+	"math/big"
+	"crypto/rand"
+)
+
+func TestWeightedThresholdAccessStructure(t *testing.T) {
+	// the bank has weight 3, four branch offices have weight 1
+	access, err := NewWeightedThresholdAccessStructure([]int{3, 1, 1, 1, 1}, 4)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing WeightedThresholdAccessStructure: %s", err))}
+	if (access.GetTotalWeight() != 7 || access.GetWeight(0) != 3 || access.GetThreshold() != 4) {t.Error("Weights are stored wrongly.")}
+	cases := []struct {
+		participants []int
+		expected bool
+	}{
+		{[]int{0, 1}, true},
+		{[]int{1, 2, 3, 4}, true},
+		{[]int{0}, false},
+		{[]int{1, 2, 3}, false},
+		{[]int{1, 1, 1, 1}, false},
+	}
+	for i := 0; i < len(cases); i++{
+		ok, err := access.TestAccessable(cases[i].participants)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when testing access: %s", err))}
+		if (ok != cases[i].expected) {t.Error(fmt.Sprintf("Access test of %v is %v, Expected: %v", cases[i].participants, ok, cases[i].expected))}
+	}
+
+	_, err = NewWeightedThresholdAccessStructure([]int{3}, 1)
+	if err == nil {t.Error("Single participant should not be accepted.")}
+	_, err = NewWeightedThresholdAccessStructure([]int{3, 0}, 1)
+	if err == nil {t.Error("Zero weight should not be accepted.")}
+	_, err = NewWeightedThresholdAccessStructure([]int{3, 1}, 5)
+	if err == nil {t.Error("Threshold larger than total weight should not be accepted.")}
+}
+
+func TestWeightedShamirSecretSharingBigIntProcedure(t *testing.T) {
+	modulus, _ := rand.Prime(rand.Reader, 64)
+	t.Run("TestWeightedShamirSecretSharingBigIntProcedure1", testWeightedShamirSecretSharingBigIntProcedure([]int{3, 1, 1, 1, 1}, 4, modulus, false))
+	t.Run("TestWeightedShamirSecretSharingBigIntProcedure2", testWeightedShamirSecretSharingBigIntProcedure([]int{5, 4, 3, 2, 1, 1}, 9, modulus, true))
+	t.Run("TestWeightedShamirSecretSharingBigIntProcedure3", testWeightedShamirSecretSharingBigIntProcedure([]int{1, 1, 1, 1}, 3, big.NewInt(5), false))
+}
+
+func testWeightedShamirSecretSharingBigIntProcedure(weights []int, threshold int, modulus *big.Int, randomAuxiliary bool) func(t *testing.T) {
+	return func(t *testing.T) {
+		participantCount := len(weights)
+		scheme, err := NewWeightedShamirSecretSharingBigInt(participantCount, modulus)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing WeightedShamirSecretSharingBigInt: %s", err))}
+		access, err := NewWeightedThresholdAccessStructure(weights, threshold)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when constructing WeightedThresholdAccessStructure: %s", err))}
+		err = scheme.SetAccessStructure(access)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when adding WeightedThresholdAccessStructure: %s", err))}
+		var auxi []interface{}
+		if (randomAuxiliary) {auxi = scheme.GenerateRandomAuxiliary()}
+		secret, _ := rand.Int(rand.Reader, modulus)
+		shares, err := scheme.GenerateShares(secret, auxi)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when generating shares: %s", err))}
+		for i := 0; i < participantCount; i++{
+			if (len(shares[i].GetValue().([]interface{})) != weights[i]) {t.Fatal("Number of points in a share should be the weight.")}
+		}
+
+		// every subset is either heavy enough and recovers the secret, or rejected
+		for mask := 0; mask < (1 << uint(participantCount)); mask++{
+			subset := make([]*SecretShare, 0)
+			weight := 0
+			for i := participantCount - 1; i >= 0; i--{
+				if (mask & (1 << uint(i)) != 0){
+					subset = append(subset, shares[i])
+					weight += weights[i]
+				}
+			}
+			secretNew, err := scheme.CalculateSecret(subset)
+			if (weight >= threshold){
+				if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret from mask %b: %s", mask, err))}
+				if (secretNew.(*big.Int).Cmp(secret) != 0){
+					t.Error(fmt.Sprintf("Calculate Result of mask %b is False, Result:%s ,Expected: %s", mask, secretNew, secret))
+				}
+			} else if err == nil {
+				t.Error(fmt.Sprintf("Secret should not be calculated from mask %b.", mask))
+			}
+		}
+
+		// shares survive the binary encoding, and repeated shares count once
+		decodedShares := make([]*SecretShare, 0)
+		for i := 0; i < participantCount; i++{
+			data, err := shares[i].MarshalBinary()
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding share: %s", err))}
+			decoded := new(SecretShare)
+			err = decoded.UnmarshalBinary(data)
+			if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding share: %s", err))}
+			decodedShares = append(decodedShares, decoded, decoded)
+		}
+		secretNew, err := scheme.CalculateSecret(decodedShares)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when calculating secret: %s", err))}
+		if (secretNew.(*big.Int).Cmp(secret) != 0) {t.Error("Calculate Result from decoded shares is False.")}
+	}
+}
+
+func TestWeightedShamirSecretSharingBigIntInvalid(t *testing.T) {
+	scheme, _ := NewWeightedShamirSecretSharingBigInt(3, big.NewInt(7))
+	_, err := scheme.GenerateShares(big.NewInt(1), nil)
+	if err == nil {t.Error("Shares should not be generated before the access structure is set.")}
+	threshold, _ := NewThresholdAccessStructure(3, 2)
+	err = scheme.SetAccessStructure(threshold)
+	if err == nil {t.Error("ThresholdAccessStructure should not be accepted.")}
+	heavy, _ := NewWeightedThresholdAccessStructure([]int{3, 2, 2}, 4)
+	err = scheme.SetAccessStructure(heavy)
+	if err == nil {t.Error("Total weight not less than the modulus should not be accepted.")}
+
+	access, _ := NewWeightedThresholdAccessStructure([]int{2, 1, 1}, 3)
+	err = scheme.SetAccessStructure(access)
+	if err != nil {t.Fatal(err)}
+	_, err = scheme.GenerateShares(big.NewInt(1), []interface{}{big.NewInt(1)})
+	if err == nil {t.Error("Wrong number of auxiliary data should not be accepted.")}
+	shares, err := scheme.GenerateShares(big.NewInt(3), nil)
+	if err != nil {t.Fatal(err)}
+	shares[0] = NewSecretShare(0, shares[1].GetValue())
+	_, err = scheme.CalculateSecret(shares)
+	if err == nil {t.Error("Share with fewer points than the weight should not be accepted.")}
+}
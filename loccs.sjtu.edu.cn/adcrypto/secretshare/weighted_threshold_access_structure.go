@@ -0,0 +1,104 @@
+package secretshare
+
+import "errors"
+
+/**
+ * The class implements a weighted threshold access structure for secret sharing.
+ *
+ * <p>
+ * Every participant carries a positive integer weight, and a set of participants matches the access structure if the
+ * total weight of its distinct participants reaches the threshold k. With all weights 1, it is the (k, n) threshold
+ * access structure.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type WeightedThresholdAccessStructure struct {
+
+	/**
+	 * Weights of the participants, indexed by ID.
+	 */
+	weights []int
+
+	/**
+	 * Threshold k of the total weight.
+	 */
+	threshold int
+
+	AccessStructure
+}
+
+/**
+ * Construct WeightedThresholdAccessStructure with the weights of the participants.
+ *
+ * @param weights Weights of the participants, indexed by ID, the number of participants is len(weights).
+ * @param threshold The threshold k of the total weight.
+ * @return newWeightedThresholdAccessStructure the new constructed WeightedThresholdAccessStructure
+ * @return error If the number of participants, any weight or the threshold is invalid.
+ */
+func NewWeightedThresholdAccessStructure(weights []int, threshold int) (*WeightedThresholdAccessStructure, error){
+	if (len(weights) < 2){
+		return nil, errors.New("Invalid participant count. Should be greater than 1.")
+	}
+	total := 0
+	for i := 0; i < len(weights); i++{
+		if (weights[i] < 1) {return nil, errors.New("Invalid weight. Should be larger than 0.")}
+		total += weights[i]
+	}
+	if (threshold < 1 || threshold > total){
+		return nil, errors.New("Invalid threshold. Should be larger than 0 and no more than the total weight.")
+	}
+	newWeightedThresholdAccessStructure := new(WeightedThresholdAccessStructure)
+	newWeightedThresholdAccessStructure.participantCount = len(weights)
+	newWeightedThresholdAccessStructure.weights = append([]int{}, weights...)
+	newWeightedThresholdAccessStructure.threshold = threshold
+	newWeightedThresholdAccessStructure.AccessStructureITF = newWeightedThresholdAccessStructure
+	return newWeightedThresholdAccessStructure, nil
+}
+
+/**
+ * Get threshold.
+ *
+ * @return threshold k of the total weight.
+ */
+func (wtaccs *WeightedThresholdAccessStructure) GetThreshold() int{
+	return wtaccs.threshold
+}
+
+/**
+ * Get weight of a participant.
+ *
+ * @param participant ID of the participant.
+ * @return The weight, 0 if the ID is invalid.
+ */
+func (wtaccs *WeightedThresholdAccessStructure) GetWeight(participant int) int{
+	if (participant < 0 || participant >= wtaccs.participantCount) {return 0}
+	return wtaccs.weights[participant]
+}
+
+/**
+ * Get the total weight of all participants.
+ *
+ * @return The total weight.
+ */
+func (wtaccs *WeightedThresholdAccessStructure) GetTotalWeight() int{
+	total := 0
+	for i := 0; i < wtaccs.participantCount; i++{
+		total += wtaccs.weights[i]
+	}
+	return total
+}
+
+/**
+ * Test if the total weight of the participants trying to calculate secret reaches threshold. Repeated IDs count once.
+ */
+func (wtaccs *WeightedThresholdAccessStructure) testAccessableImpl(participants []int) (bool){
+	present := map[int]bool{}
+	total := 0
+	for i := 0; i < len(participants); i++{
+		if (present[participants[i]]) {continue}
+		present[participants[i]] = true
+		total += wtaccs.weights[participants[i]]
+	}
+	return total >= wtaccs.threshold
+}
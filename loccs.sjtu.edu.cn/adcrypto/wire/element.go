@@ -0,0 +1,329 @@
+package wire
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+)
+
+/**
+ * Type tags of the encoded elements.
+ */
+const (
+	/**
+	 * int, encoded as a 8-byte two's complement integer in big endian.
+	 */
+	TagInt byte = iota + 1
+
+	/**
+	 * *big.Int, encoded as a sign byte (0 for non-negative, 1 for negative) followed by the absolute value in big endian.
+	 */
+	TagBigInt
+
+	/**
+	 * []interface{}, encoded as the number of elements followed by the elements.
+	 */
+	TagList
+
+	/**
+	 * uint64, encoded as a 8-byte unsigned integer in big endian.
+	 */
+	TagUint64
+
+	/**
+	 * []byte, encoded as the raw bytes.
+	 */
+	TagBytes
+)
+
+/**
+ * Append an element, i.e. int, uint64, *big.Int, []byte or []interface{} of elements.
+ * <p>
+ * An element is encoded as type tag (1 byte) | length of payload (4 bytes) | payload.
+ *
+ * @param e The element.
+ * @return error If the type of the element is not supported.
+ */
+func (writer *Writer) WriteElement(e interface{}) error{
+	switch value := e.(type) {
+	case int:
+		writer.WriteUint8(TagInt)
+		writer.WriteUint32(8)
+		writer.WriteUint64(uint64(int64(value)))
+	case uint64:
+		writer.WriteUint8(TagUint64)
+		writer.WriteUint32(8)
+		writer.WriteUint64(value)
+	case *big.Int:
+		if (value == nil) {return errors.New("Element should not be nil.")}
+		magnitude := value.Bytes()
+		writer.WriteUint8(TagBigInt)
+		writer.WriteUint32(uint32(len(magnitude) + 1))
+		if (value.Sign() < 0) {
+			writer.WriteUint8(1)
+		} else {
+			writer.WriteUint8(0)
+		}
+		writer.buffer = append(writer.buffer, magnitude...)
+	case []byte:
+		if (value == nil) {return errors.New("Element should not be nil.")}
+		writer.WriteUint8(TagBytes)
+		writer.WriteBytes(value)
+	case []interface{}:
+		list := NewWriter()
+		list.WriteUint32(uint32(len(value)))
+		for i := 0; i < len(value); i++{
+			err := list.WriteElement(value[i])
+			if (err != nil) {return err}
+		}
+		writer.WriteUint8(TagList)
+		writer.WriteBytes(list.Bytes())
+	default:
+		return errors.New("Invalid type of element, should be int, uint64, *big.Int, []byte or a list of them.")
+	}
+	return nil
+}
+
+/**
+ * Consume an element.
+ *
+ * @return The element, i.e. int, uint64, *big.Int, []byte or []interface{} of elements.
+ * @return error If the data is invalid.
+ */
+func (reader *Reader) ReadElement() (interface{}, error){
+	tag, err := reader.ReadUint8()
+	if (err != nil) {return nil, err}
+	payload, err := reader.ReadBytes()
+	if (err != nil) {return nil, err}
+	switch tag {
+	case TagInt:
+		if (len(payload) != 8) {return nil, errors.New("Invalid length of int element.")}
+		value := int64(binary.BigEndian.Uint64(payload))
+		if (int64(int(value)) != value) {return nil, errors.New("Int element overflows.")}
+		return int(value), nil
+	case TagUint64:
+		if (len(payload) != 8) {return nil, errors.New("Invalid length of uint64 element.")}
+		return binary.BigEndian.Uint64(payload), nil
+	case TagBigInt:
+		if (len(payload) < 1 || payload[0] > 1) {return nil, errors.New("Invalid BigInt element.")}
+		value := big.NewInt(0)
+		value.SetBytes(payload[1:])
+		if (payload[0] == 1) {value.Neg(value)}
+		return value, nil
+	case TagBytes:
+		return append([]byte{}, payload...), nil
+	case TagList:
+		list := NewReader(payload)
+		count, err := list.ReadUint32()
+		if (err != nil) {return nil, err}
+		if (uint64(count) > uint64(len(payload))) {return nil, errors.New("Invalid length of list element.")}
+		feedback := make([]interface{}, count)
+		for i := 0; i < int(count); i++{
+			feedback[i], err = list.ReadElement()
+			if (err != nil) {return nil, err}
+		}
+		if (list.Remaining() != 0) {return nil, errors.New("Unexpected data after list element.")}
+		return feedback, nil
+	default:
+		return nil, errors.New("Unknown type tag of element.")
+	}
+}
+
+/**
+ * Append a list of elements.
+ *
+ * @param elements The elements.
+ * @return error If the type of any element is not supported.
+ */
+func (writer *Writer) WriteElements(elements []interface{}) error{
+	return writer.WriteElement(elements)
+}
+
+/**
+ * Consume a list of elements.
+ *
+ * @return The elements.
+ * @return error If the data is invalid or not a list.
+ */
+func (reader *Reader) ReadElements() ([]interface{}, error){
+	e, err := reader.ReadElement()
+	if (err != nil) {return nil, err}
+	feedback, ok := e.([]interface{})
+	if (!ok) {return nil, errors.New("Element should be a list.")}
+	return feedback, nil
+}
+
+/**
+ * Encode an element as a frame.
+ *
+ * @param e The element.
+ * @return The encoded frame.
+ * @return error If the type of the element is not supported.
+ */
+func MarshalElement(e interface{}) ([]byte, error){
+	body := NewWriter()
+	err := body.WriteElement(e)
+	if (err != nil) {return nil, err}
+	writer := NewWriter()
+	writer.WriteFrame(KindElement, body.Bytes())
+	return writer.Bytes(), nil
+}
+
+/**
+ * Decode an element from a frame.
+ *
+ * @param data The encoded frame.
+ * @return The element.
+ * @return error If the data is invalid.
+ */
+func UnmarshalElement(data []byte) (interface{}, error){
+	reader := NewReader(data)
+	body, err := reader.ReadFrame(KindElement)
+	if (err != nil) {return nil, err}
+	if (reader.Remaining() != 0) {return nil, errors.New("Unexpected data after frame.")}
+	bodyReader := NewReader(body)
+	feedback, err := bodyReader.ReadElement()
+	if (err != nil) {return nil, err}
+	if (bodyReader.Remaining() != 0) {return nil, errors.New("Unexpected data after element.")}
+	return feedback, nil
+}
+
+/**
+ * The JSON form of an element, for debugging.
+ * <p>
+ * e.g. {"type":"int","value":"12"}, {"type":"uint64","value":"12"}, {"type":"bigint","value":"-12"}, {"type":"bytes","value":"0a0b"}
+ * or {"type":"list","values":[...]}.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type JSONElement struct {
+	/**
+	 * Type of the element, "int", "uint64", "bigint", "bytes" or "list".
+	 */
+	Type string `json:"type"`
+
+	/**
+	 * Decimal value of an int, uint64 or bigint element, or hexadecimal value of a bytes element.
+	 */
+	Value string `json:"value,omitempty"`
+
+	/**
+	 * Elements of a list element.
+	 */
+	Values []*JSONElement `json:"values,omitempty"`
+}
+
+/**
+ * Convert an element to its JSON form.
+ *
+ * @param e The element.
+ * @return The JSON form.
+ * @return error If the type of the element is not supported.
+ */
+func ToJSONElement(e interface{}) (*JSONElement, error){
+	feedback := new(JSONElement)
+	switch value := e.(type) {
+	case int:
+		feedback.Type = "int"
+		feedback.Value = big.NewInt(int64(value)).String()
+	case uint64:
+		feedback.Type = "uint64"
+		feedback.Value = new(big.Int).SetUint64(value).String()
+	case *big.Int:
+		if (value == nil) {return nil, errors.New("Element should not be nil.")}
+		feedback.Type = "bigint"
+		feedback.Value = value.String()
+	case []byte:
+		if (value == nil) {return nil, errors.New("Element should not be nil.")}
+		feedback.Type = "bytes"
+		feedback.Value = hex.EncodeToString(value)
+	case []interface{}:
+		feedback.Type = "list"
+		feedback.Values = make([]*JSONElement, len(value))
+		for i := 0; i < len(value); i++{
+			element, err := ToJSONElement(value[i])
+			if (err != nil) {return nil, err}
+			feedback.Values[i] = element
+		}
+	default:
+		return nil, errors.New("Invalid type of element, should be int, uint64, *big.Int, []byte or a list of them.")
+	}
+	return feedback, nil
+}
+
+/**
+ * Convert the JSON form back to an element.
+ *
+ * @param j The JSON form.
+ * @return The element.
+ * @return error If the JSON form is invalid.
+ */
+func FromJSONElement(j *JSONElement) (interface{}, error){
+	if (j == nil) {return nil, errors.New("Element should not be nil.")}
+	switch j.Type {
+	case "int", "uint64", "bigint":
+		value, ok := big.NewInt(0).SetString(j.Value, 10)
+		if (!ok) {return nil, errors.New("Invalid decimal value of element.")}
+		if (j.Type == "bigint") {return value, nil}
+		if (j.Type == "uint64"){
+			if (!value.IsUint64()) {return nil, errors.New("Uint64 element overflows.")}
+			return value.Uint64(), nil
+		}
+		if (!value.IsInt64() || int64(int(value.Int64())) != value.Int64()){
+			return nil, errors.New("Int element overflows.")
+		}
+		return int(value.Int64()), nil
+	case "bytes":
+		value, err := hex.DecodeString(j.Value)
+		if (err != nil) {return nil, errors.New("Invalid hexadecimal value of element.")}
+		return value, nil
+	case "list":
+		feedback := make([]interface{}, len(j.Values))
+		for i := 0; i < len(j.Values); i++{
+			element, err := FromJSONElement(j.Values[i])
+			if (err != nil) {return nil, err}
+			feedback[i] = element
+		}
+		return feedback, nil
+	default:
+		return nil, errors.New("Unknown type of element.")
+	}
+}
+
+/**
+ * Convert a list of elements to their JSON forms.
+ *
+ * @param elements The elements, can be nil.
+ * @return The JSON forms, nil if elements is nil.
+ * @return error If the type of any element is not supported.
+ */
+func ToJSONElements(elements []interface{}) ([]*JSONElement, error){
+	if (elements == nil) {return nil, nil}
+	feedback := make([]*JSONElement, len(elements))
+	for i := 0; i < len(elements); i++{
+		element, err := ToJSONElement(elements[i])
+		if (err != nil) {return nil, err}
+		feedback[i] = element
+	}
+	return feedback, nil
+}
+
+/**
+ * Convert a list of JSON forms back to elements.
+ *
+ * @param j The JSON forms, can be nil.
+ * @return The elements, nil if j is nil.
+ * @return error If any JSON form is invalid.
+ */
+func FromJSONElements(j []*JSONElement) ([]interface{}, error){
+	if (j == nil) {return nil, nil}
+	feedback := make([]interface{}, len(j))
+	for i := 0; i < len(j); i++{
+		element, err := FromJSONElement(j[i])
+		if (err != nil) {return nil, err}
+		feedback[i] = element
+	}
+	return feedback, nil
+}
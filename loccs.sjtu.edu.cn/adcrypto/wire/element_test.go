@@ -0,0 +1,63 @@
+package wire
+
+import (
+	"testing"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+func TestElementEncoding(t *testing.T) {
+	huge, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	elements := []interface{}{
+		0, -1, 1 << 40, uint64(0), uint64(1 << 63 + 29), uint64(1 << 64 - 59), big.NewInt(0), huge,
+		[]byte{}, []byte{0, 1, 255}, []interface{}{}, []interface{}{3, big.NewInt(5), []interface{}{-7, uint64(7), []byte{9}}},
+	}
+	for _, e := range elements{
+		data, err := MarshalElement(e)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding element: %s", err))}
+		decoded, err := UnmarshalElement(data)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding element: %s", err))}
+		if (fmt.Sprintf("%T %v", decoded, decoded) != fmt.Sprintf("%T %v", e, e)){
+			t.Error(fmt.Sprintf("Element mismatch, Result:%v ,Expected: %v", decoded, e))
+		}
+
+		j, err := ToJSONElement(e)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when converting element to JSON: %s", err))}
+		text, err := json.Marshal(j)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when encoding element as JSON: %s", err))}
+		var back JSONElement
+		err = json.Unmarshal(text, &back)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when decoding element from JSON: %s", err))}
+		decoded, err = FromJSONElement(&back)
+		if err != nil {t.Fatal(fmt.Sprintf("Error happens when converting element from JSON: %s", err))}
+		if (fmt.Sprintf("%T %v", decoded, decoded) != fmt.Sprintf("%T %v", e, e)){
+			t.Error(fmt.Sprintf("Element mismatch, Result:%v ,Expected: %v", decoded, e))
+		}
+	}
+
+	_, err := MarshalElement("string")
+	if err == nil {t.Error("Unsupported element should not be encoded.")}
+}
+
+func TestFrameStream(t *testing.T) {
+	var stream bytes.Buffer
+	err := WriteFrame(&stream, KindElement, []byte{1, 2, 3})
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when writing frame: %s", err))}
+	err = WriteFrame(&stream, KindMessage, nil)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when writing frame: %s", err))}
+
+	kind, frame, err := ReadFrame(&stream)
+	if err != nil {t.Fatal(fmt.Sprintf("Error happens when reading frame: %s", err))}
+	body, err := NewReader(frame).ReadFrame(KindElement)
+	if (err != nil || kind != KindElement || !bytes.Equal(body, []byte{1, 2, 3})){
+		t.Error("First frame mismatch.")
+	}
+	kind, frame, err = ReadFrame(&stream)
+	if (err != nil || kind != KindMessage || len(frame) != 6){
+		t.Error("Second frame mismatch.")
+	}
+	_, _, err = ReadFrame(&stream)
+	if err == nil {t.Error("Reading from an empty stream should fail.")}
+}
@@ -0,0 +1,386 @@
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+/**
+ * Package wire implements the versioned, length-prefixed binary encoding shared by the protocol messages and shares.
+ * <p>
+ * Every encoded object is a frame:
+ * <p>
+ * &nbsp;&nbsp;&nbsp;&nbsp;version (1 byte) | kind (1 byte) | length of body (4 bytes, big endian) | body
+ * <p>
+ * The kind tells the receiver what the body contains, and frames can be nested in the body of other frames.
+ * Elements (int, *big.Int and lists of them) are encoded with their own type tags, so that a receiver can tell
+ * which backend the value belongs to.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+
+/**
+ * Version of the encoding.
+ */
+const Version byte = 1
+
+/**
+ * Max length of a frame body, to avoid allocating huge buffers for corrupted data.
+ */
+const MaxBodyLength = 1 << 26
+
+/**
+ * Kinds of the encoded objects.
+ */
+type Kind byte
+
+const (
+	/**
+	 * A single element, i.e. int, *big.Int or a list of elements.
+	 */
+	KindElement Kind = iota + 1
+
+	/**
+	 * A secretshare.SecretShare.
+	 */
+	KindSecretShare
+
+	/**
+	 * A secretshare.ShamirSecretShareValue.
+	 */
+	KindShamirSecretShareValue
+
+	/**
+	 * An mpc.Message, i.e. an input message or an output message.
+	 */
+	KindMessage
+
+	/**
+	 * The public parameters of an mpc, i.e. modulus, coefficients and auxiliary data.
+	 */
+	KindPublicParameters
+
+	/**
+	 * A secretshare.PedersenSecretShareValue.
+	 */
+	KindPedersenSecretShareValue
+
+	/**
+	 * The header of a share stream of secretshare.SplitStream, i.e. scheme parameters, participant ID and a checksum.
+	 */
+	KindShareStreamHeader
+
+	/**
+	 * A chunk of a share stream, i.e. the chunk index, the shared bytes and a checksum.
+	 */
+	KindShareStreamChunk
+
+	/**
+	 * The end of a share stream, i.e. the number of chunks and bytes.
+	 */
+	KindShareStreamTrailer
+
+	/**
+	 * A secretshare.ShamirSecretShareValue of a refreshed share, i.e. <i>r</i>, <i>q</i>(<i>r</i>) and the epoch.
+	 * Shares of epoch 0 keep the layout of KindShamirSecretShareValue.
+	 */
+	KindShamirSecretShareValueEpoch
+
+	/**
+	 * The challenge sent by the receiver of a transport connection, i.e. a random nonce.
+	 */
+	KindHandshakeChallenge
+
+	/**
+	 * The response of the dialer of a transport connection, i.e. its ID, the ID of the receiver and a MAC of the challenge.
+	 */
+	KindHandshakeResponse
+)
+
+/**
+ * A writer appending encoded data to a buffer.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type Writer struct {
+	/**
+	 * The encoded data.
+	 */
+	buffer []byte
+}
+
+/**
+ * Construct an empty writer.
+ *
+ * @return The new constructed Writer.
+ */
+func NewWriter() *Writer{
+	feedback := new(Writer)
+	feedback.buffer = make([]byte, 0, 64)
+	return feedback
+}
+
+/**
+ * Get the encoded data.
+ *
+ * @return The encoded data.
+ */
+func (writer *Writer) Bytes() []byte{
+	return writer.buffer
+}
+
+/**
+ * Append a byte.
+ *
+ * @param value The byte.
+ */
+func (writer *Writer) WriteUint8(value byte){
+	writer.buffer = append(writer.buffer, value)
+}
+
+/**
+ * Append a 4-byte unsigned integer in big endian.
+ *
+ * @param value The integer.
+ */
+func (writer *Writer) WriteUint32(value uint32){
+	var tmp [4]byte
+	binary.BigEndian.PutUint32(tmp[:], value)
+	writer.buffer = append(writer.buffer, tmp[:]...)
+}
+
+/**
+ * Append a 8-byte unsigned integer in big endian.
+ *
+ * @param value The integer.
+ */
+func (writer *Writer) WriteUint64(value uint64){
+	var tmp [8]byte
+	binary.BigEndian.PutUint64(tmp[:], value)
+	writer.buffer = append(writer.buffer, tmp[:]...)
+}
+
+/**
+ * Append a non-negative int, e.g. participant ID, as a 4-byte unsigned integer.
+ *
+ * @param value The int.
+ * @return error If the int is negative or too large.
+ */
+func (writer *Writer) WriteIndex(value int) error{
+	if (value < 0 || int64(value) > 0xFFFFFFFF){
+		return errors.New("Index out of range.")
+	}
+	writer.WriteUint32(uint32(value))
+	return nil
+}
+
+/**
+ * Append a length-prefixed byte array.
+ *
+ * @param value The byte array.
+ */
+func (writer *Writer) WriteBytes(value []byte){
+	writer.WriteUint32(uint32(len(value)))
+	writer.buffer = append(writer.buffer, value...)
+}
+
+/**
+ * Append a frame.
+ *
+ * @param kind Kind of the frame.
+ * @param body Body of the frame.
+ */
+func (writer *Writer) WriteFrame(kind Kind, body []byte){
+	writer.WriteUint8(Version)
+	writer.WriteUint8(byte(kind))
+	writer.WriteBytes(body)
+}
+
+/**
+ * A reader consuming encoded data.
+ *
+ * @author 		LoCCS
+ * @version		1.0
+ */
+type Reader struct {
+	/**
+	 * The data not consumed yet.
+	 */
+	buffer []byte
+}
+
+/**
+ * Construct a reader of the encoded data.
+ *
+ * @param data The encoded data.
+ * @return The new constructed Reader.
+ */
+func NewReader(data []byte) *Reader{
+	feedback := new(Reader)
+	feedback.buffer = data
+	return feedback
+}
+
+/**
+ * Get the number of bytes not consumed yet.
+ *
+ * @return Number of bytes left.
+ */
+func (reader *Reader) Remaining() int{
+	return len(reader.buffer)
+}
+
+/**
+ * Consume a byte.
+ *
+ * @return The byte.
+ * @return error If the data is too short.
+ */
+func (reader *Reader) ReadUint8() (byte, error){
+	if (len(reader.buffer) < 1){
+		return 0, errors.New("Unexpected end of data.")
+	}
+	feedback := reader.buffer[0]
+	reader.buffer = reader.buffer[1:]
+	return feedback, nil
+}
+
+/**
+ * Consume a 4-byte unsigned integer in big endian.
+ *
+ * @return The integer.
+ * @return error If the data is too short.
+ */
+func (reader *Reader) ReadUint32() (uint32, error){
+	if (len(reader.buffer) < 4){
+		return 0, errors.New("Unexpected end of data.")
+	}
+	feedback := binary.BigEndian.Uint32(reader.buffer)
+	reader.buffer = reader.buffer[4:]
+	return feedback, nil
+}
+
+/**
+ * Consume a 8-byte unsigned integer in big endian.
+ *
+ * @return The integer.
+ * @return error If the data is too short.
+ */
+func (reader *Reader) ReadUint64() (uint64, error){
+	if (len(reader.buffer) < 8){
+		return 0, errors.New("Unexpected end of data.")
+	}
+	feedback := binary.BigEndian.Uint64(reader.buffer)
+	reader.buffer = reader.buffer[8:]
+	return feedback, nil
+}
+
+/**
+ * Consume a non-negative int encoded as a 4-byte unsigned integer.
+ *
+ * @return The int.
+ * @return error If the data is too short.
+ */
+func (reader *Reader) ReadIndex() (int, error){
+	value, err := reader.ReadUint32()
+	if (err != nil) {return -1, err}
+	return int(value), nil
+}
+
+/**
+ * Consume a length-prefixed byte array.
+ *
+ * @return The byte array.
+ * @return error If the data is too short.
+ */
+func (reader *Reader) ReadBytes() ([]byte, error){
+	length, err := reader.ReadUint32()
+	if (err != nil) {return nil, err}
+	if (uint64(len(reader.buffer)) < uint64(length)){
+		return nil, errors.New("Unexpected end of data.")
+	}
+	feedback := reader.buffer[:length]
+	reader.buffer = reader.buffer[length:]
+	return feedback, nil
+}
+
+/**
+ * Consume a frame of the expected kind.
+ *
+ * @param kind The expected kind.
+ * @return Body of the frame.
+ * @return error If the data is too short, or the version or the kind is unexpected.
+ */
+func (reader *Reader) ReadFrame(kind Kind) ([]byte, error){
+	version, err := reader.ReadUint8()
+	if (err != nil) {return nil, err}
+	if (version != Version){
+		return nil, errors.New("Unsupported version of encoding.")
+	}
+	frameKind, err := reader.ReadUint8()
+	if (err != nil) {return nil, err}
+	if (Kind(frameKind) != kind){
+		return nil, errors.New("Unexpected kind of frame.")
+	}
+	return reader.ReadBytes()
+}
+
+/**
+ * Peek the kind of the next frame without consuming it.
+ *
+ * @return The kind of the next frame.
+ * @return error If the data is too short or the version is unexpected.
+ */
+func (reader *Reader) PeekKind() (Kind, error){
+	if (len(reader.buffer) < 2){
+		return 0, errors.New("Unexpected end of data.")
+	}
+	if (reader.buffer[0] != Version){
+		return 0, errors.New("Unsupported version of encoding.")
+	}
+	return Kind(reader.buffer[1]), nil
+}
+
+/**
+ * Write a frame to a stream.
+ *
+ * @param w The stream.
+ * @param kind Kind of the frame.
+ * @param body Body of the frame.
+ * @return error If error happens when writing.
+ */
+func WriteFrame(w io.Writer, kind Kind, body []byte) error{
+	writer := NewWriter()
+	writer.WriteFrame(kind, body)
+	_, err := w.Write(writer.Bytes())
+	return err
+}
+
+/**
+ * Read a frame from a stream.
+ *
+ * @param r The stream.
+ * @return Kind of the frame.
+ * @return The whole frame, including the header.
+ * @return error If error happens when reading, or the frame is invalid.
+ */
+func ReadFrame(r io.Reader) (Kind, []byte, error){
+	header := make([]byte, 6)
+	_, err := io.ReadFull(r, header)
+	if (err != nil) {return 0, nil, err}
+	if (header[0] != Version){
+		return 0, nil, errors.New("Unsupported version of encoding.")
+	}
+	length := binary.BigEndian.Uint32(header[2:])
+	if (length > MaxBodyLength){
+		return 0, nil, errors.New("Frame too large.")
+	}
+	frame := make([]byte, 6 + int(length))
+	copy(frame, header)
+	_, err = io.ReadFull(r, frame[6:])
+	if (err != nil) {return 0, nil, err}
+	return Kind(header[1]), frame, nil
+}